@@ -868,6 +868,11 @@ func (f *fileImporter) fetchAndImportFiles(name string, repo Repo, cv models.Cha
 	} else {
 		log.WithFields(log.Fields{"name": name, "version": cv.Version}).Info("values.schema.json not found")
 	}
+	if dependencies, err := tarutil.ChartDependencies(files); err == nil {
+		chartFiles.Dependencies = dependencies
+	} else {
+		log.WithFields(log.Fields{"name": name, "version": cv.Version}).WithError(err).Info("dependencies not found")
+	}
 
 	// inserts the chart files if not already indexed, or updates the existing
 	// entry if digest has changed
@@ -153,6 +153,7 @@ type goodTarballClient struct {
 	skipReadme bool
 	skipValues bool
 	skipSchema bool
+	chartYAML  string
 }
 
 var testChartReadme = "# readme for chart\n\nBest chart in town"
@@ -162,7 +163,11 @@ var testChartSchema = `{"properties": {}}`
 func (h *goodTarballClient) Do(req *http.Request) (*http.Response, error) {
 	w := httptest.NewRecorder()
 	gzw := gzip.NewWriter(w)
-	files := []tartest.TarballFile{{Name: h.c.Name + "/Chart.yaml", Body: "should be a Chart.yaml here..."}}
+	chartYAML := h.chartYAML
+	if chartYAML == "" {
+		chartYAML = "should be a Chart.yaml here..."
+	}
+	files := []tartest.TarballFile{{Name: h.c.Name + "/Chart.yaml", Body: chartYAML}}
 	if !h.skipValues {
 		files = append(files, tartest.TarballFile{Name: h.c.Name + "/values.yaml", Body: testChartValues})
 	}
@@ -641,6 +646,39 @@ func Test_fetchAndImportFiles(t *testing.T) {
 		assert.NoErr(t, err)
 	})
 
+	t.Run("tarball with dependencies", func(t *testing.T) {
+		pgManager, mock, cleanup := getMockManager(t)
+		defer cleanup()
+
+		files := chartFiles
+		files.Dependencies = []models.ChartDependency{
+			{Name: "mariadb", Version: "9.x.x", Repository: "https://charts.bitnami.com/bitnami", Condition: "mariadb.enabled"},
+		}
+		mock.ExpectQuery(`SELECT EXISTS*`).
+			WithArgs(chartFilesID, repo.Name, repo.Namespace, chartVersion.Digest).
+			WillReturnRows(sqlmock.NewRows([]string{"info"}))
+		mock.ExpectQuery("INSERT INTO files *").
+			WithArgs(chartID, repo.Name, repo.Namespace, chartFilesID, files).
+			WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow("3"))
+
+		netClient := &goodTarballClient{c: charts[0], chartYAML: `apiVersion: v2
+name: acs-engine-autoscaler
+dependencies:
+- name: mariadb
+  version: 9.x.x
+  repository: https://charts.bitnami.com/bitnami
+  condition: mariadb.enabled
+`}
+		fImporter := fileImporter{pgManager, netClient}
+		helmRepo := &HelmRepo{
+			content:      []byte{},
+			RepoInternal: repo,
+			netClient:    netClient,
+		}
+		err := fImporter.fetchAndImportFiles(charts[0].Name, helmRepo, chartVersion)
+		assert.NoErr(t, err)
+	})
+
 	t.Run("file exists", func(t *testing.T) {
 		pgManager, mock, cleanup := getMockManager(t)
 		defer cleanup()
@@ -18,6 +18,7 @@ package utils
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -32,6 +33,10 @@ import (
 // TODO(mnelson): standardise error API for package.
 var ErrChartVersionNotFound = errors.New("chart version not found")
 
+// searchSnippetOptions are the options of the snippets highlighting the words
+// of the charts matching a search query.
+const searchSnippetOptions = "StartSel=<mark>, StopSel=</mark>, MinWords=10, MaxWords=30"
+
 // TODO(agamez): temporary flag, use the fallback behavior just when necessary, not globally
 var enableFallbackQueryMode = true
 
@@ -208,6 +213,94 @@ func (m *PostgresAssetManager) GetPaginatedChartListWithFilters(cq ChartQuery, p
 	return charts, numPages, nil
 }
 
+// SearchPaginatedChartList returns the charts matching the filters and the
+// full-text search query of the ChartQuery, ordered by relevance. Each word of
+// the search query matches the words of the charts starting with it.
+func (m *PostgresAssetManager) SearchPaginatedChartList(cq ChartQuery, pageNumber, pageSize int) ([]*ChartSearchResult, int, error) {
+	tsQuery := dbutils.ChartSearchQuery(cq.SearchQuery)
+	if tsQuery == "" {
+		// A query without words cannot be searched as text, so the charts
+		// are matched as with the filters.
+		charts, numPages, err := m.GetPaginatedChartListWithFilters(cq, pageNumber, pageSize)
+		if err != nil {
+			return []*ChartSearchResult{}, 0, err
+		}
+		results := make([]*ChartSearchResult, 0, len(charts))
+		for _, chart := range charts {
+			results = append(results, &ChartSearchResult{Chart: chart})
+		}
+		return results, numPages, nil
+	}
+
+	filters := cq
+	filters.SearchQuery = ""
+	whereQuery, whereQueryParams := m.GenerateWhereClause(filters)
+	whereQueryParams = append(whereQueryParams, tsQuery)
+	fromClause := fmt.Sprintf("%s, to_tsquery('%s', $%d) search_query", dbutils.ChartTable, dbutils.ChartSearchConfig, len(whereQueryParams))
+	searchClause := fmt.Sprintf("(%s @@ search_query)", dbutils.ChartSearchVector)
+	if whereQuery == "" {
+		whereQuery = "WHERE " + searchClause
+	} else {
+		whereQuery += " AND " + searchClause
+	}
+
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	paginationClause := ""
+	if pageSize > 0 {
+		offset := (pageNumber - 1) * pageSize
+		paginationClause = fmt.Sprintf("LIMIT %d OFFSET %d", pageSize, offset)
+	}
+
+	dbQuery := fmt.Sprintf("SELECT info, ts_rank_cd(%s, search_query) AS rank, ts_headline('%s', coalesce(info->>'description', ''), search_query, '%s') AS snippet FROM %s %s ORDER BY rank DESC, (info->>'name') ASC %s",
+		dbutils.ChartSearchVector, dbutils.ChartSearchConfig, searchSnippetOptions, fromClause, whereQuery, paginationClause)
+	results, err := m.queryChartSearchResults(dbQuery, whereQueryParams...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	numPages := 1
+	if pageSize > 0 {
+		dbCountQuery := fmt.Sprintf("SELECT count(info) FROM %s %s", fromClause, whereQuery)
+		count, err := m.QueryCount(dbCountQuery, whereQueryParams...)
+		if err != nil {
+			return nil, 0, err
+		}
+		numPages = int(math.Ceil(float64(count) / float64(pageSize)))
+	}
+	return results, numPages, nil
+}
+
+// queryChartSearchResults performs the search query, returning the chart,
+// relevance and snippet of each row.
+func (m *PostgresAssetManager) queryChartSearchResults(query string, args ...interface{}) ([]*ChartSearchResult, error) {
+	rows, err := m.GetDB().Query(query, args...)
+	if rows != nil {
+		defer rows.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	results := []*ChartSearchResult{}
+	for rows.Next() {
+		var info string
+		result := &ChartSearchResult{}
+		err := rows.Scan(&info, &result.Relevance, &result.Snippet)
+		if err != nil {
+			return nil, err
+		}
+		var chart models.Chart
+		err = json.Unmarshal([]byte(info), &chart)
+		if err != nil {
+			return nil, err
+		}
+		result.Chart = &chart
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
 func (m *PostgresAssetManager) GenerateWhereClause(cq ChartQuery) (string, []interface{}) {
 	whereClauses := []string{}
 	whereQueryParams := []interface{}{}
@@ -443,6 +443,103 @@ func Test_GetPaginatedChartList(t *testing.T) {
 	}
 }
 
+func Test_SearchPaginatedChartList(t *testing.T) {
+	apache := &models.Chart{ID: "bitnami/apache", Name: "apache", Description: "Apache HTTP Server"}
+	nginx := &models.Chart{ID: "bitnami/nginx", Name: "nginx", Description: "Chart for the nginx server"}
+	tests := []struct {
+		name               string
+		searchQuery        string
+		pageSize           int
+		rows               []*ChartSearchResult
+		expectedQuery      string
+		expectedParams     []driver.Value
+		expectedResults    []*ChartSearchResult
+		expectedTotalPages int
+	}{
+		{
+			name:        "it orders the matching charts by relevance",
+			searchQuery: "Serv",
+			pageSize:    1,
+			rows: []*ChartSearchResult{
+				{Chart: apache, Relevance: 0.2, Snippet: "Apache HTTP <mark>Server</mark>"},
+			},
+			expectedQuery:  `^SELECT info, ts_rank_cd\(.+, search_query\) AS rank, ts_headline\('english', .+\) AS snippet FROM charts, to_tsquery\('english', \$3\) search_query WHERE .+ @@ search_query\) ORDER BY rank DESC, \(info->>'name'\) ASC LIMIT 1 OFFSET 0$`,
+			expectedParams: []driver.Value{"my-ns", "kubeapps", "serv:*"},
+			expectedResults: []*ChartSearchResult{
+				{Chart: apache, Relevance: 0.2, Snippet: "Apache HTTP <mark>Server</mark>"},
+			},
+			expectedTotalPages: 2,
+		},
+		{
+			name:        "it matches the words of the query as prefixes",
+			searchQuery: "nginx serv",
+			rows: []*ChartSearchResult{
+				{Chart: nginx, Relevance: 0.5, Snippet: "Chart for the <mark>nginx</mark> <mark>server</mark>"},
+			},
+			expectedQuery:  `^SELECT info, .+ ORDER BY rank DESC, \(info->>'name'\) ASC$`,
+			expectedParams: []driver.Value{"my-ns", "kubeapps", "nginx:* & serv:*"},
+			expectedResults: []*ChartSearchResult{
+				{Chart: nginx, Relevance: 0.5, Snippet: "Chart for the <mark>nginx</mark> <mark>server</mark>"},
+			},
+			expectedTotalPages: 1,
+		},
+		{
+			name:               "it lists the charts without words in the query",
+			searchQuery:        "-",
+			rows:               []*ChartSearchResult{{Chart: apache}, {Chart: nginx}},
+			expectedQuery:      `^SELECT info FROM charts WHERE .+ ORDER BY \(info->>'name'\) ASC$`,
+			expectedParams:     []driver.Value{"my-ns", "kubeapps", "%-%"},
+			expectedResults:    []*ChartSearchResult{{Chart: apache}, {Chart: nginx}},
+			expectedTotalPages: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pgManager, mock, cleanup := getMockManager(t)
+			defer cleanup()
+
+			columns := []string{"info", "rank", "snippet"}
+			if dbutils.ChartSearchQuery(tt.searchQuery) == "" {
+				columns = []string{"info"}
+			}
+			rows := sqlmock.NewRows(columns)
+			for _, result := range tt.rows {
+				chartJSON, err := json.Marshal(result.Chart)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				if len(columns) == 1 {
+					rows.AddRow(string(chartJSON))
+				} else {
+					rows.AddRow(string(chartJSON), result.Relevance, result.Snippet)
+				}
+			}
+			mock.ExpectQuery(tt.expectedQuery).
+				WithArgs(tt.expectedParams...).
+				WillReturnRows(rows)
+			if tt.pageSize > 0 {
+				mock.ExpectQuery("^SELECT count(.+) FROM charts, to_tsquery").
+					WithArgs(tt.expectedParams...).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			}
+
+			results, totalPages, err := pgManager.SearchPaginatedChartList(ChartQuery{Namespace: "my-ns", SearchQuery: tt.searchQuery}, 1, tt.pageSize)
+			if err != nil {
+				t.Fatalf("Found error %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+			if totalPages != tt.expectedTotalPages {
+				t.Errorf("Unexpected number of pages, got %d expecting %d", totalPages, tt.expectedTotalPages)
+			}
+			if !cmp.Equal(results, tt.expectedResults) {
+				t.Errorf("Unexpected result %v", cmp.Diff(tt.expectedResults, results))
+			}
+		})
+	}
+}
+
 func Test_GenerateWhereClause(t *testing.T) {
 	tests := []struct {
 		name           string
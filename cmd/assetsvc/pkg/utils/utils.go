@@ -29,6 +29,16 @@ type AssetManager interface {
 	GetChartFiles(namespace, filesID string) (models.ChartFiles, error)
 	GetPaginatedChartListWithFilters(cq ChartQuery, pageNumber, pageSize int) ([]*models.Chart, int, error)
 	GetAllChartCategories(cq ChartQuery) ([]*models.ChartCategory, error)
+	SearchPaginatedChartList(cq ChartQuery, pageNumber, pageSize int) ([]*ChartSearchResult, int, error)
+}
+
+// ChartSearchResult is a chart matching the search query of a ChartQuery,
+// with its relevance and an excerpt of its description highlighting the
+// matched words
+type ChartSearchResult struct {
+	Chart     *models.Chart
+	Relevance float32
+	Snippet   string
 }
 
 // ChartQuery is a container for passing the supported query paramters for generating the WHERE query
@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
@@ -59,8 +60,17 @@ func init() {
 	rootCmd.Flags().IntVar(&serveOpts.Port, "port", 50051, "The port on which to run this api server. Both gRPC and HTTP requests will be served on this port.")
 	rootCmd.Flags().StringSliceVar(&serveOpts.PluginDirs, "plugin-dir", []string{"."}, "A directory to be scanned for .so plugins. May be specified multiple times.")
 
+	rootCmd.Flags().StringVar(&serveOpts.PluginConfigPath, "plugin-config", "", "A YAML file to enable or disable plugins, set their priority and pass them their settings. Plugins not in the file are enabled with their default settings.")
+	rootCmd.Flags().DurationVar(&serveOpts.PluginTimeout, "plugin-timeout", 30*time.Second, "The maximum time to wait for each plugin when aggregating results from all plugins. Plugins not responding in time are reported as errors in the response.")
+
 	rootCmd.Flags().StringVar(&serveOpts.ClustersConfigPath, "clusters-config-path", "", "Configuration for clusters")
 	rootCmd.Flags().StringVar(&serveOpts.PinnipedProxyURL, "pinniped-proxy-url", "http://kubeapps-internal-pinniped-proxy.kubeapps:3333", "internal url to be used for requests to clusters configured for credential proxying via pinniped")
+	rootCmd.Flags().StringVar(&serveOpts.AuthMode, "auth-mode", server.AuthModeNone, "How the bearer tokens of the requests are authenticated: 'none' only rejects malformed tokens, 'token-review' validates them with a TokenReview and 'oidc' verifies them as OIDC ID tokens.")
+	rootCmd.Flags().StringVar(&serveOpts.OIDCIssuerURL, "oidc-issuer-url", "", "The issuer of the OIDC ID tokens, required with --auth-mode=oidc.")
+	rootCmd.Flags().StringVar(&serveOpts.OIDCJWKSFile, "oidc-jwks-file", "", "A JSON Web Key Set file with the keys signing the OIDC ID tokens, required with --auth-mode=oidc.")
+	rootCmd.Flags().StringVar(&serveOpts.OIDCClientID, "oidc-client-id", "", "The client id for which the OIDC ID tokens must be issued. Any audience is accepted if empty.")
+	rootCmd.Flags().StringVar(&serveOpts.OIDCUsernameClaim, "oidc-username-claim", "email", "The claim of the OIDC ID tokens used as the username.")
+	rootCmd.Flags().StringVar(&serveOpts.OIDCGroupsClaim, "oidc-groups-claim", "groups", "The claim of the OIDC ID tokens used as the groups of the user.")
 	rootCmd.Flags().BoolVar(&serveOpts.UnsafeUseDemoSA, "unsafe-use-demo-sa", false, "if true, it will create and use a privileged Service Account for interacting with the resources instead of acting on a user's behalf.")
 	rootCmd.Flags().BoolVar(&serveOpts.UnsafeLocalDevKubeconfig, "unsafe-local-dev-kubeconfig", false, "if true, it will use the local kubeconfig at the KUBECONFIG env var instead of using the inCluster configuration.")
 }
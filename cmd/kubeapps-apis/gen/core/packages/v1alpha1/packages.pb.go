@@ -24,6 +24,191 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// EventType
+//
+// The type of change of the installed package.
+type WatchInstalledPackagesResponse_EventType int32
+
+const (
+	WatchInstalledPackagesResponse_EVENT_TYPE_UNSPECIFIED WatchInstalledPackagesResponse_EventType = 0
+	WatchInstalledPackagesResponse_EVENT_TYPE_ADDED       WatchInstalledPackagesResponse_EventType = 1
+	WatchInstalledPackagesResponse_EVENT_TYPE_MODIFIED    WatchInstalledPackagesResponse_EventType = 2
+	WatchInstalledPackagesResponse_EVENT_TYPE_DELETED     WatchInstalledPackagesResponse_EventType = 3
+)
+
+// Enum value maps for WatchInstalledPackagesResponse_EventType.
+var (
+	WatchInstalledPackagesResponse_EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_ADDED",
+		2: "EVENT_TYPE_MODIFIED",
+		3: "EVENT_TYPE_DELETED",
+	}
+	WatchInstalledPackagesResponse_EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED": 0,
+		"EVENT_TYPE_ADDED":       1,
+		"EVENT_TYPE_MODIFIED":    2,
+		"EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x WatchInstalledPackagesResponse_EventType) Enum() *WatchInstalledPackagesResponse_EventType {
+	p := new(WatchInstalledPackagesResponse_EventType)
+	*p = x
+	return p
+}
+
+func (x WatchInstalledPackagesResponse_EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchInstalledPackagesResponse_EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_enumTypes[0].Descriptor()
+}
+
+func (WatchInstalledPackagesResponse_EventType) Type() protoreflect.EnumType {
+	return &file_kubeappsapis_core_packages_v1alpha1_packages_proto_enumTypes[0]
+}
+
+func (x WatchInstalledPackagesResponse_EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchInstalledPackagesResponse_EventType.Descriptor instead.
+func (WatchInstalledPackagesResponse_EventType) EnumDescriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{17, 0}
+}
+
+// StatusReason
+//
+// Generic reasons why an installed package may be ready or not.
+// These should make sense across different packaging plugins.
+type InstalledPackageStatus_StatusReason int32
+
+const (
+	InstalledPackageStatus_STATUS_REASON_UNSPECIFIED InstalledPackageStatus_StatusReason = 0
+	InstalledPackageStatus_STATUS_REASON_INSTALLED   InstalledPackageStatus_StatusReason = 1
+	InstalledPackageStatus_STATUS_REASON_UNINSTALLED InstalledPackageStatus_StatusReason = 2
+	InstalledPackageStatus_STATUS_REASON_FAILED      InstalledPackageStatus_StatusReason = 3
+	InstalledPackageStatus_STATUS_REASON_PENDING     InstalledPackageStatus_StatusReason = 4
+)
+
+// Enum value maps for InstalledPackageStatus_StatusReason.
+var (
+	InstalledPackageStatus_StatusReason_name = map[int32]string{
+		0: "STATUS_REASON_UNSPECIFIED",
+		1: "STATUS_REASON_INSTALLED",
+		2: "STATUS_REASON_UNINSTALLED",
+		3: "STATUS_REASON_FAILED",
+		4: "STATUS_REASON_PENDING",
+	}
+	InstalledPackageStatus_StatusReason_value = map[string]int32{
+		"STATUS_REASON_UNSPECIFIED": 0,
+		"STATUS_REASON_INSTALLED":   1,
+		"STATUS_REASON_UNINSTALLED": 2,
+		"STATUS_REASON_FAILED":      3,
+		"STATUS_REASON_PENDING":     4,
+	}
+)
+
+func (x InstalledPackageStatus_StatusReason) Enum() *InstalledPackageStatus_StatusReason {
+	p := new(InstalledPackageStatus_StatusReason)
+	*p = x
+	return p
+}
+
+func (x InstalledPackageStatus_StatusReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstalledPackageStatus_StatusReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_enumTypes[1].Descriptor()
+}
+
+func (InstalledPackageStatus_StatusReason) Type() protoreflect.EnumType {
+	return &file_kubeappsapis_core_packages_v1alpha1_packages_proto_enumTypes[1]
+}
+
+func (x InstalledPackageStatus_StatusReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstalledPackageStatus_StatusReason.Descriptor instead.
+func (InstalledPackageStatus_StatusReason) EnumDescriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{22, 0}
+}
+
+// SortField
+//
+// The fields by which package summaries can be sorted.
+type SortOptions_SortField int32
+
+const (
+	// Sort by name, or by relevance when the filter options have a
+	// search query (default)
+	SortOptions_SORT_FIELD_UNSPECIFIED SortOptions_SortField = 0
+	// Sort by package name. When a plugin does not provide a name, the last
+	// segment of the package identifier is used (eg. "apache" for "bitnami/apache").
+	SortOptions_SORT_FIELD_NAME SortOptions_SortField = 1
+	// Sort by display name
+	SortOptions_SORT_FIELD_DISPLAY_NAME SortOptions_SortField = 2
+	// Sort by latest package version, compared as semantic versions when possible.
+	SortOptions_SORT_FIELD_LATEST_VERSION SortOptions_SortField = 3
+	// Sort by repository, that is the namespace of the package context followed by
+	// the identifier up to the last segment (eg. "bitnami" for "bitnami/apache").
+	SortOptions_SORT_FIELD_REPOSITORY SortOptions_SortField = 4
+	// Sort by relevance for the search query, most relevant first, then by
+	// name.
+	SortOptions_SORT_FIELD_RELEVANCE SortOptions_SortField = 5
+)
+
+// Enum value maps for SortOptions_SortField.
+var (
+	SortOptions_SortField_name = map[int32]string{
+		0: "SORT_FIELD_UNSPECIFIED",
+		1: "SORT_FIELD_NAME",
+		2: "SORT_FIELD_DISPLAY_NAME",
+		3: "SORT_FIELD_LATEST_VERSION",
+		4: "SORT_FIELD_REPOSITORY",
+		5: "SORT_FIELD_RELEVANCE",
+	}
+	SortOptions_SortField_value = map[string]int32{
+		"SORT_FIELD_UNSPECIFIED":    0,
+		"SORT_FIELD_NAME":           1,
+		"SORT_FIELD_DISPLAY_NAME":   2,
+		"SORT_FIELD_LATEST_VERSION": 3,
+		"SORT_FIELD_REPOSITORY":     4,
+		"SORT_FIELD_RELEVANCE":      5,
+	}
+)
+
+func (x SortOptions_SortField) Enum() *SortOptions_SortField {
+	p := new(SortOptions_SortField)
+	*p = x
+	return p
+}
+
+func (x SortOptions_SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOptions_SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_enumTypes[2].Descriptor()
+}
+
+func (SortOptions_SortField) Type() protoreflect.EnumType {
+	return &file_kubeappsapis_core_packages_v1alpha1_packages_proto_enumTypes[2]
+}
+
+func (x SortOptions_SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOptions_SortField.Descriptor instead.
+func (SortOptions_SortField) EnumDescriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{30, 0}
+}
+
 // GetAvailablePackageSummariesRequest
 //
 // Request for GetAvailablePackageSummaries
@@ -40,6 +225,11 @@ type GetAvailablePackageSummariesRequest struct {
 	//
 	// Pagination options specifying where to start and how many results to include.
 	PaginationOptions *PaginationOptions `protobuf:"bytes,3,opt,name=pagination_options,json=paginationOptions,proto3" json:"pagination_options,omitempty"`
+	// SortOptions
+	//
+	// Sort options specifying the order of the results. By default results are
+	// ordered by name.
+	SortOptions *SortOptions `protobuf:"bytes,4,opt,name=sort_options,json=sortOptions,proto3" json:"sort_options,omitempty"`
 }
 
 func (x *GetAvailablePackageSummariesRequest) Reset() {
@@ -95,6 +285,13 @@ func (x *GetAvailablePackageSummariesRequest) GetPaginationOptions() *Pagination
 	return nil
 }
 
+func (x *GetAvailablePackageSummariesRequest) GetSortOptions() *SortOptions {
+	if x != nil {
+		return x.SortOptions
+	}
+	return nil
+}
+
 // GetAvailablePackageDetailRequest
 //
 // Request for GetAvailablePackageDetail
@@ -169,11 +366,22 @@ type GetAvailablePackageVersionsRequest struct {
 	// The information required to uniquely
 	// identify an available package
 	AvailablePackageRef *AvailablePackageReference `protobuf:"bytes,1,opt,name=available_package_ref,json=availablePackageRef,proto3" json:"available_package_ref,omitempty"`
-	// Optional version reference for which version history is required.
-	// By default a summary of versions is returned as outlined in the
-	// response. Plugins can choose not to implement this and provide
-	// the summary only, it is provided for completeness only.
+	// Optional version reference for which full version history is required.  By
+	// default a summary of versions is returned as outlined in the response.
+	// Plugins can choose not to implement this and provide the summary only, it
+	// is provided for completeness only.
 	PkgVersion string `protobuf:"bytes,2,opt,name=pkg_version,json=pkgVersion,proto3" json:"pkg_version,omitempty"`
+	// Version constraint
+	//
+	// Optional semantic version constraint (eg. "~1.4" or ">=2.0.0 <3.0.0"). When
+	// set, every version matching the constraint is returned rather than the
+	// summary of versions.
+	VersionConstraint string `protobuf:"bytes,3,opt,name=version_constraint,json=versionConstraint,proto3" json:"version_constraint,omitempty"`
+	// Include prereleases
+	//
+	// Whether the versions matching the constraint include prereleases, which are
+	// otherwise only matched by constraints with a prerelease themselves.
+	IncludePrereleases bool `protobuf:"varint,4,opt,name=include_prereleases,json=includePrereleases,proto3" json:"include_prereleases,omitempty"`
 }
 
 func (x *GetAvailablePackageVersionsRequest) Reset() {
@@ -222,27 +430,38 @@ func (x *GetAvailablePackageVersionsRequest) GetPkgVersion() string {
 	return ""
 }
 
-// GetAvailablePackageSummariesResponse
+func (x *GetAvailablePackageVersionsRequest) GetVersionConstraint() string {
+	if x != nil {
+		return x.VersionConstraint
+	}
+	return ""
+}
+
+func (x *GetAvailablePackageVersionsRequest) GetIncludePrereleases() bool {
+	if x != nil {
+		return x.IncludePrereleases
+	}
+	return false
+}
+
+// GetInstalledPackageSummariesRequest
 //
-// Response for GetAvailablePackageSummaries
-type GetAvailablePackageSummariesResponse struct {
+// Request for GetInstalledPackageSummaries
+type GetInstalledPackageSummariesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Available packages summaries
-	//
-	// List of AvailablePackageSummary
-	AvailablePackagesSummaries []*AvailablePackageSummary `protobuf:"bytes,1,rep,name=available_packages_summaries,json=availablePackagesSummaries,proto3" json:"available_packages_summaries,omitempty"`
-	// Next page token
+	// The context (cluster/namespace) for the request
+	Context *Context `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	// PaginationOptions
 	//
-	// This field represents the pagination token to retrieve the next page of
-	// results. If the value is "", it means no further results for the request.
-	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Pagination options specifying where to start and how many results to include.
+	PaginationOptions *PaginationOptions `protobuf:"bytes,2,opt,name=pagination_options,json=paginationOptions,proto3" json:"pagination_options,omitempty"`
 }
 
-func (x *GetAvailablePackageSummariesResponse) Reset() {
-	*x = GetAvailablePackageSummariesResponse{}
+func (x *GetInstalledPackageSummariesRequest) Reset() {
+	*x = GetInstalledPackageSummariesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -250,13 +469,13 @@ func (x *GetAvailablePackageSummariesResponse) Reset() {
 	}
 }
 
-func (x *GetAvailablePackageSummariesResponse) String() string {
+func (x *GetInstalledPackageSummariesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAvailablePackageSummariesResponse) ProtoMessage() {}
+func (*GetInstalledPackageSummariesRequest) ProtoMessage() {}
 
-func (x *GetAvailablePackageSummariesResponse) ProtoReflect() protoreflect.Message {
+func (x *GetInstalledPackageSummariesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -268,41 +487,40 @@ func (x *GetAvailablePackageSummariesResponse) ProtoReflect() protoreflect.Messa
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAvailablePackageSummariesResponse.ProtoReflect.Descriptor instead.
-func (*GetAvailablePackageSummariesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetInstalledPackageSummariesRequest.ProtoReflect.Descriptor instead.
+func (*GetInstalledPackageSummariesRequest) Descriptor() ([]byte, []int) {
 	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetAvailablePackageSummariesResponse) GetAvailablePackagesSummaries() []*AvailablePackageSummary {
+func (x *GetInstalledPackageSummariesRequest) GetContext() *Context {
 	if x != nil {
-		return x.AvailablePackagesSummaries
+		return x.Context
 	}
 	return nil
 }
 
-func (x *GetAvailablePackageSummariesResponse) GetNextPageToken() string {
+func (x *GetInstalledPackageSummariesRequest) GetPaginationOptions() *PaginationOptions {
 	if x != nil {
-		return x.NextPageToken
+		return x.PaginationOptions
 	}
-	return ""
+	return nil
 }
 
-// GetAvailablePackageDetailResponse
+// GetInstalledPackageDetailRequest
 //
-// Response for GetAvailablePackageDetail
-type GetAvailablePackageDetailResponse struct {
+// Request for GetInstalledPackageDetail
+type GetInstalledPackageDetailRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Available package detail
-	//
-	// List of AvailablePackageDetail
-	AvailablePackageDetail *AvailablePackageDetail `protobuf:"bytes,1,opt,name=available_package_detail,json=availablePackageDetail,proto3" json:"available_package_detail,omitempty"`
+	// The information required to uniquely
+	// identify an installed package
+	InstalledPackageRef *InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
 }
 
-func (x *GetAvailablePackageDetailResponse) Reset() {
-	*x = GetAvailablePackageDetailResponse{}
+func (x *GetInstalledPackageDetailRequest) Reset() {
+	*x = GetInstalledPackageDetailRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -310,13 +528,13 @@ func (x *GetAvailablePackageDetailResponse) Reset() {
 	}
 }
 
-func (x *GetAvailablePackageDetailResponse) String() string {
+func (x *GetInstalledPackageDetailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAvailablePackageDetailResponse) ProtoMessage() {}
+func (*GetInstalledPackageDetailRequest) ProtoMessage() {}
 
-func (x *GetAvailablePackageDetailResponse) ProtoReflect() protoreflect.Message {
+func (x *GetInstalledPackageDetailRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -328,52 +546,47 @@ func (x *GetAvailablePackageDetailResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAvailablePackageDetailResponse.ProtoReflect.Descriptor instead.
-func (*GetAvailablePackageDetailResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetInstalledPackageDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetInstalledPackageDetailRequest) Descriptor() ([]byte, []int) {
 	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetAvailablePackageDetailResponse) GetAvailablePackageDetail() *AvailablePackageDetail {
+func (x *GetInstalledPackageDetailRequest) GetInstalledPackageRef() *InstalledPackageReference {
 	if x != nil {
-		return x.AvailablePackageDetail
+		return x.InstalledPackageRef
 	}
 	return nil
 }
 
-// GetAvailablePackageVersionsResponse
+// CreateInstalledPackageRequest
 //
-// Response for GetAvailablePackageVersions
-type GetAvailablePackageVersionsResponse struct {
+// Request for CreateInstalledPackage
+type CreateInstalledPackageRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// By default (when version_query is empty or ignored) the response
-	// should contain an ordered summary of versions including the most recent three
-	// patch versions of the most recent three minor versions of the most recent three
-	// major versions when available, something like:
-	// [
-	//   { pkg_version: "10.3.19", app_version: "2.16.8" },
-	//   { pkg_version: "10.3.18", app_version: "2.16.8" },
-	//   { pkg_version: "10.3.17", app_version: "2.16.7" },
-	//   { pkg_version: "10.2.6", app_version: "2.15.3" },
-	//   { pkg_version: "10.2.5", app_version: "2.15.2" },
-	//   { pkg_version: "10.2.4", app_version: "2.15.2" },
-	//   { pkg_version: "10.1.8", app_version: "2.13.5" },
-	//   { pkg_version: "10.1.7", app_version: "2.13.5" },
-	//   { pkg_version: "10.1.6", app_version: "2.13.5" },
-	//   { pkg_version: "9.5.4", app_version: "2.8.9" },
-	//   ...
-	//   { pkg_version: "8.2.5", app_version: "1.19.5" },
-	//   ...
-	// ]
-	// If a version_query is present and the plugin chooses to support it,
-	// the full history of versions matching the version query should be returned.
-	PackageAppVersions []*GetAvailablePackageVersionsResponse_PackageAppVersion `protobuf:"bytes,1,rep,name=package_app_versions,json=packageAppVersions,proto3" json:"package_app_versions,omitempty"`
+	// A reference uniquely identifying the package available for installation.
+	AvailablePackageRef *AvailablePackageReference `protobuf:"bytes,1,opt,name=available_package_ref,json=availablePackageRef,proto3" json:"available_package_ref,omitempty"`
+	// The target context where the package is intended to be installed.
+	TargetContext *Context `protobuf:"bytes,2,opt,name=target_context,json=targetContext,proto3" json:"target_context,omitempty"`
+	// A user-provided name for the installed package (eg. project-x-db)
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// For helm this will be the exact version in VersionReference.version
+	// For other plugins we can extend the VersionReference as needed.
+	PkgVersionReference *VersionReference `protobuf:"bytes,4,opt,name=pkg_version_reference,json=pkgVersionReference,proto3" json:"pkg_version_reference,omitempty"`
+	// An optional serialized values string to be included when templating a package
+	// in the format expected by the plugin. Included when the backend format doesn't
+	// use secrets or configmaps for values or supports both. These values are layered
+	// on top of any values refs above, when relevant.
+	Values string `protobuf:"bytes,5,opt,name=values,proto3" json:"values,omitempty"`
+	// An optional field for specifying data common to systems that reconcile
+	// the package on the cluster.
+	ReconciliationOptions *ReconciliationOptions `protobuf:"bytes,6,opt,name=reconciliation_options,json=reconciliationOptions,proto3" json:"reconciliation_options,omitempty"`
 }
 
-func (x *GetAvailablePackageVersionsResponse) Reset() {
-	*x = GetAvailablePackageVersionsResponse{}
+func (x *CreateInstalledPackageRequest) Reset() {
+	*x = CreateInstalledPackageRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -381,13 +594,13 @@ func (x *GetAvailablePackageVersionsResponse) Reset() {
 	}
 }
 
-func (x *GetAvailablePackageVersionsResponse) String() string {
+func (x *CreateInstalledPackageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAvailablePackageVersionsResponse) ProtoMessage() {}
+func (*CreateInstalledPackageRequest) ProtoMessage() {}
 
-func (x *GetAvailablePackageVersionsResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateInstalledPackageRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -399,56 +612,79 @@ func (x *GetAvailablePackageVersionsResponse) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAvailablePackageVersionsResponse.ProtoReflect.Descriptor instead.
-func (*GetAvailablePackageVersionsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateInstalledPackageRequest.ProtoReflect.Descriptor instead.
+func (*CreateInstalledPackageRequest) Descriptor() ([]byte, []int) {
 	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetAvailablePackageVersionsResponse) GetPackageAppVersions() []*GetAvailablePackageVersionsResponse_PackageAppVersion {
+func (x *CreateInstalledPackageRequest) GetAvailablePackageRef() *AvailablePackageReference {
 	if x != nil {
-		return x.PackageAppVersions
+		return x.AvailablePackageRef
 	}
 	return nil
 }
 
-// AvailablePackageSummary
+func (x *CreateInstalledPackageRequest) GetTargetContext() *Context {
+	if x != nil {
+		return x.TargetContext
+	}
+	return nil
+}
+
+func (x *CreateInstalledPackageRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateInstalledPackageRequest) GetPkgVersionReference() *VersionReference {
+	if x != nil {
+		return x.PkgVersionReference
+	}
+	return nil
+}
+
+func (x *CreateInstalledPackageRequest) GetValues() string {
+	if x != nil {
+		return x.Values
+	}
+	return ""
+}
+
+func (x *CreateInstalledPackageRequest) GetReconciliationOptions() *ReconciliationOptions {
+	if x != nil {
+		return x.ReconciliationOptions
+	}
+	return nil
+}
+
+// UpdateInstalledPackageRequest
 //
-// An AvailablePackageSummary provides a summary of a package available for installation
-// useful when aggregating many available packages.
-type AvailablePackageSummary struct {
+// Request for UpdateInstalledPackage
+type UpdateInstalledPackageRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Available package reference
-	//
-	// A reference uniquely identifying the package.
-	AvailablePackageRef *AvailablePackageReference `protobuf:"bytes,1,opt,name=available_package_ref,json=availablePackageRef,proto3" json:"available_package_ref,omitempty"`
-	// Available package name
-	//
-	// The name of the available package
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	// Latest available package version
-	//
-	// The latest version available for this package. Often expected when viewing
-	// a summary of many available packages.
-	LatestPkgVersion string `protobuf:"bytes,3,opt,name=latest_pkg_version,json=latestPkgVersion,proto3" json:"latest_pkg_version,omitempty"`
-	// Available package Icon URL
-	//
-	// A url for an icon.
-	IconUrl string `protobuf:"bytes,4,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
-	// Available package display name
-	//
-	// A name as displayed to users
-	DisplayName string `protobuf:"bytes,5,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
-	// Available package short description
-	//
-	// A short description of the app provided by the package
-	ShortDescription string `protobuf:"bytes,6,opt,name=short_description,json=shortDescription,proto3" json:"short_description,omitempty"`
+	// A reference uniquely identifying the installed package being updated.
+	InstalledPackageRef *InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+	// For helm this will be the exact version in VersionReference.version
+	// For other plugins we can extend the VersionReference as needed.
+	PkgVersionReference *VersionReference `protobuf:"bytes,2,opt,name=pkg_version_reference,json=pkgVersionReference,proto3" json:"pkg_version_reference,omitempty"`
+	// An optional serialized values string to be included when templating a
+	// package in the format expected by the plugin. Included when the backend
+	// format doesn't use secrets or configmaps for values or supports both.
+	// These values are layered on top of any values refs above, when
+	// relevant.
+	Values string `protobuf:"bytes,3,opt,name=values,proto3" json:"values,omitempty"`
+	// An optional field for specifying data common to systems that reconcile
+	// the package on the cluster.
+	ReconciliationOptions *ReconciliationOptions `protobuf:"bytes,4,opt,name=reconciliation_options,json=reconciliationOptions,proto3" json:"reconciliation_options,omitempty"`
 }
 
-func (x *AvailablePackageSummary) Reset() {
-	*x = AvailablePackageSummary{}
+func (x *UpdateInstalledPackageRequest) Reset() {
+	*x = UpdateInstalledPackageRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -456,13 +692,13 @@ func (x *AvailablePackageSummary) Reset() {
 	}
 }
 
-func (x *AvailablePackageSummary) String() string {
+func (x *UpdateInstalledPackageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AvailablePackageSummary) ProtoMessage() {}
+func (*UpdateInstalledPackageRequest) ProtoMessage() {}
 
-func (x *AvailablePackageSummary) ProtoReflect() protoreflect.Message {
+func (x *UpdateInstalledPackageRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -474,131 +710,1083 @@ func (x *AvailablePackageSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AvailablePackageSummary.ProtoReflect.Descriptor instead.
-func (*AvailablePackageSummary) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateInstalledPackageRequest.ProtoReflect.Descriptor instead.
+func (*UpdateInstalledPackageRequest) Descriptor() ([]byte, []int) {
 	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *AvailablePackageSummary) GetAvailablePackageRef() *AvailablePackageReference {
+func (x *UpdateInstalledPackageRequest) GetInstalledPackageRef() *InstalledPackageReference {
 	if x != nil {
-		return x.AvailablePackageRef
+		return x.InstalledPackageRef
 	}
 	return nil
 }
 
-func (x *AvailablePackageSummary) GetName() string {
+func (x *UpdateInstalledPackageRequest) GetPkgVersionReference() *VersionReference {
 	if x != nil {
-		return x.Name
+		return x.PkgVersionReference
 	}
-	return ""
+	return nil
 }
 
-func (x *AvailablePackageSummary) GetLatestPkgVersion() string {
+func (x *UpdateInstalledPackageRequest) GetValues() string {
 	if x != nil {
-		return x.LatestPkgVersion
+		return x.Values
 	}
 	return ""
 }
 
-func (x *AvailablePackageSummary) GetIconUrl() string {
+func (x *UpdateInstalledPackageRequest) GetReconciliationOptions() *ReconciliationOptions {
 	if x != nil {
-		return x.IconUrl
+		return x.ReconciliationOptions
 	}
-	return ""
+	return nil
 }
 
-func (x *AvailablePackageSummary) GetDisplayName() string {
-	if x != nil {
-		return x.DisplayName
-	}
-	return ""
-}
+// DeleteInstalledPackageRequest
+//
+// Request for DeleteInstalledPackage
+type DeleteInstalledPackageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *AvailablePackageSummary) GetShortDescription() string {
-	if x != nil {
-		return x.ShortDescription
-	}
-	return ""
+	// A reference uniquely identifying the installed package being deleted.
+	InstalledPackageRef *InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
 }
 
-// AvailablePackageDetail
+func (x *DeleteInstalledPackageRequest) Reset() {
+	*x = DeleteInstalledPackageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteInstalledPackageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteInstalledPackageRequest) ProtoMessage() {}
+
+func (x *DeleteInstalledPackageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteInstalledPackageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteInstalledPackageRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteInstalledPackageRequest) GetInstalledPackageRef() *InstalledPackageReference {
+	if x != nil {
+		return x.InstalledPackageRef
+	}
+	return nil
+}
+
+// WatchInstalledPackagesRequest
+//
+// Request for WatchInstalledPackages
+type WatchInstalledPackagesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The context (cluster/namespace) for which the installed packages are watched.
+	Context *Context `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+}
+
+func (x *WatchInstalledPackagesRequest) Reset() {
+	*x = WatchInstalledPackagesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchInstalledPackagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchInstalledPackagesRequest) ProtoMessage() {}
+
+func (x *WatchInstalledPackagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchInstalledPackagesRequest.ProtoReflect.Descriptor instead.
+func (*WatchInstalledPackagesRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchInstalledPackagesRequest) GetContext() *Context {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// GetAvailablePackageSummariesResponse
+//
+// Response for GetAvailablePackageSummaries
+type GetAvailablePackageSummariesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Available packages summaries
+	//
+	// List of AvailablePackageSummary
+	AvailablePackagesSummaries []*AvailablePackageSummary `protobuf:"bytes,1,rep,name=available_packages_summaries,json=availablePackagesSummaries,proto3" json:"available_packages_summaries,omitempty"`
+	// Next page token
+	//
+	// This field represents the pagination token to retrieve the next page of
+	// results. If the value is "", it means no further results for the request.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Plugin errors
+	//
+	// When aggregating results from several plugins, the errors of any plugin
+	// which failed to respond. The results of the other plugins are still
+	// returned, so clients can present partial results.
+	PluginErrors []*PluginError `protobuf:"bytes,3,rep,name=plugin_errors,json=pluginErrors,proto3" json:"plugin_errors,omitempty"`
+}
+
+func (x *GetAvailablePackageSummariesResponse) Reset() {
+	*x = GetAvailablePackageSummariesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAvailablePackageSummariesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailablePackageSummariesResponse) ProtoMessage() {}
+
+func (x *GetAvailablePackageSummariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailablePackageSummariesResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailablePackageSummariesResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAvailablePackageSummariesResponse) GetAvailablePackagesSummaries() []*AvailablePackageSummary {
+	if x != nil {
+		return x.AvailablePackagesSummaries
+	}
+	return nil
+}
+
+func (x *GetAvailablePackageSummariesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetAvailablePackageSummariesResponse) GetPluginErrors() []*PluginError {
+	if x != nil {
+		return x.PluginErrors
+	}
+	return nil
+}
+
+// GetAvailablePackageDetailResponse
+//
+// Response for GetAvailablePackageDetail
+type GetAvailablePackageDetailResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Available package detail
+	//
+	// List of AvailablePackageDetail
+	AvailablePackageDetail *AvailablePackageDetail `protobuf:"bytes,1,opt,name=available_package_detail,json=availablePackageDetail,proto3" json:"available_package_detail,omitempty"`
+}
+
+func (x *GetAvailablePackageDetailResponse) Reset() {
+	*x = GetAvailablePackageDetailResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAvailablePackageDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailablePackageDetailResponse) ProtoMessage() {}
+
+func (x *GetAvailablePackageDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailablePackageDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailablePackageDetailResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAvailablePackageDetailResponse) GetAvailablePackageDetail() *AvailablePackageDetail {
+	if x != nil {
+		return x.AvailablePackageDetail
+	}
+	return nil
+}
+
+// GetAvailablePackageVersionsResponse
+//
+// Response for GetAvailablePackageVersions
+type GetAvailablePackageVersionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// By default (when version_query is empty or ignored) the response
+	// should contain an ordered summary of versions including the most recent three
+	// patch versions of the most recent three minor versions of the most recent three
+	// major versions when available, something like:
+	// [
+	//   { pkg_version: "10.3.19", app_version: "2.16.8" },
+	//   { pkg_version: "10.3.18", app_version: "2.16.8" },
+	//   { pkg_version: "10.3.17", app_version: "2.16.7" },
+	//   { pkg_version: "10.2.6", app_version: "2.15.3" },
+	//   { pkg_version: "10.2.5", app_version: "2.15.2" },
+	//   { pkg_version: "10.2.4", app_version: "2.15.2" },
+	//   { pkg_version: "10.1.8", app_version: "2.13.5" },
+	//   { pkg_version: "10.1.7", app_version: "2.13.5" },
+	//   { pkg_version: "10.1.6", app_version: "2.13.5" },
+	//   { pkg_version: "9.5.4", app_version: "2.8.9" },
+	//   ...
+	//   { pkg_version: "8.2.5", app_version: "1.19.5" },
+	//   ...
+	// ]
+	// If a version_query is present and the plugin chooses to support it,
+	// the full history of versions matching the version query should be returned.
+	PackageAppVersions []*GetAvailablePackageVersionsResponse_PackageAppVersion `protobuf:"bytes,1,rep,name=package_app_versions,json=packageAppVersions,proto3" json:"package_app_versions,omitempty"`
+}
+
+func (x *GetAvailablePackageVersionsResponse) Reset() {
+	*x = GetAvailablePackageVersionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAvailablePackageVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailablePackageVersionsResponse) ProtoMessage() {}
+
+func (x *GetAvailablePackageVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailablePackageVersionsResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailablePackageVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetAvailablePackageVersionsResponse) GetPackageAppVersions() []*GetAvailablePackageVersionsResponse_PackageAppVersion {
+	if x != nil {
+		return x.PackageAppVersions
+	}
+	return nil
+}
+
+// GetInstalledPackageSummariesResponse
+//
+// Response for GetInstalledPackageSummaries
+type GetInstalledPackageSummariesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Installed packages summaries
+	//
+	// List of InstalledPackageSummary
+	InstalledPackageSummaries []*InstalledPackageSummary `protobuf:"bytes,1,rep,name=installed_package_summaries,json=installedPackageSummaries,proto3" json:"installed_package_summaries,omitempty"`
+	// Next page token
+	//
+	// This field represents the pagination token to retrieve the next page of
+	// results. If the value is "", it means no further results for the request.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Plugin errors
+	//
+	// When aggregating results from several plugins, the errors of any plugin
+	// which failed to respond. The results of the other plugins are still
+	// returned, so clients can present partial results.
+	PluginErrors []*PluginError `protobuf:"bytes,3,rep,name=plugin_errors,json=pluginErrors,proto3" json:"plugin_errors,omitempty"`
+}
+
+func (x *GetInstalledPackageSummariesResponse) Reset() {
+	*x = GetInstalledPackageSummariesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInstalledPackageSummariesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstalledPackageSummariesResponse) ProtoMessage() {}
+
+func (x *GetInstalledPackageSummariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstalledPackageSummariesResponse.ProtoReflect.Descriptor instead.
+func (*GetInstalledPackageSummariesResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetInstalledPackageSummariesResponse) GetInstalledPackageSummaries() []*InstalledPackageSummary {
+	if x != nil {
+		return x.InstalledPackageSummaries
+	}
+	return nil
+}
+
+func (x *GetInstalledPackageSummariesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetInstalledPackageSummariesResponse) GetPluginErrors() []*PluginError {
+	if x != nil {
+		return x.PluginErrors
+	}
+	return nil
+}
+
+// GetInstalledPackageDetailResponse
+//
+// Response for GetInstalledPackageDetail
+type GetInstalledPackageDetailResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// InstalledPackageDetail
+	//
+	// The requested InstalledPackageDetail
+	InstalledPackageDetail *InstalledPackageDetail `protobuf:"bytes,1,opt,name=installed_package_detail,json=installedPackageDetail,proto3" json:"installed_package_detail,omitempty"`
+}
+
+func (x *GetInstalledPackageDetailResponse) Reset() {
+	*x = GetInstalledPackageDetailResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInstalledPackageDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstalledPackageDetailResponse) ProtoMessage() {}
+
+func (x *GetInstalledPackageDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstalledPackageDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetInstalledPackageDetailResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetInstalledPackageDetailResponse) GetInstalledPackageDetail() *InstalledPackageDetail {
+	if x != nil {
+		return x.InstalledPackageDetail
+	}
+	return nil
+}
+
+// CreateInstalledPackageResponse
+//
+// Response for CreateInstalledPackage
+type CreateInstalledPackageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A reference uniquely identifying the installed package just created.
+	InstalledPackageRef *InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+}
+
+func (x *CreateInstalledPackageResponse) Reset() {
+	*x = CreateInstalledPackageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateInstalledPackageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInstalledPackageResponse) ProtoMessage() {}
+
+func (x *CreateInstalledPackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInstalledPackageResponse.ProtoReflect.Descriptor instead.
+func (*CreateInstalledPackageResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateInstalledPackageResponse) GetInstalledPackageRef() *InstalledPackageReference {
+	if x != nil {
+		return x.InstalledPackageRef
+	}
+	return nil
+}
+
+// UpdateInstalledPackageResponse
+//
+// Response for UpdateInstalledPackage
+type UpdateInstalledPackageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A reference uniquely identifying the installed package just updated.
+	InstalledPackageRef *InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+}
+
+func (x *UpdateInstalledPackageResponse) Reset() {
+	*x = UpdateInstalledPackageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateInstalledPackageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInstalledPackageResponse) ProtoMessage() {}
+
+func (x *UpdateInstalledPackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInstalledPackageResponse.ProtoReflect.Descriptor instead.
+func (*UpdateInstalledPackageResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateInstalledPackageResponse) GetInstalledPackageRef() *InstalledPackageReference {
+	if x != nil {
+		return x.InstalledPackageRef
+	}
+	return nil
+}
+
+// DeleteInstalledPackageResponse
+//
+// Response for DeleteInstalledPackage
+type DeleteInstalledPackageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteInstalledPackageResponse) Reset() {
+	*x = DeleteInstalledPackageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteInstalledPackageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteInstalledPackageResponse) ProtoMessage() {}
+
+func (x *DeleteInstalledPackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteInstalledPackageResponse.ProtoReflect.Descriptor instead.
+func (*DeleteInstalledPackageResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{16}
+}
+
+// WatchInstalledPackagesResponse
+//
+// Response streamed by WatchInstalledPackages for each change of an installed package.
+type WatchInstalledPackagesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type
+	//
+	// The type of the event. Installed packages existing when the watch starts
+	// are sent as added events.
+	Type WatchInstalledPackagesResponse_EventType `protobuf:"varint,1,opt,name=type,proto3,enum=kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesResponse_EventType" json:"type,omitempty"`
+	// Installed package summary
+	//
+	// The summary of the installed package which changed. For deleted events,
+	// the summary reports the last known state of the installed package.
+	InstalledPackageSummary *InstalledPackageSummary `protobuf:"bytes,2,opt,name=installed_package_summary,json=installedPackageSummary,proto3" json:"installed_package_summary,omitempty"`
+	// Plugin error
+	//
+	// Set instead of the summary when the watch of a single plugin failed, so
+	// that clients continue to receive the events of the other plugins.
+	PluginError *PluginError `protobuf:"bytes,3,opt,name=plugin_error,json=pluginError,proto3" json:"plugin_error,omitempty"`
+}
+
+func (x *WatchInstalledPackagesResponse) Reset() {
+	*x = WatchInstalledPackagesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchInstalledPackagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchInstalledPackagesResponse) ProtoMessage() {}
+
+func (x *WatchInstalledPackagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchInstalledPackagesResponse.ProtoReflect.Descriptor instead.
+func (*WatchInstalledPackagesResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WatchInstalledPackagesResponse) GetType() WatchInstalledPackagesResponse_EventType {
+	if x != nil {
+		return x.Type
+	}
+	return WatchInstalledPackagesResponse_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *WatchInstalledPackagesResponse) GetInstalledPackageSummary() *InstalledPackageSummary {
+	if x != nil {
+		return x.InstalledPackageSummary
+	}
+	return nil
+}
+
+func (x *WatchInstalledPackagesResponse) GetPluginError() *PluginError {
+	if x != nil {
+		return x.PluginError
+	}
+	return nil
+}
+
+// AvailablePackageSummary
+//
+// An AvailablePackageSummary provides a summary of a package available for installation
+// useful when aggregating many available packages.
+type AvailablePackageSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Available package reference
+	//
+	// A reference uniquely identifying the package.
+	AvailablePackageRef *AvailablePackageReference `protobuf:"bytes,1,opt,name=available_package_ref,json=availablePackageRef,proto3" json:"available_package_ref,omitempty"`
+	// Available package name
+	//
+	// The name of the available package
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Latest available package version
+	//
+	// The latest version available for this package. Often expected when viewing
+	// a summary of many available packages.
+	LatestPkgVersion string `protobuf:"bytes,3,opt,name=latest_pkg_version,json=latestPkgVersion,proto3" json:"latest_pkg_version,omitempty"`
+	// Available package Icon URL
+	//
+	// A url for an icon.
+	IconUrl string `protobuf:"bytes,4,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
+	// Available package display name
+	//
+	// A name as displayed to users
+	DisplayName string `protobuf:"bytes,5,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Available package short description
+	//
+	// A short description of the app provided by the package
+	ShortDescription string `protobuf:"bytes,6,opt,name=short_description,json=shortDescription,proto3" json:"short_description,omitempty"`
+	// Search snippet
+	//
+	// An excerpt of the description of the package with the words matching the
+	// search query of the request highlighted between <mark> and </mark>. Only
+	// set by plugins supporting full-text search.
+	SearchSnippet string `protobuf:"bytes,7,opt,name=search_snippet,json=searchSnippet,proto3" json:"search_snippet,omitempty"`
+	// Relevance
+	//
+	// The relevance of the package for the search query of the request, greater
+	// for better matches. Only set by plugins supporting full-text search.
+	Relevance float32 `protobuf:"fixed32,8,opt,name=relevance,proto3" json:"relevance,omitempty"`
+}
+
+func (x *AvailablePackageSummary) Reset() {
+	*x = AvailablePackageSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AvailablePackageSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailablePackageSummary) ProtoMessage() {}
+
+func (x *AvailablePackageSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailablePackageSummary.ProtoReflect.Descriptor instead.
+func (*AvailablePackageSummary) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AvailablePackageSummary) GetAvailablePackageRef() *AvailablePackageReference {
+	if x != nil {
+		return x.AvailablePackageRef
+	}
+	return nil
+}
+
+func (x *AvailablePackageSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AvailablePackageSummary) GetLatestPkgVersion() string {
+	if x != nil {
+		return x.LatestPkgVersion
+	}
+	return ""
+}
+
+func (x *AvailablePackageSummary) GetIconUrl() string {
+	if x != nil {
+		return x.IconUrl
+	}
+	return ""
+}
+
+func (x *AvailablePackageSummary) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *AvailablePackageSummary) GetShortDescription() string {
+	if x != nil {
+		return x.ShortDescription
+	}
+	return ""
+}
+
+func (x *AvailablePackageSummary) GetSearchSnippet() string {
+	if x != nil {
+		return x.SearchSnippet
+	}
+	return ""
+}
+
+func (x *AvailablePackageSummary) GetRelevance() float32 {
+	if x != nil {
+		return x.Relevance
+	}
+	return 0
+}
+
+// AvailablePackageDetail
+//
+// An AvailablePackageDetail provides additional details required when
+// inspecting an individual package.
+type AvailablePackageDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Available package reference
+	//
+	// A reference uniquely identifying the package.
+	AvailablePackageRef *AvailablePackageReference `protobuf:"bytes,1,opt,name=available_package_ref,json=availablePackageRef,proto3" json:"available_package_ref,omitempty"`
+	// Available package name
+	//
+	// The name of the available package
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Available package version
+	//
+	// The version of the package (eg. chart version)
+	PkgVersion string `protobuf:"bytes,3,opt,name=pkg_version,json=pkgVersion,proto3" json:"pkg_version,omitempty"`
+	// Available package app version
+	//
+	// The version of the packaged application (eg. wordpress version or whatever).
+	AppVersion string `protobuf:"bytes,4,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	// Available package icon URL
+	//
+	// A url for an icon.
+	IconUrl string `protobuf:"bytes,5,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
+	// Available package display name
+	//
+	// A name as displayed to users
+	DisplayName string `protobuf:"bytes,6,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Available package short description
+	//
+	// A short description of the app provided by the package
+	ShortDescription string `protobuf:"bytes,7,opt,name=short_description,json=shortDescription,proto3" json:"short_description,omitempty"`
+	// Available package long description
+	//
+	// A longer description of the package, a few sentences.
+	LongDescription string `protobuf:"bytes,8,opt,name=long_description,json=longDescription,proto3" json:"long_description,omitempty"`
+	// Available package readme
+	//
+	// A longer README with potentially pages of formatted Markdown.
+	Readme string `protobuf:"bytes,9,opt,name=readme,proto3" json:"readme,omitempty"`
+	// Available package default values
+	//
+	// An example of default values used during package templating that can serve
+	// as documentation or a starting point for user customization.
+	DefaultValues string `protobuf:"bytes,10,opt,name=default_values,json=defaultValues,proto3" json:"default_values,omitempty"`
+	ValuesSchema  string `protobuf:"bytes,11,opt,name=values_schema,json=valuesSchema,proto3" json:"values_schema,omitempty"`
+	// Available package maintainers
+	//
+	// List of Maintainer
+	Maintainers []*Maintainer `protobuf:"bytes,12,rep,name=maintainers,proto3" json:"maintainers,omitempty"`
+	// Custom data added by the plugin
+	//
+	// Some additional information added by the plugin
+	CustomDetail *anypb.Any `protobuf:"bytes,13,opt,name=custom_detail,json=customDetail,proto3" json:"custom_detail,omitempty"`
+}
+
+func (x *AvailablePackageDetail) Reset() {
+	*x = AvailablePackageDetail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AvailablePackageDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailablePackageDetail) ProtoMessage() {}
+
+func (x *AvailablePackageDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailablePackageDetail.ProtoReflect.Descriptor instead.
+func (*AvailablePackageDetail) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AvailablePackageDetail) GetAvailablePackageRef() *AvailablePackageReference {
+	if x != nil {
+		return x.AvailablePackageRef
+	}
+	return nil
+}
+
+func (x *AvailablePackageDetail) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AvailablePackageDetail) GetPkgVersion() string {
+	if x != nil {
+		return x.PkgVersion
+	}
+	return ""
+}
+
+func (x *AvailablePackageDetail) GetAppVersion() string {
+	if x != nil {
+		return x.AppVersion
+	}
+	return ""
+}
+
+func (x *AvailablePackageDetail) GetIconUrl() string {
+	if x != nil {
+		return x.IconUrl
+	}
+	return ""
+}
+
+func (x *AvailablePackageDetail) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *AvailablePackageDetail) GetShortDescription() string {
+	if x != nil {
+		return x.ShortDescription
+	}
+	return ""
+}
+
+func (x *AvailablePackageDetail) GetLongDescription() string {
+	if x != nil {
+		return x.LongDescription
+	}
+	return ""
+}
+
+func (x *AvailablePackageDetail) GetReadme() string {
+	if x != nil {
+		return x.Readme
+	}
+	return ""
+}
+
+func (x *AvailablePackageDetail) GetDefaultValues() string {
+	if x != nil {
+		return x.DefaultValues
+	}
+	return ""
+}
+
+func (x *AvailablePackageDetail) GetValuesSchema() string {
+	if x != nil {
+		return x.ValuesSchema
+	}
+	return ""
+}
+
+func (x *AvailablePackageDetail) GetMaintainers() []*Maintainer {
+	if x != nil {
+		return x.Maintainers
+	}
+	return nil
+}
+
+func (x *AvailablePackageDetail) GetCustomDetail() *anypb.Any {
+	if x != nil {
+		return x.CustomDetail
+	}
+	return nil
+}
+
+// InstalledPackageSummary
 //
-// An AvailablePackageDetail provides additional details required when
-// inspecting an individual package.
-type AvailablePackageDetail struct {
+// An InstalledPackageSummary provides a summary of an installed package
+// useful when aggregating many installed packages.
+type InstalledPackageSummary struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Available package reference
+	// InstalledPackageReference
 	//
 	// A reference uniquely identifying the package.
-	AvailablePackageRef *AvailablePackageReference `protobuf:"bytes,1,opt,name=available_package_ref,json=availablePackageRef,proto3" json:"available_package_ref,omitempty"`
-	// Available package name
+	InstalledPackageRef *InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+	// Name
 	//
-	// The name of the available package
+	// A name given to the installation of the package (eg. "my-postgresql-for-testing").
 	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	// Available package version
-	//
-	// The version of the package (eg. chart version)
-	PkgVersion string `protobuf:"bytes,3,opt,name=pkg_version,json=pkgVersion,proto3" json:"pkg_version,omitempty"`
-	// Available package app version
-	//
-	// The version of the packaged application (eg. wordpress version or whatever).
-	AppVersion string `protobuf:"bytes,4,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
-	// Available package icon URL
+	// PkgVersionReference
 	//
-	// A url for an icon.
-	IconUrl string `protobuf:"bytes,5,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
-	// Available package display name
+	// The package version reference defines a version or constraint limiting
+	// matching package versions.
+	PkgVersionReference *VersionReference `protobuf:"bytes,3,opt,name=pkg_version_reference,json=pkgVersionReference,proto3" json:"pkg_version_reference,omitempty"`
+	// CurrentPkgVersion
 	//
-	// A name as displayed to users
-	DisplayName string `protobuf:"bytes,6,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
-	// Available package short description
+	// The currently installed package version.
+	CurrentPkgVersion string `protobuf:"bytes,4,opt,name=current_pkg_version,json=currentPkgVersion,proto3" json:"current_pkg_version,omitempty"`
+	// CurrentAppVersion
 	//
-	// A short description of the app provided by the package
-	ShortDescription string `protobuf:"bytes,7,opt,name=short_description,json=shortDescription,proto3" json:"short_description,omitempty"`
-	// Available package long description
+	// The version of the application currently installed.
+	CurrentAppVersion string `protobuf:"bytes,5,opt,name=current_app_version,json=currentAppVersion,proto3" json:"current_app_version,omitempty"`
+	// Installed package icon URL
 	//
-	// A longer description of the package, a few sentences.
-	LongDescription string `protobuf:"bytes,8,opt,name=long_description,json=longDescription,proto3" json:"long_description,omitempty"`
-	// Available package readme
+	// A url for an icon.
+	IconUrl string `protobuf:"bytes,6,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
+	// PackageDisplayName
 	//
-	// A longer README with potentially pages of formatted Markdown.
-	Readme string `protobuf:"bytes,9,opt,name=readme,proto3" json:"readme,omitempty"`
-	// Available package default values
+	// The package name as displayed to users (provided by the package, eg. "PostgreSQL")
+	PkgDisplayName string `protobuf:"bytes,7,opt,name=pkg_display_name,json=pkgDisplayName,proto3" json:"pkg_display_name,omitempty"`
+	// ShortDescription
 	//
-	// An example of default values used during package templating that can serve
-	// as documentation or a starting point for user customization.
-	DefaultValues string `protobuf:"bytes,10,opt,name=default_values,json=defaultValues,proto3" json:"default_values,omitempty"`
-	ValuesSchema  string `protobuf:"bytes,11,opt,name=values_schema,json=valuesSchema,proto3" json:"values_schema,omitempty"`
-	// Available package maintainers
+	// A short description of the package (provided by the package)
+	ShortDescription string `protobuf:"bytes,8,opt,name=short_description,json=shortDescription,proto3" json:"short_description,omitempty"`
+	// LatestPkgVersion
 	//
-	// List of Maintainer
-	Maintainers []*Maintainer `protobuf:"bytes,12,rep,name=maintainers,proto3" json:"maintainers,omitempty"`
-	// Custom data added by the plugin
+	// The latest version available for this package, regardless of the pkg_version_reference.
+	LatestPkgVersion string `protobuf:"bytes,9,opt,name=latest_pkg_version,json=latestPkgVersion,proto3" json:"latest_pkg_version,omitempty"`
+	// Status
 	//
-	// Some additional information added by the plugin
-	CustomDetail *anypb.Any `protobuf:"bytes,13,opt,name=custom_detail,json=customDetail,proto3" json:"custom_detail,omitempty"`
+	// The current status of the installed package.
+	Status *InstalledPackageStatus `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-func (x *AvailablePackageDetail) Reset() {
-	*x = AvailablePackageDetail{}
+func (x *InstalledPackageSummary) Reset() {
+	*x = InstalledPackageSummary{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[7]
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AvailablePackageDetail) String() string {
+func (x *InstalledPackageSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AvailablePackageDetail) ProtoMessage() {}
+func (*InstalledPackageSummary) ProtoMessage() {}
 
-func (x *AvailablePackageDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[7]
+func (x *InstalledPackageSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -609,102 +1797,408 @@ func (x *AvailablePackageDetail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AvailablePackageDetail.ProtoReflect.Descriptor instead.
-func (*AvailablePackageDetail) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use InstalledPackageSummary.ProtoReflect.Descriptor instead.
+func (*InstalledPackageSummary) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *AvailablePackageDetail) GetAvailablePackageRef() *AvailablePackageReference {
+func (x *InstalledPackageSummary) GetInstalledPackageRef() *InstalledPackageReference {
 	if x != nil {
-		return x.AvailablePackageRef
+		return x.InstalledPackageRef
 	}
 	return nil
 }
 
-func (x *AvailablePackageDetail) GetName() string {
+func (x *InstalledPackageSummary) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *AvailablePackageDetail) GetPkgVersion() string {
+func (x *InstalledPackageSummary) GetPkgVersionReference() *VersionReference {
 	if x != nil {
-		return x.PkgVersion
+		return x.PkgVersionReference
+	}
+	return nil
+}
+
+func (x *InstalledPackageSummary) GetCurrentPkgVersion() string {
+	if x != nil {
+		return x.CurrentPkgVersion
 	}
 	return ""
 }
 
-func (x *AvailablePackageDetail) GetAppVersion() string {
+func (x *InstalledPackageSummary) GetCurrentAppVersion() string {
 	if x != nil {
-		return x.AppVersion
+		return x.CurrentAppVersion
 	}
 	return ""
 }
 
-func (x *AvailablePackageDetail) GetIconUrl() string {
+func (x *InstalledPackageSummary) GetIconUrl() string {
 	if x != nil {
 		return x.IconUrl
 	}
 	return ""
 }
 
-func (x *AvailablePackageDetail) GetDisplayName() string {
+func (x *InstalledPackageSummary) GetPkgDisplayName() string {
 	if x != nil {
-		return x.DisplayName
+		return x.PkgDisplayName
 	}
 	return ""
 }
 
-func (x *AvailablePackageDetail) GetShortDescription() string {
+func (x *InstalledPackageSummary) GetShortDescription() string {
 	if x != nil {
 		return x.ShortDescription
 	}
 	return ""
 }
 
-func (x *AvailablePackageDetail) GetLongDescription() string {
+func (x *InstalledPackageSummary) GetLatestPkgVersion() string {
 	if x != nil {
-		return x.LongDescription
+		return x.LatestPkgVersion
 	}
 	return ""
 }
 
-func (x *AvailablePackageDetail) GetReadme() string {
+func (x *InstalledPackageSummary) GetStatus() *InstalledPackageStatus {
 	if x != nil {
-		return x.Readme
+		return x.Status
+	}
+	return nil
+}
+
+// InstalledPackageDetail
+//
+// An InstalledPackageDetail includes details about the installed package that are
+// typically useful when presenting a single installed package.
+type InstalledPackageDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// InstalledPackageReference
+	//
+	// A reference uniquely identifying the installed package.
+	InstalledPackageRef *InstalledPackageReference `protobuf:"bytes,1,opt,name=installed_package_ref,json=installedPackageRef,proto3" json:"installed_package_ref,omitempty"`
+	// PkgVersionReference
+	//
+	// The package version reference defines a version or constraint limiting
+	// matching package versions.
+	PkgVersionReference *VersionReference `protobuf:"bytes,2,opt,name=pkg_version_reference,json=pkgVersionReference,proto3" json:"pkg_version_reference,omitempty"`
+	// Installed package name
+	//
+	// The name given to the installed package
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// CurrentPkgVersion
+	//
+	// The version of the package which is currently installed.
+	CurrentPkgVersion string `protobuf:"bytes,4,opt,name=current_pkg_version,json=currentPkgVersion,proto3" json:"current_pkg_version,omitempty"`
+	// CurrentAppVersion
+	//
+	// The version of the application currently installed.
+	CurrentAppVersion string `protobuf:"bytes,5,opt,name=current_app_version,json=currentAppVersion,proto3" json:"current_app_version,omitempty"`
+	// ValuesApplied
+	//
+	// The values applied currently for the installed package.
+	ValuesApplied string `protobuf:"bytes,6,opt,name=values_applied,json=valuesApplied,proto3" json:"values_applied,omitempty"`
+	// ReconciliationOptions
+	//
+	// An optional field specifying data common to systems that reconcile
+	// the package installation on the cluster asynchronously. In particular,
+	// this specifies the service account used to perform the reconcilliation.
+	ReconciliationOptions *ReconciliationOptions `protobuf:"bytes,7,opt,name=reconciliation_options,json=reconciliationOptions,proto3" json:"reconciliation_options,omitempty"`
+	// Status
+	//
+	// The current status of the installed package.
+	Status *InstalledPackageStatus `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	// PostInstallationNotes
+	//
+	// Optional notes generated by package and intended for the user post installation.
+	PostInstallationNotes string `protobuf:"bytes,9,opt,name=post_installation_notes,json=postInstallationNotes,proto3" json:"post_installation_notes,omitempty"`
+	// Available package reference
+	//
+	// A reference to the available package for this installation.
+	// Useful to lookup the package display name, icon and other info.
+	AvailablePackageRef *AvailablePackageReference `protobuf:"bytes,10,opt,name=available_package_ref,json=availablePackageRef,proto3" json:"available_package_ref,omitempty"`
+	// Custom data added by the plugin
+	//
+	// A plugin can define custom details for data which is not yet, or never will
+	// be specified in the core.packaging.CreateInstalledPackageRequest fields. The use
+	// of an `Any` field means that each plugin can define the structure of this
+	// message as required, while still satisfying the core interface.
+	// See https://developers.google.com/protocol-buffers/docs/proto3#any
+	CustomDetail *anypb.Any `protobuf:"bytes,11,opt,name=custom_detail,json=customDetail,proto3" json:"custom_detail,omitempty"`
+}
+
+func (x *InstalledPackageDetail) Reset() {
+	*x = InstalledPackageDetail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstalledPackageDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstalledPackageDetail) ProtoMessage() {}
+
+func (x *InstalledPackageDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstalledPackageDetail.ProtoReflect.Descriptor instead.
+func (*InstalledPackageDetail) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *InstalledPackageDetail) GetInstalledPackageRef() *InstalledPackageReference {
+	if x != nil {
+		return x.InstalledPackageRef
+	}
+	return nil
+}
+
+func (x *InstalledPackageDetail) GetPkgVersionReference() *VersionReference {
+	if x != nil {
+		return x.PkgVersionReference
+	}
+	return nil
+}
+
+func (x *InstalledPackageDetail) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (x *AvailablePackageDetail) GetDefaultValues() string {
+func (x *InstalledPackageDetail) GetCurrentPkgVersion() string {
 	if x != nil {
-		return x.DefaultValues
+		return x.CurrentPkgVersion
 	}
 	return ""
 }
 
-func (x *AvailablePackageDetail) GetValuesSchema() string {
+func (x *InstalledPackageDetail) GetCurrentAppVersion() string {
 	if x != nil {
-		return x.ValuesSchema
+		return x.CurrentAppVersion
 	}
 	return ""
 }
 
-func (x *AvailablePackageDetail) GetMaintainers() []*Maintainer {
+func (x *InstalledPackageDetail) GetValuesApplied() string {
 	if x != nil {
-		return x.Maintainers
+		return x.ValuesApplied
+	}
+	return ""
+}
+
+func (x *InstalledPackageDetail) GetReconciliationOptions() *ReconciliationOptions {
+	if x != nil {
+		return x.ReconciliationOptions
 	}
 	return nil
 }
 
-func (x *AvailablePackageDetail) GetCustomDetail() *anypb.Any {
+func (x *InstalledPackageDetail) GetStatus() *InstalledPackageStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *InstalledPackageDetail) GetPostInstallationNotes() string {
+	if x != nil {
+		return x.PostInstallationNotes
+	}
+	return ""
+}
+
+func (x *InstalledPackageDetail) GetAvailablePackageRef() *AvailablePackageReference {
+	if x != nil {
+		return x.AvailablePackageRef
+	}
+	return nil
+}
+
+func (x *InstalledPackageDetail) GetCustomDetail() *anypb.Any {
 	if x != nil {
 		return x.CustomDetail
 	}
 	return nil
 }
 
+// InstalledPackageStatus
+//
+// An InstalledPackageStatus reports on the current status of the installation.
+type InstalledPackageStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Ready
+	//
+	// An indication of whether the installation is ready or not
+	Ready bool `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	// Reason
+	//
+	// An enum indicating the reason for the current status.
+	Reason InstalledPackageStatus_StatusReason `protobuf:"varint,2,opt,name=reason,proto3,enum=kubeappsapis.core.packages.v1alpha1.InstalledPackageStatus_StatusReason" json:"reason,omitempty"`
+	// UserReason
+	//
+	// Optional text to return for user context, which may be plugin specific.
+	UserReason string `protobuf:"bytes,3,opt,name=user_reason,json=userReason,proto3" json:"user_reason,omitempty"`
+}
+
+func (x *InstalledPackageStatus) Reset() {
+	*x = InstalledPackageStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstalledPackageStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstalledPackageStatus) ProtoMessage() {}
+
+func (x *InstalledPackageStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstalledPackageStatus.ProtoReflect.Descriptor instead.
+func (*InstalledPackageStatus) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *InstalledPackageStatus) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *InstalledPackageStatus) GetReason() InstalledPackageStatus_StatusReason {
+	if x != nil {
+		return x.Reason
+	}
+	return InstalledPackageStatus_STATUS_REASON_UNSPECIFIED
+}
+
+func (x *InstalledPackageStatus) GetUserReason() string {
+	if x != nil {
+		return x.UserReason
+	}
+	return ""
+}
+
+// ReconciliationOptions
+//
+// ReconciliationOptions enable specifying standard fields for backends that continuously
+// reconcile a package install as new matching versions are released. Most of the naming
+// is from the flux HelmReleaseSpec though it should be relevant for kapp controller
+// PackageInstall too.
+type ReconciliationOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Reconciliation Interval
+	//
+	// The interval with which the package is checked for reconciliation (in seconds)
+	Interval int32 `protobuf:"varint,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Suspend
+	//
+	// Whether reconciliation should be suspended until otherwise enabled.
+	// This is useful for debugging, and could be presented in UIs for that purpose.
+	Suspend bool `protobuf:"varint,2,opt,name=suspend,proto3" json:"suspend,omitempty"`
+	// ServiceAccountName
+	//
+	// A name for a service account in the same namespace which should be used
+	// to perform the reconciliation.
+	ServiceAccountName string `protobuf:"bytes,3,opt,name=service_account_name,json=serviceAccountName,proto3" json:"service_account_name,omitempty"`
+}
+
+func (x *ReconciliationOptions) Reset() {
+	*x = ReconciliationOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconciliationOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationOptions) ProtoMessage() {}
+
+func (x *ReconciliationOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationOptions.ProtoReflect.Descriptor instead.
+func (*ReconciliationOptions) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReconciliationOptions) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *ReconciliationOptions) GetSuspend() bool {
+	if x != nil {
+		return x.Suspend
+	}
+	return false
+}
+
+func (x *ReconciliationOptions) GetServiceAccountName() string {
+	if x != nil {
+		return x.ServiceAccountName
+	}
+	return ""
+}
+
 // Context
 //
 // A Context specifies the context of the message
@@ -728,7 +2222,7 @@ type Context struct {
 func (x *Context) Reset() {
 	*x = Context{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[8]
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -741,7 +2235,7 @@ func (x *Context) String() string {
 func (*Context) ProtoMessage() {}
 
 func (x *Context) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[8]
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -754,7 +2248,7 @@ func (x *Context) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Context.ProtoReflect.Descriptor instead.
 func (*Context) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{8}
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *Context) GetCluster() string {
@@ -808,7 +2302,7 @@ type AvailablePackageReference struct {
 func (x *AvailablePackageReference) Reset() {
 	*x = AvailablePackageReference{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[9]
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -821,7 +2315,7 @@ func (x *AvailablePackageReference) String() string {
 func (*AvailablePackageReference) ProtoMessage() {}
 
 func (x *AvailablePackageReference) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[9]
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -834,7 +2328,7 @@ func (x *AvailablePackageReference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AvailablePackageReference.ProtoReflect.Descriptor instead.
 func (*AvailablePackageReference) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{9}
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *AvailablePackageReference) GetContext() *Context {
@@ -858,6 +2352,137 @@ func (x *AvailablePackageReference) GetPlugin() *v1alpha1.Plugin {
 	return nil
 }
 
+// InstalledPackageReference
+//
+// An InstalledPackageReference has the minimum information required to uniquely
+// identify an installed package.
+type InstalledPackageReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Installed package context
+	//
+	// The context (cluster/namespace) for the package.
+	Context *Context `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	// The fully qualified identifier for the installed package
+	// (ie. a unique name for the context).
+	Identifier string `protobuf:"bytes,2,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	// The plugin used to identify and interact with the installed package.
+	// This field can be omitted when the request is in the context of a specific plugin.
+	Plugin *v1alpha1.Plugin `protobuf:"bytes,3,opt,name=plugin,proto3" json:"plugin,omitempty"`
+}
+
+func (x *InstalledPackageReference) Reset() {
+	*x = InstalledPackageReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstalledPackageReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstalledPackageReference) ProtoMessage() {}
+
+func (x *InstalledPackageReference) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstalledPackageReference.ProtoReflect.Descriptor instead.
+func (*InstalledPackageReference) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *InstalledPackageReference) GetContext() *Context {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *InstalledPackageReference) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *InstalledPackageReference) GetPlugin() *v1alpha1.Plugin {
+	if x != nil {
+		return x.Plugin
+	}
+	return nil
+}
+
+// VersionReference
+//
+// A VersionReference defines a version or constraint limiting matching versions.
+// The reason it is a separate message is so that in the future we can add other
+// fields as necessary (such as something similar to helm's `--devel` flag to
+// allow matching pre-release versions).
+type VersionReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version
+	//
+	// The format of the version constraint depends on the backend. For example,
+	// for a flux v2 and Carvel it’s a semver expression, such as ">=10.3 < 10.4"
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *VersionReference) Reset() {
+	*x = VersionReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionReference) ProtoMessage() {}
+
+func (x *VersionReference) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionReference.ProtoReflect.Descriptor instead.
+func (*VersionReference) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *VersionReference) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
 // Maintainer
 //
 // Maintainers for the package.
@@ -879,7 +2504,7 @@ type Maintainer struct {
 func (x *Maintainer) Reset() {
 	*x = Maintainer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[10]
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -892,7 +2517,7 @@ func (x *Maintainer) String() string {
 func (*Maintainer) ProtoMessage() {}
 
 func (x *Maintainer) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[10]
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -905,7 +2530,7 @@ func (x *Maintainer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Maintainer.ProtoReflect.Descriptor instead.
 func (*Maintainer) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{10}
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *Maintainer) GetName() string {
@@ -950,12 +2575,22 @@ type FilterOptions struct {
 	//
 	// Packaged app version for the request
 	AppVersion string `protobuf:"bytes,5,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	// Version constraint
+	//
+	// Semantic version constraint (eg. "~1.4") which at least one version of
+	// the packages must match. The latest version of each package is then the
+	// latest version matching the constraint.
+	VersionConstraint string `protobuf:"bytes,6,opt,name=version_constraint,json=versionConstraint,proto3" json:"version_constraint,omitempty"`
+	// Include prereleases
+	//
+	// Whether the versions matching the version constraint include prereleases.
+	IncludePrereleases bool `protobuf:"varint,7,opt,name=include_prereleases,json=includePrereleases,proto3" json:"include_prereleases,omitempty"`
 }
 
 func (x *FilterOptions) Reset() {
 	*x = FilterOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[11]
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -968,7 +2603,7 @@ func (x *FilterOptions) String() string {
 func (*FilterOptions) ProtoMessage() {}
 
 func (x *FilterOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[11]
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -981,7 +2616,7 @@ func (x *FilterOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FilterOptions.ProtoReflect.Descriptor instead.
 func (*FilterOptions) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{11}
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *FilterOptions) GetQuery() string {
@@ -1019,6 +2654,162 @@ func (x *FilterOptions) GetAppVersion() string {
 	return ""
 }
 
+func (x *FilterOptions) GetVersionConstraint() string {
+	if x != nil {
+		return x.VersionConstraint
+	}
+	return ""
+}
+
+func (x *FilterOptions) GetIncludePrereleases() bool {
+	if x != nil {
+		return x.IncludePrereleases
+	}
+	return false
+}
+
+// SortOptions
+//
+// SortOptions available when requesting summaries. Paginated results can
+// only be sorted by name or relevance in ascending order, the
+// order in which plugins return their pages.
+type SortOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Field
+	//
+	// The field used to sort the results.
+	Field SortOptions_SortField `protobuf:"varint,1,opt,name=field,proto3,enum=kubeappsapis.core.packages.v1alpha1.SortOptions_SortField" json:"field,omitempty"`
+	// Descending
+	//
+	// Whether the results are sorted in descending order.
+	Descending bool `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+}
+
+func (x *SortOptions) Reset() {
+	*x = SortOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SortOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortOptions) ProtoMessage() {}
+
+func (x *SortOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortOptions.ProtoReflect.Descriptor instead.
+func (*SortOptions) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SortOptions) GetField() SortOptions_SortField {
+	if x != nil {
+		return x.Field
+	}
+	return SortOptions_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *SortOptions) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+// PluginError
+//
+// A PluginError reports the failure of a single plugin when aggregating the
+// results of several plugins.
+type PluginError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Plugin
+	//
+	// The plugin which returned the error.
+	Plugin *v1alpha1.Plugin `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	// Status code
+	//
+	// The gRPC status code returned by the plugin, such as `DeadlineExceeded`.
+	StatusCode string `protobuf:"bytes,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// Message
+	//
+	// The error message returned by the plugin.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *PluginError) Reset() {
+	*x = PluginError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PluginError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginError) ProtoMessage() {}
+
+func (x *PluginError) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginError.ProtoReflect.Descriptor instead.
+func (*PluginError) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PluginError) GetPlugin() *v1alpha1.Plugin {
+	if x != nil {
+		return x.Plugin
+	}
+	return nil
+}
+
+func (x *PluginError) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *PluginError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 // PaginationOptions
 //
 // The PaginationOptions based on the example proto at:
@@ -1032,6 +2823,9 @@ type PaginationOptions struct {
 	// Page token
 	//
 	// The client uses this field to request a specific page of the list results.
+	// When requesting the core API, the token is an opaque value returned as the
+	// next_page_token of the previous response, which encodes the position of each
+	// plugin's results.
 	PageToken string `protobuf:"bytes,1,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	// Page size
 	//
@@ -1045,7 +2839,7 @@ type PaginationOptions struct {
 func (x *PaginationOptions) Reset() {
 	*x = PaginationOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[12]
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1058,7 +2852,7 @@ func (x *PaginationOptions) String() string {
 func (*PaginationOptions) ProtoMessage() {}
 
 func (x *PaginationOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[12]
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,7 +2865,7 @@ func (x *PaginationOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PaginationOptions.ProtoReflect.Descriptor instead.
 func (*PaginationOptions) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{12}
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *PaginationOptions) GetPageToken() string {
@@ -1101,7 +2895,7 @@ type GetAvailablePackageVersionsResponse_PackageAppVersion struct {
 func (x *GetAvailablePackageVersionsResponse_PackageAppVersion) Reset() {
 	*x = GetAvailablePackageVersionsResponse_PackageAppVersion{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[13]
+		mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1114,7 +2908,7 @@ func (x *GetAvailablePackageVersionsResponse_PackageAppVersion) String() string
 func (*GetAvailablePackageVersionsResponse_PackageAppVersion) ProtoMessage() {}
 
 func (x *GetAvailablePackageVersionsResponse_PackageAppVersion) ProtoReflect() protoreflect.Message {
-	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[13]
+	mi := &file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1127,7 +2921,7 @@ func (x *GetAvailablePackageVersionsResponse_PackageAppVersion) ProtoReflect() p
 
 // Deprecated: Use GetAvailablePackageVersionsResponse_PackageAppVersion.ProtoReflect.Descriptor instead.
 func (*GetAvailablePackageVersionsResponse_PackageAppVersion) Descriptor() ([]byte, []int) {
-	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{5, 0}
+	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP(), []int{11, 0}
 }
 
 func (x *GetAvailablePackageVersionsResponse_PackageAppVersion) GetPkgVersion() string {
@@ -1162,7 +2956,7 @@ var file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e,
 	0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
 	0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xaf, 0x02, 0x0a, 0x23, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x84, 0x03, 0x0a, 0x23, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69,
 	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d,
 	0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07,
 	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e,
@@ -1181,165 +2975,528 @@ var file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDesc = []byte{
 	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
 	0x31, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69,
 	0x6f, 0x6e, 0x73, 0x52, 0x11, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb7, 0x01, 0x0a, 0x20, 0x47, 0x65, 0x74, 0x41, 0x76,
-	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x72, 0x0a, 0x15, 0x61,
-	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x53, 0x0a, 0x0c, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b,
+	0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0b,
+	0x73, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb7, 0x01, 0x0a, 0x20,
+	0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x72, 0x0a, 0x15, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x66, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6b, 0x67, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x99, 0x02, 0x0a, 0x22, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x72, 0x0a, 0x15,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66,
+	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74,
+	0x12, 0x2f, 0x0a, 0x13, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x72,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x72, 0x65, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x73, 0x22, 0xd4, 0x01, 0x0a, 0x23, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62,
 	0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70,
 	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x61, 0x76, 0x61, 0x69,
-	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12,
-	0x1f, 0x0a, 0x0b, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x22, 0xb9, 0x01, 0x0a, 0x22, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
-	0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x72, 0x0a, 0x15, 0x61, 0x76, 0x61, 0x69, 0x6c,
-	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70,
-	0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61,
-	0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x76, 0x61,
-	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
-	0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
-	0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xce, 0x01, 0x0a,
-	0x24, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7e, 0x0a, 0x1c, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
-	0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x5f, 0x73, 0x75, 0x6d, 0x6d,
-	0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x6b, 0x75,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x65, 0x0a, 0x12, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x11, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x96, 0x01, 0x0a, 0x20, 0x47, 0x65, 0x74,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x72, 0x0a,
+	0x15, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b,
+	0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x66, 0x22, 0xf2, 0x03, 0x0a, 0x1d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x72, 0x0a, 0x15, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x53, 0x0a, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0d, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x69, 0x0a, 0x15, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x35, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x12, 0x71, 0x0a, 0x16, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70,
+	0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63,
+	0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x15, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x89, 0x03, 0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x72, 0x0a, 0x15, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65,
+	0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70,
+	0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x69, 0x0a, 0x15,
+	0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x75,
 	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
 	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61,
-	0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x1a, 0x61, 0x76, 0x61, 0x69, 0x6c,
-	0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x53, 0x75, 0x6d, 0x6d,
-	0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61,
-	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
-	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x9a, 0x01,
-	0x0a, 0x21, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61,
-	0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x75, 0x0a, 0x18, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
-	0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73,
-	0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x69,
-	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61,
-	0x69, 0x6c, 0x52, 0x16, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x8b, 0x02, 0x0a, 0x23, 0x47,
-	0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61,
-	0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x8c, 0x01, 0x0a, 0x14, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x61,
-	0x70, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x5a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73,
+	0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x13, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12,
+	0x71, 0x0a, 0x16, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x3a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x15, 0x72, 0x65, 0x63,
+	0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x1d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x72, 0x0a, 0x15, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70,
+	0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x22, 0x67, 0x0a, 0x1d, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62,
+	0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x22, 0xa5, 0x02, 0x0a, 0x24, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7e, 0x0a, 0x1c, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
+	0x5f, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x3c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x1a,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x55, 0x0a, 0x0d, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x0c, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x9a, 0x01, 0x0a, 0x21, 0x47, 0x65,
+	0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x75, 0x0a, 0x18, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x3b, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73,
 	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c,
-	0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x61, 0x63, 0x6b,
-	0x61, 0x67, 0x65, 0x41, 0x70, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x70,
-	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x41, 0x70, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x1a, 0x55, 0x0a, 0x11, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x41, 0x70, 0x70, 0x56,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6b, 0x67,
-	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70,
-	0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xba, 0x02, 0x0a, 0x17, 0x41, 0x76, 0x61,
-	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d,
-	0x6d, 0x61, 0x72, 0x79, 0x12, 0x72, 0x0a, 0x15, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
-	0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x16,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x8b, 0x02, 0x0a, 0x23, 0x47, 0x65, 0x74, 0x41, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8c,
+	0x01, 0x0a, 0x14, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x61, 0x70, 0x70, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x5a, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x41,
+	0x70, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x41, 0x70, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x55, 0x0a,
+	0x11, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x41, 0x70, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0xa3, 0x02, 0x0a, 0x24, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7c, 0x0a,
+	0x1b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x52, 0x19, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x55, 0x0a, 0x0d, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x75, 0x62,
+	0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x0c, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x9a, 0x01, 0x0a, 0x21, 0x47,
+	0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x75, 0x0a, 0x18, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52,
+	0x16, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x94, 0x01, 0x0a, 0x1e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x15, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
+	0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x22, 0x94,
+	0x01, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x72, 0x0a, 0x15, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x66, 0x22, 0x20, 0x0a, 0x1e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc2, 0x03, 0x0a, 0x1e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x4d, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61,
+	0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x78, 0x0a,
+	0x19, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x3c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x17,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x53, 0x0a, 0x0c, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
+	0x0b, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x6e, 0x0a, 0x09,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x56, 0x45,
+	0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x22, 0xff, 0x02, 0x0a,
+	0x17, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x72, 0x0a, 0x15, 0x61, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65,
+	0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70,
+	0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x2c, 0x0a, 0x12, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x6b, 0x67, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x74, 0x50, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19,
+	0x0a, 0x08, 0x69, 0x63, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x69, 0x63, 0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11,
+	0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x5f, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x09, 0x72, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x22, 0xea,
+	0x04, 0x0a, 0x16, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x72, 0x0a, 0x15, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72,
+	0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61,
+	0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x63, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x63, 0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x21,
+	0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x68,
+	0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29,
+	0x0a, 0x10, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x6f, 0x6e, 0x67, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x64, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x64, 0x6d,
+	0x65, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x51, 0x0a,
+	0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x52, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73,
+	0x12, 0x39, 0x0a, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x0c, 0x63,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0xe1, 0x04, 0x0a, 0x17,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x72, 0x0a, 0x15, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70,
+	0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x69, 0x0a, 0x15, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x50, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x41, 0x70, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x63,
+	0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x63,
+	0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x6b, 0x67, 0x5f, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x70, 0x6b, 0x67, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x2b, 0x0a, 0x11, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x68, 0x6f, 0x72,
+	0x74, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x12,
+	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74,
+	0x50, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x53, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6b, 0x75, 0x62,
+	0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
+	0xc1, 0x06, 0x0a, 0x16, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x72, 0x0a, 0x15, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f,
+	0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x69,
+	0x0a, 0x15, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a,
+	0x13, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x50, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a,
+	0x13, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x70, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x41, 0x70, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a,
+	0x0e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x5f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x41, 0x70, 0x70,
+	0x6c, 0x69, 0x65, 0x64, 0x12, 0x71, 0x0a, 0x16, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c,
+	0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e,
+	0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x15, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x53, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70,
+	0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x36, 0x0a, 0x17,
+	0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x70,
+	0x6f, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
+	0x6f, 0x74, 0x65, 0x73, 0x12, 0x72, 0x0a, 0x15, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x0a, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70,
 	0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
 	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61,
 	0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
 	0x6e, 0x63, 0x65, 0x52, 0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61,
-	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12,
-	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74,
-	0x50, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x63,
-	0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x63,
-	0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73,
-	0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x68, 0x6f, 0x72,
-	0x74, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xea, 0x04, 0x0a, 0x16, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61,
-	0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
-	0x12, 0x72, 0x0a, 0x15, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x61,
-	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50,
-	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52,
-	0x13, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6b, 0x67, 0x5f,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70,
-	0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x70,
-	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x61, 0x70, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x63,
-	0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x63,
-	0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73,
-	0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x68, 0x6f, 0x72,
-	0x74, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0f, 0x6c, 0x6f, 0x6e, 0x67, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x64, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x72, 0x65, 0x61, 0x64, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61,
-	0x75, 0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12,
-	0x23, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x53, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x12, 0x51, 0x0a, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x75, 0x62, 0x65,
-	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61,
-	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x4d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x0b, 0x6d, 0x61, 0x69, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x39, 0x0a, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f,
-	0x6d, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x41, 0x6e, 0x79, 0x52, 0x0c, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x44, 0x65, 0x74, 0x61,
-	0x69, 0x6c, 0x22, 0x41, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x18, 0x0a,
-	0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0xc7, 0x01, 0x0a, 0x19, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61,
-	0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x12, 0x39, 0x0a, 0x0d, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x0c, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x22, 0xd2, 0x02, 0x0a, 0x16, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72,
+	0x65, 0x61, 0x64, 0x79, 0x12, 0x60, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x48, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
 	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x69,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x42, 0x0a, 0x06, 0x70,
-	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b, 0x75,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x9e, 0x01, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x53, 0x54, 0x41, 0x4c, 0x4c,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52,
+	0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x49, 0x4e, 0x53, 0x54, 0x41, 0x4c, 0x4c, 0x45,
+	0x44, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45,
+	0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x19, 0x0a,
+	0x15, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x50,
+	0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x22, 0x7f, 0x0a, 0x15, 0x52, 0x65, 0x63, 0x6f,
+	0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x41, 0x0a, 0x07, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1c,
+	0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0xc7, 0x01, 0x0a,
+	0x19, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75,
 	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
-	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x52, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x22,
-	0x36, 0x0a, 0x0a, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0xab, 0x01, 0x0a, 0x0d, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65,
-	0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12,
-	0x1e, 0x0a, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12,
-	0x22, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
-	0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4f, 0x0a, 0x11, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x12, 0x42, 0x0a, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x52, 0x06,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x22, 0xc7, 0x01, 0x0a, 0x19, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73,
+	0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x42, 0x0a, 0x06,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b,
+	0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x52, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x22, 0x2c, 0x0a, 0x10, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x36,
+	0x0a, 0x0a, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x8b, 0x02, 0x0a, 0x0d, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x13, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70,
+	0x72, 0x65, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x72, 0x65, 0x72, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x73, 0x22, 0xaf, 0x02, 0x0a, 0x0b, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x50, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x3a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70,
+	0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0xad, 0x01, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x49, 0x45,
+	0x4c, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x4e,
+	0x41, 0x4d, 0x45, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x49,
+	0x45, 0x4c, 0x44, 0x5f, 0x44, 0x49, 0x53, 0x50, 0x4c, 0x41, 0x59, 0x5f, 0x4e, 0x41, 0x4d, 0x45,
+	0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44,
+	0x5f, 0x4c, 0x41, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x10,
+	0x03, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f,
+	0x52, 0x45, 0x50, 0x4f, 0x53, 0x49, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14,
+	0x53, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x52, 0x45, 0x4c, 0x45, 0x56,
+	0x41, 0x4e, 0x43, 0x45, 0x10, 0x05, 0x22, 0x8c, 0x01, 0x0a, 0x0b, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x42, 0x0a, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70,
+	0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x52, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4f, 0x0a, 0x11, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
 	0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61,
 	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
 	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67,
 	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
-	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x32, 0xd5, 0x05, 0x0a, 0x0f, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x32, 0x96, 0x10, 0x0a, 0x0f, 0x50, 0x61, 0x63, 0x6b, 0x61,
 	0x67, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xee, 0x01, 0x0a, 0x1c, 0x47,
 	0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61,
 	0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x48, 0x2e, 0x6b, 0x75,
@@ -1384,13 +3541,97 @@ var file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDesc = []byte{
 	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x38, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x32, 0x12, 0x30, 0x2f, 0x63,
 	0x6f, 0x72, 0x65, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61,
 	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x70,
-	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x4b,
-	0x5a, 0x49, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x75, 0x62,
-	0x65, 0x61, 0x70, 0x70, 0x73, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x2f, 0x63,
-	0x6d, 0x64, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x73,
-	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0xee,
+	0x01, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x48, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x49, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x39, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x33, 0x12, 0x31, 0x2f, 0x63,
+	0x6f, 0x72, 0x65, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0xe3, 0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x45, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x46, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x37, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x31, 0x12, 0x2f, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0xd7, 0x01, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x12, 0x42, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x43, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x34, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x2e, 0x3a, 0x01, 0x2a, 0x22, 0x29, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x12,
+	0xd7, 0x01, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x42, 0x2e, 0x6b, 0x75, 0x62,
+	0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x43,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x34, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2e, 0x3a, 0x01, 0x2a, 0x1a, 0x29,
+	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x12, 0xd4, 0x01, 0x0a, 0x16, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x12, 0x42, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x43, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61,
+	0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x31, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x2b, 0x2a, 0x29, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
+	0x12, 0xdc, 0x01, 0x0a, 0x16, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x12, 0x42, 0x2e, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x43, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x37, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x31, 0x12, 0x2f, 0x2f, 0x63,
+	0x6f, 0x72, 0x65, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x42,
+	0x4b, 0x5a, 0x49, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x2f,
+	0x63, 0x6d, 0x64, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x2d, 0x61, 0x70, 0x69,
+	0x73, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1405,51 +3646,123 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescGZIP() []byt
 	return file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDescData
 }
 
-var file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_kubeappsapis_core_packages_v1alpha1_packages_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_kubeappsapis_core_packages_v1alpha1_packages_proto_goTypes = []interface{}{
-	(*GetAvailablePackageSummariesRequest)(nil),                   // 0: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest
-	(*GetAvailablePackageDetailRequest)(nil),                      // 1: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailRequest
-	(*GetAvailablePackageVersionsRequest)(nil),                    // 2: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsRequest
-	(*GetAvailablePackageSummariesResponse)(nil),                  // 3: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesResponse
-	(*GetAvailablePackageDetailResponse)(nil),                     // 4: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailResponse
-	(*GetAvailablePackageVersionsResponse)(nil),                   // 5: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsResponse
-	(*AvailablePackageSummary)(nil),                               // 6: kubeappsapis.core.packages.v1alpha1.AvailablePackageSummary
-	(*AvailablePackageDetail)(nil),                                // 7: kubeappsapis.core.packages.v1alpha1.AvailablePackageDetail
-	(*Context)(nil),                                               // 8: kubeappsapis.core.packages.v1alpha1.Context
-	(*AvailablePackageReference)(nil),                             // 9: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
-	(*Maintainer)(nil),                                            // 10: kubeappsapis.core.packages.v1alpha1.Maintainer
-	(*FilterOptions)(nil),                                         // 11: kubeappsapis.core.packages.v1alpha1.FilterOptions
-	(*PaginationOptions)(nil),                                     // 12: kubeappsapis.core.packages.v1alpha1.PaginationOptions
-	(*GetAvailablePackageVersionsResponse_PackageAppVersion)(nil), // 13: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsResponse.PackageAppVersion
-	(*anypb.Any)(nil),                                             // 14: google.protobuf.Any
-	(*v1alpha1.Plugin)(nil),                                       // 15: kubeappsapis.core.plugins.v1alpha1.Plugin
+	(WatchInstalledPackagesResponse_EventType)(0),                 // 0: kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesResponse.EventType
+	(InstalledPackageStatus_StatusReason)(0),                      // 1: kubeappsapis.core.packages.v1alpha1.InstalledPackageStatus.StatusReason
+	(SortOptions_SortField)(0),                                    // 2: kubeappsapis.core.packages.v1alpha1.SortOptions.SortField
+	(*GetAvailablePackageSummariesRequest)(nil),                   // 3: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest
+	(*GetAvailablePackageDetailRequest)(nil),                      // 4: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailRequest
+	(*GetAvailablePackageVersionsRequest)(nil),                    // 5: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsRequest
+	(*GetInstalledPackageSummariesRequest)(nil),                   // 6: kubeappsapis.core.packages.v1alpha1.GetInstalledPackageSummariesRequest
+	(*GetInstalledPackageDetailRequest)(nil),                      // 7: kubeappsapis.core.packages.v1alpha1.GetInstalledPackageDetailRequest
+	(*CreateInstalledPackageRequest)(nil),                         // 8: kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageRequest
+	(*UpdateInstalledPackageRequest)(nil),                         // 9: kubeappsapis.core.packages.v1alpha1.UpdateInstalledPackageRequest
+	(*DeleteInstalledPackageRequest)(nil),                         // 10: kubeappsapis.core.packages.v1alpha1.DeleteInstalledPackageRequest
+	(*WatchInstalledPackagesRequest)(nil),                         // 11: kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesRequest
+	(*GetAvailablePackageSummariesResponse)(nil),                  // 12: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesResponse
+	(*GetAvailablePackageDetailResponse)(nil),                     // 13: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailResponse
+	(*GetAvailablePackageVersionsResponse)(nil),                   // 14: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsResponse
+	(*GetInstalledPackageSummariesResponse)(nil),                  // 15: kubeappsapis.core.packages.v1alpha1.GetInstalledPackageSummariesResponse
+	(*GetInstalledPackageDetailResponse)(nil),                     // 16: kubeappsapis.core.packages.v1alpha1.GetInstalledPackageDetailResponse
+	(*CreateInstalledPackageResponse)(nil),                        // 17: kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageResponse
+	(*UpdateInstalledPackageResponse)(nil),                        // 18: kubeappsapis.core.packages.v1alpha1.UpdateInstalledPackageResponse
+	(*DeleteInstalledPackageResponse)(nil),                        // 19: kubeappsapis.core.packages.v1alpha1.DeleteInstalledPackageResponse
+	(*WatchInstalledPackagesResponse)(nil),                        // 20: kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesResponse
+	(*AvailablePackageSummary)(nil),                               // 21: kubeappsapis.core.packages.v1alpha1.AvailablePackageSummary
+	(*AvailablePackageDetail)(nil),                                // 22: kubeappsapis.core.packages.v1alpha1.AvailablePackageDetail
+	(*InstalledPackageSummary)(nil),                               // 23: kubeappsapis.core.packages.v1alpha1.InstalledPackageSummary
+	(*InstalledPackageDetail)(nil),                                // 24: kubeappsapis.core.packages.v1alpha1.InstalledPackageDetail
+	(*InstalledPackageStatus)(nil),                                // 25: kubeappsapis.core.packages.v1alpha1.InstalledPackageStatus
+	(*ReconciliationOptions)(nil),                                 // 26: kubeappsapis.core.packages.v1alpha1.ReconciliationOptions
+	(*Context)(nil),                                               // 27: kubeappsapis.core.packages.v1alpha1.Context
+	(*AvailablePackageReference)(nil),                             // 28: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
+	(*InstalledPackageReference)(nil),                             // 29: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	(*VersionReference)(nil),                                      // 30: kubeappsapis.core.packages.v1alpha1.VersionReference
+	(*Maintainer)(nil),                                            // 31: kubeappsapis.core.packages.v1alpha1.Maintainer
+	(*FilterOptions)(nil),                                         // 32: kubeappsapis.core.packages.v1alpha1.FilterOptions
+	(*SortOptions)(nil),                                           // 33: kubeappsapis.core.packages.v1alpha1.SortOptions
+	(*PluginError)(nil),                                           // 34: kubeappsapis.core.packages.v1alpha1.PluginError
+	(*PaginationOptions)(nil),                                     // 35: kubeappsapis.core.packages.v1alpha1.PaginationOptions
+	(*GetAvailablePackageVersionsResponse_PackageAppVersion)(nil), // 36: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsResponse.PackageAppVersion
+	(*anypb.Any)(nil),                                             // 37: google.protobuf.Any
+	(*v1alpha1.Plugin)(nil),                                       // 38: kubeappsapis.core.plugins.v1alpha1.Plugin
 }
 var file_kubeappsapis_core_packages_v1alpha1_packages_proto_depIdxs = []int32{
-	8,  // 0: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
-	11, // 1: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest.filter_options:type_name -> kubeappsapis.core.packages.v1alpha1.FilterOptions
-	12, // 2: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest.pagination_options:type_name -> kubeappsapis.core.packages.v1alpha1.PaginationOptions
-	9,  // 3: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailRequest.available_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
-	9,  // 4: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsRequest.available_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
-	6,  // 5: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesResponse.available_packages_summaries:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageSummary
-	7,  // 6: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailResponse.available_package_detail:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageDetail
-	13, // 7: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsResponse.package_app_versions:type_name -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsResponse.PackageAppVersion
-	9,  // 8: kubeappsapis.core.packages.v1alpha1.AvailablePackageSummary.available_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
-	9,  // 9: kubeappsapis.core.packages.v1alpha1.AvailablePackageDetail.available_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
-	10, // 10: kubeappsapis.core.packages.v1alpha1.AvailablePackageDetail.maintainers:type_name -> kubeappsapis.core.packages.v1alpha1.Maintainer
-	14, // 11: kubeappsapis.core.packages.v1alpha1.AvailablePackageDetail.custom_detail:type_name -> google.protobuf.Any
-	8,  // 12: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
-	15, // 13: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference.plugin:type_name -> kubeappsapis.core.plugins.v1alpha1.Plugin
-	0,  // 14: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageSummaries:input_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest
-	1,  // 15: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageDetail:input_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailRequest
-	2,  // 16: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageVersions:input_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsRequest
-	3,  // 17: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageSummaries:output_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesResponse
-	4,  // 18: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageDetail:output_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailResponse
-	5,  // 19: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageVersions:output_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsResponse
-	17, // [17:20] is the sub-list for method output_type
-	14, // [14:17] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	27, // 0: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	32, // 1: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest.filter_options:type_name -> kubeappsapis.core.packages.v1alpha1.FilterOptions
+	35, // 2: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest.pagination_options:type_name -> kubeappsapis.core.packages.v1alpha1.PaginationOptions
+	33, // 3: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest.sort_options:type_name -> kubeappsapis.core.packages.v1alpha1.SortOptions
+	28, // 4: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailRequest.available_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
+	28, // 5: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsRequest.available_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
+	27, // 6: kubeappsapis.core.packages.v1alpha1.GetInstalledPackageSummariesRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	35, // 7: kubeappsapis.core.packages.v1alpha1.GetInstalledPackageSummariesRequest.pagination_options:type_name -> kubeappsapis.core.packages.v1alpha1.PaginationOptions
+	29, // 8: kubeappsapis.core.packages.v1alpha1.GetInstalledPackageDetailRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	28, // 9: kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageRequest.available_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
+	27, // 10: kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageRequest.target_context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	30, // 11: kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageRequest.pkg_version_reference:type_name -> kubeappsapis.core.packages.v1alpha1.VersionReference
+	26, // 12: kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageRequest.reconciliation_options:type_name -> kubeappsapis.core.packages.v1alpha1.ReconciliationOptions
+	29, // 13: kubeappsapis.core.packages.v1alpha1.UpdateInstalledPackageRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	30, // 14: kubeappsapis.core.packages.v1alpha1.UpdateInstalledPackageRequest.pkg_version_reference:type_name -> kubeappsapis.core.packages.v1alpha1.VersionReference
+	26, // 15: kubeappsapis.core.packages.v1alpha1.UpdateInstalledPackageRequest.reconciliation_options:type_name -> kubeappsapis.core.packages.v1alpha1.ReconciliationOptions
+	29, // 16: kubeappsapis.core.packages.v1alpha1.DeleteInstalledPackageRequest.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	27, // 17: kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	21, // 18: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesResponse.available_packages_summaries:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageSummary
+	34, // 19: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesResponse.plugin_errors:type_name -> kubeappsapis.core.packages.v1alpha1.PluginError
+	22, // 20: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailResponse.available_package_detail:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageDetail
+	36, // 21: kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsResponse.package_app_versions:type_name -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsResponse.PackageAppVersion
+	23, // 22: kubeappsapis.core.packages.v1alpha1.GetInstalledPackageSummariesResponse.installed_package_summaries:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageSummary
+	34, // 23: kubeappsapis.core.packages.v1alpha1.GetInstalledPackageSummariesResponse.plugin_errors:type_name -> kubeappsapis.core.packages.v1alpha1.PluginError
+	24, // 24: kubeappsapis.core.packages.v1alpha1.GetInstalledPackageDetailResponse.installed_package_detail:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageDetail
+	29, // 25: kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageResponse.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	29, // 26: kubeappsapis.core.packages.v1alpha1.UpdateInstalledPackageResponse.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	0,  // 27: kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesResponse.type:type_name -> kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesResponse.EventType
+	23, // 28: kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesResponse.installed_package_summary:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageSummary
+	34, // 29: kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesResponse.plugin_error:type_name -> kubeappsapis.core.packages.v1alpha1.PluginError
+	28, // 30: kubeappsapis.core.packages.v1alpha1.AvailablePackageSummary.available_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
+	28, // 31: kubeappsapis.core.packages.v1alpha1.AvailablePackageDetail.available_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
+	31, // 32: kubeappsapis.core.packages.v1alpha1.AvailablePackageDetail.maintainers:type_name -> kubeappsapis.core.packages.v1alpha1.Maintainer
+	37, // 33: kubeappsapis.core.packages.v1alpha1.AvailablePackageDetail.custom_detail:type_name -> google.protobuf.Any
+	29, // 34: kubeappsapis.core.packages.v1alpha1.InstalledPackageSummary.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	30, // 35: kubeappsapis.core.packages.v1alpha1.InstalledPackageSummary.pkg_version_reference:type_name -> kubeappsapis.core.packages.v1alpha1.VersionReference
+	25, // 36: kubeappsapis.core.packages.v1alpha1.InstalledPackageSummary.status:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageStatus
+	29, // 37: kubeappsapis.core.packages.v1alpha1.InstalledPackageDetail.installed_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageReference
+	30, // 38: kubeappsapis.core.packages.v1alpha1.InstalledPackageDetail.pkg_version_reference:type_name -> kubeappsapis.core.packages.v1alpha1.VersionReference
+	26, // 39: kubeappsapis.core.packages.v1alpha1.InstalledPackageDetail.reconciliation_options:type_name -> kubeappsapis.core.packages.v1alpha1.ReconciliationOptions
+	25, // 40: kubeappsapis.core.packages.v1alpha1.InstalledPackageDetail.status:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageStatus
+	28, // 41: kubeappsapis.core.packages.v1alpha1.InstalledPackageDetail.available_package_ref:type_name -> kubeappsapis.core.packages.v1alpha1.AvailablePackageReference
+	37, // 42: kubeappsapis.core.packages.v1alpha1.InstalledPackageDetail.custom_detail:type_name -> google.protobuf.Any
+	1,  // 43: kubeappsapis.core.packages.v1alpha1.InstalledPackageStatus.reason:type_name -> kubeappsapis.core.packages.v1alpha1.InstalledPackageStatus.StatusReason
+	27, // 44: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	38, // 45: kubeappsapis.core.packages.v1alpha1.AvailablePackageReference.plugin:type_name -> kubeappsapis.core.plugins.v1alpha1.Plugin
+	27, // 46: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	38, // 47: kubeappsapis.core.packages.v1alpha1.InstalledPackageReference.plugin:type_name -> kubeappsapis.core.plugins.v1alpha1.Plugin
+	2,  // 48: kubeappsapis.core.packages.v1alpha1.SortOptions.field:type_name -> kubeappsapis.core.packages.v1alpha1.SortOptions.SortField
+	38, // 49: kubeappsapis.core.packages.v1alpha1.PluginError.plugin:type_name -> kubeappsapis.core.plugins.v1alpha1.Plugin
+	3,  // 50: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageSummaries:input_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesRequest
+	4,  // 51: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageDetail:input_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailRequest
+	5,  // 52: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageVersions:input_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsRequest
+	6,  // 53: kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageSummaries:input_type -> kubeappsapis.core.packages.v1alpha1.GetInstalledPackageSummariesRequest
+	7,  // 54: kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageDetail:input_type -> kubeappsapis.core.packages.v1alpha1.GetInstalledPackageDetailRequest
+	8,  // 55: kubeappsapis.core.packages.v1alpha1.PackagesService.CreateInstalledPackage:input_type -> kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageRequest
+	9,  // 56: kubeappsapis.core.packages.v1alpha1.PackagesService.UpdateInstalledPackage:input_type -> kubeappsapis.core.packages.v1alpha1.UpdateInstalledPackageRequest
+	10, // 57: kubeappsapis.core.packages.v1alpha1.PackagesService.DeleteInstalledPackage:input_type -> kubeappsapis.core.packages.v1alpha1.DeleteInstalledPackageRequest
+	11, // 58: kubeappsapis.core.packages.v1alpha1.PackagesService.WatchInstalledPackages:input_type -> kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesRequest
+	12, // 59: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageSummaries:output_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageSummariesResponse
+	13, // 60: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageDetail:output_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageDetailResponse
+	14, // 61: kubeappsapis.core.packages.v1alpha1.PackagesService.GetAvailablePackageVersions:output_type -> kubeappsapis.core.packages.v1alpha1.GetAvailablePackageVersionsResponse
+	15, // 62: kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageSummaries:output_type -> kubeappsapis.core.packages.v1alpha1.GetInstalledPackageSummariesResponse
+	16, // 63: kubeappsapis.core.packages.v1alpha1.PackagesService.GetInstalledPackageDetail:output_type -> kubeappsapis.core.packages.v1alpha1.GetInstalledPackageDetailResponse
+	17, // 64: kubeappsapis.core.packages.v1alpha1.PackagesService.CreateInstalledPackage:output_type -> kubeappsapis.core.packages.v1alpha1.CreateInstalledPackageResponse
+	18, // 65: kubeappsapis.core.packages.v1alpha1.PackagesService.UpdateInstalledPackage:output_type -> kubeappsapis.core.packages.v1alpha1.UpdateInstalledPackageResponse
+	19, // 66: kubeappsapis.core.packages.v1alpha1.PackagesService.DeleteInstalledPackage:output_type -> kubeappsapis.core.packages.v1alpha1.DeleteInstalledPackageResponse
+	20, // 67: kubeappsapis.core.packages.v1alpha1.PackagesService.WatchInstalledPackages:output_type -> kubeappsapis.core.packages.v1alpha1.WatchInstalledPackagesResponse
+	59, // [59:68] is the sub-list for method output_type
+	50, // [50:59] is the sub-list for method input_type
+	50, // [50:50] is the sub-list for extension type_name
+	50, // [50:50] is the sub-list for extension extendee
+	0,  // [0:50] is the sub-list for field type_name
 }
 
 func init() { file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() }
@@ -1495,7 +3808,7 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAvailablePackageSummariesResponse); i {
+			switch v := v.(*GetInstalledPackageSummariesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1507,7 +3820,7 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAvailablePackageDetailResponse); i {
+			switch v := v.(*GetInstalledPackageDetailRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1519,7 +3832,7 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAvailablePackageVersionsResponse); i {
+			switch v := v.(*CreateInstalledPackageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1531,7 +3844,7 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AvailablePackageSummary); i {
+			switch v := v.(*UpdateInstalledPackageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1543,7 +3856,7 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AvailablePackageDetail); i {
+			switch v := v.(*DeleteInstalledPackageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1555,7 +3868,7 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Context); i {
+			switch v := v.(*WatchInstalledPackagesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1567,7 +3880,7 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AvailablePackageReference); i {
+			switch v := v.(*GetAvailablePackageSummariesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1579,7 +3892,7 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Maintainer); i {
+			switch v := v.(*GetAvailablePackageDetailResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1591,7 +3904,7 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FilterOptions); i {
+			switch v := v.(*GetAvailablePackageVersionsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1603,7 +3916,7 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PaginationOptions); i {
+			switch v := v.(*GetInstalledPackageSummariesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1615,6 +3928,246 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 			}
 		}
 		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInstalledPackageDetailResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateInstalledPackageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateInstalledPackageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteInstalledPackageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchInstalledPackagesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AvailablePackageSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AvailablePackageDetail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstalledPackageSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstalledPackageDetail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstalledPackageStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconciliationOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Context); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AvailablePackageReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstalledPackageReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Maintainer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SortOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PluginError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PaginationOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetAvailablePackageVersionsResponse_PackageAppVersion); i {
 			case 0:
 				return &v.state
@@ -1632,13 +4185,14 @@ func file_kubeappsapis_core_packages_v1alpha1_packages_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kubeappsapis_core_packages_v1alpha1_packages_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      3,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_kubeappsapis_core_packages_v1alpha1_packages_proto_goTypes,
 		DependencyIndexes: file_kubeappsapis_core_packages_v1alpha1_packages_proto_depIdxs,
+		EnumInfos:         file_kubeappsapis_core_packages_v1alpha1_packages_proto_enumTypes,
 		MessageInfos:      file_kubeappsapis_core_packages_v1alpha1_packages_proto_msgTypes,
 	}.Build()
 	File_kubeappsapis_core_packages_v1alpha1_packages_proto = out.File
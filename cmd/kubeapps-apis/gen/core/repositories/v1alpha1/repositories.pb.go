@@ -0,0 +1,2066 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.17.1
+// source: kubeappsapis/core/repositories/v1alpha1/repositories.proto
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	v1alpha11 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PackageRepositoryAuthType
+//
+// The kind of authentication used with the package repository.
+type PackageRepositoryAuth_PackageRepositoryAuthType int32
+
+const (
+	PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_UNSPECIFIED          PackageRepositoryAuth_PackageRepositoryAuthType = 0
+	PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH           PackageRepositoryAuth_PackageRepositoryAuthType = 1
+	PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER               PackageRepositoryAuth_PackageRepositoryAuthType = 2
+	PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_AUTHORIZATION_HEADER PackageRepositoryAuth_PackageRepositoryAuthType = 3
+	PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON   PackageRepositoryAuth_PackageRepositoryAuthType = 4
+)
+
+// Enum value maps for PackageRepositoryAuth_PackageRepositoryAuthType.
+var (
+	PackageRepositoryAuth_PackageRepositoryAuthType_name = map[int32]string{
+		0: "PACKAGE_REPOSITORY_AUTH_TYPE_UNSPECIFIED",
+		1: "PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH",
+		2: "PACKAGE_REPOSITORY_AUTH_TYPE_BEARER",
+		3: "PACKAGE_REPOSITORY_AUTH_TYPE_AUTHORIZATION_HEADER",
+		4: "PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON",
+	}
+	PackageRepositoryAuth_PackageRepositoryAuthType_value = map[string]int32{
+		"PACKAGE_REPOSITORY_AUTH_TYPE_UNSPECIFIED":          0,
+		"PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH":           1,
+		"PACKAGE_REPOSITORY_AUTH_TYPE_BEARER":               2,
+		"PACKAGE_REPOSITORY_AUTH_TYPE_AUTHORIZATION_HEADER": 3,
+		"PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON":   4,
+	}
+)
+
+func (x PackageRepositoryAuth_PackageRepositoryAuthType) Enum() *PackageRepositoryAuth_PackageRepositoryAuthType {
+	p := new(PackageRepositoryAuth_PackageRepositoryAuthType)
+	*p = x
+	return p
+}
+
+func (x PackageRepositoryAuth_PackageRepositoryAuthType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PackageRepositoryAuth_PackageRepositoryAuthType) Descriptor() protoreflect.EnumDescriptor {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_enumTypes[0].Descriptor()
+}
+
+func (PackageRepositoryAuth_PackageRepositoryAuthType) Type() protoreflect.EnumType {
+	return &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_enumTypes[0]
+}
+
+func (x PackageRepositoryAuth_PackageRepositoryAuthType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PackageRepositoryAuth_PackageRepositoryAuthType.Descriptor instead.
+func (PackageRepositoryAuth_PackageRepositoryAuthType) EnumDescriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{15, 0}
+}
+
+// StatusReason
+//
+// Generic reasons why a package repository may be ready or not.
+// These should make sense across different packaging plugins.
+type PackageRepositoryStatus_StatusReason int32
+
+const (
+	PackageRepositoryStatus_STATUS_REASON_UNSPECIFIED PackageRepositoryStatus_StatusReason = 0
+	PackageRepositoryStatus_STATUS_REASON_SUCCESS     PackageRepositoryStatus_StatusReason = 1
+	PackageRepositoryStatus_STATUS_REASON_FAILED      PackageRepositoryStatus_StatusReason = 2
+	PackageRepositoryStatus_STATUS_REASON_PENDING     PackageRepositoryStatus_StatusReason = 3
+)
+
+// Enum value maps for PackageRepositoryStatus_StatusReason.
+var (
+	PackageRepositoryStatus_StatusReason_name = map[int32]string{
+		0: "STATUS_REASON_UNSPECIFIED",
+		1: "STATUS_REASON_SUCCESS",
+		2: "STATUS_REASON_FAILED",
+		3: "STATUS_REASON_PENDING",
+	}
+	PackageRepositoryStatus_StatusReason_value = map[string]int32{
+		"STATUS_REASON_UNSPECIFIED": 0,
+		"STATUS_REASON_SUCCESS":     1,
+		"STATUS_REASON_FAILED":      2,
+		"STATUS_REASON_PENDING":     3,
+	}
+)
+
+func (x PackageRepositoryStatus_StatusReason) Enum() *PackageRepositoryStatus_StatusReason {
+	p := new(PackageRepositoryStatus_StatusReason)
+	*p = x
+	return p
+}
+
+func (x PackageRepositoryStatus_StatusReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PackageRepositoryStatus_StatusReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_enumTypes[1].Descriptor()
+}
+
+func (PackageRepositoryStatus_StatusReason) Type() protoreflect.EnumType {
+	return &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_enumTypes[1]
+}
+
+func (x PackageRepositoryStatus_StatusReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PackageRepositoryStatus_StatusReason.Descriptor instead.
+func (PackageRepositoryStatus_StatusReason) EnumDescriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{16, 0}
+}
+
+// GetPackageRepositorySummariesRequest
+//
+// Request for GetPackageRepositorySummaries
+type GetPackageRepositorySummariesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The context (cluster/namespace) for the request
+	Context *v1alpha1.Context `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+}
+
+func (x *GetPackageRepositorySummariesRequest) Reset() {
+	*x = GetPackageRepositorySummariesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPackageRepositorySummariesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPackageRepositorySummariesRequest) ProtoMessage() {}
+
+func (x *GetPackageRepositorySummariesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPackageRepositorySummariesRequest.ProtoReflect.Descriptor instead.
+func (*GetPackageRepositorySummariesRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPackageRepositorySummariesRequest) GetContext() *v1alpha1.Context {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// GetPackageRepositoryDetailRequest
+//
+// Request for GetPackageRepositoryDetail
+type GetPackageRepositoryDetailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The information required to uniquely identify a package repository
+	PackageRepoRef *PackageRepositoryReference `protobuf:"bytes,1,opt,name=package_repo_ref,json=packageRepoRef,proto3" json:"package_repo_ref,omitempty"`
+}
+
+func (x *GetPackageRepositoryDetailRequest) Reset() {
+	*x = GetPackageRepositoryDetailRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPackageRepositoryDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPackageRepositoryDetailRequest) ProtoMessage() {}
+
+func (x *GetPackageRepositoryDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPackageRepositoryDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetPackageRepositoryDetailRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPackageRepositoryDetailRequest) GetPackageRepoRef() *PackageRepositoryReference {
+	if x != nil {
+		return x.PackageRepoRef
+	}
+	return nil
+}
+
+// CreatePackageRepositoryRequest
+//
+// Request for CreatePackageRepository
+type CreatePackageRepositoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The context (cluster/namespace) in which the repository is created.
+	// A repository created in the global packaging namespace is available in
+	// every namespace.
+	Context *v1alpha1.Context `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	// The name of the package repository.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// An optional user-facing description of the package repository.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// The type of the package repository, such as "helm" or "oci". Valid values
+	// are plugin specific.
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// The URL of the package repository.
+	Url string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	// The interval with which the package repository is synchronized (in seconds).
+	// When not set, the plugin default is used.
+	Interval int32 `protobuf:"varint,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Optional authentication for the package repository.
+	Auth *PackageRepositoryAuth `protobuf:"bytes,7,opt,name=auth,proto3" json:"auth,omitempty"`
+	// The plugin with which the package repository is created.
+	Plugin *v1alpha11.Plugin `protobuf:"bytes,8,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	// Optional plugin-specific details for the package repository.
+	CustomDetail *anypb.Any `protobuf:"bytes,9,opt,name=custom_detail,json=customDetail,proto3" json:"custom_detail,omitempty"`
+}
+
+func (x *CreatePackageRepositoryRequest) Reset() {
+	*x = CreatePackageRepositoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreatePackageRepositoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePackageRepositoryRequest) ProtoMessage() {}
+
+func (x *CreatePackageRepositoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePackageRepositoryRequest.ProtoReflect.Descriptor instead.
+func (*CreatePackageRepositoryRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreatePackageRepositoryRequest) GetContext() *v1alpha1.Context {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *CreatePackageRepositoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePackageRepositoryRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreatePackageRepositoryRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreatePackageRepositoryRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreatePackageRepositoryRequest) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *CreatePackageRepositoryRequest) GetAuth() *PackageRepositoryAuth {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *CreatePackageRepositoryRequest) GetPlugin() *v1alpha11.Plugin {
+	if x != nil {
+		return x.Plugin
+	}
+	return nil
+}
+
+func (x *CreatePackageRepositoryRequest) GetCustomDetail() *anypb.Any {
+	if x != nil {
+		return x.CustomDetail
+	}
+	return nil
+}
+
+// UpdatePackageRepositoryRequest
+//
+// Request for UpdatePackageRepository
+type UpdatePackageRepositoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A reference uniquely identifying the package repository being updated.
+	PackageRepoRef *PackageRepositoryReference `protobuf:"bytes,1,opt,name=package_repo_ref,json=packageRepoRef,proto3" json:"package_repo_ref,omitempty"`
+	// The user-facing description of the package repository.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// The URL of the package repository.
+	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// The interval with which the package repository is synchronized (in seconds).
+	Interval int32 `protobuf:"varint,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Optional authentication for the package repository.
+	Auth *PackageRepositoryAuth `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
+	// Optional plugin-specific details for the package repository.
+	CustomDetail *anypb.Any `protobuf:"bytes,6,opt,name=custom_detail,json=customDetail,proto3" json:"custom_detail,omitempty"`
+}
+
+func (x *UpdatePackageRepositoryRequest) Reset() {
+	*x = UpdatePackageRepositoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdatePackageRepositoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePackageRepositoryRequest) ProtoMessage() {}
+
+func (x *UpdatePackageRepositoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePackageRepositoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePackageRepositoryRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdatePackageRepositoryRequest) GetPackageRepoRef() *PackageRepositoryReference {
+	if x != nil {
+		return x.PackageRepoRef
+	}
+	return nil
+}
+
+func (x *UpdatePackageRepositoryRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdatePackageRepositoryRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UpdatePackageRepositoryRequest) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *UpdatePackageRepositoryRequest) GetAuth() *PackageRepositoryAuth {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *UpdatePackageRepositoryRequest) GetCustomDetail() *anypb.Any {
+	if x != nil {
+		return x.CustomDetail
+	}
+	return nil
+}
+
+// DeletePackageRepositoryRequest
+//
+// Request for DeletePackageRepository
+type DeletePackageRepositoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A reference uniquely identifying the package repository being deleted.
+	PackageRepoRef *PackageRepositoryReference `protobuf:"bytes,1,opt,name=package_repo_ref,json=packageRepoRef,proto3" json:"package_repo_ref,omitempty"`
+}
+
+func (x *DeletePackageRepositoryRequest) Reset() {
+	*x = DeletePackageRepositoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletePackageRepositoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePackageRepositoryRequest) ProtoMessage() {}
+
+func (x *DeletePackageRepositoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePackageRepositoryRequest.ProtoReflect.Descriptor instead.
+func (*DeletePackageRepositoryRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeletePackageRepositoryRequest) GetPackageRepoRef() *PackageRepositoryReference {
+	if x != nil {
+		return x.PackageRepoRef
+	}
+	return nil
+}
+
+// RefreshPackageRepositoryRequest
+//
+// Request for RefreshPackageRepository
+type RefreshPackageRepositoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A reference uniquely identifying the package repository to be
+	// synchronized again.
+	PackageRepoRef *PackageRepositoryReference `protobuf:"bytes,1,opt,name=package_repo_ref,json=packageRepoRef,proto3" json:"package_repo_ref,omitempty"`
+}
+
+func (x *RefreshPackageRepositoryRequest) Reset() {
+	*x = RefreshPackageRepositoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshPackageRepositoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshPackageRepositoryRequest) ProtoMessage() {}
+
+func (x *RefreshPackageRepositoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshPackageRepositoryRequest.ProtoReflect.Descriptor instead.
+func (*RefreshPackageRepositoryRequest) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RefreshPackageRepositoryRequest) GetPackageRepoRef() *PackageRepositoryReference {
+	if x != nil {
+		return x.PackageRepoRef
+	}
+	return nil
+}
+
+// GetPackageRepositorySummariesResponse
+//
+// Response for GetPackageRepositorySummaries
+type GetPackageRepositorySummariesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Package repository summaries
+	//
+	// List of PackageRepositorySummary
+	PackageRepositorySummaries []*PackageRepositorySummary `protobuf:"bytes,1,rep,name=package_repository_summaries,json=packageRepositorySummaries,proto3" json:"package_repository_summaries,omitempty"`
+	// Plugin errors
+	//
+	// Errors of the plugins which failed to return their package repositories.
+	// The package repositories of the remaining plugins are still returned.
+	PluginErrors []*v1alpha1.PluginError `protobuf:"bytes,2,rep,name=plugin_errors,json=pluginErrors,proto3" json:"plugin_errors,omitempty"`
+}
+
+func (x *GetPackageRepositorySummariesResponse) Reset() {
+	*x = GetPackageRepositorySummariesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPackageRepositorySummariesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPackageRepositorySummariesResponse) ProtoMessage() {}
+
+func (x *GetPackageRepositorySummariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPackageRepositorySummariesResponse.ProtoReflect.Descriptor instead.
+func (*GetPackageRepositorySummariesResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPackageRepositorySummariesResponse) GetPackageRepositorySummaries() []*PackageRepositorySummary {
+	if x != nil {
+		return x.PackageRepositorySummaries
+	}
+	return nil
+}
+
+func (x *GetPackageRepositorySummariesResponse) GetPluginErrors() []*v1alpha1.PluginError {
+	if x != nil {
+		return x.PluginErrors
+	}
+	return nil
+}
+
+// GetPackageRepositoryDetailResponse
+//
+// Response for GetPackageRepositoryDetail
+type GetPackageRepositoryDetailResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Package repository detail
+	//
+	// The requested PackageRepositoryDetail
+	PackageRepositoryDetail *PackageRepositoryDetail `protobuf:"bytes,1,opt,name=package_repository_detail,json=packageRepositoryDetail,proto3" json:"package_repository_detail,omitempty"`
+}
+
+func (x *GetPackageRepositoryDetailResponse) Reset() {
+	*x = GetPackageRepositoryDetailResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPackageRepositoryDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPackageRepositoryDetailResponse) ProtoMessage() {}
+
+func (x *GetPackageRepositoryDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPackageRepositoryDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetPackageRepositoryDetailResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPackageRepositoryDetailResponse) GetPackageRepositoryDetail() *PackageRepositoryDetail {
+	if x != nil {
+		return x.PackageRepositoryDetail
+	}
+	return nil
+}
+
+// CreatePackageRepositoryResponse
+//
+// Response for CreatePackageRepository
+type CreatePackageRepositoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageRepoRef *PackageRepositoryReference `protobuf:"bytes,1,opt,name=package_repo_ref,json=packageRepoRef,proto3" json:"package_repo_ref,omitempty"`
+}
+
+func (x *CreatePackageRepositoryResponse) Reset() {
+	*x = CreatePackageRepositoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreatePackageRepositoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePackageRepositoryResponse) ProtoMessage() {}
+
+func (x *CreatePackageRepositoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePackageRepositoryResponse.ProtoReflect.Descriptor instead.
+func (*CreatePackageRepositoryResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreatePackageRepositoryResponse) GetPackageRepoRef() *PackageRepositoryReference {
+	if x != nil {
+		return x.PackageRepoRef
+	}
+	return nil
+}
+
+// UpdatePackageRepositoryResponse
+//
+// Response for UpdatePackageRepository
+type UpdatePackageRepositoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageRepoRef *PackageRepositoryReference `protobuf:"bytes,1,opt,name=package_repo_ref,json=packageRepoRef,proto3" json:"package_repo_ref,omitempty"`
+}
+
+func (x *UpdatePackageRepositoryResponse) Reset() {
+	*x = UpdatePackageRepositoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdatePackageRepositoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePackageRepositoryResponse) ProtoMessage() {}
+
+func (x *UpdatePackageRepositoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePackageRepositoryResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePackageRepositoryResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdatePackageRepositoryResponse) GetPackageRepoRef() *PackageRepositoryReference {
+	if x != nil {
+		return x.PackageRepoRef
+	}
+	return nil
+}
+
+// DeletePackageRepositoryResponse
+//
+// Response for DeletePackageRepository
+type DeletePackageRepositoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeletePackageRepositoryResponse) Reset() {
+	*x = DeletePackageRepositoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletePackageRepositoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePackageRepositoryResponse) ProtoMessage() {}
+
+func (x *DeletePackageRepositoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePackageRepositoryResponse.ProtoReflect.Descriptor instead.
+func (*DeletePackageRepositoryResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{10}
+}
+
+// RefreshPackageRepositoryResponse
+//
+// Response for RefreshPackageRepository
+type RefreshPackageRepositoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageRepoRef *PackageRepositoryReference `protobuf:"bytes,1,opt,name=package_repo_ref,json=packageRepoRef,proto3" json:"package_repo_ref,omitempty"`
+}
+
+func (x *RefreshPackageRepositoryResponse) Reset() {
+	*x = RefreshPackageRepositoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshPackageRepositoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshPackageRepositoryResponse) ProtoMessage() {}
+
+func (x *RefreshPackageRepositoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshPackageRepositoryResponse.ProtoReflect.Descriptor instead.
+func (*RefreshPackageRepositoryResponse) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RefreshPackageRepositoryResponse) GetPackageRepoRef() *PackageRepositoryReference {
+	if x != nil {
+		return x.PackageRepoRef
+	}
+	return nil
+}
+
+// PackageRepositoryReference
+//
+// A PackageRepositoryReference has the minimum information required to uniquely
+// identify a package repository.
+type PackageRepositoryReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Package repository context
+	//
+	// The context (cluster/namespace) for the package repository.
+	Context *v1alpha1.Context `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	// Package repository identifier
+	//
+	// The fully qualified identifier for the package repository
+	// (ie. a unique name for the context).
+	Identifier string `protobuf:"bytes,2,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	// Plugin for the package repository
+	//
+	// The plugin used to interact with this package repository.
+	Plugin *v1alpha11.Plugin `protobuf:"bytes,3,opt,name=plugin,proto3" json:"plugin,omitempty"`
+}
+
+func (x *PackageRepositoryReference) Reset() {
+	*x = PackageRepositoryReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageRepositoryReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageRepositoryReference) ProtoMessage() {}
+
+func (x *PackageRepositoryReference) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageRepositoryReference.ProtoReflect.Descriptor instead.
+func (*PackageRepositoryReference) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PackageRepositoryReference) GetContext() *v1alpha1.Context {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *PackageRepositoryReference) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *PackageRepositoryReference) GetPlugin() *v1alpha11.Plugin {
+	if x != nil {
+		return x.Plugin
+	}
+	return nil
+}
+
+// PackageRepositorySummary
+//
+// A PackageRepositorySummary provides a summary of a package repository for
+// use in listings.
+type PackageRepositorySummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Package repository reference
+	//
+	// A reference uniquely identifying the package repository.
+	PackageRepoRef *PackageRepositoryReference `protobuf:"bytes,1,opt,name=package_repo_ref,json=packageRepoRef,proto3" json:"package_repo_ref,omitempty"`
+	// Name
+	//
+	// The name of the package repository.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Description
+	//
+	// An optional user-facing description of the package repository.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Type
+	//
+	// The plugin specific type of the package repository, such as "helm" or "oci".
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// URL
+	//
+	// The URL of the package repository.
+	Url string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	// Status
+	//
+	// The current status of the package repository.
+	Status *PackageRepositoryStatus `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *PackageRepositorySummary) Reset() {
+	*x = PackageRepositorySummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageRepositorySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageRepositorySummary) ProtoMessage() {}
+
+func (x *PackageRepositorySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageRepositorySummary.ProtoReflect.Descriptor instead.
+func (*PackageRepositorySummary) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PackageRepositorySummary) GetPackageRepoRef() *PackageRepositoryReference {
+	if x != nil {
+		return x.PackageRepoRef
+	}
+	return nil
+}
+
+func (x *PackageRepositorySummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageRepositorySummary) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PackageRepositorySummary) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PackageRepositorySummary) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *PackageRepositorySummary) GetStatus() *PackageRepositoryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// PackageRepositoryDetail
+//
+// A PackageRepositoryDetail provides the full details of a package repository.
+type PackageRepositoryDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Package repository reference
+	//
+	// A reference uniquely identifying the package repository.
+	PackageRepoRef *PackageRepositoryReference `protobuf:"bytes,1,opt,name=package_repo_ref,json=packageRepoRef,proto3" json:"package_repo_ref,omitempty"`
+	// Name
+	//
+	// The name of the package repository.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Description
+	//
+	// An optional user-facing description of the package repository.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Type
+	//
+	// The plugin specific type of the package repository, such as "helm" or "oci".
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// URL
+	//
+	// The URL of the package repository.
+	Url string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	// Interval
+	//
+	// The interval with which the package repository is synchronized (in seconds).
+	Interval int32 `protobuf:"varint,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Auth
+	//
+	// The authentication configured for the package repository. Secret values
+	// are never returned, only the referenced secrets.
+	Auth *PackageRepositoryAuth `protobuf:"bytes,7,opt,name=auth,proto3" json:"auth,omitempty"`
+	// Status
+	//
+	// The current status of the package repository.
+	Status *PackageRepositoryStatus `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	// Custom data added by the plugin
+	//
+	// A plugin can define custom details for data which is not yet, or never will
+	// be specified in the core.repositories.PackageRepositoryDetail fields. The use
+	// of an `Any` field means that each plugin can define the structure of this
+	// message as required, while still satisfying the core interface.
+	// See https://developers.google.com/protocol-buffers/docs/proto3#any
+	CustomDetail *anypb.Any `protobuf:"bytes,9,opt,name=custom_detail,json=customDetail,proto3" json:"custom_detail,omitempty"`
+}
+
+func (x *PackageRepositoryDetail) Reset() {
+	*x = PackageRepositoryDetail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageRepositoryDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageRepositoryDetail) ProtoMessage() {}
+
+func (x *PackageRepositoryDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageRepositoryDetail.ProtoReflect.Descriptor instead.
+func (*PackageRepositoryDetail) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PackageRepositoryDetail) GetPackageRepoRef() *PackageRepositoryReference {
+	if x != nil {
+		return x.PackageRepoRef
+	}
+	return nil
+}
+
+func (x *PackageRepositoryDetail) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageRepositoryDetail) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PackageRepositoryDetail) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PackageRepositoryDetail) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *PackageRepositoryDetail) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *PackageRepositoryDetail) GetAuth() *PackageRepositoryAuth {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *PackageRepositoryDetail) GetStatus() *PackageRepositoryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *PackageRepositoryDetail) GetCustomDetail() *anypb.Any {
+	if x != nil {
+		return x.CustomDetail
+	}
+	return nil
+}
+
+// PackageRepositoryAuth
+//
+// Authentication for a package repository.
+type PackageRepositoryAuth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type
+	//
+	// The type of authentication.
+	Type PackageRepositoryAuth_PackageRepositoryAuthType `protobuf:"varint,1,opt,name=type,proto3,enum=kubeappsapis.core.repositories.v1alpha1.PackageRepositoryAuth_PackageRepositoryAuthType" json:"type,omitempty"`
+	// Secret reference
+	//
+	// The name of an existing secret, in the namespace of the package repository,
+	// holding the credentials.
+	SecretRef string `protobuf:"bytes,2,opt,name=secret_ref,json=secretRef,proto3" json:"secret_ref,omitempty"`
+	// Header
+	//
+	// The credentials used to create the secret when no secret_ref is given: the
+	// full authorization header for AUTHORIZATION_HEADER, the token for BEARER or
+	// "user:password" for BASIC_AUTH. It is never returned.
+	Header string `protobuf:"bytes,3,opt,name=header,proto3" json:"header,omitempty"`
+	// Custom CA
+	//
+	// An optional PEM encoded CA certificate used to verify the package
+	// repository. It is never returned.
+	CustomCa string `protobuf:"bytes,4,opt,name=custom_ca,json=customCa,proto3" json:"custom_ca,omitempty"`
+}
+
+func (x *PackageRepositoryAuth) Reset() {
+	*x = PackageRepositoryAuth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageRepositoryAuth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageRepositoryAuth) ProtoMessage() {}
+
+func (x *PackageRepositoryAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageRepositoryAuth.ProtoReflect.Descriptor instead.
+func (*PackageRepositoryAuth) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PackageRepositoryAuth) GetType() PackageRepositoryAuth_PackageRepositoryAuthType {
+	if x != nil {
+		return x.Type
+	}
+	return PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_UNSPECIFIED
+}
+
+func (x *PackageRepositoryAuth) GetSecretRef() string {
+	if x != nil {
+		return x.SecretRef
+	}
+	return ""
+}
+
+func (x *PackageRepositoryAuth) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *PackageRepositoryAuth) GetCustomCa() string {
+	if x != nil {
+		return x.CustomCa
+	}
+	return ""
+}
+
+// PackageRepositoryStatus
+//
+// A PackageRepositoryStatus reports on the current status of the repository.
+type PackageRepositoryStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Ready
+	//
+	// An indication of whether the package repository is ready or not
+	Ready bool `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	// Reason
+	//
+	// An enum indicating the reason for the current status.
+	Reason PackageRepositoryStatus_StatusReason `protobuf:"varint,2,opt,name=reason,proto3,enum=kubeappsapis.core.repositories.v1alpha1.PackageRepositoryStatus_StatusReason" json:"reason,omitempty"`
+	// UserReason
+	//
+	// Optional text to return for user context, which may be plugin specific.
+	UserReason string `protobuf:"bytes,3,opt,name=user_reason,json=userReason,proto3" json:"user_reason,omitempty"`
+}
+
+func (x *PackageRepositoryStatus) Reset() {
+	*x = PackageRepositoryStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageRepositoryStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageRepositoryStatus) ProtoMessage() {}
+
+func (x *PackageRepositoryStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageRepositoryStatus.ProtoReflect.Descriptor instead.
+func (*PackageRepositoryStatus) Descriptor() ([]byte, []int) {
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PackageRepositoryStatus) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *PackageRepositoryStatus) GetReason() PackageRepositoryStatus_StatusReason {
+	if x != nil {
+		return x.Reason
+	}
+	return PackageRepositoryStatus_STATUS_REASON_UNSPECIFIED
+}
+
+func (x *PackageRepositoryStatus) GetUserReason() string {
+	if x != nil {
+		return x.UserReason
+	}
+	return ""
+}
+
+var File_kubeappsapis_core_repositories_v1alpha1_repositories_proto protoreflect.FileDescriptor
+
+var file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDesc = []byte{
+	0x0a, 0x3a, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x63,
+	0x6f, 0x72, 0x65, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x27, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x32,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2f, 0x63, 0x6f, 0x72,
+	0x65, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x30, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73,
+	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6e, 0x0a, 0x24, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x22, 0x92, 0x01, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x6d, 0x0a, 0x10, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x66, 0x22, 0xb3, 0x03, 0x0a, 0x1e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x52, 0x0a, 0x04,
+	0x61, 0x75, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x62,
+	0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x41, 0x75, 0x74, 0x68, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68,
+	0x12, 0x42, 0x0a, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x52, 0x06, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x12, 0x39, 0x0a, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e,
+	0x79, 0x52, 0x0c, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22,
+	0xee, 0x02, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x6d, 0x0a, 0x10, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65,
+	0x70, 0x6f, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x6b,
+	0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65,
+	0x66, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x12, 0x52, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x3e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x41, 0x75, 0x74, 0x68, 0x52,
+	0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x39, 0x0a, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41,
+	0x6e, 0x79, 0x52, 0x0c, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x22, 0x8f, 0x01, 0x0a, 0x1e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x6d, 0x0a, 0x10, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72,
+	0x65, 0x70, 0x6f, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e,
+	0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x52,
+	0x65, 0x66, 0x22, 0x90, 0x01, 0x0a, 0x1f, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x6d, 0x0a, 0x10, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x43, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x52, 0x65, 0x66, 0x22, 0x84, 0x02, 0x0a, 0x25, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x75,
+	0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x83, 0x01, 0x0a, 0x1c, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70,
+	0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x79, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x1a, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x55, 0x0a, 0x0d, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b,
+	0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x0c,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0xa2, 0x01, 0x0a,
+	0x22, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x79, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x7c, 0x0a, 0x19, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x40, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70,
+	0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x79, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x17, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x22, 0x90, 0x01, 0x0a, 0x1f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a, 0x10, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x43, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x52, 0x65, 0x66, 0x22, 0x90, 0x01, 0x0a, 0x1f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a, 0x10, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x43, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x66, 0x22, 0x21, 0x0a, 0x1f, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x20, 0x52,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x6d, 0x0a, 0x10, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f,
+	0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0e,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x66, 0x22, 0xc8,
+	0x01, 0x0a, 0x1a, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x46, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x42, 0x0a, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73,
+	0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x52, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x22, 0xbf, 0x02, 0x0a, 0x18, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x6d, 0x0a, 0x10, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x43, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x6c, 0x12, 0x58, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x40, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xe9, 0x03, 0x0a, 0x17,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x79, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x6d, 0x0a, 0x10, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x43, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
+	0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x52,
+	0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b,
+	0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x41, 0x75, 0x74, 0x68, 0x52, 0x04, 0x61, 0x75,
+	0x74, 0x68, 0x12, 0x58, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x40, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x0d,
+	0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x0c, 0x63, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0xe7, 0x03, 0x0a, 0x15, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x41, 0x75, 0x74,
+	0x68, 0x12, 0x6c, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x58, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x41, 0x75, 0x74, 0x68, 0x2e,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x5f, 0x63, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x43, 0x61, 0x22, 0x8b, 0x02, 0x0a, 0x19, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x2c, 0x0a, 0x28, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x50,
+	0x4f, 0x53, 0x49, 0x54, 0x4f, 0x52, 0x59, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x2b, 0x0a, 0x27, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x53,
+	0x49, 0x54, 0x4f, 0x52, 0x59, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x42, 0x41, 0x53, 0x49, 0x43, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x10, 0x01, 0x12, 0x27, 0x0a, 0x23,
+	0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x53, 0x49, 0x54, 0x4f,
+	0x52, 0x59, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x45, 0x41,
+	0x52, 0x45, 0x52, 0x10, 0x02, 0x12, 0x35, 0x0a, 0x31, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45,
+	0x5f, 0x52, 0x45, 0x50, 0x4f, 0x53, 0x49, 0x54, 0x4f, 0x52, 0x59, 0x5f, 0x41, 0x55, 0x54, 0x48,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x4f, 0x52, 0x49, 0x5a, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x48, 0x45, 0x41, 0x44, 0x45, 0x52, 0x10, 0x03, 0x12, 0x33, 0x0a, 0x2f,
+	0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x53, 0x49, 0x54, 0x4f,
+	0x52, 0x59, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x4f, 0x43,
+	0x4b, 0x45, 0x52, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f, 0x4a, 0x53, 0x4f, 0x4e, 0x10,
+	0x04, 0x22, 0xb6, 0x02, 0x0a, 0x17, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65,
+	0x61, 0x64, 0x79, 0x12, 0x65, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x4d, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70,
+	0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x75, 0x73, 0x65, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x7d, 0x0a, 0x0c, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x19, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x55, 0x43, 0x43,
+	0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12,
+	0x19, 0x0a, 0x15, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e,
+	0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x32, 0xc0, 0x0b, 0x0a, 0x13, 0x52,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0xfe, 0x01, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x4d, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x4e, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70,
+	0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x79, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x3e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x38, 0x12, 0x36, 0x2f, 0x63, 0x6f,
+	0x72, 0x65, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2f,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0xf3, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x12, 0x4a, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69,
+	0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x79, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x4b,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3c, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x36, 0x12, 0x34, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x79, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0xe8, 0x01, 0x0a, 0x17, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x47, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73,
+	0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x48,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x34,
+	0x3a, 0x01, 0x2a, 0x22, 0x2f, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0xe8, 0x01, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x47, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x48, 0x2e, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x3a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x34, 0x3a, 0x01, 0x2a, 0x1a, 0x2f,
+	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69,
+	0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0xe5, 0x01, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x47, 0x2e, 0x6b, 0x75,
+	0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x48, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x37,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x31, 0x2a, 0x2f, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0xf3, 0x01, 0x0a, 0x18, 0x52, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x79, 0x12, 0x48, 0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61,
+	0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x49,
+	0x2e, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x42, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x3c, 0x3a, 0x01, 0x2a, 0x22, 0x37, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x72, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x2f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x42, 0x4f, 0x5a,
+	0x4d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x75, 0x62, 0x65,
+	0x61, 0x70, 0x70, 0x73, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x2f, 0x63, 0x6d,
+	0x64, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x61, 0x70, 0x70, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x73, 0x2f,
+	0x67, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescOnce sync.Once
+	file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescData = file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDesc
+)
+
+func file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescGZIP() []byte {
+	file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescOnce.Do(func() {
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescData = protoimpl.X.CompressGZIP(file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescData)
+	})
+	return file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDescData
+}
+
+var file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_goTypes = []interface{}{
+	(PackageRepositoryAuth_PackageRepositoryAuthType)(0), // 0: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryAuth.PackageRepositoryAuthType
+	(PackageRepositoryStatus_StatusReason)(0),            // 1: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryStatus.StatusReason
+	(*GetPackageRepositorySummariesRequest)(nil),         // 2: kubeappsapis.core.repositories.v1alpha1.GetPackageRepositorySummariesRequest
+	(*GetPackageRepositoryDetailRequest)(nil),            // 3: kubeappsapis.core.repositories.v1alpha1.GetPackageRepositoryDetailRequest
+	(*CreatePackageRepositoryRequest)(nil),               // 4: kubeappsapis.core.repositories.v1alpha1.CreatePackageRepositoryRequest
+	(*UpdatePackageRepositoryRequest)(nil),               // 5: kubeappsapis.core.repositories.v1alpha1.UpdatePackageRepositoryRequest
+	(*DeletePackageRepositoryRequest)(nil),               // 6: kubeappsapis.core.repositories.v1alpha1.DeletePackageRepositoryRequest
+	(*RefreshPackageRepositoryRequest)(nil),              // 7: kubeappsapis.core.repositories.v1alpha1.RefreshPackageRepositoryRequest
+	(*GetPackageRepositorySummariesResponse)(nil),        // 8: kubeappsapis.core.repositories.v1alpha1.GetPackageRepositorySummariesResponse
+	(*GetPackageRepositoryDetailResponse)(nil),           // 9: kubeappsapis.core.repositories.v1alpha1.GetPackageRepositoryDetailResponse
+	(*CreatePackageRepositoryResponse)(nil),              // 10: kubeappsapis.core.repositories.v1alpha1.CreatePackageRepositoryResponse
+	(*UpdatePackageRepositoryResponse)(nil),              // 11: kubeappsapis.core.repositories.v1alpha1.UpdatePackageRepositoryResponse
+	(*DeletePackageRepositoryResponse)(nil),              // 12: kubeappsapis.core.repositories.v1alpha1.DeletePackageRepositoryResponse
+	(*RefreshPackageRepositoryResponse)(nil),             // 13: kubeappsapis.core.repositories.v1alpha1.RefreshPackageRepositoryResponse
+	(*PackageRepositoryReference)(nil),                   // 14: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference
+	(*PackageRepositorySummary)(nil),                     // 15: kubeappsapis.core.repositories.v1alpha1.PackageRepositorySummary
+	(*PackageRepositoryDetail)(nil),                      // 16: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryDetail
+	(*PackageRepositoryAuth)(nil),                        // 17: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryAuth
+	(*PackageRepositoryStatus)(nil),                      // 18: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryStatus
+	(*v1alpha1.Context)(nil),                             // 19: kubeappsapis.core.packages.v1alpha1.Context
+	(*v1alpha11.Plugin)(nil),                             // 20: kubeappsapis.core.plugins.v1alpha1.Plugin
+	(*anypb.Any)(nil),                                    // 21: google.protobuf.Any
+	(*v1alpha1.PluginError)(nil),                         // 22: kubeappsapis.core.packages.v1alpha1.PluginError
+}
+var file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_depIdxs = []int32{
+	19, // 0: kubeappsapis.core.repositories.v1alpha1.GetPackageRepositorySummariesRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	14, // 1: kubeappsapis.core.repositories.v1alpha1.GetPackageRepositoryDetailRequest.package_repo_ref:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference
+	19, // 2: kubeappsapis.core.repositories.v1alpha1.CreatePackageRepositoryRequest.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	17, // 3: kubeappsapis.core.repositories.v1alpha1.CreatePackageRepositoryRequest.auth:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryAuth
+	20, // 4: kubeappsapis.core.repositories.v1alpha1.CreatePackageRepositoryRequest.plugin:type_name -> kubeappsapis.core.plugins.v1alpha1.Plugin
+	21, // 5: kubeappsapis.core.repositories.v1alpha1.CreatePackageRepositoryRequest.custom_detail:type_name -> google.protobuf.Any
+	14, // 6: kubeappsapis.core.repositories.v1alpha1.UpdatePackageRepositoryRequest.package_repo_ref:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference
+	17, // 7: kubeappsapis.core.repositories.v1alpha1.UpdatePackageRepositoryRequest.auth:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryAuth
+	21, // 8: kubeappsapis.core.repositories.v1alpha1.UpdatePackageRepositoryRequest.custom_detail:type_name -> google.protobuf.Any
+	14, // 9: kubeappsapis.core.repositories.v1alpha1.DeletePackageRepositoryRequest.package_repo_ref:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference
+	14, // 10: kubeappsapis.core.repositories.v1alpha1.RefreshPackageRepositoryRequest.package_repo_ref:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference
+	15, // 11: kubeappsapis.core.repositories.v1alpha1.GetPackageRepositorySummariesResponse.package_repository_summaries:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositorySummary
+	22, // 12: kubeappsapis.core.repositories.v1alpha1.GetPackageRepositorySummariesResponse.plugin_errors:type_name -> kubeappsapis.core.packages.v1alpha1.PluginError
+	16, // 13: kubeappsapis.core.repositories.v1alpha1.GetPackageRepositoryDetailResponse.package_repository_detail:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryDetail
+	14, // 14: kubeappsapis.core.repositories.v1alpha1.CreatePackageRepositoryResponse.package_repo_ref:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference
+	14, // 15: kubeappsapis.core.repositories.v1alpha1.UpdatePackageRepositoryResponse.package_repo_ref:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference
+	14, // 16: kubeappsapis.core.repositories.v1alpha1.RefreshPackageRepositoryResponse.package_repo_ref:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference
+	19, // 17: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference.context:type_name -> kubeappsapis.core.packages.v1alpha1.Context
+	20, // 18: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference.plugin:type_name -> kubeappsapis.core.plugins.v1alpha1.Plugin
+	14, // 19: kubeappsapis.core.repositories.v1alpha1.PackageRepositorySummary.package_repo_ref:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference
+	18, // 20: kubeappsapis.core.repositories.v1alpha1.PackageRepositorySummary.status:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryStatus
+	14, // 21: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryDetail.package_repo_ref:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryReference
+	17, // 22: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryDetail.auth:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryAuth
+	18, // 23: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryDetail.status:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryStatus
+	21, // 24: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryDetail.custom_detail:type_name -> google.protobuf.Any
+	0,  // 25: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryAuth.type:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryAuth.PackageRepositoryAuthType
+	1,  // 26: kubeappsapis.core.repositories.v1alpha1.PackageRepositoryStatus.reason:type_name -> kubeappsapis.core.repositories.v1alpha1.PackageRepositoryStatus.StatusReason
+	2,  // 27: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.GetPackageRepositorySummaries:input_type -> kubeappsapis.core.repositories.v1alpha1.GetPackageRepositorySummariesRequest
+	3,  // 28: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.GetPackageRepositoryDetail:input_type -> kubeappsapis.core.repositories.v1alpha1.GetPackageRepositoryDetailRequest
+	4,  // 29: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.CreatePackageRepository:input_type -> kubeappsapis.core.repositories.v1alpha1.CreatePackageRepositoryRequest
+	5,  // 30: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.UpdatePackageRepository:input_type -> kubeappsapis.core.repositories.v1alpha1.UpdatePackageRepositoryRequest
+	6,  // 31: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.DeletePackageRepository:input_type -> kubeappsapis.core.repositories.v1alpha1.DeletePackageRepositoryRequest
+	7,  // 32: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.RefreshPackageRepository:input_type -> kubeappsapis.core.repositories.v1alpha1.RefreshPackageRepositoryRequest
+	8,  // 33: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.GetPackageRepositorySummaries:output_type -> kubeappsapis.core.repositories.v1alpha1.GetPackageRepositorySummariesResponse
+	9,  // 34: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.GetPackageRepositoryDetail:output_type -> kubeappsapis.core.repositories.v1alpha1.GetPackageRepositoryDetailResponse
+	10, // 35: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.CreatePackageRepository:output_type -> kubeappsapis.core.repositories.v1alpha1.CreatePackageRepositoryResponse
+	11, // 36: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.UpdatePackageRepository:output_type -> kubeappsapis.core.repositories.v1alpha1.UpdatePackageRepositoryResponse
+	12, // 37: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.DeletePackageRepository:output_type -> kubeappsapis.core.repositories.v1alpha1.DeletePackageRepositoryResponse
+	13, // 38: kubeappsapis.core.repositories.v1alpha1.RepositoriesService.RefreshPackageRepository:output_type -> kubeappsapis.core.repositories.v1alpha1.RefreshPackageRepositoryResponse
+	33, // [33:39] is the sub-list for method output_type
+	27, // [27:33] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_init() }
+func file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_init() {
+	if File_kubeappsapis_core_repositories_v1alpha1_repositories_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPackageRepositorySummariesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPackageRepositoryDetailRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreatePackageRepositoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdatePackageRepositoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletePackageRepositoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshPackageRepositoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPackageRepositorySummariesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPackageRepositoryDetailResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreatePackageRepositoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdatePackageRepositoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletePackageRepositoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshPackageRepositoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PackageRepositoryReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PackageRepositorySummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PackageRepositoryDetail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PackageRepositoryAuth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PackageRepositoryStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_goTypes,
+		DependencyIndexes: file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_depIdxs,
+		EnumInfos:         file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_enumTypes,
+		MessageInfos:      file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_msgTypes,
+	}.Build()
+	File_kubeappsapis_core_repositories_v1alpha1_repositories_proto = out.File
+	file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_rawDesc = nil
+	file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_goTypes = nil
+	file_kubeappsapis_core_repositories_v1alpha1_repositories_proto_depIdxs = nil
+}
@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RepositoriesServiceClient is the client API for RepositoriesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RepositoriesServiceClient interface {
+	GetPackageRepositorySummaries(ctx context.Context, in *GetPackageRepositorySummariesRequest, opts ...grpc.CallOption) (*GetPackageRepositorySummariesResponse, error)
+	GetPackageRepositoryDetail(ctx context.Context, in *GetPackageRepositoryDetailRequest, opts ...grpc.CallOption) (*GetPackageRepositoryDetailResponse, error)
+	CreatePackageRepository(ctx context.Context, in *CreatePackageRepositoryRequest, opts ...grpc.CallOption) (*CreatePackageRepositoryResponse, error)
+	UpdatePackageRepository(ctx context.Context, in *UpdatePackageRepositoryRequest, opts ...grpc.CallOption) (*UpdatePackageRepositoryResponse, error)
+	DeletePackageRepository(ctx context.Context, in *DeletePackageRepositoryRequest, opts ...grpc.CallOption) (*DeletePackageRepositoryResponse, error)
+	RefreshPackageRepository(ctx context.Context, in *RefreshPackageRepositoryRequest, opts ...grpc.CallOption) (*RefreshPackageRepositoryResponse, error)
+}
+
+type repositoriesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRepositoriesServiceClient(cc grpc.ClientConnInterface) RepositoriesServiceClient {
+	return &repositoriesServiceClient{cc}
+}
+
+func (c *repositoriesServiceClient) GetPackageRepositorySummaries(ctx context.Context, in *GetPackageRepositorySummariesRequest, opts ...grpc.CallOption) (*GetPackageRepositorySummariesResponse, error) {
+	out := new(GetPackageRepositorySummariesResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/GetPackageRepositorySummaries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoriesServiceClient) GetPackageRepositoryDetail(ctx context.Context, in *GetPackageRepositoryDetailRequest, opts ...grpc.CallOption) (*GetPackageRepositoryDetailResponse, error) {
+	out := new(GetPackageRepositoryDetailResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/GetPackageRepositoryDetail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoriesServiceClient) CreatePackageRepository(ctx context.Context, in *CreatePackageRepositoryRequest, opts ...grpc.CallOption) (*CreatePackageRepositoryResponse, error) {
+	out := new(CreatePackageRepositoryResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/CreatePackageRepository", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoriesServiceClient) UpdatePackageRepository(ctx context.Context, in *UpdatePackageRepositoryRequest, opts ...grpc.CallOption) (*UpdatePackageRepositoryResponse, error) {
+	out := new(UpdatePackageRepositoryResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/UpdatePackageRepository", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoriesServiceClient) DeletePackageRepository(ctx context.Context, in *DeletePackageRepositoryRequest, opts ...grpc.CallOption) (*DeletePackageRepositoryResponse, error) {
+	out := new(DeletePackageRepositoryResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/DeletePackageRepository", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoriesServiceClient) RefreshPackageRepository(ctx context.Context, in *RefreshPackageRepositoryRequest, opts ...grpc.CallOption) (*RefreshPackageRepositoryResponse, error) {
+	out := new(RefreshPackageRepositoryResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/RefreshPackageRepository", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RepositoriesServiceServer is the server API for RepositoriesService service.
+// All implementations should embed UnimplementedRepositoriesServiceServer
+// for forward compatibility
+type RepositoriesServiceServer interface {
+	GetPackageRepositorySummaries(context.Context, *GetPackageRepositorySummariesRequest) (*GetPackageRepositorySummariesResponse, error)
+	GetPackageRepositoryDetail(context.Context, *GetPackageRepositoryDetailRequest) (*GetPackageRepositoryDetailResponse, error)
+	CreatePackageRepository(context.Context, *CreatePackageRepositoryRequest) (*CreatePackageRepositoryResponse, error)
+	UpdatePackageRepository(context.Context, *UpdatePackageRepositoryRequest) (*UpdatePackageRepositoryResponse, error)
+	DeletePackageRepository(context.Context, *DeletePackageRepositoryRequest) (*DeletePackageRepositoryResponse, error)
+	RefreshPackageRepository(context.Context, *RefreshPackageRepositoryRequest) (*RefreshPackageRepositoryResponse, error)
+}
+
+// UnimplementedRepositoriesServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedRepositoriesServiceServer struct {
+}
+
+func (UnimplementedRepositoriesServiceServer) GetPackageRepositorySummaries(context.Context, *GetPackageRepositorySummariesRequest) (*GetPackageRepositorySummariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPackageRepositorySummaries not implemented")
+}
+func (UnimplementedRepositoriesServiceServer) GetPackageRepositoryDetail(context.Context, *GetPackageRepositoryDetailRequest) (*GetPackageRepositoryDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPackageRepositoryDetail not implemented")
+}
+func (UnimplementedRepositoriesServiceServer) CreatePackageRepository(context.Context, *CreatePackageRepositoryRequest) (*CreatePackageRepositoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePackageRepository not implemented")
+}
+func (UnimplementedRepositoriesServiceServer) UpdatePackageRepository(context.Context, *UpdatePackageRepositoryRequest) (*UpdatePackageRepositoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePackageRepository not implemented")
+}
+func (UnimplementedRepositoriesServiceServer) DeletePackageRepository(context.Context, *DeletePackageRepositoryRequest) (*DeletePackageRepositoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePackageRepository not implemented")
+}
+func (UnimplementedRepositoriesServiceServer) RefreshPackageRepository(context.Context, *RefreshPackageRepositoryRequest) (*RefreshPackageRepositoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshPackageRepository not implemented")
+}
+
+// UnsafeRepositoriesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RepositoriesServiceServer will
+// result in compilation errors.
+type UnsafeRepositoriesServiceServer interface {
+	mustEmbedUnimplementedRepositoriesServiceServer()
+}
+
+func RegisterRepositoriesServiceServer(s grpc.ServiceRegistrar, srv RepositoriesServiceServer) {
+	s.RegisterService(&RepositoriesService_ServiceDesc, srv)
+}
+
+func _RepositoriesService_GetPackageRepositorySummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPackageRepositorySummariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).GetPackageRepositorySummaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/GetPackageRepositorySummaries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).GetPackageRepositorySummaries(ctx, req.(*GetPackageRepositorySummariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoriesService_GetPackageRepositoryDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPackageRepositoryDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).GetPackageRepositoryDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/GetPackageRepositoryDetail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).GetPackageRepositoryDetail(ctx, req.(*GetPackageRepositoryDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoriesService_CreatePackageRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePackageRepositoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).CreatePackageRepository(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/CreatePackageRepository",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).CreatePackageRepository(ctx, req.(*CreatePackageRepositoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoriesService_UpdatePackageRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePackageRepositoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).UpdatePackageRepository(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/UpdatePackageRepository",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).UpdatePackageRepository(ctx, req.(*UpdatePackageRepositoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoriesService_DeletePackageRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePackageRepositoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).DeletePackageRepository(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/DeletePackageRepository",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).DeletePackageRepository(ctx, req.(*DeletePackageRepositoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoriesService_RefreshPackageRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshPackageRepositoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).RefreshPackageRepository(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.core.repositories.v1alpha1.RepositoriesService/RefreshPackageRepository",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).RefreshPackageRepository(ctx, req.(*RefreshPackageRepositoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RepositoriesService_ServiceDesc is the grpc.ServiceDesc for RepositoriesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RepositoriesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kubeappsapis.core.repositories.v1alpha1.RepositoriesService",
+	HandlerType: (*RepositoriesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPackageRepositorySummaries",
+			Handler:    _RepositoriesService_GetPackageRepositorySummaries_Handler,
+		},
+		{
+			MethodName: "GetPackageRepositoryDetail",
+			Handler:    _RepositoriesService_GetPackageRepositoryDetail_Handler,
+		},
+		{
+			MethodName: "CreatePackageRepository",
+			Handler:    _RepositoriesService_CreatePackageRepository_Handler,
+		},
+		{
+			MethodName: "UpdatePackageRepository",
+			Handler:    _RepositoriesService_UpdatePackageRepository_Handler,
+		},
+		{
+			MethodName: "DeletePackageRepository",
+			Handler:    _RepositoriesService_DeletePackageRepository_Handler,
+		},
+		{
+			MethodName: "RefreshPackageRepository",
+			Handler:    _RepositoriesService_RefreshPackageRepository_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kubeappsapis/core/repositories/v1alpha1/repositories.proto",
+}
@@ -5,6 +5,7 @@ package v1alpha1
 import (
 	context "context"
 	v1alpha1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	v1alpha11 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/repositories/v1alpha1"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -23,8 +24,45 @@ type HelmPackagesServiceClient interface {
 	GetAvailablePackageSummaries(ctx context.Context, in *v1alpha1.GetAvailablePackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageSummariesResponse, error)
 	// GetAvailablePackageDetail returns the package details managed by the 'helm' plugin
 	GetAvailablePackageDetail(ctx context.Context, in *v1alpha1.GetAvailablePackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageDetailResponse, error)
-	// GetAvailablePackageVersions returns the package versions managed by the 'kapp_controller' plugin
+	// GetAvailablePackageVersions returns the package versions managed by the 'helm' plugin
 	GetAvailablePackageVersions(ctx context.Context, in *v1alpha1.GetAvailablePackageVersionsRequest, opts ...grpc.CallOption) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetInstalledPackageSummaries returns the installed packages managed by the 'helm' plugin
+	GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
+	// GetInstalledPackageDetail returns the requested installed package managed by the 'helm' plugin
+	GetInstalledPackageDetail(ctx context.Context, in *v1alpha1.GetInstalledPackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CreateInstalledPackage creates an installed package based on the request.
+	CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error)
+	// UpdateInstalledPackage updates an installed package based on the request.
+	UpdateInstalledPackage(ctx context.Context, in *v1alpha1.UpdateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.UpdateInstalledPackageResponse, error)
+	// DeleteInstalledPackage deletes an installed package based on the request.
+	DeleteInstalledPackage(ctx context.Context, in *v1alpha1.DeleteInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.DeleteInstalledPackageResponse, error)
+	// RollbackInstalledPackage rolls back an installed package to a previous revision.
+	RollbackInstalledPackage(ctx context.Context, in *RollbackInstalledPackageRequest, opts ...grpc.CallOption) (*RollbackInstalledPackageResponse, error)
+	// GetInstalledPackageRevisions returns the revisions of an installed package.
+	GetInstalledPackageRevisions(ctx context.Context, in *GetInstalledPackageRevisionsRequest, opts ...grpc.CallOption) (*GetInstalledPackageRevisionsResponse, error)
+	// GetInstalledPackageRevisionDiff returns the differences of the manifest
+	// and values between two revisions of an installed package.
+	GetInstalledPackageRevisionDiff(ctx context.Context, in *GetInstalledPackageRevisionDiffRequest, opts ...grpc.CallOption) (*GetInstalledPackageRevisionDiffResponse, error)
+	// DryRunCreateInstalledPackage renders the package to install, without
+	// installing it, reporting the actions forbidden to the user.
+	DryRunCreateInstalledPackage(ctx context.Context, in *DryRunCreateInstalledPackageRequest, opts ...grpc.CallOption) (*DryRunInstalledPackageResponse, error)
+	// DryRunUpdateInstalledPackage renders the updated installed package,
+	// without updating it, reporting the actions forbidden to the user.
+	DryRunUpdateInstalledPackage(ctx context.Context, in *DryRunUpdateInstalledPackageRequest, opts ...grpc.CallOption) (*DryRunInstalledPackageResponse, error)
+	// ValidatePackageValues validates values against the values.schema.json
+	// of an available package.
+	ValidatePackageValues(ctx context.Context, in *ValidatePackageValuesRequest, opts ...grpc.CallOption) (*ValidatePackageValuesResponse, error)
+	// GetInstalledPackageResourceTree returns the resources of an installed
+	// package, with the resources they own, and their health.
+	GetInstalledPackageResourceTree(ctx context.Context, in *GetInstalledPackageResourceTreeRequest, opts ...grpc.CallOption) (*GetInstalledPackageResourceTreeResponse, error)
+	// GetInstalledPackageUpgrades compares the chart version of each release
+	// in a namespace with the versions available in the catalog.
+	GetInstalledPackageUpgrades(ctx context.Context, in *GetInstalledPackageUpgradesRequest, opts ...grpc.CallOption) (*GetInstalledPackageUpgradesResponse, error)
+	// CreateInstalledPackageFromURL installs the chart of an explicit URL,
+	// either an OCI reference or the URL of a chart archive, without a
+	// repository. It must be enabled in the plugin settings and allowed by
+	// RBAC in the target namespace.
+	CreateInstalledPackageFromURL(ctx context.Context, in *CreateInstalledPackageFromURLRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error)
 }
 
 type helmPackagesServiceClient struct {
@@ -62,6 +100,132 @@ func (c *helmPackagesServiceClient) GetAvailablePackageVersions(ctx context.Cont
 	return out, nil
 }
 
+func (c *helmPackagesServiceClient) GetInstalledPackageSummaries(ctx context.Context, in *v1alpha1.GetInstalledPackageSummariesRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageSummariesResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageSummaries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) GetInstalledPackageDetail(ctx context.Context, in *v1alpha1.GetInstalledPackageDetailRequest, opts ...grpc.CallOption) (*v1alpha1.GetInstalledPackageDetailResponse, error) {
+	out := new(v1alpha1.GetInstalledPackageDetailResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageDetail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) CreateInstalledPackage(ctx context.Context, in *v1alpha1.CreateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error) {
+	out := new(v1alpha1.CreateInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/CreateInstalledPackage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) UpdateInstalledPackage(ctx context.Context, in *v1alpha1.UpdateInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.UpdateInstalledPackageResponse, error) {
+	out := new(v1alpha1.UpdateInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/UpdateInstalledPackage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) DeleteInstalledPackage(ctx context.Context, in *v1alpha1.DeleteInstalledPackageRequest, opts ...grpc.CallOption) (*v1alpha1.DeleteInstalledPackageResponse, error) {
+	out := new(v1alpha1.DeleteInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/DeleteInstalledPackage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) RollbackInstalledPackage(ctx context.Context, in *RollbackInstalledPackageRequest, opts ...grpc.CallOption) (*RollbackInstalledPackageResponse, error) {
+	out := new(RollbackInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/RollbackInstalledPackage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) GetInstalledPackageRevisions(ctx context.Context, in *GetInstalledPackageRevisionsRequest, opts ...grpc.CallOption) (*GetInstalledPackageRevisionsResponse, error) {
+	out := new(GetInstalledPackageRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) GetInstalledPackageRevisionDiff(ctx context.Context, in *GetInstalledPackageRevisionDiffRequest, opts ...grpc.CallOption) (*GetInstalledPackageRevisionDiffResponse, error) {
+	out := new(GetInstalledPackageRevisionDiffResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageRevisionDiff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) DryRunCreateInstalledPackage(ctx context.Context, in *DryRunCreateInstalledPackageRequest, opts ...grpc.CallOption) (*DryRunInstalledPackageResponse, error) {
+	out := new(DryRunInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/DryRunCreateInstalledPackage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) DryRunUpdateInstalledPackage(ctx context.Context, in *DryRunUpdateInstalledPackageRequest, opts ...grpc.CallOption) (*DryRunInstalledPackageResponse, error) {
+	out := new(DryRunInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/DryRunUpdateInstalledPackage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) ValidatePackageValues(ctx context.Context, in *ValidatePackageValuesRequest, opts ...grpc.CallOption) (*ValidatePackageValuesResponse, error) {
+	out := new(ValidatePackageValuesResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/ValidatePackageValues", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) GetInstalledPackageResourceTree(ctx context.Context, in *GetInstalledPackageResourceTreeRequest, opts ...grpc.CallOption) (*GetInstalledPackageResourceTreeResponse, error) {
+	out := new(GetInstalledPackageResourceTreeResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageResourceTree", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) GetInstalledPackageUpgrades(ctx context.Context, in *GetInstalledPackageUpgradesRequest, opts ...grpc.CallOption) (*GetInstalledPackageUpgradesResponse, error) {
+	out := new(GetInstalledPackageUpgradesResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageUpgrades", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmPackagesServiceClient) CreateInstalledPackageFromURL(ctx context.Context, in *CreateInstalledPackageFromURLRequest, opts ...grpc.CallOption) (*v1alpha1.CreateInstalledPackageResponse, error) {
+	out := new(v1alpha1.CreateInstalledPackageResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/CreateInstalledPackageFromURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HelmPackagesServiceServer is the server API for HelmPackagesService service.
 // All implementations should embed UnimplementedHelmPackagesServiceServer
 // for forward compatibility
@@ -70,8 +234,45 @@ type HelmPackagesServiceServer interface {
 	GetAvailablePackageSummaries(context.Context, *v1alpha1.GetAvailablePackageSummariesRequest) (*v1alpha1.GetAvailablePackageSummariesResponse, error)
 	// GetAvailablePackageDetail returns the package details managed by the 'helm' plugin
 	GetAvailablePackageDetail(context.Context, *v1alpha1.GetAvailablePackageDetailRequest) (*v1alpha1.GetAvailablePackageDetailResponse, error)
-	// GetAvailablePackageVersions returns the package versions managed by the 'kapp_controller' plugin
+	// GetAvailablePackageVersions returns the package versions managed by the 'helm' plugin
 	GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error)
+	// GetInstalledPackageSummaries returns the installed packages managed by the 'helm' plugin
+	GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error)
+	// GetInstalledPackageDetail returns the requested installed package managed by the 'helm' plugin
+	GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error)
+	// CreateInstalledPackage creates an installed package based on the request.
+	CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error)
+	// UpdateInstalledPackage updates an installed package based on the request.
+	UpdateInstalledPackage(context.Context, *v1alpha1.UpdateInstalledPackageRequest) (*v1alpha1.UpdateInstalledPackageResponse, error)
+	// DeleteInstalledPackage deletes an installed package based on the request.
+	DeleteInstalledPackage(context.Context, *v1alpha1.DeleteInstalledPackageRequest) (*v1alpha1.DeleteInstalledPackageResponse, error)
+	// RollbackInstalledPackage rolls back an installed package to a previous revision.
+	RollbackInstalledPackage(context.Context, *RollbackInstalledPackageRequest) (*RollbackInstalledPackageResponse, error)
+	// GetInstalledPackageRevisions returns the revisions of an installed package.
+	GetInstalledPackageRevisions(context.Context, *GetInstalledPackageRevisionsRequest) (*GetInstalledPackageRevisionsResponse, error)
+	// GetInstalledPackageRevisionDiff returns the differences of the manifest
+	// and values between two revisions of an installed package.
+	GetInstalledPackageRevisionDiff(context.Context, *GetInstalledPackageRevisionDiffRequest) (*GetInstalledPackageRevisionDiffResponse, error)
+	// DryRunCreateInstalledPackage renders the package to install, without
+	// installing it, reporting the actions forbidden to the user.
+	DryRunCreateInstalledPackage(context.Context, *DryRunCreateInstalledPackageRequest) (*DryRunInstalledPackageResponse, error)
+	// DryRunUpdateInstalledPackage renders the updated installed package,
+	// without updating it, reporting the actions forbidden to the user.
+	DryRunUpdateInstalledPackage(context.Context, *DryRunUpdateInstalledPackageRequest) (*DryRunInstalledPackageResponse, error)
+	// ValidatePackageValues validates values against the values.schema.json
+	// of an available package.
+	ValidatePackageValues(context.Context, *ValidatePackageValuesRequest) (*ValidatePackageValuesResponse, error)
+	// GetInstalledPackageResourceTree returns the resources of an installed
+	// package, with the resources they own, and their health.
+	GetInstalledPackageResourceTree(context.Context, *GetInstalledPackageResourceTreeRequest) (*GetInstalledPackageResourceTreeResponse, error)
+	// GetInstalledPackageUpgrades compares the chart version of each release
+	// in a namespace with the versions available in the catalog.
+	GetInstalledPackageUpgrades(context.Context, *GetInstalledPackageUpgradesRequest) (*GetInstalledPackageUpgradesResponse, error)
+	// CreateInstalledPackageFromURL installs the chart of an explicit URL,
+	// either an OCI reference or the URL of a chart archive, without a
+	// repository. It must be enabled in the plugin settings and allowed by
+	// RBAC in the target namespace.
+	CreateInstalledPackageFromURL(context.Context, *CreateInstalledPackageFromURLRequest) (*v1alpha1.CreateInstalledPackageResponse, error)
 }
 
 // UnimplementedHelmPackagesServiceServer should be embedded to have forward compatible implementations.
@@ -87,6 +288,48 @@ func (UnimplementedHelmPackagesServiceServer) GetAvailablePackageDetail(context.
 func (UnimplementedHelmPackagesServiceServer) GetAvailablePackageVersions(context.Context, *v1alpha1.GetAvailablePackageVersionsRequest) (*v1alpha1.GetAvailablePackageVersionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAvailablePackageVersions not implemented")
 }
+func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageSummaries(context.Context, *v1alpha1.GetInstalledPackageSummariesRequest) (*v1alpha1.GetInstalledPackageSummariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageSummaries not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageDetail(context.Context, *v1alpha1.GetInstalledPackageDetailRequest) (*v1alpha1.GetInstalledPackageDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageDetail not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) CreateInstalledPackage(context.Context, *v1alpha1.CreateInstalledPackageRequest) (*v1alpha1.CreateInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInstalledPackage not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) UpdateInstalledPackage(context.Context, *v1alpha1.UpdateInstalledPackageRequest) (*v1alpha1.UpdateInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateInstalledPackage not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) DeleteInstalledPackage(context.Context, *v1alpha1.DeleteInstalledPackageRequest) (*v1alpha1.DeleteInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteInstalledPackage not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) RollbackInstalledPackage(context.Context, *RollbackInstalledPackageRequest) (*RollbackInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackInstalledPackage not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageRevisions(context.Context, *GetInstalledPackageRevisionsRequest) (*GetInstalledPackageRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageRevisions not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageRevisionDiff(context.Context, *GetInstalledPackageRevisionDiffRequest) (*GetInstalledPackageRevisionDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageRevisionDiff not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) DryRunCreateInstalledPackage(context.Context, *DryRunCreateInstalledPackageRequest) (*DryRunInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunCreateInstalledPackage not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) DryRunUpdateInstalledPackage(context.Context, *DryRunUpdateInstalledPackageRequest) (*DryRunInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunUpdateInstalledPackage not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) ValidatePackageValues(context.Context, *ValidatePackageValuesRequest) (*ValidatePackageValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatePackageValues not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageResourceTree(context.Context, *GetInstalledPackageResourceTreeRequest) (*GetInstalledPackageResourceTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageResourceTree not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) GetInstalledPackageUpgrades(context.Context, *GetInstalledPackageUpgradesRequest) (*GetInstalledPackageUpgradesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstalledPackageUpgrades not implemented")
+}
+func (UnimplementedHelmPackagesServiceServer) CreateInstalledPackageFromURL(context.Context, *CreateInstalledPackageFromURLRequest) (*v1alpha1.CreateInstalledPackageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInstalledPackageFromURL not implemented")
+}
 
 // UnsafeHelmPackagesServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to HelmPackagesServiceServer will
@@ -153,6 +396,258 @@ func _HelmPackagesService_GetAvailablePackageVersions_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HelmPackagesService_GetInstalledPackageSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageSummariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageSummaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageSummaries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageSummaries(ctx, req.(*v1alpha1.GetInstalledPackageSummariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_GetInstalledPackageDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.GetInstalledPackageDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageDetail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageDetail(ctx, req.(*v1alpha1.GetInstalledPackageDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_CreateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.CreateInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).CreateInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/CreateInstalledPackage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).CreateInstalledPackage(ctx, req.(*v1alpha1.CreateInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_UpdateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.UpdateInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).UpdateInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/UpdateInstalledPackage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).UpdateInstalledPackage(ctx, req.(*v1alpha1.UpdateInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_DeleteInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha1.DeleteInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).DeleteInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/DeleteInstalledPackage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).DeleteInstalledPackage(ctx, req.(*v1alpha1.DeleteInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_RollbackInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).RollbackInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/RollbackInstalledPackage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).RollbackInstalledPackage(ctx, req.(*RollbackInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_GetInstalledPackageRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageRevisions(ctx, req.(*GetInstalledPackageRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_GetInstalledPackageRevisionDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageRevisionDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageRevisionDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageRevisionDiff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageRevisionDiff(ctx, req.(*GetInstalledPackageRevisionDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_DryRunCreateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunCreateInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).DryRunCreateInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/DryRunCreateInstalledPackage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).DryRunCreateInstalledPackage(ctx, req.(*DryRunCreateInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_DryRunUpdateInstalledPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunUpdateInstalledPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).DryRunUpdateInstalledPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/DryRunUpdateInstalledPackage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).DryRunUpdateInstalledPackage(ctx, req.(*DryRunUpdateInstalledPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_ValidatePackageValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatePackageValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).ValidatePackageValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/ValidatePackageValues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).ValidatePackageValues(ctx, req.(*ValidatePackageValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_GetInstalledPackageResourceTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageResourceTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageResourceTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageResourceTree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageResourceTree(ctx, req.(*GetInstalledPackageResourceTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_GetInstalledPackageUpgrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstalledPackageUpgradesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageUpgrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetInstalledPackageUpgrades",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).GetInstalledPackageUpgrades(ctx, req.(*GetInstalledPackageUpgradesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmPackagesService_CreateInstalledPackageFromURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInstalledPackageFromURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmPackagesServiceServer).CreateInstalledPackageFromURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/CreateInstalledPackageFromURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmPackagesServiceServer).CreateInstalledPackageFromURL(ctx, req.(*CreateInstalledPackageFromURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HelmPackagesService_ServiceDesc is the grpc.ServiceDesc for HelmPackagesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -172,6 +667,338 @@ var HelmPackagesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAvailablePackageVersions",
 			Handler:    _HelmPackagesService_GetAvailablePackageVersions_Handler,
 		},
+		{
+			MethodName: "GetInstalledPackageSummaries",
+			Handler:    _HelmPackagesService_GetInstalledPackageSummaries_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageDetail",
+			Handler:    _HelmPackagesService_GetInstalledPackageDetail_Handler,
+		},
+		{
+			MethodName: "CreateInstalledPackage",
+			Handler:    _HelmPackagesService_CreateInstalledPackage_Handler,
+		},
+		{
+			MethodName: "UpdateInstalledPackage",
+			Handler:    _HelmPackagesService_UpdateInstalledPackage_Handler,
+		},
+		{
+			MethodName: "DeleteInstalledPackage",
+			Handler:    _HelmPackagesService_DeleteInstalledPackage_Handler,
+		},
+		{
+			MethodName: "RollbackInstalledPackage",
+			Handler:    _HelmPackagesService_RollbackInstalledPackage_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageRevisions",
+			Handler:    _HelmPackagesService_GetInstalledPackageRevisions_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageRevisionDiff",
+			Handler:    _HelmPackagesService_GetInstalledPackageRevisionDiff_Handler,
+		},
+		{
+			MethodName: "DryRunCreateInstalledPackage",
+			Handler:    _HelmPackagesService_DryRunCreateInstalledPackage_Handler,
+		},
+		{
+			MethodName: "DryRunUpdateInstalledPackage",
+			Handler:    _HelmPackagesService_DryRunUpdateInstalledPackage_Handler,
+		},
+		{
+			MethodName: "ValidatePackageValues",
+			Handler:    _HelmPackagesService_ValidatePackageValues_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageResourceTree",
+			Handler:    _HelmPackagesService_GetInstalledPackageResourceTree_Handler,
+		},
+		{
+			MethodName: "GetInstalledPackageUpgrades",
+			Handler:    _HelmPackagesService_GetInstalledPackageUpgrades_Handler,
+		},
+		{
+			MethodName: "CreateInstalledPackageFromURL",
+			Handler:    _HelmPackagesService_CreateInstalledPackageFromURL_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kubeappsapis/plugins/helm/packages/v1alpha1/helm.proto",
+}
+
+// HelmRepositoriesServiceClient is the client API for HelmRepositoriesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HelmRepositoriesServiceClient interface {
+	// GetPackageRepositorySummaries returns the AppRepositories managed by the 'helm' plugin
+	GetPackageRepositorySummaries(ctx context.Context, in *v1alpha11.GetPackageRepositorySummariesRequest, opts ...grpc.CallOption) (*v1alpha11.GetPackageRepositorySummariesResponse, error)
+	// GetPackageRepositoryDetail returns the requested AppRepository
+	GetPackageRepositoryDetail(ctx context.Context, in *v1alpha11.GetPackageRepositoryDetailRequest, opts ...grpc.CallOption) (*v1alpha11.GetPackageRepositoryDetailResponse, error)
+	// CreatePackageRepository creates an AppRepository based on the request.
+	CreatePackageRepository(ctx context.Context, in *v1alpha11.CreatePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha11.CreatePackageRepositoryResponse, error)
+	// UpdatePackageRepository updates an AppRepository based on the request.
+	UpdatePackageRepository(ctx context.Context, in *v1alpha11.UpdatePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha11.UpdatePackageRepositoryResponse, error)
+	// DeletePackageRepository deletes an AppRepository based on the request.
+	DeletePackageRepository(ctx context.Context, in *v1alpha11.DeletePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha11.DeletePackageRepositoryResponse, error)
+	// RefreshPackageRepository requests the AppRepository to be synchronized again.
+	RefreshPackageRepository(ctx context.Context, in *v1alpha11.RefreshPackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha11.RefreshPackageRepositoryResponse, error)
+}
+
+type helmRepositoriesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHelmRepositoriesServiceClient(cc grpc.ClientConnInterface) HelmRepositoriesServiceClient {
+	return &helmRepositoriesServiceClient{cc}
+}
+
+func (c *helmRepositoriesServiceClient) GetPackageRepositorySummaries(ctx context.Context, in *v1alpha11.GetPackageRepositorySummariesRequest, opts ...grpc.CallOption) (*v1alpha11.GetPackageRepositorySummariesResponse, error) {
+	out := new(v1alpha11.GetPackageRepositorySummariesResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/GetPackageRepositorySummaries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmRepositoriesServiceClient) GetPackageRepositoryDetail(ctx context.Context, in *v1alpha11.GetPackageRepositoryDetailRequest, opts ...grpc.CallOption) (*v1alpha11.GetPackageRepositoryDetailResponse, error) {
+	out := new(v1alpha11.GetPackageRepositoryDetailResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/GetPackageRepositoryDetail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmRepositoriesServiceClient) CreatePackageRepository(ctx context.Context, in *v1alpha11.CreatePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha11.CreatePackageRepositoryResponse, error) {
+	out := new(v1alpha11.CreatePackageRepositoryResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/CreatePackageRepository", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmRepositoriesServiceClient) UpdatePackageRepository(ctx context.Context, in *v1alpha11.UpdatePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha11.UpdatePackageRepositoryResponse, error) {
+	out := new(v1alpha11.UpdatePackageRepositoryResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/UpdatePackageRepository", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmRepositoriesServiceClient) DeletePackageRepository(ctx context.Context, in *v1alpha11.DeletePackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha11.DeletePackageRepositoryResponse, error) {
+	out := new(v1alpha11.DeletePackageRepositoryResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/DeletePackageRepository", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helmRepositoriesServiceClient) RefreshPackageRepository(ctx context.Context, in *v1alpha11.RefreshPackageRepositoryRequest, opts ...grpc.CallOption) (*v1alpha11.RefreshPackageRepositoryResponse, error) {
+	out := new(v1alpha11.RefreshPackageRepositoryResponse)
+	err := c.cc.Invoke(ctx, "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/RefreshPackageRepository", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HelmRepositoriesServiceServer is the server API for HelmRepositoriesService service.
+// All implementations should embed UnimplementedHelmRepositoriesServiceServer
+// for forward compatibility
+type HelmRepositoriesServiceServer interface {
+	// GetPackageRepositorySummaries returns the AppRepositories managed by the 'helm' plugin
+	GetPackageRepositorySummaries(context.Context, *v1alpha11.GetPackageRepositorySummariesRequest) (*v1alpha11.GetPackageRepositorySummariesResponse, error)
+	// GetPackageRepositoryDetail returns the requested AppRepository
+	GetPackageRepositoryDetail(context.Context, *v1alpha11.GetPackageRepositoryDetailRequest) (*v1alpha11.GetPackageRepositoryDetailResponse, error)
+	// CreatePackageRepository creates an AppRepository based on the request.
+	CreatePackageRepository(context.Context, *v1alpha11.CreatePackageRepositoryRequest) (*v1alpha11.CreatePackageRepositoryResponse, error)
+	// UpdatePackageRepository updates an AppRepository based on the request.
+	UpdatePackageRepository(context.Context, *v1alpha11.UpdatePackageRepositoryRequest) (*v1alpha11.UpdatePackageRepositoryResponse, error)
+	// DeletePackageRepository deletes an AppRepository based on the request.
+	DeletePackageRepository(context.Context, *v1alpha11.DeletePackageRepositoryRequest) (*v1alpha11.DeletePackageRepositoryResponse, error)
+	// RefreshPackageRepository requests the AppRepository to be synchronized again.
+	RefreshPackageRepository(context.Context, *v1alpha11.RefreshPackageRepositoryRequest) (*v1alpha11.RefreshPackageRepositoryResponse, error)
+}
+
+// UnimplementedHelmRepositoriesServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedHelmRepositoriesServiceServer struct {
+}
+
+func (UnimplementedHelmRepositoriesServiceServer) GetPackageRepositorySummaries(context.Context, *v1alpha11.GetPackageRepositorySummariesRequest) (*v1alpha11.GetPackageRepositorySummariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPackageRepositorySummaries not implemented")
+}
+func (UnimplementedHelmRepositoriesServiceServer) GetPackageRepositoryDetail(context.Context, *v1alpha11.GetPackageRepositoryDetailRequest) (*v1alpha11.GetPackageRepositoryDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPackageRepositoryDetail not implemented")
+}
+func (UnimplementedHelmRepositoriesServiceServer) CreatePackageRepository(context.Context, *v1alpha11.CreatePackageRepositoryRequest) (*v1alpha11.CreatePackageRepositoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePackageRepository not implemented")
+}
+func (UnimplementedHelmRepositoriesServiceServer) UpdatePackageRepository(context.Context, *v1alpha11.UpdatePackageRepositoryRequest) (*v1alpha11.UpdatePackageRepositoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePackageRepository not implemented")
+}
+func (UnimplementedHelmRepositoriesServiceServer) DeletePackageRepository(context.Context, *v1alpha11.DeletePackageRepositoryRequest) (*v1alpha11.DeletePackageRepositoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePackageRepository not implemented")
+}
+func (UnimplementedHelmRepositoriesServiceServer) RefreshPackageRepository(context.Context, *v1alpha11.RefreshPackageRepositoryRequest) (*v1alpha11.RefreshPackageRepositoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshPackageRepository not implemented")
+}
+
+// UnsafeHelmRepositoriesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HelmRepositoriesServiceServer will
+// result in compilation errors.
+type UnsafeHelmRepositoriesServiceServer interface {
+	mustEmbedUnimplementedHelmRepositoriesServiceServer()
+}
+
+func RegisterHelmRepositoriesServiceServer(s grpc.ServiceRegistrar, srv HelmRepositoriesServiceServer) {
+	s.RegisterService(&HelmRepositoriesService_ServiceDesc, srv)
+}
+
+func _HelmRepositoriesService_GetPackageRepositorySummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha11.GetPackageRepositorySummariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmRepositoriesServiceServer).GetPackageRepositorySummaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/GetPackageRepositorySummaries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmRepositoriesServiceServer).GetPackageRepositorySummaries(ctx, req.(*v1alpha11.GetPackageRepositorySummariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmRepositoriesService_GetPackageRepositoryDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha11.GetPackageRepositoryDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmRepositoriesServiceServer).GetPackageRepositoryDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/GetPackageRepositoryDetail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmRepositoriesServiceServer).GetPackageRepositoryDetail(ctx, req.(*v1alpha11.GetPackageRepositoryDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmRepositoriesService_CreatePackageRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha11.CreatePackageRepositoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmRepositoriesServiceServer).CreatePackageRepository(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/CreatePackageRepository",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmRepositoriesServiceServer).CreatePackageRepository(ctx, req.(*v1alpha11.CreatePackageRepositoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmRepositoriesService_UpdatePackageRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha11.UpdatePackageRepositoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmRepositoriesServiceServer).UpdatePackageRepository(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/UpdatePackageRepository",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmRepositoriesServiceServer).UpdatePackageRepository(ctx, req.(*v1alpha11.UpdatePackageRepositoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmRepositoriesService_DeletePackageRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha11.DeletePackageRepositoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmRepositoriesServiceServer).DeletePackageRepository(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/DeletePackageRepository",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmRepositoriesServiceServer).DeletePackageRepository(ctx, req.(*v1alpha11.DeletePackageRepositoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HelmRepositoriesService_RefreshPackageRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1alpha11.RefreshPackageRepositoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelmRepositoriesServiceServer).RefreshPackageRepository(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService/RefreshPackageRepository",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelmRepositoriesServiceServer).RefreshPackageRepository(ctx, req.(*v1alpha11.RefreshPackageRepositoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HelmRepositoriesService_ServiceDesc is the grpc.ServiceDesc for HelmRepositoriesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HelmRepositoriesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kubeappsapis.plugins.helm.packages.v1alpha1.HelmRepositoriesService",
+	HandlerType: (*HelmRepositoriesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPackageRepositorySummaries",
+			Handler:    _HelmRepositoriesService_GetPackageRepositorySummaries_Handler,
+		},
+		{
+			MethodName: "GetPackageRepositoryDetail",
+			Handler:    _HelmRepositoriesService_GetPackageRepositoryDetail_Handler,
+		},
+		{
+			MethodName: "CreatePackageRepository",
+			Handler:    _HelmRepositoriesService_CreatePackageRepository_Handler,
+		},
+		{
+			MethodName: "UpdatePackageRepository",
+			Handler:    _HelmRepositoriesService_UpdatePackageRepository_Handler,
+		},
+		{
+			MethodName: "DeletePackageRepository",
+			Handler:    _HelmRepositoriesService_DeletePackageRepository_Handler,
+		},
+		{
+			MethodName: "RefreshPackageRepository",
+			Handler:    _HelmRepositoriesService_RefreshPackageRepository_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kubeappsapis/plugins/helm/packages/v1alpha1/helm.proto",
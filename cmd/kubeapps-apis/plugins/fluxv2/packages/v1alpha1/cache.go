@@ -19,6 +19,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/server"
@@ -26,11 +27,17 @@ import (
 	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
+	k8scache "k8s.io/client-go/tools/cache"
 	log "k8s.io/klog/v2"
 )
 
+// defaultCacheResyncPeriod is the period with which the objects are listed again
+const defaultCacheResyncPeriod = 10 * time.Minute
+
 // a type of cache that is based on watching for changes to specified kubernetes resources
 type ResourceWatcherCache struct {
 	// these expected to be provided by the caller when creating new cache
@@ -39,7 +46,16 @@ type ResourceWatcherCache struct {
 	watcherStarted bool
 	// internal state: this mutex guards watcherStarted var
 	watcherMutex sync.Mutex
-	redisCli     *redis.Client
+	// store keeps the values of the cache, in redis or in memory
+	store keyValueStore
+	// internal state: this mutex guards the sync state below
+	syncMutex sync.RWMutex
+	// the resource versions of the objects whose values are up to date in the store, so a
+	// resync does not compute them again
+	resourceVersions map[string]string
+	// the time the objects were last listed and whether they are currently watched
+	lastSyncTime time.Time
+	watching     bool
 	// this WaitGroup is used exclusively by unit tests to block until all expected objects have
 	// been 'processed' by the go routine running in the background. The creation of the WaitGroup object
 	// and to call to .Add() is expected to be done by the unit test client. The server-side only signals
@@ -52,6 +68,8 @@ type ResourceWatcherCache struct {
 type cacheConfig struct {
 	gvr          schema.GroupVersionResource
 	clientGetter server.KubernetesClientGetter
+	// the period with which the objects are listed again, to recover from missed watch events
+	resyncPeriod time.Duration
 	// 'onAdd' and 'onModify' hooks are called when a new or modified object comes about and
 	// allows the plug-in to return information about WHETHER OR NOT and WHAT is to be stored
 	// in the cache for a given k8s object (passed in as a untyped/unstructured map)
@@ -65,46 +83,73 @@ type cacheConfig struct {
 	onDelete func(string, map[string]interface{}) (bool, error)
 }
 
-func newCache(config cacheConfig) (*ResourceWatcherCache, error) {
-	log.Infof("+newCache")
-	// TODO (gfichtenholt) small preference for reading all config in the main.go
-	// (whether from env vars or cmd-line options) only in the one spot and passing
-	// explicitly to functions (so functions are less dependent on env state).
-	REDIS_ADDR, ok := os.LookupEnv("REDIS_ADDR")
-	if !ok {
-		return nil, status.Errorf(codes.FailedPrecondition, "missing environment variable REDIS_ADDR")
+// redisSettings configure the connection to the redis server backing the
+// cache. The REDIS_ADDR, REDIS_PASSWORD and REDIS_DB environment variables
+// are used for the settings missing from the plugin config.
+type redisSettings struct {
+	Addr     string `json:"addr,omitempty"`
+	Password string `json:"password,omitempty"`
+	DB       *int   `json:"db,omitempty"`
+}
+
+// redisOptions returns the options of the redis client for the settings,
+// falling back to the environment variables.
+func redisOptions(settings redisSettings) (*redis.Options, error) {
+	options := &redis.Options{
+		Addr:     settings.Addr,
+		Password: settings.Password,
 	}
-	REDIS_PASSWORD, ok := os.LookupEnv("REDIS_PASSWORD")
-	if !ok {
-		return nil, status.Errorf(codes.FailedPrecondition, "missing environment variable REDIS_PASSWORD")
+	if options.Addr == "" {
+		REDIS_ADDR, ok := os.LookupEnv("REDIS_ADDR")
+		if !ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "missing redis addr in the plugin config or environment variable REDIS_ADDR")
+		}
+		options.Addr = REDIS_ADDR
 	}
-	REDIS_DB, ok := os.LookupEnv("REDIS_DB")
-	if !ok {
-		return nil, status.Errorf(codes.FailedPrecondition, "missing environment variable REDIS_DB")
+	if options.Password == "" {
+		REDIS_PASSWORD, ok := os.LookupEnv("REDIS_PASSWORD")
+		if !ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "missing redis password in the plugin config or environment variable REDIS_PASSWORD")
+		}
+		options.Password = REDIS_PASSWORD
+	}
+	if settings.DB != nil {
+		options.DB = *settings.DB
+	} else {
+		REDIS_DB, ok := os.LookupEnv("REDIS_DB")
+		if !ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "missing redis db in the plugin config or environment variable REDIS_DB")
+		}
+		REDIS_DB_NUM, err := strconv.Atoi(REDIS_DB)
+		if err != nil {
+			return nil, err
+		}
+		options.DB = REDIS_DB_NUM
 	}
+	return options, nil
+}
 
-	REDIS_DB_NUM, err := strconv.Atoi(REDIS_DB)
+func newCache(config cacheConfig, settings cacheSettings, redisConfig redisSettings) (*ResourceWatcherCache, error) {
+	log.Infof("+newCache")
+	store, err := newKeyValueStore(settings, redisConfig)
 	if err != nil {
 		return nil, err
 	}
-
-	log.Infof("newCache: addr: [%s], password: [%s], DB=[%d]", REDIS_ADDR, REDIS_PASSWORD, REDIS_DB_NUM)
-
-	return newCacheWithRedisClient(
-		config,
-		redis.NewClient(&redis.Options{
-			Addr:     REDIS_ADDR,
-			Password: REDIS_PASSWORD,
-			DB:       REDIS_DB_NUM,
-		}))
+	return newCacheWithStore(config, store)
 }
 
 func newCacheWithRedisClient(config cacheConfig, redisCli *redis.Client) (*ResourceWatcherCache, error) {
 	log.Infof("+newCacheWithRedisClient")
 
-	if redisCli == nil {
-		return nil, status.Errorf(codes.FailedPrecondition, "server not configured with redis Client")
+	store, err := newRedisStore(redisCli)
+	if err != nil {
+		return nil, err
 	}
+	return newCacheWithStore(config, store)
+}
+
+func newCacheWithStore(config cacheConfig, store keyValueStore) (*ResourceWatcherCache, error) {
+	log.Infof("+newCacheWithStore")
 
 	if config.clientGetter == nil {
 		return nil, status.Errorf(codes.FailedPrecondition, "server not configured with configGetter")
@@ -114,23 +159,31 @@ func newCacheWithRedisClient(config cacheConfig, redisCli *redis.Client) (*Resou
 		return nil, status.Errorf(codes.FailedPrecondition, "server not configured with expected cache hooks")
 	}
 
-	// sanity check that the redis client is connected
-	pong, err := redisCli.Ping(redisCli.Context()).Result()
-	if err != nil {
+	// sanity check that the store is reachable
+	if err := store.ping(context.Background()); err != nil {
 		return nil, err
 	}
-	log.Infof("[PING] -> [%s]", pong)
+
+	if config.resyncPeriod == 0 {
+		config.resyncPeriod = defaultCacheResyncPeriod
+	}
 
 	c := ResourceWatcherCache{
-		config:         config,
-		watcherStarted: false,
-		watcherMutex:   sync.Mutex{},
-		redisCli:       redisCli,
+		config:           config,
+		watcherStarted:   false,
+		watcherMutex:     sync.Mutex{},
+		store:            store,
+		resourceVersions: make(map[string]string),
 	}
 	go c.startResourceWatcher()
 	return &c, nil
 }
 
+// ping checks that the store of the cache is reachable.
+func (c *ResourceWatcherCache) ping(ctx context.Context) error {
+	return c.store.ping(ctx)
+}
+
 func (c *ResourceWatcherCache) startResourceWatcher() {
 	log.Infof("+ResourceWatcherCache startResourceWatcher")
 	c.watcherMutex.Lock()
@@ -138,7 +191,7 @@ func (c *ResourceWatcherCache) startResourceWatcher() {
 	// we never return from this func
 
 	if !c.watcherStarted {
-		ch, err := c.newResourceWatcherChan()
+		reflector, err := c.newResourceReflector()
 		if err != nil {
 			c.watcherMutex.Unlock()
 			log.Errorf("failed to start resource watcher due to: %v", err)
@@ -148,7 +201,7 @@ func (c *ResourceWatcherCache) startResourceWatcher() {
 		c.watcherMutex.Unlock()
 		log.Infof("watcher for [%s] successfully started. waiting for events...", c.config.gvr)
 
-		c.processEvents(ch)
+		c.runReflector(reflector)
 	} else {
 		c.watcherMutex.Unlock()
 		log.Infof("watcher already started. exiting...")
@@ -157,51 +210,236 @@ func (c *ResourceWatcherCache) startResourceWatcher() {
 	log.Warningf("-ResourceWatcherCache startResourceWatcher")
 }
 
-func (c *ResourceWatcherCache) newResourceWatcherChan() (<-chan watch.Event, error) {
-	ctx := context.Background()
-
-	_, dynamicClient, err := c.config.clientGetter(ctx)
+// newResourceReflector returns a reflector which lists the objects, replacing the contents
+// of the cache, and then watches them from the resource version of the list. The reflector
+// requests bookmarks, so that the watch can be resumed from a recent resource version, and
+// lists the objects again when the resource version has expired (410 Gone).
+func (c *ResourceWatcherCache) newResourceReflector() (*k8scache.Reflector, error) {
+	// the cache watches the repositories of the cluster on which Kubeapps is installed
+	_, dynamicClient, err := c.config.clientGetter(context.Background(), "")
 	if err != nil {
 		return nil, status.Errorf(codes.FailedPrecondition, "unable to get client due to: %v", err)
 	}
 
-	// this will start a watcher on all namespaces
-	watcher, err := dynamicClient.Resource(c.config.gvr).Namespace("").Watch(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
+	// this will list and watch all namespaces
+	resourceIfc := dynamicClient.Resource(c.config.gvr).Namespace("")
+	listerWatcher := &k8scache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return resourceIfc.List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return resourceIfc.Watch(context.Background(), options)
+		},
 	}
-
-	return watcher.ResultChan(), nil
+	return k8scache.NewReflector(listerWatcher, &unstructured.Unstructured{}, &reflectorStore{cache: c}, 0), nil
 }
 
-// this is an infinite loop that waits for new events and processes them when they happen
-func (c *ResourceWatcherCache) processEvents(ch <-chan watch.Event) {
+// runReflector is an infinite loop that lists and watches the objects. The watch is
+// stopped every resync period, so that the objects are listed again, which recovers
+// from missed events, e.g. while the API server restarts.
+func (c *ResourceWatcherCache) runReflector(reflector *k8scache.Reflector) {
+	backoff := newWatcherBackoff()
 	for {
-		event := <-ch
-		if event.Type == "" {
-			// not quite sure why this happens (the docs don't say), but it seems to happen quite often
+		lastSyncTime := c.status().lastSyncTime
+		stopCh := make(chan struct{})
+		resyncTimer := time.AfterFunc(c.config.resyncPeriod, func() { close(stopCh) })
+		err := reflector.ListAndWatch(stopCh)
+		resync := !resyncTimer.Stop()
+		c.setWatching(false)
+
+		if err != nil {
+			log.Errorf("failed to list and watch [%s] due to: %v", c.config.gvr, err)
+		}
+		if c.status().lastSyncTime != lastSyncTime {
+			// the objects were listed, so the previous failures are over
+			backoff = newWatcherBackoff()
+		}
+		if resync {
+			log.Infof("resyncing [%s]", c.config.gvr)
 			continue
 		}
-		log.Infof("got event: type: [%v] object:\n[%s]", event.Type, prettyPrintObject(event.Object))
-		switch event.Type {
-		case watch.Added, watch.Modified, watch.Deleted:
-			unstructuredRepo, ok := event.Object.(*unstructured.Unstructured)
-			if !ok {
-				log.Errorf("Could not cast to unstructured.Unstructured")
-			} else {
-				if event.Type == watch.Added {
-					go c.onAddOrModify(true, unstructuredRepo.Object)
-				} else if event.Type == watch.Modified {
-					go c.onAddOrModify(false, unstructuredRepo.Object)
-				} else {
-					go c.onDelete(unstructuredRepo.Object)
-				}
+		// the watch ended with an error, e.g. the resource version expired or the API server
+		// is not reachable, so the objects are listed again after a backoff
+		time.Sleep(backoff.Step())
+	}
+}
+
+func newWatcherBackoff() wait.Backoff {
+	return wait.Backoff{
+		Duration: time.Second,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    math.MaxInt32,
+		Cap:      time.Minute,
+	}
+}
+
+// resync replaces the contents of the cache with the listed objects: the values of the
+// objects that changed since they were last computed are computed again and the keys of
+// the objects that no longer exist, e.g. deleted while the plugin was not running, are
+// evicted from the store.
+func (c *ResourceWatcherCache) resync(unstructuredObjs []map[string]interface{}) {
+	log.Infof("+ResourceWatcherCache resync [%s]: [%d] objects", c.config.gvr, len(unstructuredObjs))
+
+	keys := make(map[string]bool)
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, maxWorkers)
+	for _, unstructuredObj := range unstructuredObjs {
+		key, err := c.redisKeyFor(unstructuredObj)
+		if err != nil {
+			log.Errorf("Failed to get redis key due to: %v", err)
+			continue
+		}
+		keys[*key] = true
+
+		resourceVersion, _, _ := unstructured.NestedString(unstructuredObj, "metadata", "resourceVersion")
+		c.syncMutex.RLock()
+		upToDate := resourceVersion != "" && c.resourceVersions[*key] == resourceVersion
+		c.syncMutex.RUnlock()
+		if upToDate {
+			continue
+		}
+
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(unstructuredObj map[string]interface{}) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			c.syncObject(true, unstructuredObj)
+		}(unstructuredObj)
+	}
+	wg.Wait()
+
+	storedKeys, err := c.store.keys(context.Background(), c.config.gvr.Resource+":")
+	if err != nil {
+		log.Errorf("Failed to get the keys of the cache due to: %v", err)
+	}
+	for _, key := range storedKeys {
+		if !keys[key] {
+			log.Infof("evicting orphaned key [%s] from cache", key)
+			if err = c.store.del(context.Background(), key); err != nil {
+				log.Errorf("Failed to delete value for object [%s] from cache due to: %v", key, err)
 			}
-		default:
-			// TODO (gfichtenholt) handle other kinds of events?
-			log.Errorf("got unexpected event: %v", event)
 		}
 	}
+
+	c.syncMutex.Lock()
+	defer c.syncMutex.Unlock()
+	for key := range c.resourceVersions {
+		if !keys[key] {
+			delete(c.resourceVersions, key)
+		}
+	}
+	c.lastSyncTime = time.Now()
+	c.watching = true
+}
+
+func (c *ResourceWatcherCache) setWatching(watching bool) {
+	c.syncMutex.Lock()
+	defer c.syncMutex.Unlock()
+	c.watching = watching
+}
+
+// cacheStatus describes how up to date the cache is
+type cacheStatus struct {
+	synced       bool
+	stale        bool
+	lastSyncTime time.Time
+}
+
+// status returns the status of the cache. The cache is stale while the objects are not
+// watched or were not listed within two resync periods.
+func (c *ResourceWatcherCache) status() cacheStatus {
+	c.syncMutex.RLock()
+	defer c.syncMutex.RUnlock()
+	synced := !c.lastSyncTime.IsZero()
+	return cacheStatus{
+		synced:       synced,
+		stale:        !synced || !c.watching || time.Since(c.lastSyncTime) > 2*c.config.resyncPeriod,
+		lastSyncTime: c.lastSyncTime,
+	}
+}
+
+// reflectorStore receives the objects listed and watched by the reflector. Unlike the
+// stores of informers, it does not keep the objects: it only updates the cache. The events
+// are processed one at a time on the goroutine of the reflector, in the order they are
+// watched, so that an event is never processed concurrently with an older event for the
+// same object, nor with a resync evicting the keys of deleted objects.
+type reflectorStore struct {
+	cache *ResourceWatcherCache
+}
+
+// Compile-time statement to ensure the reflector can use the store
+var _ k8scache.Store = (*reflectorStore)(nil)
+
+func (s *reflectorStore) Add(obj interface{}) error {
+	unstructuredObj, err := unstructuredObjectFor(obj)
+	if err != nil {
+		return err
+	}
+	s.cache.onAddOrModify(true, unstructuredObj)
+	return nil
+}
+
+func (s *reflectorStore) Update(obj interface{}) error {
+	unstructuredObj, err := unstructuredObjectFor(obj)
+	if err != nil {
+		return err
+	}
+	s.cache.onAddOrModify(false, unstructuredObj)
+	return nil
+}
+
+func (s *reflectorStore) Delete(obj interface{}) error {
+	unstructuredObj, err := unstructuredObjectFor(obj)
+	if err != nil {
+		return err
+	}
+	s.cache.onDelete(unstructuredObj)
+	return nil
+}
+
+func (s *reflectorStore) Replace(list []interface{}, resourceVersion string) error {
+	unstructuredObjs := make([]map[string]interface{}, 0, len(list))
+	for _, obj := range list {
+		unstructuredObj, err := unstructuredObjectFor(obj)
+		if err != nil {
+			return err
+		}
+		unstructuredObjs = append(unstructuredObjs, unstructuredObj)
+	}
+	s.cache.resync(unstructuredObjs)
+	return nil
+}
+
+func (s *reflectorStore) Resync() error {
+	return nil
+}
+
+func (s *reflectorStore) List() []interface{} {
+	return nil
+}
+
+func (s *reflectorStore) ListKeys() []string {
+	return nil
+}
+
+func (s *reflectorStore) Get(obj interface{}) (interface{}, bool, error) {
+	return nil, false, nil
+}
+
+func (s *reflectorStore) GetByKey(key string) (interface{}, bool, error) {
+	return nil, false, nil
+}
+
+func unstructuredObjectFor(obj interface{}) (map[string]interface{}, error) {
+	unstructuredObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "could not cast to unstructured.Unstructured: %v", obj)
+	}
+	return unstructuredObj.Object, nil
 }
 
 // this is effectively a cache PUT operation
@@ -211,7 +449,12 @@ func (c *ResourceWatcherCache) onAddOrModify(add bool, unstructuredObj map[strin
 			c.eventProcessingWaitGroup.Done()
 		}
 	}()
+	c.syncObject(add, unstructuredObj)
+}
 
+// syncObject computes the value of the object with the 'onAdd' or 'onModify' hook and sets
+// it in the store
+func (c *ResourceWatcherCache) syncObject(add bool, unstructuredObj map[string]interface{}) {
 	key, err := c.redisKeyFor(unstructuredObj)
 	if err != nil {
 		log.Errorf("Failed to get redis key due to: %v", err)
@@ -220,7 +463,9 @@ func (c *ResourceWatcherCache) onAddOrModify(add bool, unstructuredObj map[strin
 
 	// clear that key so cache doesn't contain any stale info for this object if not ready or
 	// indexing or marshalling fails for whatever reason
-	c.redisCli.Del(c.redisCli.Context(), *key)
+	if err = c.store.del(context.Background(), *key); err != nil {
+		log.Errorf("Failed to delete value for object [%s] from cache due to: %v", *key, err)
+	}
 
 	var funcName string
 	var value interface{}
@@ -243,8 +488,12 @@ func (c *ResourceWatcherCache) onAddOrModify(add bool, unstructuredObj map[strin
 	}
 
 	if setVal {
-		// Zero expiration means the key has no expiration time.
-		err = c.redisCli.Set(c.redisCli.Context(), *key, value, 0).Err()
+		bytes, ok := value.([]byte)
+		if !ok {
+			log.Errorf("Invokation of [%s] for object with key [%s] returned unexpected value: %v", funcName, *key, value)
+			return
+		}
+		err = c.store.set(context.Background(), *key, bytes, 0)
 		if err != nil {
 			log.Errorf("Failed to set value for object with key [%s] in cache due to: %v", *key, err)
 			return
@@ -252,6 +501,11 @@ func (c *ResourceWatcherCache) onAddOrModify(add bool, unstructuredObj map[strin
 			log.Infof("set value for object with key [%s] in cache", *key)
 		}
 	}
+
+	resourceVersion, _, _ := unstructured.NestedString(unstructuredObj, "metadata", "resourceVersion")
+	c.syncMutex.Lock()
+	defer c.syncMutex.Unlock()
+	c.resourceVersions[*key] = resourceVersion
 }
 
 // this is effectively a cache DEL operation
@@ -268,6 +522,10 @@ func (c *ResourceWatcherCache) onDelete(unstructuredObj map[string]interface{})
 		return
 	}
 
+	c.syncMutex.Lock()
+	delete(c.resourceVersions, *key)
+	c.syncMutex.Unlock()
+
 	delete, err := c.config.onDelete(*key, unstructuredObj)
 	if err != nil {
 		log.Errorf("Invokation of 'onDelete' for object %s\nfailed due to: %v", prettyPrintMap(unstructuredObj), err)
@@ -275,7 +533,7 @@ func (c *ResourceWatcherCache) onDelete(unstructuredObj map[string]interface{})
 	}
 
 	if delete {
-		err = c.redisCli.Del(c.redisCli.Context(), *key).Err()
+		err = c.store.del(context.Background(), *key)
 		if err != nil {
 			log.Errorf("Failed to delete value for object [%s] from cache due to: %v", *key, err)
 		}
@@ -284,20 +542,18 @@ func (c *ResourceWatcherCache) onDelete(unstructuredObj map[string]interface{})
 
 // this is effectively a cache GET operation
 func (c *ResourceWatcherCache) fetchForOne(key string) (interface{}, error) {
-	// read back from cache: should be what we previously wrote or Redis.Nil
+	// read back from cache: should be what we previously wrote or nothing
 	// TODO (gfichtenholt) See if there might be a cleaner way than to have onGet() take []byte as
 	// a 2nd argument. In theory, I would have liked to pass in an interface{}, just like onAdd/onModify.
 	// The limitation here is caused by the fact that redis go client does not offer a
-	// generic Get() method that would work with interface{}. Instead, all results are returned as
-	// strings which can be converted to desired types as needed, e.g.
-	// redisCli.Get(ctx, key).Bytes() first gets the string and then converts it to bytes.
-	bytes, err := c.redisCli.Get(c.redisCli.Context(), key).Bytes()
-	if err == redis.Nil {
-		// this is normal if the key does not exist
-		return nil, nil
-	} else if err != nil {
+	// generic Get() method that would work with interface{}, so the store keeps bytes.
+	bytes, found, err := c.store.get(context.Background(), key)
+	if err != nil {
 		log.Errorf("Failed to get value for key [%s] from cache due to: %v", key, err)
 		return nil, err
+	} else if !found {
+		// this is normal if the key does not exist
+		return nil, nil
 	}
 
 	val, err := c.config.onGet(key, bytes)
@@ -310,13 +566,40 @@ func (c *ResourceWatcherCache) fetchForOne(key string) (interface{}, error) {
 	return val, nil
 }
 
+// this is effectively a cache GET operation that, on a cache miss, e.g. after the value
+// was evicted from the store, computes the value of the object with the 'onAdd' hook and
+// stores it again
+func (c *ResourceWatcherCache) fetchOrLoadForOne(key string, unstructuredObj map[string]interface{}) (interface{}, error) {
+	val, err := c.fetchForOne(key)
+	if err != nil || val != nil {
+		return val, err
+	}
+
+	value, setVal, err := c.config.onAdd(key, unstructuredObj)
+	if err != nil {
+		return nil, err
+	} else if !setVal {
+		return nil, nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unexpected value computed for key [%s]: %v", key, value)
+	}
+	if err = c.store.set(context.Background(), key, bytes, 0); err != nil {
+		// the value is still returned, it will be computed again on the next miss
+		log.Errorf("Failed to set value for object with key [%s] in cache due to: %v", key, err)
+	}
+	return c.config.onGet(key, bytes)
+}
+
 const (
 	// max number of concurrent workers reading results for fetch() at the same time
 	maxWorkers = 10
 )
 
 type fetchValueJob struct {
-	key string
+	key    string
+	object map[string]interface{}
 }
 
 type fetchValueJobResult struct {
@@ -324,7 +607,7 @@ type fetchValueJobResult struct {
 	err    error
 }
 
-// each object is read from redis in a separate go routine (lightweight thread of execution)
+// each object is read from the store in a separate go routine (lightweight thread of execution)
 // listItems is a list of unstructured objects.
 // TODO 1 (gfichtenholt) all we really need is the list of keys, so we should have a flavor of this func
 // that accepts that
@@ -343,7 +626,7 @@ func (c *ResourceWatcherCache) fetchCachedObjects(requestItems []unstructured.Un
 		go func() {
 			for job := range requestChan {
 				// The following loop will only terminate when the request channel is closed (and there are no more items)
-				result, err := c.fetchForOne(job.key)
+				result, err := c.fetchOrLoadForOne(job.key, job.object)
 				responseChan <- fetchValueJobResult{result, err}
 			}
 			wg.Done()
@@ -360,7 +643,7 @@ func (c *ResourceWatcherCache) fetchCachedObjects(requestItems []unstructured.Un
 			if err != nil {
 				log.Errorf("Failed to get redis key due to: %v", err)
 			} else {
-				requestChan <- fetchValueJob{*key}
+				requestChan <- fetchValueJob{*key, item.Object}
 			}
 		}
 		close(requestChan)
@@ -0,0 +1,230 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	chart "github.com/kubeapps/kubeapps/pkg/chart/models"
+	httpclient "github.com/kubeapps/kubeapps/pkg/http-client"
+	tar "github.com/kubeapps/kubeapps/pkg/tarutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	h3chart "helm.sh/helm/v3/pkg/chart"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+var helmChartsGvr = schema.GroupVersionResource{
+	Group:    fluxGroup,
+	Version:  fluxVersion,
+	Resource: fluxHelmCharts,
+}
+
+// chartDetailCacheExpiration is the time after which the chart details expire
+// from the cache, if they have not been evicted before. The details of a
+// digest never change, so this only bounds the size of a redis cache.
+const chartDetailCacheExpiration = 24 * time.Hour
+
+const (
+	// helmChartManagedByLabel and helmChartManagedByValue label the HelmCharts created
+	// by the plugin, so that they can be told apart from those created by users
+	helmChartManagedByLabel = "app.kubernetes.io/managed-by"
+	helmChartManagedByValue = "kubeapps-apis-fluxv2"
+	// helmChartExpiration is the age after which the HelmCharts created by the plugin are
+	// deleted. The details of their charts are cached, so a version is only pulled again
+	// once these expire as well.
+	helmChartExpiration = time.Hour
+)
+
+// helmChartMatches returns whether the HelmChart pulls the version of the
+// chart from the repository. An empty version is the latest one, which is
+// pulled by the HelmCharts without a version or with the "*" version.
+func helmChartMatches(unstructuredChart map[string]interface{}, repoName, chartName, version string) bool {
+	thisChartName, found, err := unstructured.NestedString(unstructuredChart, "spec", "chart")
+	if err != nil || !found || thisChartName != chartName {
+		return false
+	}
+	thisRepoName, found, err := unstructured.NestedString(unstructuredChart, "spec", "sourceRef", "name")
+	if err != nil || !found || thisRepoName != repoName {
+		return false
+	}
+	thisVersion, _, err := unstructured.NestedString(unstructuredChart, "spec", "version")
+	if err != nil {
+		return false
+	}
+	if version == "" {
+		return thisVersion == "" || thisVersion == "*"
+	}
+	return thisVersion == version
+}
+
+// helmChartArtifact returns the url from which the chart .tgz of a pulled
+// HelmChart can be downloaded and its digest, which may be empty.
+func helmChartArtifact(unstructuredChart *unstructured.Unstructured) (string, string, error) {
+	url, found, err := unstructured.NestedString(unstructuredChart.Object, "status", "url")
+	if err != nil || !found {
+		return "", "", status.Errorf(codes.Internal, "expected field status.url not found on HelmChart: %v:\n%v", err, unstructuredChart)
+	}
+	digest, _, err := unstructured.NestedString(unstructuredChart.Object, "status", "artifact", "checksum")
+	if err != nil {
+		return "", "", status.Errorf(codes.Internal, "unexpected field status.artifact.checksum on HelmChart: %v:\n%v", err, unstructuredChart)
+	}
+	return url, digest, nil
+}
+
+// isPluginHelmChart returns whether the HelmChart was created by the plugin
+func isPluginHelmChart(unstructuredChart *unstructured.Unstructured) bool {
+	return unstructuredChart.GetLabels()[helmChartManagedByLabel] == helmChartManagedByValue
+}
+
+// isExpiredHelmChart returns whether the HelmChart was created by the plugin longer than
+// helmChartExpiration ago
+func isExpiredHelmChart(unstructuredChart *unstructured.Unstructured) bool {
+	created := unstructuredChart.GetCreationTimestamp()
+	return isPluginHelmChart(unstructuredChart) && !created.IsZero() && time.Since(created.Time) > helmChartExpiration
+}
+
+// deleteHelmChart deletes a HelmChart created by the plugin. A failure is only logged,
+// since the HelmChart is deleted again by a later request.
+func deleteHelmChart(ctx context.Context, resourceIfc dynamic.ResourceInterface, unstructuredChart *unstructured.Unstructured) {
+	err := resourceIfc.Delete(ctx, unstructuredChart.GetName(), metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		log.Errorf("Failed to delete HelmChart [%s] due to: %v", unstructuredChart.GetName(), err)
+		return
+	}
+	log.Infof("deleted HelmChart [%s]", unstructuredChart.GetName())
+}
+
+func chartDetailCacheKey(digest string) string {
+	// "helmcharts:digest", following the key format of the cache
+	return fmt.Sprintf("%s:%s", fluxHelmCharts, digest)
+}
+
+func chartDigestCacheKey(namespace, repoName, chartName, version string) string {
+	// "helmcharts:namespace:repo/chart:version", following the key format of the cache
+	return fmt.Sprintf("%s:%s:%s/%s:%s", fluxHelmCharts, namespace, repoName, chartName, version)
+}
+
+// cachedChartDigest returns the digest of the chart .tgz of the version, cached when the
+// version was last pulled, or "" if not cached. The latest version is never cached, since
+// it changes with the repository.
+func (s *Server) cachedChartDigest(ctx context.Context, namespace, repoName, chartName, version string) string {
+	if s.cache == nil || version == "" {
+		return ""
+	}
+	key := chartDigestCacheKey(namespace, repoName, chartName, version)
+	bytes, found, err := s.cache.store.get(ctx, key)
+	if err != nil {
+		log.Errorf("Failed to get chart digest [%s] from cache due to: %v", key, err)
+		return ""
+	} else if !found {
+		return ""
+	}
+	return string(bytes)
+}
+
+// cacheChartDigest caches the digest of the chart .tgz pulled for the version, so that
+// its details can be found in the cache without a HelmChart.
+func (s *Server) cacheChartDigest(ctx context.Context, namespace, repoName, chartName, version, digest string) {
+	if s.cache == nil || version == "" || digest == "" {
+		return
+	}
+	key := chartDigestCacheKey(namespace, repoName, chartName, version)
+	if err := s.cache.store.set(ctx, key, []byte(digest), chartDetailCacheExpiration); err != nil {
+		log.Errorf("Failed to set chart digest [%s] in cache due to: %v", key, err)
+	}
+}
+
+// cachedChartDetail returns the files of the chart .tgz of the digest, if cached.
+func (s *Server) cachedChartDetail(ctx context.Context, digest string) (map[string]string, bool) {
+	if s.cache == nil || digest == "" {
+		return nil, false
+	}
+	bytes, found, err := s.cache.store.get(ctx, chartDetailCacheKey(digest))
+	if err != nil {
+		log.Errorf("Failed to get chart detail for digest [%s] from cache due to: %v", digest, err)
+		return nil, false
+	} else if !found {
+		return nil, false
+	}
+	detail := map[string]string{}
+	if err = json.Unmarshal(bytes, &detail); err != nil {
+		log.Errorf("Failed to unmarshal chart detail for digest [%s] due to: %v", digest, err)
+		return nil, false
+	}
+	return detail, true
+}
+
+// fetchChartDetail returns the files of the chart .tgz extracted by
+// tar.FetchChartDetailFromTarball. They are cached by digest, since the
+// same chart .tgz may be pulled by several HelmCharts.
+func (s *Server) fetchChartDetail(ctx context.Context, identifier, url, digest string) (map[string]string, error) {
+	if detail, found := s.cachedChartDetail(ctx, digest); found {
+		return detail, nil
+	}
+	cacheable := s.cache != nil && digest != ""
+
+	// no need to provide authz, userAgent or any of the TLS details, as we are pulling .tgz file from
+	// local cluster, not remote repo.
+	// E.g. http://source-controller.flux-system.svc.cluster.local./helmchart/default/redis-j6wtx/redis-latest.tgz
+	// Flux does the hard work of pulling the bits from remote repo
+	// based on secretRef associated with HelmRepository, if applicable
+	detail, err := tar.FetchChartDetailFromTarball(identifier, url, "", "", httpclient.New())
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheable {
+		bytes, err := json.Marshal(detail)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to marshal chart detail: %v", err)
+		}
+		if err = s.cache.store.set(ctx, chartDetailCacheKey(digest), bytes, chartDetailCacheExpiration); err != nil {
+			// the detail is still returned, it will be fetched again on the next request
+			log.Errorf("Failed to set chart detail for digest [%s] in cache due to: %v", digest, err)
+		}
+	}
+	return detail, nil
+}
+
+// availablePackageDetailFromChartDetail returns the package detail of the
+// files of the chart .tgz.
+func availablePackageDetailFromChartDetail(packageRef *corev1.AvailablePackageReference, name string, detail map[string]string) (*corev1.AvailablePackageDetail, error) {
+	var metadata h3chart.Metadata
+	if err := yaml.Unmarshal([]byte(detail[chart.ChartYAMLKey]), &metadata); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to parse Chart.yaml of chart [%s]: %v", packageRef.GetIdentifier(), err)
+	}
+	return &corev1.AvailablePackageDetail{
+		AvailablePackageRef: packageRef,
+		Name:                name,
+		PkgVersion:          metadata.Version,
+		AppVersion:          metadata.AppVersion,
+		IconUrl:             metadata.Icon,
+		DisplayName:         name,
+		ShortDescription:    metadata.Description,
+		LongDescription:     detail[chart.ReadmeKey],
+		Readme:              detail[chart.ReadmeKey],
+		DefaultValues:       detail[chart.ValuesKey],
+		ValuesSchema:        detail[chart.SchemaKey],
+	}, nil
+}
@@ -14,9 +14,13 @@ package main
 
 import (
 	"fmt"
+	"time"
 
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/watch"
 	log "k8s.io/klog/v2"
@@ -75,6 +79,111 @@ func isRepoReady(obj map[string]interface{}) (bool, error) {
 	return false, nil
 }
 
+// packageRepositoryStatus returns the status of the package repository for
+// the Ready condition of a HelmRepository.
+func packageRepositoryStatus(obj map[string]interface{}) *v1alpha1.PackageRepositoryStatus {
+	// see docs at https://fluxcd.io/docs/components/source/helmrepositories/
+	repoStatus := &v1alpha1.PackageRepositoryStatus{Reason: "Reconciling"}
+	if lastUpdateTime, found, err := unstructured.NestedString(obj, "status", "artifact", "lastUpdateTime"); err == nil && found {
+		repoStatus.LastReconciledTime = timestampFromString(lastUpdateTime)
+	}
+
+	observedGeneration, found, err := unstructured.NestedInt64(obj, "status", "observedGeneration")
+	if err != nil || !found {
+		return repoStatus
+	}
+	generation, found, err := unstructured.NestedInt64(obj, "metadata", "generation")
+	if err != nil || !found || generation != observedGeneration {
+		return repoStatus
+	}
+
+	conditions, found, err := unstructured.NestedSlice(obj, "status", "conditions")
+	if err != nil || !found {
+		return repoStatus
+	}
+	for _, conditionUnstructured := range conditions {
+		if conditionAsMap, ok := conditionUnstructured.(map[string]interface{}); ok {
+			if typeString, ok := conditionAsMap["type"]; ok && typeString == "Ready" {
+				repoStatus.Ready = conditionAsMap["status"] == "True"
+				if reason, ok := conditionAsMap["reason"].(string); ok {
+					repoStatus.Reason = reason
+				}
+				if !repoStatus.Ready {
+					repoStatus.Message, _ = conditionAsMap["message"].(string)
+				}
+				if repoStatus.LastReconciledTime == nil {
+					if lastTransitionTime, ok := conditionAsMap["lastTransitionTime"].(string); ok {
+						repoStatus.LastReconciledTime = timestampFromString(lastTransitionTime)
+					}
+				}
+			}
+		}
+	}
+	return repoStatus
+}
+
+// timestampFromString returns the timestamp of an RFC 3339 time, or nil if it
+// cannot be parsed.
+func timestampFromString(value string) *timestamppb.Timestamp {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// installedPackageStatusFromRelease returns the status of the installed package
+// for the Ready condition of a HelmRelease.
+func installedPackageStatusFromRelease(obj map[string]interface{}) *corev1.InstalledPackageStatus {
+	// see docs at https://fluxcd.io/docs/components/helm/helmreleases/
+	pending := &corev1.InstalledPackageStatus{
+		Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+		UserReason: "Reconciling",
+	}
+	observedGeneration, found, err := unstructured.NestedInt64(obj, "status", "observedGeneration")
+	if err != nil || !found {
+		return pending
+	}
+	generation, found, err := unstructured.NestedInt64(obj, "metadata", "generation")
+	if err != nil || !found || generation != observedGeneration {
+		return pending
+	}
+
+	conditions, found, err := unstructured.NestedSlice(obj, "status", "conditions")
+	if err != nil || !found {
+		return pending
+	}
+
+	for _, conditionUnstructured := range conditions {
+		if conditionAsMap, ok := conditionUnstructured.(map[string]interface{}); ok {
+			if typeString, ok := conditionAsMap["type"]; ok && typeString == "Ready" {
+				reason, _ := conditionAsMap["reason"].(string)
+				switch conditionAsMap["status"] {
+				case "True":
+					return &corev1.InstalledPackageStatus{
+						Ready:      true,
+						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+						UserReason: reason,
+					}
+				case "False":
+					msg, ok := conditionAsMap["message"].(string)
+					if !ok {
+						msg = reason
+					}
+					return &corev1.InstalledPackageStatus{
+						Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+						UserReason: msg,
+					}
+				}
+				if reason != "" {
+					pending.UserReason = reason
+				}
+			}
+		}
+	}
+	return pending
+}
+
 // the goal of this fn is to answer whether or not to stop waiting for chart reconciliation
 // which is different from answering whether the chart was pulled successfully
 // TODO (gfichtenholt): As above, hopefully this fn isn't required if we can only list charts that we know are ready.
@@ -137,7 +246,7 @@ func isChartPullComplete(unstructuredChart *unstructured.Unstructured) (bool, er
 // immediately and you wait on the results channel at the call-site, which would mean
 // you could call it for 20 different charts and just wait for the results to come in
 //  whatever order they happen to take, rather than serially.
-func waitUntilChartPullComplete(watcher watch.Interface) (*string, error) {
+func waitUntilChartPullComplete(watcher watch.Interface) (*unstructured.Unstructured, error) {
 	ch := watcher.ResultChan()
 	// LISTEN TO CHANNEL
 	for {
@@ -152,11 +261,7 @@ func waitUntilChartPullComplete(watcher watch.Interface) (*string, error) {
 			if err != nil {
 				return nil, err
 			} else if done {
-				url, found, err := unstructured.NestedString(unstructuredChart.Object, "status", "url")
-				if err != nil || !found {
-					return nil, status.Errorf(codes.Internal, "expected field status.url not found on HelmChart: %v:\n%v", err, unstructuredChart)
-				}
-				return &url, nil
+				return unstructuredChart, nil
 			}
 		} else {
 			// TODO handle other kinds of events
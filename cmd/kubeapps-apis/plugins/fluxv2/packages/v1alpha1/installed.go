@@ -0,0 +1,451 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+var helmReleasesGvr = schema.GroupVersionResource{
+	Group:    fluxHelmReleaseGroup,
+	Version:  fluxHelmReleaseVersion,
+	Resource: fluxHelmReleases,
+}
+
+// defaultReleaseInterval is the reconciliation interval of the HelmReleases
+// created without one.
+const defaultReleaseInterval = "1m"
+
+// GetInstalledPackageSummaries returns the summaries of the flux HelmReleases
+// in the requested namespace, or in every namespace if none.
+func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *corev1.GetInstalledPackageSummariesRequest) (*corev1.GetInstalledPackageSummariesResponse, error) {
+	log.Infof("+fluxv2 GetInstalledPackageSummaries(request: [%v])", request)
+
+	resourceIfc, err := s.helmReleasesResource(ctx, request.GetContext().GetCluster(), request.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	releases, err := resourceIfc.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "list", "HelmReleases")
+	}
+
+	summaries := make([]*corev1.InstalledPackageSummary, 0, len(releases.Items))
+	for i := range releases.Items {
+		summaries = append(summaries, installedPackageSummaryFromRelease(&releases.Items[i], request.GetContext()))
+	}
+	return &corev1.GetInstalledPackageSummariesResponse{
+		InstalledPackageSummaries: summaries,
+	}, nil
+}
+
+// GetInstalledPackageDetail returns the detail of a flux HelmRelease, with its
+// reconciliation status.
+func (s *Server) GetInstalledPackageDetail(ctx context.Context, request *corev1.GetInstalledPackageDetailRequest) (*corev1.GetInstalledPackageDetailResponse, error) {
+	log.Infof("+fluxv2 GetInstalledPackageDetail(request: [%v])", request)
+
+	ref := request.GetInstalledPackageRef()
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+	resourceIfc, err := s.helmReleasesResource(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	unstructuredRelease, err := resourceIfc.Get(ctx, ref.GetIdentifier(), metav1.GetOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "get", fmt.Sprintf("HelmRelease [%s]", ref.GetIdentifier()))
+	}
+	detail, err := installedPackageDetailFromRelease(unstructuredRelease, ref.GetContext().GetCluster())
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.GetInstalledPackageDetailResponse{
+		InstalledPackageDetail: detail,
+	}, nil
+}
+
+// CreateInstalledPackage creates a flux HelmRelease installing the chart of
+// a HelmRepository in the target namespace. Flux then reconciles it.
+func (s *Server) CreateInstalledPackage(ctx context.Context, request *corev1.CreateInstalledPackageRequest) (*corev1.CreateInstalledPackageResponse, error) {
+	log.Infof("+fluxv2 CreateInstalledPackage(request: [%v])", request)
+
+	packageRef := request.GetAvailablePackageRef()
+	if packageRef.GetContext().GetNamespace() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "AvailablePackageReference is missing required 'namespace' field")
+	}
+	packageIdParts := strings.Split(packageRef.GetIdentifier(), "/")
+	if len(packageIdParts) != 2 || packageIdParts[0] == "" || packageIdParts[1] == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid package ref identifier: [%s]", packageRef.GetIdentifier())
+	}
+	targetContext := request.GetTargetContext()
+	if targetContext.GetNamespace() == "" || request.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Required target namespace or name not provided")
+	}
+
+	unstructuredRelease := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": fmt.Sprintf("%s/%s", fluxHelmReleaseGroup, fluxHelmReleaseVersion),
+			"kind":       fluxHelmRelease,
+			"metadata": map[string]interface{}{
+				"name":      request.GetName(),
+				"namespace": targetContext.GetNamespace(),
+			},
+			"spec": map[string]interface{}{
+				"chart": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"chart": packageIdParts[1],
+						"sourceRef": map[string]interface{}{
+							"kind":      fluxHelmRepository,
+							"name":      packageIdParts[0],
+							"namespace": packageRef.GetContext().GetNamespace(),
+						},
+					},
+				},
+				"interval": defaultReleaseInterval,
+				// Flux prefixes the name of the helm release with the target
+				// namespace unless it is set.
+				"releaseName":     request.GetName(),
+				"targetNamespace": targetContext.GetNamespace(),
+			},
+		},
+	}
+	if err := setReleaseSpec(unstructuredRelease, request.GetPkgVersionReference().GetVersion(), request.GetValues(), request.GetReconciliationOptions()); err != nil {
+		return nil, err
+	}
+
+	resourceIfc, err := s.helmReleasesResource(ctx, targetContext.GetCluster(), targetContext.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	newRelease, err := resourceIfc.Create(ctx, unstructuredRelease, metav1.CreateOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "create", fmt.Sprintf("HelmRelease [%s]", request.GetName()))
+	}
+
+	return &corev1.CreateInstalledPackageResponse{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context: &corev1.Context{
+				Cluster:   targetContext.GetCluster(),
+				Namespace: newRelease.GetNamespace(),
+			},
+			Identifier: newRelease.GetName(),
+		},
+	}, nil
+}
+
+// UpdateInstalledPackage updates the version constraint, values and
+// reconciliation options of a flux HelmRelease. Flux then upgrades the helm
+// release. Requested reconciliation options replace the suspension and the
+// service account of the HelmRelease, as a whole, so that an update can
+// resume the reconciliation or drop the service account.
+func (s *Server) UpdateInstalledPackage(ctx context.Context, request *corev1.UpdateInstalledPackageRequest) (*corev1.UpdateInstalledPackageResponse, error) {
+	log.Infof("+fluxv2 UpdateInstalledPackage(request: [%v])", request)
+
+	ref := request.GetInstalledPackageRef()
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+	resourceIfc, err := s.helmReleasesResource(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	unstructuredRelease, err := resourceIfc.Get(ctx, ref.GetIdentifier(), metav1.GetOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "get", fmt.Sprintf("HelmRelease [%s]", ref.GetIdentifier()))
+	}
+
+	// The values are replaced, as for helm upgrades, while the version and
+	// reconciliation options are kept unless requested. The interval, which
+	// flux requires, is kept when the requested options leave it unset.
+	unstructured.RemoveNestedField(unstructuredRelease.Object, "spec", "values")
+	if err = setReleaseSpec(unstructuredRelease, request.GetPkgVersionReference().GetVersion(), request.GetValues(), request.GetReconciliationOptions()); err != nil {
+		return nil, err
+	}
+	if _, err = resourceIfc.Update(ctx, unstructuredRelease, metav1.UpdateOptions{}); err != nil {
+		return nil, statusErrorFromK8sError(err, "update", fmt.Sprintf("HelmRelease [%s]", ref.GetIdentifier()))
+	}
+
+	return &corev1.UpdateInstalledPackageResponse{
+		InstalledPackageRef: ref,
+	}, nil
+}
+
+// DeleteInstalledPackage deletes a flux HelmRelease, which flux uninstalls.
+func (s *Server) DeleteInstalledPackage(ctx context.Context, request *corev1.DeleteInstalledPackageRequest) (*corev1.DeleteInstalledPackageResponse, error) {
+	log.Infof("+fluxv2 DeleteInstalledPackage(request: [%v])", request)
+
+	ref := request.GetInstalledPackageRef()
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+	resourceIfc, err := s.helmReleasesResource(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	if err = resourceIfc.Delete(ctx, ref.GetIdentifier(), metav1.DeleteOptions{}); err != nil {
+		return nil, statusErrorFromK8sError(err, "delete", fmt.Sprintf("HelmRelease [%s]", ref.GetIdentifier()))
+	}
+	return &corev1.DeleteInstalledPackageResponse{}, nil
+}
+
+// helmReleasesResource returns the dynamic client of the HelmReleases in the
+// namespace of the cluster.
+func (s *Server) helmReleasesResource(ctx context.Context, cluster, namespace string) (dynamic.ResourceInterface, error) {
+	_, client, err := s.GetClients(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(helmReleasesGvr).Namespace(namespace), nil
+}
+
+// validateInstalledPackageRef returns an error if the reference lacks the
+// namespace or the name of the HelmRelease.
+func validateInstalledPackageRef(ref *corev1.InstalledPackageReference) error {
+	if ref.GetContext().GetNamespace() == "" || ref.GetIdentifier() == "" {
+		return status.Errorf(codes.InvalidArgument, "InstalledPackageReference is missing required 'namespace' or 'identifier' field")
+	}
+	return nil
+}
+
+// setReleaseSpec sets the version constraint, the values and the
+// reconciliation options of the HelmRelease spec, when requested. The
+// options set the suspension and the service account even when unset in
+// them, resuming the reconciliation and removing the service account.
+func setReleaseSpec(unstructuredRelease *unstructured.Unstructured, version, values string, options *corev1.ReconciliationOptions) error {
+	obj := unstructuredRelease.Object
+	if version != "" {
+		if err := unstructured.SetNestedField(obj, version, "spec", "chart", "spec", "version"); err != nil {
+			return status.Errorf(codes.Internal, "unable to set the version of the HelmRelease: %v", err)
+		}
+	}
+	if values != "" {
+		var valuesMap map[string]interface{}
+		if err := yaml.Unmarshal([]byte(values), &valuesMap); err != nil {
+			return status.Errorf(codes.InvalidArgument, "Unable to parse the values: %v", err)
+		}
+		if len(valuesMap) > 0 {
+			if err := unstructured.SetNestedMap(obj, valuesMap, "spec", "values"); err != nil {
+				return status.Errorf(codes.Internal, "unable to set the values of the HelmRelease: %v", err)
+			}
+		}
+	}
+	if options == nil {
+		return nil
+	}
+	if options.GetInterval() < 0 {
+		return status.Errorf(codes.InvalidArgument, "Invalid reconciliation interval: [%d]", options.GetInterval())
+	}
+	if options.GetInterval() > 0 {
+		interval := (time.Duration(options.GetInterval()) * time.Second).String()
+		if err := unstructured.SetNestedField(obj, interval, "spec", "interval"); err != nil {
+			return status.Errorf(codes.Internal, "unable to set the interval of the HelmRelease: %v", err)
+		}
+	}
+	if err := unstructured.SetNestedField(obj, options.GetSuspend(), "spec", "suspend"); err != nil {
+		return status.Errorf(codes.Internal, "unable to set the suspension of the HelmRelease: %v", err)
+	}
+	if options.GetServiceAccountName() == "" {
+		unstructured.RemoveNestedField(obj, "spec", "serviceAccountName")
+	} else if err := unstructured.SetNestedField(obj, options.GetServiceAccountName(), "spec", "serviceAccountName"); err != nil {
+		return status.Errorf(codes.Internal, "unable to set the service account of the HelmRelease: %v", err)
+	}
+	return nil
+}
+
+// statusErrorFromK8sError returns a gRPC status error for the error of a
+// request on the k8s resource.
+func statusErrorFromK8sError(err error, verb, resource string) error {
+	code := codes.Internal
+	switch {
+	case errors.IsNotFound(err):
+		code = codes.NotFound
+	case errors.IsAlreadyExists(err):
+		code = codes.AlreadyExists
+	case errors.IsConflict(err):
+		code = codes.Aborted
+	case errors.IsForbidden(err):
+		code = codes.PermissionDenied
+	case errors.IsUnauthorized(err):
+		code = codes.Unauthenticated
+	case errors.IsInvalid(err):
+		code = codes.InvalidArgument
+	}
+	return status.Errorf(code, "unable to %s %s due to: %v", verb, resource, err)
+}
+
+// WatchInstalledPackages streams the changes of the flux HelmReleases in the
+// requested namespace.
+func (s *Server) WatchInstalledPackages(request *corev1.WatchInstalledPackagesRequest, stream corev1.PackagesService_WatchInstalledPackagesServer) error {
+	log.Infof("+fluxv2 WatchInstalledPackages(request: [%v])", request)
+
+	ctx := stream.Context()
+	_, dynamicClient, err := s.GetClients(ctx, request.GetContext().GetCluster())
+	if err != nil {
+		return err
+	}
+
+	namespace := request.GetContext().GetNamespace()
+	// The watch is started again from the last seen resource version
+	// whenever the api server closes it.
+	resourceVersion := ""
+	for {
+		watcher, err := dynamicClient.Resource(helmReleasesGvr).Namespace(namespace).Watch(ctx, metav1.ListOptions{
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "unable to watch HelmReleases due to: %v", err)
+		}
+		resourceVersion, err = sendHelmReleaseEvents(ctx, watcher.ResultChan(), stream, request.GetContext(), resourceVersion)
+		watcher.Stop()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// sendHelmReleaseEvents sends the event of each HelmRelease change until the
+// channel is closed, returning the resource version to continue watching from.
+func sendHelmReleaseEvents(ctx context.Context, ch <-chan watch.Event, stream corev1.PackagesService_WatchInstalledPackagesServer, pkgContext *corev1.Context, resourceVersion string) (string, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return "", status.FromContextError(ctx.Err()).Err()
+		case event, ok := <-ch:
+			if !ok {
+				return resourceVersion, nil
+			}
+			var eventType corev1.WatchInstalledPackagesResponse_EventType
+			switch event.Type {
+			case watch.Added:
+				eventType = corev1.WatchInstalledPackagesResponse_EVENT_TYPE_ADDED
+			case watch.Modified:
+				eventType = corev1.WatchInstalledPackagesResponse_EVENT_TYPE_MODIFIED
+			case watch.Deleted:
+				eventType = corev1.WatchInstalledPackagesResponse_EVENT_TYPE_DELETED
+			case watch.Error:
+				// The resource version is too old or the watch otherwise
+				// failed, so the watch is started again without it.
+				log.Errorf("got error event: %v", event.Object)
+				return "", nil
+			default:
+				continue
+			}
+			unstructuredRelease, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				log.Errorf("Could not cast to unstructured.Unstructured")
+				continue
+			}
+			resourceVersion = unstructuredRelease.GetResourceVersion()
+			err := stream.Send(&corev1.WatchInstalledPackagesResponse{
+				Type:                    eventType,
+				InstalledPackageSummary: installedPackageSummaryFromRelease(unstructuredRelease, pkgContext),
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+}
+
+// installedPackageSummaryFromRelease returns the summary of the installed
+// package for a HelmRelease.
+func installedPackageSummaryFromRelease(unstructuredRelease *unstructured.Unstructured, pkgContext *corev1.Context) *corev1.InstalledPackageSummary {
+	obj := unstructuredRelease.Object
+	chartName, _, _ := unstructured.NestedString(obj, "spec", "chart", "spec", "chart")
+	chartVersion, _, _ := unstructured.NestedString(obj, "spec", "chart", "spec", "version")
+	lastAppliedRevision, _, _ := unstructured.NestedString(obj, "status", "lastAppliedRevision")
+
+	return &corev1.InstalledPackageSummary{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context: &corev1.Context{
+				Cluster:   pkgContext.GetCluster(),
+				Namespace: unstructuredRelease.GetNamespace(),
+			},
+			Identifier: unstructuredRelease.GetName(),
+		},
+		Name:                unstructuredRelease.GetName(),
+		PkgVersionReference: &corev1.VersionReference{Version: chartVersion},
+		CurrentPkgVersion:   lastAppliedRevision,
+		PkgDisplayName:      chartName,
+		Status:              installedPackageStatusFromRelease(obj),
+	}
+}
+
+// installedPackageDetailFromRelease returns the detail of the installed
+// package for a HelmRelease.
+func installedPackageDetailFromRelease(unstructuredRelease *unstructured.Unstructured, cluster string) (*corev1.InstalledPackageDetail, error) {
+	obj := unstructuredRelease.Object
+	chartName, _, _ := unstructured.NestedString(obj, "spec", "chart", "spec", "chart")
+	chartVersion, _, _ := unstructured.NestedString(obj, "spec", "chart", "spec", "version")
+	repoName, _, _ := unstructured.NestedString(obj, "spec", "chart", "spec", "sourceRef", "name")
+	repoNamespace, _, _ := unstructured.NestedString(obj, "spec", "chart", "spec", "sourceRef", "namespace")
+	if repoNamespace == "" {
+		repoNamespace = unstructuredRelease.GetNamespace()
+	}
+	lastAppliedRevision, _, _ := unstructured.NestedString(obj, "status", "lastAppliedRevision")
+
+	valuesApplied := ""
+	if values, found, _ := unstructured.NestedMap(obj, "spec", "values"); found && len(values) > 0 {
+		valuesBytes, err := yaml.Marshal(values)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to serialize the values of HelmRelease [%s]: %v", unstructuredRelease.GetName(), err)
+		}
+		valuesApplied = string(valuesBytes)
+	}
+
+	options := &corev1.ReconciliationOptions{}
+	if interval, found, _ := unstructured.NestedString(obj, "spec", "interval"); found {
+		if duration, err := time.ParseDuration(interval); err == nil {
+			options.Interval = int32(duration.Seconds())
+		}
+	}
+	options.Suspend, _, _ = unstructured.NestedBool(obj, "spec", "suspend")
+	options.ServiceAccountName, _, _ = unstructured.NestedString(obj, "spec", "serviceAccountName")
+
+	return &corev1.InstalledPackageDetail{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context: &corev1.Context{
+				Cluster:   cluster,
+				Namespace: unstructuredRelease.GetNamespace(),
+			},
+			Identifier: unstructuredRelease.GetName(),
+		},
+		PkgVersionReference:   &corev1.VersionReference{Version: chartVersion},
+		Name:                  unstructuredRelease.GetName(),
+		CurrentPkgVersion:     lastAppliedRevision,
+		ValuesApplied:         valuesApplied,
+		ReconciliationOptions: options,
+		Status:                installedPackageStatusFromRelease(obj),
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Namespace: repoNamespace},
+			Identifier: fmt.Sprintf("%s/%s", repoName, chartName),
+			Plugin:     GetPluginDetail(),
+		},
+	}, nil
+}
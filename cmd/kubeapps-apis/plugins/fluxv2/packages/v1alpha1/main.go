@@ -24,11 +24,21 @@ import (
 	log "k8s.io/klog/v2"
 )
 
+// pluginSettings are the settings of the plugin in the plugin config.
+type pluginSettings struct {
+	Cache cacheSettings `json:"cache"`
+	Redis redisSettings `json:"redis"`
+}
+
 // RegisterWithGRPCServer enables a plugin to register with a gRPC server
 // returning the server implementation.
-func RegisterWithGRPCServer(s grpc.ServiceRegistrar, clientGetter server.KubernetesClientGetter) (interface{}, error) {
+func RegisterWithGRPCServer(s grpc.ServiceRegistrar, clientGetter server.KubernetesClientGetter, configGetter server.KubernetesConfigGetter, pluginConfig *server.PluginConfig) (interface{}, error) {
 	log.Infof("+fluxv2 RegisterWithGRPCServer")
-	svr, err := NewServer(clientGetter)
+	var settings pluginSettings
+	if err := pluginConfig.DecodeSettings(&settings); err != nil {
+		return nil, err
+	}
+	svr, err := NewServer(clientGetter, settings)
 	if err != nil {
 		return nil, err
 	}
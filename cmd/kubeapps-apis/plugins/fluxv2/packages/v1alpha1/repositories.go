@@ -0,0 +1,179 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	log "k8s.io/klog/v2"
+)
+
+var helmRepositoriesGvr = schema.GroupVersionResource{
+	Group:    fluxGroup,
+	Version:  fluxVersion,
+	Resource: fluxHelmRepositories,
+}
+
+// defaultRepositoryInterval is the interval with which the index of the
+// HelmRepositories created without one is fetched.
+const defaultRepositoryInterval = "10m"
+
+// CreatePackageRepository creates a flux HelmRepository, whose index the
+// source-controller then fetches.
+func (s *Server) CreatePackageRepository(ctx context.Context, request *v1alpha1.CreatePackageRepositoryRequest) (*v1alpha1.CreatePackageRepositoryResponse, error) {
+	log.Infof("+fluxv2 CreatePackageRepository(request: [%v])", request)
+
+	if request.GetContext().GetNamespace() == "" || request.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Required context namespace or name not provided")
+	}
+	unstructuredRepo := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": fmt.Sprintf("%s/%s", fluxGroup, fluxVersion),
+			"kind":       fluxHelmRepository,
+			"metadata": map[string]interface{}{
+				"name":      request.GetName(),
+				"namespace": request.GetContext().GetNamespace(),
+			},
+		},
+	}
+	if err := setRepositorySpec(unstructuredRepo, request.GetSpec()); err != nil {
+		return nil, err
+	}
+
+	resourceIfc, err := s.helmRepositoriesResource(ctx, request.GetContext().GetCluster(), request.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	newRepo, err := resourceIfc.Create(ctx, unstructuredRepo, metav1.CreateOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "create", fmt.Sprintf("HelmRepository [%s]", request.GetName()))
+	}
+	repo, err := newPackageRepository(newRepo.Object)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.CreatePackageRepositoryResponse{
+		Repository: repo,
+	}, nil
+}
+
+// UpdatePackageRepository replaces the spec of a flux HelmRepository, which
+// suspends or resumes its reconciliation.
+func (s *Server) UpdatePackageRepository(ctx context.Context, request *v1alpha1.UpdatePackageRepositoryRequest) (*v1alpha1.UpdatePackageRepositoryResponse, error) {
+	log.Infof("+fluxv2 UpdatePackageRepository(request: [%v])", request)
+
+	if request.GetContext().GetNamespace() == "" || request.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Required context namespace or name not provided")
+	}
+	resourceIfc, err := s.helmRepositoriesResource(ctx, request.GetContext().GetCluster(), request.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	unstructuredRepo, err := resourceIfc.Get(ctx, request.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "get", fmt.Sprintf("HelmRepository [%s]", request.GetName()))
+	}
+
+	// Fields of the spec not managed by the plugin, such as the timeout, are
+	// kept.
+	for _, field := range []string{"interval", "secretRef", "suspend"} {
+		unstructured.RemoveNestedField(unstructuredRepo.Object, "spec", field)
+	}
+	if err = setRepositorySpec(unstructuredRepo, request.GetSpec()); err != nil {
+		return nil, err
+	}
+	updatedRepo, err := resourceIfc.Update(ctx, unstructuredRepo, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "update", fmt.Sprintf("HelmRepository [%s]", request.GetName()))
+	}
+	repo, err := newPackageRepository(updatedRepo.Object)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.UpdatePackageRepositoryResponse{
+		Repository: repo,
+	}, nil
+}
+
+// DeletePackageRepository deletes a flux HelmRepository. Its packages are
+// removed from the cache when the deletion is watched.
+func (s *Server) DeletePackageRepository(ctx context.Context, request *v1alpha1.DeletePackageRepositoryRequest) (*v1alpha1.DeletePackageRepositoryResponse, error) {
+	log.Infof("+fluxv2 DeletePackageRepository(request: [%v])", request)
+
+	if request.GetContext().GetNamespace() == "" || request.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Required context namespace or name not provided")
+	}
+	resourceIfc, err := s.helmRepositoriesResource(ctx, request.GetContext().GetCluster(), request.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	if err = resourceIfc.Delete(ctx, request.GetName(), metav1.DeleteOptions{}); err != nil {
+		return nil, statusErrorFromK8sError(err, "delete", fmt.Sprintf("HelmRepository [%s]", request.GetName()))
+	}
+	return &v1alpha1.DeletePackageRepositoryResponse{}, nil
+}
+
+// helmRepositoriesResource returns the dynamic client of the HelmRepositories
+// in the namespace of the cluster.
+func (s *Server) helmRepositoriesResource(ctx context.Context, cluster, namespace string) (dynamic.ResourceInterface, error) {
+	_, client, err := s.GetClients(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(helmRepositoriesGvr).Namespace(namespace), nil
+}
+
+// setRepositorySpec sets the url, interval, secret reference and suspension
+// of the HelmRepository spec.
+func setRepositorySpec(unstructuredRepo *unstructured.Unstructured, spec *v1alpha1.PackageRepositorySpec) error {
+	repoURL, err := url.ParseRequestURI(spec.GetUrl())
+	if err != nil || (repoURL.Scheme != "http" && repoURL.Scheme != "https") {
+		return status.Errorf(codes.InvalidArgument, "Invalid repository url: [%s]", spec.GetUrl())
+	}
+	if spec.GetInterval() < 0 {
+		return status.Errorf(codes.InvalidArgument, "Invalid repository interval: [%d]", spec.GetInterval())
+	}
+
+	obj := unstructuredRepo.Object
+	if err = unstructured.SetNestedField(obj, spec.GetUrl(), "spec", "url"); err != nil {
+		return status.Errorf(codes.Internal, "unable to set the url of the HelmRepository: %v", err)
+	}
+	interval := defaultRepositoryInterval
+	if spec.GetInterval() > 0 {
+		interval = (time.Duration(spec.GetInterval()) * time.Second).String()
+	}
+	if err = unstructured.SetNestedField(obj, interval, "spec", "interval"); err != nil {
+		return status.Errorf(codes.Internal, "unable to set the interval of the HelmRepository: %v", err)
+	}
+	if spec.GetSecretRef() != "" {
+		if err = unstructured.SetNestedField(obj, spec.GetSecretRef(), "spec", "secretRef", "name"); err != nil {
+			return status.Errorf(codes.Internal, "unable to set the secret of the HelmRepository: %v", err)
+		}
+	}
+	if spec.GetSuspend() {
+		if err = unstructured.SetNestedField(obj, true, "spec", "suspend"); err != nil {
+			return status.Errorf(codes.Internal, "unable to suspend the HelmRepository: %v", err)
+		}
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -26,10 +27,7 @@ import (
 	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
 	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/server"
-	chart "github.com/kubeapps/kubeapps/pkg/chart/models"
-	httpclient "github.com/kubeapps/kubeapps/pkg/http-client"
 
-	tar "github.com/kubeapps/kubeapps/pkg/tarutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	log "k8s.io/klog/v2"
@@ -45,6 +43,13 @@ const (
 	fluxHelmChart          = "HelmChart"
 	fluxHelmCharts         = "helmcharts"
 	fluxHelmChartList      = "HelmChartList"
+
+	// see docs at https://fluxcd.io/docs/components/helm/
+	fluxHelmReleaseGroup   = "helm.toolkit.fluxcd.io"
+	fluxHelmReleaseVersion = "v2beta1"
+	fluxHelmRelease        = "HelmRelease"
+	fluxHelmReleases       = "helmreleases"
+	fluxHelmReleaseList    = "HelmReleaseList"
 )
 
 // Compile-time statement to ensure this service implementation satisfies the core packaging API
@@ -64,21 +69,26 @@ type Server struct {
 
 // NewServer returns a Server automatically configured with a function to obtain
 // the k8s client config.
-func NewServer(clientGetter server.KubernetesClientGetter) (*Server, error) {
+func NewServer(clientGetter server.KubernetesClientGetter, settings pluginSettings) (*Server, error) {
 	repositoriesGvr := schema.GroupVersionResource{
 		Group:    fluxGroup,
 		Version:  fluxVersion,
 		Resource: fluxHelmRepositories,
 	}
+	resyncPeriod, err := settings.Cache.resyncPeriod()
+	if err != nil {
+		return nil, err
+	}
 	config := cacheConfig{
 		gvr:          repositoriesGvr,
 		clientGetter: clientGetter,
+		resyncPeriod: resyncPeriod,
 		onAdd:        onAddOrModifyRepo,
 		onModify:     onAddOrModifyRepo,
 		onGet:        onGetRepo,
 		onDelete:     onDeleteRepo,
 	}
-	cache, err := newCache(config)
+	cache, err := newCache(config, settings.Cache, settings.Redis)
 	if err != nil {
 		return nil, err
 	}
@@ -88,18 +98,33 @@ func NewServer(clientGetter server.KubernetesClientGetter) (*Server, error) {
 	}, nil
 }
 
-// getClients ensures a client getter is available and uses it to return both a typed and dynamic k8s client.
-func (s *Server) GetClients(ctx context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+// getClients ensures a client getter is available and uses it to return both a typed and dynamic k8s client
+// for the requested cluster, or the cluster on which Kubeapps is installed if empty.
+func (s *Server) GetClients(ctx context.Context, cluster string) (kubernetes.Interface, dynamic.Interface, error) {
 	if s.clientGetter == nil {
 		return nil, nil, status.Errorf(codes.Internal, "server not configured with configGetter")
 	}
-	typedClient, dynamicClient, err := s.clientGetter(ctx)
+	typedClient, dynamicClient, err := s.clientGetter(ctx, cluster)
 	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			// The client getter already returns a gRPC error for an invalid
+			// cluster or authorization.
+			return nil, nil, err
+		}
 		return nil, nil, status.Errorf(codes.FailedPrecondition, "unable to get client due to: %v", err)
 	}
 	return typedClient, dynamicClient, nil
 }
 
+// CheckReadiness reports the server as ready to serve requests while the
+// store backing its cache is reachable.
+func (s *Server) CheckReadiness(ctx context.Context) error {
+	if s.cache == nil {
+		return status.Errorf(codes.FailedPrecondition, "server cache has not been properly initialized")
+	}
+	return s.cache.ping(ctx)
+}
+
 // ===== general note on error handling ========
 // using fmt.Errorf vs status.Errorf in functions exposed as grpc:
 //
@@ -112,8 +137,8 @@ func (s *Server) GetClients(ctx context.Context) (kubernetes.Interface, dynamic.
 // function that returns an error, then your actual handler function handles
 // that error by returning a status.Errorf with the appropriate code
 
-// GetPackageRepositories returns the package repositories based on the request.
-// note that this func currently returns ALL repositories, not just those in 'ready' (reconciled) state
+// GetPackageRepositories returns the package repositories based on the request,
+// including those which are not in 'ready' (reconciled) state unless requested.
 func (s *Server) GetPackageRepositories(ctx context.Context, request *v1alpha1.GetPackageRepositoriesRequest) (*v1alpha1.GetPackageRepositoriesResponse, error) {
 	log.Infof("+fluxv2 GetPackageRepositories(request: [%v])", request)
 
@@ -121,29 +146,31 @@ func (s *Server) GetPackageRepositories(ctx context.Context, request *v1alpha1.G
 		return nil, status.Errorf(codes.InvalidArgument, "No context provided")
 	}
 
-	if request.Context.Cluster != "" {
-		return nil, status.Errorf(
-			codes.Unimplemented,
-			"Not supported yet: request.Context.Cluster: [%v]",
-			request.Context.Cluster)
-	}
-
-	repos, err := s.getHelmRepos(ctx, request.Context.Namespace)
+	repos, err := s.getHelmRepos(ctx, request.Context.Cluster, request.Context.Namespace)
 	if err != nil {
 		return nil, err
 	}
 
 	responseRepos := []*v1alpha1.PackageRepository{}
 	for _, repoUnstructured := range repos.Items {
+		if request.ReadyOnly {
+			if ready, err := isRepoReady(repoUnstructured.Object); err != nil || !ready {
+				continue
+			}
+		}
 		repo, err := newPackageRepository(repoUnstructured.Object)
 		if err != nil {
 			return nil, err
 		}
 		responseRepos = append(responseRepos, repo)
 	}
-	return &v1alpha1.GetPackageRepositoriesResponse{
+	response := &v1alpha1.GetPackageRepositoriesResponse{
 		Repositories: responseRepos,
-	}, nil
+	}
+	if s.cache != nil {
+		response.CacheStatus = packageCacheStatus(s.cache.status())
+	}
+	return response, nil
 }
 
 // GetAvailablePackageSummaries returns the available packages based on the request.
@@ -155,6 +182,7 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *core
 	log.Infof("+fluxv2 GetAvailablePackageSummaries(request: [%v])", request)
 
 	// grpc compiles in getters for you which automatically return a default (empty) struct if the pointer was nil
+	// The cache only indexes the repositories of the cluster on which Kubeapps is installed.
 	if request != nil && request.GetContext().GetCluster() != "" {
 		return nil, status.Errorf(
 			codes.Unimplemented,
@@ -169,7 +197,7 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *core
 	}
 
 	// TODO (gfichtenholt) use request.FilterOptions
-	repos, err := s.getHelmRepos(ctx, "")
+	repos, err := s.getHelmRepos(ctx, "", "")
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +206,7 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *core
 	if err != nil {
 		return nil, err
 	}
+	setCacheStatusHeader(ctx, s.cache.status())
 
 	// this non-sense below is only here to convert from []interface{} which is
 	// what the generic cache implementation returns for cache hits to
@@ -218,58 +247,81 @@ func (s *Server) GetAvailablePackageDetail(ctx context.Context, request *corev1.
 	if len(packageIdParts) != 2 {
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid package ref identifier: [%s]", packageRef.Identifier)
 	}
+	repoName, chartName, namespace := packageIdParts[0], packageIdParts[1], packageRef.Context.Namespace
 
-	if request.PkgVersion != "" {
-		return nil, status.Errorf(
-			codes.Unimplemented,
-			"Not supported yet: version: [%v]",
-			request.PkgVersion)
+	// the details of a version pulled before are found in the cache without a HelmChart,
+	// once the user is known to have access to the repository of the chart
+	digest := s.cachedChartDigest(ctx, namespace, repoName, chartName, request.PkgVersion)
+	if detail, found := s.cachedChartDetail(ctx, digest); found {
+		if err := s.checkHelmRepositoryAccess(ctx, namespace, repoName); err != nil {
+			return nil, err
+		}
+		log.Infof("Found cached chart detail for: [%s], version: [%s], digest: [%s]", packageRef.Identifier, request.PkgVersion, digest)
+		return availablePackageDetailResponse(packageRef, chartName, detail)
 	}
 
 	// TODO (gfichtenholt) check if the repo has been indexed, stored in the cache and requested
 	// package is part of it. Otherwise, there is a time window when this scenario can happen:
 	// - GetAvailablePackageSummaries may return {} while a ready repo is being indexed BUT
 	// - GetAvailablePackageDetail may return package detail
-	url, err := s.pullChartTarball(ctx, packageIdParts[0], packageIdParts[1], packageRef.Context.Namespace)
+	unstructuredChart, err := s.pullChartTarball(ctx, repoName, chartName, request.PkgVersion, namespace)
 	if err != nil {
 		return nil, err
 	}
-	log.Infof("Found chart url: [%s]", *url)
+	url, digest, err := helmChartArtifact(unstructuredChart)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Found chart url: [%s], digest: [%s]", url, digest)
 
-	// unzip and untar .tgz file
-	// no need to provide authz, userAgent or any of the TLS details, as we are pulling .tgz file from
-	// local cluster, not remote repo.
-	// E.g. http://source-controller.flux-system.svc.cluster.local./helmchart/default/redis-j6wtx/redis-latest.tgz
-	// Flux does the hard work of pulling the bits from remote repo
-	// based on secretRef associated with HelmRepository, if applicable
-	detail, err := tar.FetchChartDetailFromTarball(packageRef.Identifier, *url, "", "", httpclient.New())
+	// unzip and untar .tgz file, unless its detail is cached
+	detail, err := s.fetchChartDetail(ctx, packageRef.Identifier, url, digest)
 	if err != nil {
 		return nil, err
 	}
+	s.cacheChartDigest(ctx, namespace, repoName, chartName, request.PkgVersion, digest)
 
+	return availablePackageDetailResponse(packageRef, chartName, detail)
+}
+
+func availablePackageDetailResponse(packageRef *corev1.AvailablePackageReference, name string, detail map[string]string) (*corev1.GetAvailablePackageDetailResponse, error) {
+	packageDetail, err := availablePackageDetailFromChartDetail(packageRef, name, detail)
+	if err != nil {
+		return nil, err
+	}
 	return &corev1.GetAvailablePackageDetailResponse{
-		AvailablePackageDetail: &corev1.AvailablePackageDetail{
-			AvailablePackageRef: packageRef, // copy just for now
-			Name:                packageIdParts[1],
-			LongDescription:     detail[chart.ReadmeKey],
-		},
+		AvailablePackageDetail: packageDetail,
 	}, nil
 }
 
-// returns the url from which chart .tgz can be downloaded
-func (s *Server) pullChartTarball(ctx context.Context, repoName string, chartName string, namespace string) (*string, error) {
-	_, client, err := s.GetClients(ctx)
+// checkHelmRepositoryAccess returns an error unless the user can get the HelmRepository,
+// which is checked before returning details from the cache rather than from a HelmChart
+// the user can access. As for HelmCharts, the HelmRepository is on the cluster on which
+// Kubeapps is installed.
+func (s *Server) checkHelmRepositoryAccess(ctx context.Context, namespace, repoName string) error {
+	_, client, err := s.GetClients(ctx, "")
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if _, err = client.Resource(helmRepositoriesGvr).Namespace(namespace).Get(ctx, repoName, metav1.GetOptions{}); err != nil {
+		return statusErrorFromK8sError(err, "get", fmt.Sprintf("HelmRepository [%s]", repoName))
 	}
+	return nil
+}
 
-	chartsResource := schema.GroupVersionResource{
-		Group:    fluxGroup,
-		Version:  fluxVersion,
-		Resource: fluxHelmCharts,
+// returns the HelmChart which pulled the version of the chart .tgz, reusing an existing
+// HelmChart for the version, if any, rather than creating a new one. An empty version is
+// the latest one. The HelmCharts which failed to pull the chart are not reused, and those
+// created by the plugin are deleted when they failed or expired.
+// The chart is pulled by the source-controller of the cluster on which Kubeapps is installed,
+// since the tarball is then fetched from its cluster-local url.
+func (s *Server) pullChartTarball(ctx context.Context, repoName string, chartName string, version string, namespace string) (*unstructured.Unstructured, error) {
+	_, client, err := s.GetClients(ctx, "")
+	if err != nil {
+		return nil, err
 	}
 
-	resourceIfc := client.Resource(chartsResource).Namespace(namespace)
+	resourceIfc := client.Resource(helmChartsGvr).Namespace(namespace)
 
 	// see if we the chart already exists
 	// TODO (gfichtenholt):
@@ -285,25 +337,34 @@ func (s *Server) pullChartTarball(ctx context.Context, repoName string, chartNam
 		return nil, err
 	}
 
-	for _, unstructuredChart := range chartList.Items {
-		thisChartName, found, err := unstructured.NestedString(unstructuredChart.Object, "spec", "chart")
-		thisRepoName, found2, err2 := unstructured.NestedString(unstructuredChart.Object, "spec", "sourceRef", "name")
-
-		// TODO (gfichtenholt) compare chart versions too
-		if err == nil && err2 == nil && found && found2 && repoName == thisRepoName && chartName == thisChartName {
-			done, err := isChartPullComplete(&unstructuredChart)
-			if err != nil {
-				return nil, err
-			} else if done {
-				url, found, err := unstructured.NestedString(unstructuredChart.Object, "status", "url")
-				if err != nil || !found {
-					return nil, status.Errorf(codes.Internal, "expected field status.url not found on HelmChart: %v:\n%v", err, unstructuredChart)
-				}
-				log.Infof("Found existing HelmChart for: [%s/%s]", repoName, chartName)
-				return &url, nil
+	for i := range chartList.Items {
+		unstructuredChart := &chartList.Items[i]
+		if isExpiredHelmChart(unstructuredChart) {
+			deleteHelmChart(ctx, resourceIfc, unstructuredChart)
+			continue
+		}
+		if !helmChartMatches(unstructuredChart.Object, repoName, chartName, version) {
+			continue
+		}
+		done, err := isChartPullComplete(unstructuredChart)
+		if done && err != nil {
+			// the pull failed, e.g. the repository was not reachable at the time, so a new
+			// HelmChart pulls the chart again rather than returning the same error forever
+			log.Infof("Ignoring failed HelmChart [%s] for: [%s/%s], version: [%s]: %v", unstructuredChart.GetName(), repoName, chartName, version, err)
+			if isPluginHelmChart(unstructuredChart) {
+				deleteHelmChart(ctx, resourceIfc, unstructuredChart)
 			}
-			// TODO (gfichtenholt) waitUntilChartPullComplete?
+			continue
+		} else if err != nil {
+			return nil, err
+		} else if done {
+			log.Infof("Found existing HelmChart for: [%s/%s], version: [%s]", repoName, chartName, version)
+			return unstructuredChart, nil
 		}
+		// the chart is still being pulled, e.g. for a previous request, so there is no
+		// need for another HelmChart
+		log.Infof("Waiting for existing HelmChart for: [%s/%s], version: [%s]", repoName, chartName, version)
+		return waitForHelmChart(ctx, resourceIfc, unstructuredChart)
 	}
 
 	// did not find the chart, need to create
@@ -318,6 +379,9 @@ func (s *Server) pullChartTarball(ctx context.Context, repoName string, chartNam
 			"kind":       fluxHelmChart,
 			"metadata": map[string]interface{}{
 				"generateName": fmt.Sprintf("%s-", chartName),
+				"labels": map[string]interface{}{
+					helmChartManagedByLabel: helmChartManagedByValue,
+				},
 			},
 			"spec": map[string]interface{}{
 				"chart": chartName,
@@ -329,6 +393,11 @@ func (s *Server) pullChartTarball(ctx context.Context, repoName string, chartNam
 			},
 		},
 	}
+	if version != "" {
+		if err = unstructured.SetNestedField(unstructuredChart.Object, version, "spec", "version"); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to set the version of the HelmChart: %v", err)
+		}
+	}
 
 	newChart, err := resourceIfc.Create(ctx, &unstructuredChart, metav1.CreateOptions{})
 	if err != nil {
@@ -338,22 +407,28 @@ func (s *Server) pullChartTarball(ctx context.Context, repoName string, chartNam
 
 	log.Infof("created chart: [%v]", newChart)
 
-	// wait until flux reconciles
+	return waitForHelmChart(ctx, resourceIfc, newChart)
+}
+
+// waitForHelmChart waits until flux has pulled the chart of the HelmChart
+func waitForHelmChart(ctx context.Context, resourceIfc dynamic.ResourceInterface, unstructuredChart *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 	watcher, err := resourceIfc.Watch(ctx, metav1.ListOptions{
-		ResourceVersion: newChart.GetResourceVersion(),
+		ResourceVersion: unstructuredChart.GetResourceVersion(),
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", unstructuredChart.GetName()).String(),
 	})
 	if err != nil {
 		log.Errorf("error creating watch: %v\n%v", err, unstructuredChart)
 		return nil, err
 	}
+	defer watcher.Stop()
 
 	// wait til we have chart url available
 	return waitUntilChartPullComplete(watcher)
 }
 
 // namespace maybe "", in which case repositories from all namespaces are returned
-func (s *Server) getHelmRepos(ctx context.Context, namespace string) (*unstructured.UnstructuredList, error) {
-	_, client, err := s.GetClients(ctx)
+func (s *Server) getHelmRepos(ctx context.Context, cluster string, namespace string) (*unstructured.UnstructuredList, error) {
+	_, client, err := s.GetClients(ctx, cluster)
 	if err != nil {
 		return nil, err
 	}
@@ -368,10 +443,8 @@ func (s *Server) getHelmRepos(ctx context.Context, namespace string) (*unstructu
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "unable to list fluxv2 helmrepositories: %v", err)
 	} else {
-		// TODO (gfichtenholt): should we filter out those repos that don't have .status.condition.Ready == True?
-		// like we do in GetAvailablePackageSummaries()?
-		// i.e. should GetAvailableRepos() call semantics be such that only "Ready" repos are returned
-		// ongoing slack discussion https://vmware.slack.com/archives/C4HEXCX3N/p1621846518123800
+		// The repos which are not ready are filtered out by the callers
+		// when needed, see GetPackageRepositoriesRequest.ReadyOnly.
 		return repos, nil
 	}
 }
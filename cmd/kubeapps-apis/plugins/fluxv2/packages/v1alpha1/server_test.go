@@ -19,19 +19,27 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	redismock "github.com/go-redis/redismock/v8"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
 	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/fluxv2/packages/v1alpha1"
 	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/server"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -82,7 +90,7 @@ func TestBadClientGetter(t *testing.T) {
 	}{
 		{
 			name: "returns failed-precondition when configGetter itself errors",
-			clientGetter: func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+			clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 				return nil, nil, fmt.Errorf("Bang!")
 			},
 			statusCode: codes.FailedPrecondition,
@@ -116,6 +124,267 @@ func TestBadClientGetter(t *testing.T) {
 	}
 }
 
+func TestCheckReadiness(t *testing.T) {
+	testCases := []struct {
+		name       string
+		noCache    bool
+		pingErr    error
+		statusCode codes.Code
+	}{
+		{
+			name:       "it is ready when redis is reachable",
+			statusCode: codes.OK,
+		},
+		{
+			name:       "it is not ready when redis is not reachable",
+			pingErr:    fmt.Errorf("connection refused"),
+			statusCode: codes.Unavailable,
+		},
+		{
+			name:       "it is not ready without cache",
+			noCache:    true,
+			statusCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			redisCli, mock := redismock.NewClientMock()
+			s := &Server{}
+			if !tc.noCache {
+				s.cache = &ResourceWatcherCache{store: &redisStore{client: redisCli}}
+				if tc.pingErr != nil {
+					mock.ExpectPing().SetErr(tc.pingErr)
+				} else {
+					mock.ExpectPing().SetVal("PONG")
+				}
+			}
+
+			err := s.CheckReadiness(context.Background())
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Errorf("got: %+v, want: %+v", got, want)
+			}
+
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("%v", err)
+			}
+		})
+	}
+}
+
+func TestRedisOptions(t *testing.T) {
+	db := 2
+	testCases := []struct {
+		name          string
+		settings      redisSettings
+		env           map[string]string
+		expectedAddr  string
+		expectedDB    int
+		expectedError bool
+	}{
+		{
+			name:         "it uses the settings of the plugin config",
+			settings:     redisSettings{Addr: "redis:6379", Password: "secret", DB: &db},
+			expectedAddr: "redis:6379",
+			expectedDB:   2,
+		},
+		{
+			name:         "it falls back to the environment variables",
+			settings:     redisSettings{Password: "secret"},
+			env:          map[string]string{"REDIS_ADDR": "env-redis:6379", "REDIS_DB": "1"},
+			expectedAddr: "env-redis:6379",
+			expectedDB:   1,
+		},
+		{
+			name:          "it returns an error without addr",
+			settings:      redisSettings{Password: "secret", DB: &db},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{"REDIS_ADDR", "REDIS_PASSWORD", "REDIS_DB"} {
+				value, ok := os.LookupEnv(name)
+				os.Unsetenv(name)
+				if ok {
+					defer os.Setenv(name, value)
+				}
+			}
+			for name, value := range tc.env {
+				os.Setenv(name, value)
+				defer os.Unsetenv(name)
+			}
+
+			options, err := redisOptions(tc.settings)
+			if tc.expectedError {
+				if got, want := status.Code(err), codes.FailedPrecondition; got != want {
+					t.Fatalf("got: %+v, want: %+v", got, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := options.Addr, tc.expectedAddr; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := options.DB, tc.expectedDB; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+		})
+	}
+}
+
+func TestNewKeyValueStore(t *testing.T) {
+	testCases := []struct {
+		name       string
+		settings   cacheSettings
+		statusCode codes.Code
+	}{
+		{
+			name:       "it returns a memory store",
+			settings:   cacheSettings{Backend: memoryCacheBackend, MaxEntries: 10},
+			statusCode: codes.OK,
+		},
+		{
+			name:       "it returns an error for invalid memory limits",
+			settings:   cacheSettings{Backend: memoryCacheBackend, MaxBytes: -1},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "it returns an error for an unsupported backend",
+			settings:   cacheSettings{Backend: "memcached"},
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newKeyValueStore(tc.settings, redisSettings{})
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Errorf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+		})
+	}
+}
+
+func TestLRUStore(t *testing.T) {
+	type setOp struct {
+		key   string
+		value string
+	}
+	testCases := []struct {
+		name         string
+		maxEntries   int
+		maxBytes     int64
+		sets         []setOp
+		gets         []string
+		expectedKeys []string
+		statusCode   codes.Code
+	}{
+		{
+			name:         "it keeps the values within the limits",
+			maxEntries:   2,
+			sets:         []setOp{{"a", "1"}, {"b", "2"}},
+			expectedKeys: []string{"a", "b"},
+		},
+		{
+			name:         "it evicts the least recently set value beyond the max entries",
+			maxEntries:   2,
+			sets:         []setOp{{"a", "1"}, {"b", "2"}, {"c", "3"}},
+			expectedKeys: []string{"b", "c"},
+		},
+		{
+			name:         "it evicts the least recently read value beyond the max entries",
+			maxEntries:   2,
+			sets:         []setOp{{"a", "1"}, {"b", "2"}},
+			gets:         []string{"a"},
+			expectedKeys: []string{"a", "c"},
+		},
+		{
+			name:         "it evicts values beyond the max bytes",
+			maxBytes:     7,
+			sets:         []setOp{{"a", "123"}, {"b", "123"}},
+			expectedKeys: []string{"b"},
+		},
+		{
+			name:         "it replaces the value of a key",
+			maxBytes:     8,
+			sets:         []setOp{{"a", "123"}, {"a", "123456"}},
+			expectedKeys: []string{"a"},
+		},
+		{
+			name:       "it returns an error for a value larger than the max bytes",
+			maxBytes:   4,
+			sets:       []setOp{{"a", "123456"}},
+			statusCode: codes.ResourceExhausted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			store, err := newLRUStore(tc.maxEntries, tc.maxBytes)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			for _, op := range tc.sets {
+				err = store.set(ctx, op.key, []byte(op.value), 0)
+			}
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			for _, key := range tc.gets {
+				if _, found, _ := store.get(ctx, key); !found {
+					t.Fatalf("key [%s] not found", key)
+				}
+			}
+			if len(tc.gets) > 0 {
+				if err = store.set(ctx, "c", []byte("3"), 0); err != nil {
+					t.Fatalf("%+v", err)
+				}
+			}
+
+			keys := []string{}
+			for _, key := range []string{"a", "b", "c"} {
+				if _, found, _ := store.get(ctx, key); found {
+					keys = append(keys, key)
+				}
+			}
+			if got, want := keys, tc.expectedKeys; !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, cmpopts.EquateEmpty()))
+			}
+		})
+	}
+}
+
+func TestLRUStoreExpiration(t *testing.T) {
+	ctx := context.Background()
+	store, err := newLRUStore(0, 0)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err = store.set(ctx, "expiring", []byte("1"), time.Millisecond); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err = store.set(ctx, "persistent", []byte("2"), 0); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if _, found, _ := store.get(ctx, "expiring"); found {
+		t.Errorf("expired key found")
+	}
+	keys, err := store.keys(ctx, "")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := keys, []string{"persistent"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
 func TestGetAvailablePackagesStatus(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -643,6 +912,14 @@ func TestGetAvailablePackageSummariesAfterFluxHelmRepoDelete(t *testing.T) {
 		s.cache.eventProcessingWaitGroup.Add(1)
 		key := redisKeyForRuntimeObject(repo)
 		mock.ExpectDel(key).SetVal(0)
+		_, dynamicClient, err := s.clientGetter(context.Background(), "")
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		err = dynamicClient.Resource(helmRepositoriesGvr).Namespace("default").Delete(context.Background(), "bitnami-1", metav1.DeleteOptions{})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
 		watcher.Delete(repo)
 		s.cache.eventProcessingWaitGroup.Wait()
 
@@ -651,8 +928,6 @@ func TestGetAvailablePackageSummariesAfterFluxHelmRepoDelete(t *testing.T) {
 			t.Fatalf("%v", err)
 		}
 
-		mock.ExpectGet(key).RedisNil()
-
 		responseAfterDelete, err := s.GetAvailablePackageSummaries(
 			context.Background(),
 			&corev1.GetAvailablePackageSummariesRequest{Context: &corev1.Context{}})
@@ -671,96 +946,395 @@ func TestGetAvailablePackageSummariesAfterFluxHelmRepoDelete(t *testing.T) {
 	})
 }
 
-func TestGetPackageRepositories(t *testing.T) {
+func TestGetAvailablePackageSummariesWithMemoryCache(t *testing.T) {
+	indexYaml, err := ioutil.ReadFile("testdata/valid-index.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, string(indexYaml))
+	}))
+	defer ts.Close()
+
+	repoStatus := map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True", "reason": "IndexationSucceed"},
+		},
+		"url": ts.URL,
+	}
+	repos := []runtime.Object{
+		newRepo("bitnami-1", "default", map[string]interface{}{"url": "https://example.repo.com/charts", "interval": "1m0s"}, repoStatus),
+		newRepo("bitnami-2", "default", map[string]interface{}{"url": "https://example.repo.com/charts", "interval": "1m0s"}, repoStatus),
+	}
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			helmRepositoriesGvr: fluxHelmRepositoryList,
+		},
+		repos...)
+	clientGetter := func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
+		return nil, dynamicClient, nil
+	}
+
+	// the store only has room for one of the repositories, so the other one is evicted
+	// and indexed again on each request
+	store, err := newLRUStore(1, 0)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	cache, err := newCacheWithStore(cacheConfig{
+		gvr:          helmRepositoriesGvr,
+		clientGetter: clientGetter,
+		onAdd:        onAddOrModifyRepo,
+		onModify:     onAddOrModifyRepo,
+		onGet:        onGetRepo,
+		onDelete:     onDeleteRepo,
+	}, store)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	s := &Server{clientGetter: clientGetter, cache: cache}
+
+	for i := 0; i < 2; i++ {
+		response, err := s.GetAvailablePackageSummaries(
+			context.Background(),
+			&corev1.GetAvailablePackageSummariesRequest{Context: &corev1.Context{}})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := len(response.AvailablePackagesSummaries), 4; got != want {
+			t.Errorf("got: %d packages, want: %d", got, want)
+		}
+	}
+	keys, err := store.keys(context.Background(), "")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := len(keys), 1; got != want {
+		t.Errorf("got: %d values in the store, want: %d", got, want)
+	}
+}
+
+func TestResourceWatcherCacheResync(t *testing.T) {
 	testCases := []struct {
-		name                        string
-		request                     *v1alpha1.GetPackageRepositoriesRequest
-		repoNamespace               string
-		repoSpecs                   map[string]map[string]interface{}
-		expectedPackageRepositories []*v1alpha1.PackageRepository
-		statusCode                  codes.Code
+		name             string
+		storedKeys       []string
+		resourceVersions map[string]string
+		repos            []runtime.Object
+		expectedKeys     []string
+		expectedIndexed  []string
 	}{
 		{
-			name:          "returns an internal error status if item in response cannot be converted to v1alpha1.PackageRepository",
-			request:       &v1alpha1.GetPackageRepositoriesRequest{Context: &corev1.Context{}},
-			repoNamespace: "default",
-			repoSpecs: map[string]map[string]interface{}{
-				"repo-1": {
-					"foo": "bar",
-				},
-			},
-			statusCode: codes.Internal,
-		},
-		{
-			name:          "returns expected repositories",
-			request:       &v1alpha1.GetPackageRepositoriesRequest{Context: &corev1.Context{}},
-			repoNamespace: "default",
-			repoSpecs: map[string]map[string]interface{}{
-				"repo-1": {
-					"url": "https://charts.bitnami.com/bitnami",
-				},
-				"repo-2": {
-					"url": "https://charts.helm.sh/stable",
-				},
-			},
-			expectedPackageRepositories: []*v1alpha1.PackageRepository{
-				{
-					Name:      "repo-1",
-					Namespace: "default",
-					Url:       "https://charts.bitnami.com/bitnami",
-				},
-				{
-					Name:      "repo-2",
-					Namespace: "default",
-					Url:       "https://charts.helm.sh/stable",
-				},
+			name:       "it evicts the orphaned keys when it starts",
+			storedKeys: []string{"helmrepositories:default:repo-1", "helmrepositories:default:deleted", "other:default:deleted"},
+			repos: []runtime.Object{
+				newRepoWithResourceVersion("repo-1", "1"),
+				newRepoWithResourceVersion("repo-2", "1"),
 			},
+			expectedKeys:    []string{"helmrepositories:default:repo-1", "helmrepositories:default:repo-2", "other:default:deleted"},
+			expectedIndexed: []string{"helmrepositories:default:repo-1", "helmrepositories:default:repo-2"},
 		},
 		{
-			name: "returns expected repositories in specific namespace",
-			request: &v1alpha1.GetPackageRepositoriesRequest{
-				Context: &corev1.Context{
-					Namespace: "default",
-				},
-			},
-			repoNamespace: "non-default",
-			repoSpecs: map[string]map[string]interface{}{
-				"repo-1": {
-					"url": "https://charts.bitnami.com/bitnami",
-				},
-				"repo-2": {
-					"url": "https://charts.helm.sh/stable",
-				},
+			name:             "it computes again only the values of the changed objects",
+			storedKeys:       []string{"helmrepositories:default:repo-1", "helmrepositories:default:repo-2"},
+			resourceVersions: map[string]string{"helmrepositories:default:repo-1": "1", "helmrepositories:default:repo-2": "1"},
+			repos: []runtime.Object{
+				newRepoWithResourceVersion("repo-1", "1"),
+				newRepoWithResourceVersion("repo-2", "2"),
 			},
-			expectedPackageRepositories: []*v1alpha1.PackageRepository{},
+			expectedKeys:    []string{"helmrepositories:default:repo-1", "helmrepositories:default:repo-2"},
+			expectedIndexed: []string{"helmrepositories:default:repo-2"},
 		},
-		{
-			name: "returns expected repositories in specific namespace",
-			request: &v1alpha1.GetPackageRepositoriesRequest{
-				Context: &corev1.Context{
-					Namespace: "default",
-				},
-			},
-			repoNamespace: "default",
-			repoSpecs: map[string]map[string]interface{}{
-				"repo-1": {
-					"url": "https://charts.bitnami.com/bitnami",
-				},
-				"repo-2": {
-					"url": "https://charts.helm.sh/stable",
-				},
-			},
-			expectedPackageRepositories: []*v1alpha1.PackageRepository{
-				{
-					Name:      "repo-1",
-					Namespace: "default",
-					Url:       "https://charts.bitnami.com/bitnami",
-				},
-				{
-					Name:      "repo-2",
-					Namespace: "default",
-					Url:       "https://charts.helm.sh/stable",
-				},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store, err := newLRUStore(0, 0)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			for _, key := range tc.storedKeys {
+				if err = store.set(context.Background(), key, []byte("stale"), 0); err != nil {
+					t.Fatalf("%+v", err)
+				}
+			}
+			c, indexed := newTestCache(store, tc.repos...)
+			for key, resourceVersion := range tc.resourceVersions {
+				c.resourceVersions[key] = resourceVersion
+			}
+
+			objects := []map[string]interface{}{}
+			for _, r := range tc.repos {
+				objects = append(objects, r.(*unstructured.Unstructured).Object)
+			}
+			c.resync(objects)
+
+			keys, err := store.keys(context.Background(), "")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			opt := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+			if got, want := keys, tc.expectedKeys; !cmp.Equal(want, got, opt) {
+				t.Errorf("mismatch in keys (-want +got):\n%s", cmp.Diff(want, got, opt))
+			}
+			if got, want := indexed(), tc.expectedIndexed; !cmp.Equal(want, got, opt, cmpopts.EquateEmpty()) {
+				t.Errorf("mismatch in indexed (-want +got):\n%s", cmp.Diff(want, got, opt, cmpopts.EquateEmpty()))
+			}
+			if cacheStatus := c.status(); !cacheStatus.synced || cacheStatus.stale {
+				t.Errorf("got: %+v, want a synced cache", cacheStatus)
+			}
+		})
+	}
+}
+
+func TestResourceWatcherCacheProcessesEventsInOrder(t *testing.T) {
+	store, err := newLRUStore(0, 0)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	c, indexed := newTestCache(store, newRepoWithResourceVersion("repo-1", "1"))
+	_, dynamicClient, err := c.config.clientGetter(context.Background(), "")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	watchers := make(chan *watch.FakeWatcher, 1)
+	dynamicClient.(*fake.FakeDynamicClient).PrependWatchReactor("*", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watcher := watch.NewFake()
+		watchers <- watcher
+		return true, watcher, nil
+	})
+	c.eventProcessingWaitGroup = &sync.WaitGroup{}
+	c.eventProcessingWaitGroup.Add(5)
+
+	go c.startResourceWatcher()
+	watcher := waitForWatch(t, watchers)
+
+	// each event must be processed after the previous ones for the same object, otherwise
+	// a deleted repository could be stored again or an older version could win
+	watcher.Modify(newRepoWithResourceVersion("repo-1", "2"))
+	watcher.Delete(newRepoWithResourceVersion("repo-1", "2"))
+	watcher.Add(newRepoWithResourceVersion("repo-2", "3"))
+	watcher.Modify(newRepoWithResourceVersion("repo-2", "4"))
+	watcher.Modify(newRepoWithResourceVersion("repo-2", "5"))
+	c.eventProcessingWaitGroup.Wait()
+
+	if _, found, _ := store.get(context.Background(), "helmrepositories:default:repo-1"); found {
+		t.Errorf("deleted key found")
+	}
+	value, found, err := store.get(context.Background(), "helmrepositories:default:repo-2")
+	if err != nil || !found {
+		t.Fatalf("key not found: %v", err)
+	}
+	if got, want := string(value), "5"; got != want {
+		t.Errorf("got value: %q, want: %q", got, want)
+	}
+	expectedIndexed := []string{
+		"helmrepositories:default:repo-1",
+		"helmrepositories:default:repo-1",
+		"helmrepositories:default:repo-2",
+		"helmrepositories:default:repo-2",
+		"helmrepositories:default:repo-2",
+	}
+	if got, want := indexed(), expectedIndexed; !cmp.Equal(want, got) {
+		t.Errorf("mismatch in indexed (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestResourceWatcherCacheRelistsAfterExpiredWatch(t *testing.T) {
+	store, err := newLRUStore(0, 0)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err = store.set(context.Background(), "helmrepositories:default:deleted", []byte("stale"), 0); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	c, _ := newTestCache(store, newRepoWithResourceVersion("repo-1", "1"))
+	_, dynamicClient, err := c.config.clientGetter(context.Background(), "")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	watchers := make(chan *watch.FakeWatcher, 2)
+	dynamicClient.(*fake.FakeDynamicClient).PrependWatchReactor("*", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watcher := watch.NewFake()
+		watchers <- watcher
+		return true, watcher, nil
+	})
+
+	go c.startResourceWatcher()
+	watcher := waitForWatch(t, watchers)
+	if got, want := c.status().stale, false; got != want {
+		t.Errorf("got stale: %t, want: %t", got, want)
+	}
+
+	// the repository is created while the watch is broken, so it is only seen by listing again
+	_, err = dynamicClient.Resource(helmRepositoriesGvr).Namespace("default").Create(
+		context.Background(), newRepoWithResourceVersion("repo-2", "2"), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	watcher.Error(&metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   http.StatusGone,
+		Reason: metav1.StatusReasonExpired,
+	})
+	waitForWatch(t, watchers)
+
+	for _, key := range []string{"helmrepositories:default:repo-1", "helmrepositories:default:repo-2"} {
+		if _, found, _ := store.get(context.Background(), key); !found {
+			t.Errorf("key [%s] not found", key)
+		}
+	}
+	if _, found, _ := store.get(context.Background(), "helmrepositories:default:deleted"); found {
+		t.Errorf("orphaned key found")
+	}
+	if got, want := c.status().stale, false; got != want {
+		t.Errorf("got stale: %t, want: %t", got, want)
+	}
+}
+
+func TestResourceWatcherCacheStatus(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name           string
+		lastSyncTime   time.Time
+		watching       bool
+		expectedStatus cacheStatus
+	}{
+		{
+			name:           "it is stale before the first sync",
+			expectedStatus: cacheStatus{stale: true},
+		},
+		{
+			name:           "it is not stale while watching",
+			lastSyncTime:   now.Add(-time.Minute),
+			watching:       true,
+			expectedStatus: cacheStatus{synced: true, lastSyncTime: now.Add(-time.Minute)},
+		},
+		{
+			name:           "it is stale while not watching",
+			lastSyncTime:   now.Add(-time.Minute),
+			expectedStatus: cacheStatus{synced: true, stale: true, lastSyncTime: now.Add(-time.Minute)},
+		},
+		{
+			name:           "it is stale if not synced within two resync periods",
+			lastSyncTime:   now.Add(-21 * time.Minute),
+			watching:       true,
+			expectedStatus: cacheStatus{synced: true, stale: true, lastSyncTime: now.Add(-21 * time.Minute)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &ResourceWatcherCache{
+				config:       cacheConfig{resyncPeriod: 10 * time.Minute},
+				lastSyncTime: tc.lastSyncTime,
+				watching:     tc.watching,
+			}
+			if got, want := c.status(), tc.expectedStatus; got != want {
+				t.Errorf("got: %+v, want: %+v", got, want)
+			}
+
+			if got, want := packageCacheStatus(c.status()).GetStale(), tc.expectedStatus.stale; got != want {
+				t.Errorf("got stale: %t, want: %t", got, want)
+			}
+		})
+	}
+}
+
+func TestGetPackageRepositories(t *testing.T) {
+	testCases := []struct {
+		name                        string
+		request                     *v1alpha1.GetPackageRepositoriesRequest
+		repoNamespace               string
+		repoSpecs                   map[string]map[string]interface{}
+		expectedPackageRepositories []*v1alpha1.PackageRepository
+		statusCode                  codes.Code
+	}{
+		{
+			name:          "returns an internal error status if item in response cannot be converted to v1alpha1.PackageRepository",
+			request:       &v1alpha1.GetPackageRepositoriesRequest{Context: &corev1.Context{}},
+			repoNamespace: "default",
+			repoSpecs: map[string]map[string]interface{}{
+				"repo-1": {
+					"foo": "bar",
+				},
+			},
+			statusCode: codes.Internal,
+		},
+		{
+			name:          "returns expected repositories",
+			request:       &v1alpha1.GetPackageRepositoriesRequest{Context: &corev1.Context{}},
+			repoNamespace: "default",
+			repoSpecs: map[string]map[string]interface{}{
+				"repo-1": {
+					"url": "https://charts.bitnami.com/bitnami",
+				},
+				"repo-2": {
+					"url": "https://charts.helm.sh/stable",
+				},
+			},
+			expectedPackageRepositories: []*v1alpha1.PackageRepository{
+				{
+					Name:      "repo-1",
+					Namespace: "default",
+					Url:       "https://charts.bitnami.com/bitnami",
+					Status:    &v1alpha1.PackageRepositoryStatus{Reason: "Reconciling"},
+				},
+				{
+					Name:      "repo-2",
+					Namespace: "default",
+					Url:       "https://charts.helm.sh/stable",
+					Status:    &v1alpha1.PackageRepositoryStatus{Reason: "Reconciling"},
+				},
+			},
+		},
+		{
+			name: "returns expected repositories in specific namespace",
+			request: &v1alpha1.GetPackageRepositoriesRequest{
+				Context: &corev1.Context{
+					Namespace: "default",
+				},
+			},
+			repoNamespace: "non-default",
+			repoSpecs: map[string]map[string]interface{}{
+				"repo-1": {
+					"url": "https://charts.bitnami.com/bitnami",
+				},
+				"repo-2": {
+					"url": "https://charts.helm.sh/stable",
+				},
+			},
+			expectedPackageRepositories: []*v1alpha1.PackageRepository{},
+		},
+		{
+			name: "returns expected repositories in specific namespace",
+			request: &v1alpha1.GetPackageRepositoriesRequest{
+				Context: &corev1.Context{
+					Namespace: "default",
+				},
+			},
+			repoNamespace: "default",
+			repoSpecs: map[string]map[string]interface{}{
+				"repo-1": {
+					"url": "https://charts.bitnami.com/bitnami",
+				},
+				"repo-2": {
+					"url": "https://charts.helm.sh/stable",
+				},
+			},
+			expectedPackageRepositories: []*v1alpha1.PackageRepository{
+				{
+					Name:      "repo-1",
+					Namespace: "default",
+					Url:       "https://charts.bitnami.com/bitnami",
+					Status:    &v1alpha1.PackageRepositoryStatus{Reason: "Reconciling"},
+				},
+				{
+					Name:      "repo-2",
+					Namespace: "default",
+					Url:       "https://charts.helm.sh/stable",
+					Status:    &v1alpha1.PackageRepositoryStatus{Reason: "Reconciling"},
+				},
 			},
 		},
 	}
@@ -783,7 +1357,7 @@ func TestGetPackageRepositories(t *testing.T) {
 				if response == nil {
 					t.Fatalf("got: nil, want: response")
 				} else {
-					opt1 := cmpopts.IgnoreUnexported(v1alpha1.PackageRepository{}, corev1.Context{})
+					opt1 := cmpopts.IgnoreUnexported(v1alpha1.PackageRepository{}, v1alpha1.PackageRepositoryStatus{}, corev1.Context{})
 					opt2 := cmpopts.SortSlices(lessPackageRepositoryFunc)
 					if got, want := response.Repositories, tc.expectedPackageRepositories; !cmp.Equal(got, want, opt1, opt2) {
 						t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opt1, opt2))
@@ -799,182 +1373,1478 @@ func TestGetPackageRepositories(t *testing.T) {
 	}
 }
 
-func TestGetAvailablePackageDetail(t *testing.T) {
+func TestGetPackageRepositoriesReadyOnly(t *testing.T) {
+	readyRepo := newRepo("repo-1", "default", map[string]interface{}{
+		"url":      "https://charts.bitnami.com/bitnami",
+		"interval": "10m",
+	}, map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True", "reason": "IndexationSucceed", "lastTransitionTime": "2021-07-01T10:00:00Z"},
+		},
+	})
+	failedRepo := newRepo("repo-2", "default", map[string]interface{}{
+		"url":       "https://charts.example.com/private",
+		"interval":  "1m",
+		"secretRef": map[string]interface{}{"name": "private-credentials"},
+	}, map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False", "reason": "IndexationFailed", "message": "401 Unauthorized", "lastTransitionTime": "2021-07-01T11:00:00Z"},
+		},
+	})
+	testCases := []struct {
+		name                        string
+		request                     *v1alpha1.GetPackageRepositoriesRequest
+		expectedPackageRepositories []*v1alpha1.PackageRepository
+	}{
+		{
+			name:    "returns the repositories with their status",
+			request: &v1alpha1.GetPackageRepositoriesRequest{Context: &corev1.Context{Namespace: "default"}},
+			expectedPackageRepositories: []*v1alpha1.PackageRepository{
+				{
+					Name:      "repo-1",
+					Namespace: "default",
+					Url:       "https://charts.bitnami.com/bitnami",
+					Interval:  600,
+					Status: &v1alpha1.PackageRepositoryStatus{
+						Ready:              true,
+						Reason:             "IndexationSucceed",
+						LastReconciledTime: &timestamppb.Timestamp{Seconds: 1625133600},
+					},
+				},
+				{
+					Name:      "repo-2",
+					Namespace: "default",
+					Url:       "https://charts.example.com/private",
+					Interval:  60,
+					SecretRef: "private-credentials",
+					Status: &v1alpha1.PackageRepositoryStatus{
+						Reason:             "IndexationFailed",
+						Message:            "401 Unauthorized",
+						LastReconciledTime: &timestamppb.Timestamp{Seconds: 1625137200},
+					},
+				},
+			},
+		},
+		{
+			name:    "returns only the ready repositories",
+			request: &v1alpha1.GetPackageRepositoriesRequest{Context: &corev1.Context{Namespace: "default"}, ReadyOnly: true},
+			expectedPackageRepositories: []*v1alpha1.PackageRepository{
+				{
+					Name:      "repo-1",
+					Namespace: "default",
+					Url:       "https://charts.bitnami.com/bitnami",
+					Interval:  600,
+					Status: &v1alpha1.PackageRepositoryStatus{
+						Ready:              true,
+						Reason:             "IndexationSucceed",
+						LastReconciledTime: &timestamppb.Timestamp{Seconds: 1625133600},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _ := newServerWithoutCache(readyRepo.DeepCopy(), failedRepo.DeepCopy())
+
+			response, err := s.GetPackageRepositories(context.Background(), tc.request)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			opts := cmpopts.IgnoreUnexported(v1alpha1.PackageRepository{}, v1alpha1.PackageRepositoryStatus{}, timestamppb.Timestamp{})
+			if got, want := response.Repositories, tc.expectedPackageRepositories; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
+func TestPackageRepositoryStatus(t *testing.T) {
+	testCases := []struct {
+		name           string
+		generation     int64
+		status         map[string]interface{}
+		expectedStatus *v1alpha1.PackageRepositoryStatus
+	}{
+		{
+			name:           "it is reconciling without status",
+			generation:     1,
+			expectedStatus: &v1alpha1.PackageRepositoryStatus{Reason: "Reconciling"},
+		},
+		{
+			name:       "it is reconciling while the generation is not observed",
+			generation: 2,
+			status: map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "True", "reason": "IndexationSucceed"},
+				},
+			},
+			expectedStatus: &v1alpha1.PackageRepositoryStatus{Reason: "Reconciling"},
+		},
+		{
+			name:       "it prefers the last update time of the artifact",
+			generation: 1,
+			status: map[string]interface{}{
+				"artifact": map[string]interface{}{"lastUpdateTime": "2021-07-01T12:00:00Z"},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "True", "reason": "IndexationSucceed", "lastTransitionTime": "2021-07-01T10:00:00Z"},
+				},
+			},
+			expectedStatus: &v1alpha1.PackageRepositoryStatus{
+				Ready:              true,
+				Reason:             "IndexationSucceed",
+				LastReconciledTime: &timestamppb.Timestamp{Seconds: 1625140800},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newRepo("repo-1", "default", nil, tc.status)
+			repo.SetGeneration(tc.generation)
+
+			opts := cmpopts.IgnoreUnexported(v1alpha1.PackageRepositoryStatus{}, timestamppb.Timestamp{})
+			if got, want := packageRepositoryStatus(repo.Object), tc.expectedStatus; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
+func TestCreatePackageRepository(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *v1alpha1.CreatePackageRepositoryRequest
+		expectedStatus codes.Code
+		expectedSpec   map[string]interface{}
+	}{
+		{
+			name: "it creates a HelmRepository",
+			request: &v1alpha1.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "private",
+				Spec: &v1alpha1.PackageRepositorySpec{
+					Url:       "https://charts.example.com/private",
+					Interval:  120,
+					SecretRef: "private-credentials",
+					Suspend:   true,
+				},
+			},
+			expectedStatus: codes.OK,
+			expectedSpec: map[string]interface{}{
+				"url":       "https://charts.example.com/private",
+				"interval":  "2m0s",
+				"secretRef": map[string]interface{}{"name": "private-credentials"},
+				"suspend":   true,
+			},
+		},
+		{
+			name: "it creates a HelmRepository with the default interval",
+			request: &v1alpha1.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "private",
+				Spec:    &v1alpha1.PackageRepositorySpec{Url: "https://charts.example.com/private"},
+			},
+			expectedStatus: codes.OK,
+			expectedSpec: map[string]interface{}{
+				"url":      "https://charts.example.com/private",
+				"interval": defaultRepositoryInterval,
+			},
+		},
+		{
+			name: "it returns an invalid argument error for an invalid url",
+			request: &v1alpha1.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "private",
+				Spec:    &v1alpha1.PackageRepositorySpec{Url: "charts.example.com"},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "it returns an invalid argument error without a namespace",
+			request: &v1alpha1.CreatePackageRepositoryRequest{
+				Name: "private",
+				Spec: &v1alpha1.PackageRepositorySpec{Url: "https://charts.example.com/private"},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "it returns an already exists error if the HelmRepository exists",
+			request: &v1alpha1.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "bitnami",
+				Spec:    &v1alpha1.PackageRepositorySpec{Url: "https://charts.bitnami.com/bitnami"},
+			},
+			expectedStatus: codes.AlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, dynamicClient := newServerWithoutCache(newRepo("bitnami", "default", map[string]interface{}{"url": "https://charts.bitnami.com/bitnami"}, nil))
+
+			response, err := s.CreatePackageRepository(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if got, want := response.GetRepository().GetName(), tc.request.GetName(); got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			repo, err := dynamicClient.Resource(helmRepositoriesGvr).Namespace("default").Get(context.Background(), tc.request.GetName(), metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := repo.Object["spec"], tc.expectedSpec; !cmp.Equal(want, got) {
+				t.Errorf("mismatch in spec (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestUpdatePackageRepository(t *testing.T) {
+	existingSpec := map[string]interface{}{
+		"url":       "https://charts.example.com/private",
+		"interval":  "1m",
+		"timeout":   "30s",
+		"secretRef": map[string]interface{}{"name": "private-credentials"},
+		"suspend":   true,
+	}
+	testCases := []struct {
+		name           string
+		request        *v1alpha1.UpdatePackageRepositoryRequest
+		expectedStatus codes.Code
+		expectedSpec   map[string]interface{}
+	}{
+		{
+			name: "it resumes the HelmRepository and replaces its spec",
+			request: &v1alpha1.UpdatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "private",
+				Spec: &v1alpha1.PackageRepositorySpec{
+					Url:      "https://charts.example.com/other",
+					Interval: 300,
+				},
+			},
+			expectedStatus: codes.OK,
+			expectedSpec: map[string]interface{}{
+				"url":      "https://charts.example.com/other",
+				"interval": "5m0s",
+				"timeout":  "30s",
+			},
+		},
+		{
+			name: "it returns a not found error if the HelmRepository does not exist",
+			request: &v1alpha1.UpdatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "other",
+				Spec:    &v1alpha1.PackageRepositorySpec{Url: "https://charts.example.com/other"},
+			},
+			expectedStatus: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, dynamicClient := newServerWithoutCache(newRepo("private", "default", runtime.DeepCopyJSON(existingSpec), nil))
+
+			response, err := s.UpdatePackageRepository(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if got, want := response.GetRepository().GetSuspend(), false; got != want {
+				t.Errorf("got suspend: %t, want: %t", got, want)
+			}
+			repo, err := dynamicClient.Resource(helmRepositoriesGvr).Namespace("default").Get(context.Background(), "private", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := repo.Object["spec"], tc.expectedSpec; !cmp.Equal(want, got) {
+				t.Errorf("mismatch in spec (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestDeletePackageRepository(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *v1alpha1.DeletePackageRepositoryRequest
+		expectedStatus codes.Code
+	}{
+		{
+			name: "it deletes the HelmRepository",
+			request: &v1alpha1.DeletePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "bitnami",
+			},
+			expectedStatus: codes.OK,
+		},
+		{
+			name: "it returns a not found error if the HelmRepository does not exist",
+			request: &v1alpha1.DeletePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "other",
+			},
+			expectedStatus: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, dynamicClient := newServerWithoutCache(newRepo("bitnami", "default", map[string]interface{}{"url": "https://charts.bitnami.com/bitnami"}, nil))
+
+			_, err := s.DeletePackageRepository(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			_, err = dynamicClient.Resource(helmRepositoriesGvr).Namespace("default").Get(context.Background(), "bitnami", metav1.GetOptions{})
+			if !errors.IsNotFound(err) {
+				t.Errorf("got: %+v, want: not found error", err)
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageDetail(t *testing.T) {
+	testCases := []struct {
+		testName              string
+		request               *corev1.GetAvailablePackageDetailRequest
+		repoName              string
+		repoNamespace         string
+		chartName             string
+		chartTarGz            string
+		expectedPackageDetail *corev1.AvailablePackageDetail
+	}{
+		{
+			testName:      "it returns details about the redis package in bitnami repo",
+			repoName:      "bitnami-1",
+			repoNamespace: "default",
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Identifier: "bitnami-1/redis",
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
+				}},
+			chartName:  "redis",
+			chartTarGz: "testdata/redis-14.4.0.tgz",
+			expectedPackageDetail: &corev1.AvailablePackageDetail{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Identifier: "bitnami-1/redis",
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
+				},
+				Name:             "redis",
+				PkgVersion:       "14.4.0",
+				AppVersion:       "6.2.4",
+				IconUrl:          "https://bitnami.com/assets/stacks/redis/img/redis-stack-220x234.png",
+				DisplayName:      "redis",
+				ShortDescription: "Open source, advanced key-value store. It is often referred to as a data structure server since keys can contain strings, hashes, lists, sets and sorted sets.",
+				LongDescription:  "Redis<sup>TM</sup> Chart packaged by Bitnami\n\n[Redis<sup>TM</sup>](http://redis.io/) is an advanced key-value cache",
+			},
+		},
+		// TODO (gfichtenholt) negative test
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			tarGzBytes, err := ioutil.ReadFile(tc.chartTarGz)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			// stand up an http server just for the duration of this test
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(200)
+				w.Write(tarGzBytes)
+			}))
+			defer ts.Close()
+
+			chartSpec := map[string]interface{}{
+				"chart": tc.chartName,
+				"sourceRef": map[string]interface{}{
+					"name": tc.repoName,
+					"kind": fluxHelmRepository,
+				},
+				"interval": "10m",
+			}
+			chartStatus := map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":   "Ready",
+						"status": "True",
+						"reason": "ChartPullSucceeded",
+					},
+				},
+				"url": ts.URL,
+			}
+			chart := newChart(tc.chartName, tc.repoNamespace, chartSpec, chartStatus)
+
+			s, _, mock, err := newServerWithCharts(chart)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			response, err := s.GetAvailablePackageDetail(context.Background(), tc.request)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			opt1 := cmpopts.IgnoreUnexported(corev1.AvailablePackageDetail{}, corev1.AvailablePackageReference{}, corev1.Context{})
+			opt2 := cmpopts.IgnoreFields(corev1.AvailablePackageDetail{}, "LongDescription", "Readme", "DefaultValues", "ValuesSchema")
+			if got, want := response.AvailablePackageDetail, tc.expectedPackageDetail; !cmp.Equal(got, want, opt1, opt2) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opt1, opt2))
+			}
+			if !strings.Contains(response.AvailablePackageDetail.LongDescription, tc.expectedPackageDetail.LongDescription) {
+				t.Errorf("substring mismatch (-want: %s\n+got: %s):\n", tc.expectedPackageDetail.LongDescription, response.AvailablePackageDetail.LongDescription)
+			}
+			if got, want := response.AvailablePackageDetail.Readme, response.AvailablePackageDetail.LongDescription; got != want {
+				t.Errorf("got readme: %q, want: %q", got, want)
+			}
+			if !strings.Contains(response.AvailablePackageDetail.DefaultValues, "## @section Global parameters") {
+				t.Errorf("unexpected default values: %q", response.AvailablePackageDetail.DefaultValues)
+			}
+
+			err = mock.ExpectationsWereMet()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+		})
+	}
+
+	negativeTestCases := []struct {
+		testName      string
+		request       *corev1.GetAvailablePackageDetailRequest
+		repoName      string
+		repoNamespace string
+		chartName     string
+		statusCode    codes.Code
+	}{
+		{
+			testName:      "it fails if request is missing namespace",
+			repoName:      "bitnami-1",
+			repoNamespace: "default",
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Identifier: "redis",
+				}},
+			chartName:  "redis",
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			testName:      "it fails if request has invalid identifier",
+			repoName:      "bitnami-1",
+			repoNamespace: "default",
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Identifier: "redis",
+					Context: &corev1.Context{
+						Namespace: "default",
+					},
+				}},
+			chartName:  "redis",
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			chartSpec := map[string]interface{}{
+				"chart": tc.chartName,
+				"sourceRef": map[string]interface{}{
+					"name": "does-not-matter-for-now",
+					"kind": "HelmRepository",
+				},
+				"interval": "10m",
+			}
+			chartStatus := map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":   "Ready",
+						"status": "True",
+						"reason": "ChartPullSucceeded",
+					},
+				},
+				"url": "does-not-matter",
+			}
+			chart := newChart(tc.chartName, tc.repoNamespace, chartSpec, chartStatus)
+			s, _, mock, err := newServerWithCharts(chart)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			_, err = s.GetAvailablePackageDetail(context.Background(), tc.request)
+			if err == nil {
+				t.Fatalf("got nil, want error")
+			}
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			err = mock.ExpectationsWereMet()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageDetailMatchesHelmChartVersion(t *testing.T) {
+	tarGzBytes, err := ioutil.ReadFile("testdata/redis-14.4.0.tgz")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var mutex sync.Mutex
+	requestedPaths := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		mutex.Unlock()
+		w.WriteHeader(200)
+		w.Write(tarGzBytes)
+	}))
+	defer ts.Close()
+
+	newPulledChart := func(name, chartName, version, path string) *unstructured.Unstructured {
+		chartSpec := map[string]interface{}{
+			"chart":     chartName,
+			"sourceRef": map[string]interface{}{"name": "bitnami-1", "kind": fluxHelmRepository},
+			"interval":  "10m",
+		}
+		if version != "" {
+			chartSpec["version"] = version
+		}
+		return newChart(name, "default", chartSpec, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True", "reason": "ChartPullSucceeded"},
+			},
+			"url": ts.URL + path,
+		})
+	}
+	charts := []runtime.Object{
+		newPulledChart("redis-latest", "redis", "*", "/redis-latest.tgz"),
+		newPulledChart("redis-14-4-0", "redis", "14.4.0", "/redis-14.4.0.tgz"),
+		newPulledChart("mariadb-14-4-0", "mariadb", "14.4.0", "/mariadb-14.4.0.tgz"),
+	}
+
+	testCases := []struct {
+		name          string
+		version       string
+		expectedPath  string
+		expectedCount int
+	}{
+		{
+			name:          "it uses the existing HelmChart of the latest version",
+			expectedPath:  "/redis-latest.tgz",
+			expectedCount: 3,
+		},
+		{
+			name:          "it uses the existing HelmChart of the requested version",
+			version:       "14.4.0",
+			expectedPath:  "/redis-14.4.0.tgz",
+			expectedCount: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mutex.Lock()
+			requestedPaths = []string{}
+			mutex.Unlock()
+			objects := []runtime.Object{}
+			for _, c := range charts {
+				objects = append(objects, c.DeepCopyObject())
+			}
+			s, dynamicClient := newServerWithoutCache(objects...)
+
+			_, err := s.GetAvailablePackageDetail(context.Background(), &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Identifier: "bitnami-1/redis",
+					Context:    &corev1.Context{Namespace: "default"},
+				},
+				PkgVersion: tc.version,
+			})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if got, want := requestedPaths, []string{tc.expectedPath}; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			chartList, err := dynamicClient.Resource(helmChartsGvr).Namespace("default").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := len(chartList.Items), tc.expectedCount; got != want {
+				t.Errorf("got: %d HelmCharts, want: %d", got, want)
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageDetailWaitsForExistingHelmChart(t *testing.T) {
+	tarGzBytes, err := ioutil.ReadFile("testdata/redis-14.4.0.tgz")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		w.Write(tarGzBytes)
+	}))
+	defer ts.Close()
+
+	chartSpec := map[string]interface{}{
+		"chart":     "redis",
+		"sourceRef": map[string]interface{}{"name": "bitnami-1", "kind": fluxHelmRepository},
+		"version":   "14.4.0",
+		"interval":  "10m",
+	}
+	// the chart is still being pulled by flux
+	pullingChart := newChart("redis-14-4-0", "default", chartSpec, nil)
+	s, dynamicClient := newServerWithoutCache(pullingChart)
+
+	watchers := make(chan *watch.FakeWatcher, 1)
+	dynamicClient.PrependWatchReactor("*", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watcher := watch.NewFake()
+		watchers <- watcher
+		return true, watcher, nil
+	})
+	go func() {
+		watcher := <-watchers
+		watcher.Modify(newChart("redis-14-4-0", "default", chartSpec, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True", "reason": "ChartPullSucceeded"},
+			},
+			"url": ts.URL,
+		}))
+	}()
+
+	response, err := s.GetAvailablePackageDetail(context.Background(), &corev1.GetAvailablePackageDetailRequest{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Identifier: "bitnami-1/redis",
+			Context:    &corev1.Context{Namespace: "default"},
+		},
+		PkgVersion: "14.4.0",
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := response.AvailablePackageDetail.PkgVersion, "14.4.0"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	chartList, err := dynamicClient.Resource(helmChartsGvr).Namespace("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := len(chartList.Items), 1; got != want {
+		t.Errorf("got: %d HelmCharts, want: %d", got, want)
+	}
+}
+
+func TestGetAvailablePackageDetailCachesChartDetail(t *testing.T) {
+	tarGzBytes, err := ioutil.ReadFile("testdata/redis-14.4.0.tgz")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var mutex sync.Mutex
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests++
+		mutex.Unlock()
+		w.WriteHeader(200)
+		w.Write(tarGzBytes)
+	}))
+	defer ts.Close()
+
+	const digest = "2b6b8c6f0c3bb3c1d1a9b1f1e84d7c7c1e2c1a5b3d0e3e2b8c1f0a9d8e7c6b5a"
+	newPulledChart := func(name, namespace string) *unstructured.Unstructured {
+		return newChart(name, namespace, map[string]interface{}{
+			"chart":     "redis",
+			"sourceRef": map[string]interface{}{"name": "bitnami-1", "kind": fluxHelmRepository},
+			"interval":  "10m",
+		}, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True", "reason": "ChartPullSucceeded"},
+			},
+			"artifact": map[string]interface{}{"checksum": digest, "revision": "14.4.0"},
+			"url":      ts.URL,
+		})
+	}
+	s, _ := newServerWithoutCache(newPulledChart("redis", "default"), newPulledChart("redis", "other"))
+	store, err := newLRUStore(0, 0)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	s.cache = &ResourceWatcherCache{store: store}
+
+	// the chart .tgz of the same digest is only fetched once, whichever HelmChart pulled it
+	for _, namespace := range []string{"default", "default", "other"} {
+		response, err := s.GetAvailablePackageDetail(context.Background(), &corev1.GetAvailablePackageDetailRequest{
+			AvailablePackageRef: &corev1.AvailablePackageReference{
+				Identifier: "bitnami-1/redis",
+				Context:    &corev1.Context{Namespace: namespace},
+			},
+		})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := response.AvailablePackageDetail.PkgVersion, "14.4.0"; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+		if response.AvailablePackageDetail.DefaultValues == "" {
+			t.Errorf("got empty default values")
+		}
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if got, want := requests, 1; got != want {
+		t.Errorf("got: %d requests, want: %d", got, want)
+	}
+	if _, found, _ := store.get(context.Background(), chartDetailCacheKey(digest)); !found {
+		t.Errorf("chart detail not found in cache")
+	}
+}
+
+func TestGetAvailablePackageDetailCachesChartDigestOfVersion(t *testing.T) {
+	tarGzBytes, err := ioutil.ReadFile("testdata/redis-14.4.0.tgz")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		w.Write(tarGzBytes)
+	}))
+	defer ts.Close()
+
+	const digest = "2b6b8c6f0c3bb3c1d1a9b1f1e84d7c7c1e2c1a5b3d0e3e2b8c1f0a9d8e7c6b5a"
+	pulledChart := newChart("redis-14-4-0", "default", map[string]interface{}{
+		"chart":     "redis",
+		"sourceRef": map[string]interface{}{"name": "bitnami-1", "kind": fluxHelmRepository},
+		"version":   "14.4.0",
+		"interval":  "10m",
+	}, map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True", "reason": "ChartPullSucceeded"},
+		},
+		"artifact": map[string]interface{}{"checksum": digest, "revision": "14.4.0"},
+		"url":      ts.URL,
+	})
+	repo := newRepo("bitnami-1", "default", map[string]interface{}{"url": "https://example.repo.com/charts"}, nil)
+	s, dynamicClient := newServerWithoutCache(pulledChart, repo)
+	store, err := newLRUStore(0, 0)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	s.cache = &ResourceWatcherCache{store: store}
+
+	request := &corev1.GetAvailablePackageDetailRequest{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Identifier: "bitnami-1/redis",
+			Context:    &corev1.Context{Namespace: "default"},
+		},
+		PkgVersion: "14.4.0",
+	}
+	if _, err = s.GetAvailablePackageDetail(context.Background(), request); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	// once the version was pulled, its detail is found without any HelmChart
+	err = dynamicClient.Resource(helmChartsGvr).Namespace("default").Delete(context.Background(), "redis-14-4-0", metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	dynamicClient.ClearActions()
+	response, err := s.GetAvailablePackageDetail(context.Background(), request)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := response.AvailablePackageDetail.PkgVersion, "14.4.0"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	// only the access of the user to the repository is checked
+	actions := dynamicClient.Actions()
+	if len(actions) != 1 || actions[0].GetVerb() != "get" || actions[0].GetResource() != helmRepositoriesGvr {
+		t.Errorf("got unexpected actions: %v", actions)
+	}
+
+	// a user who cannot access the repository does not get the cached detail
+	dynamicClient.PrependReactor("get", fluxHelmRepositories, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.NewForbidden(helmRepositoriesGvr.GroupResource(), "bitnami-1", fmt.Errorf("forbidden"))
+	})
+	_, err = s.GetAvailablePackageDetail(context.Background(), request)
+	if got, want := status.Code(err), codes.PermissionDenied; got != want {
+		t.Errorf("got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+}
+
+func TestGetAvailablePackageDetailReplacesFailedHelmChart(t *testing.T) {
+	tarGzBytes, err := ioutil.ReadFile("testdata/redis-14.4.0.tgz")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		w.Write(tarGzBytes)
+	}))
+	defer ts.Close()
+
+	chartSpec := map[string]interface{}{
+		"chart":     "redis",
+		"sourceRef": map[string]interface{}{"name": "bitnami-1", "kind": fluxHelmRepository},
+		"version":   "14.4.0",
+		"interval":  "10m",
+	}
+	failedStatus := map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False", "reason": "ChartPullFailed", "message": "failed to fetch index"},
+		},
+	}
+	pluginChart := newChart("redis-plugin", "default", chartSpec, failedStatus)
+	pluginChart.SetLabels(map[string]string{helmChartManagedByLabel: helmChartManagedByValue})
+	userChart := newChart("redis-user", "default", chartSpec, failedStatus)
+	s, dynamicClient := newServerWithoutCache(pluginChart, userChart)
+
+	watchers := make(chan *watch.FakeWatcher, 1)
+	dynamicClient.PrependWatchReactor("*", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watcher := watch.NewFake()
+		watchers <- watcher
+		return true, watcher, nil
+	})
+	go func() {
+		watcher := <-watchers
+		watcher.Modify(newChart("", "default", chartSpec, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True", "reason": "ChartPullSucceeded"},
+			},
+			"url": ts.URL,
+		}))
+	}()
+
+	response, err := s.GetAvailablePackageDetail(context.Background(), &corev1.GetAvailablePackageDetailRequest{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Identifier: "bitnami-1/redis",
+			Context:    &corev1.Context{Namespace: "default"},
+		},
+		PkgVersion: "14.4.0",
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if got, want := response.AvailablePackageDetail.PkgVersion, "14.4.0"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	// the failed HelmChart of the plugin is replaced by a new one, the user's one is kept
+	chartList, err := dynamicClient.Resource(helmChartsGvr).Namespace("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	names, managed := []string{}, 0
+	for _, c := range chartList.Items {
+		names = append(names, c.GetName())
+		if isPluginHelmChart(&c) {
+			managed++
+		}
+	}
+	sort.Strings(names)
+	if got, want := names, []string{"", "redis-user"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	if got, want := managed, 1; got != want {
+		t.Errorf("got: %d HelmCharts created by the plugin, want: %d", got, want)
+	}
+}
+
+func TestGetAvailablePackageDetailDeletesExpiredHelmCharts(t *testing.T) {
+	tarGzBytes, err := ioutil.ReadFile("testdata/redis-14.4.0.tgz")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		w.Write(tarGzBytes)
+	}))
+	defer ts.Close()
+
+	newPulledChart := func(name, chartName string, age time.Duration, managed bool) *unstructured.Unstructured {
+		c := newChart(name, "default", map[string]interface{}{
+			"chart":     chartName,
+			"sourceRef": map[string]interface{}{"name": "bitnami-1", "kind": fluxHelmRepository},
+			"interval":  "10m",
+		}, map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True", "reason": "ChartPullSucceeded"},
+			},
+			"url": ts.URL,
+		})
+		c.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-age)))
+		if managed {
+			c.SetLabels(map[string]string{helmChartManagedByLabel: helmChartManagedByValue})
+		}
+		return c
+	}
+	s, dynamicClient := newServerWithoutCache(
+		newPulledChart("mariadb-expired", "mariadb", 2*helmChartExpiration, true),
+		newPulledChart("mariadb-user", "mariadb", 2*helmChartExpiration, false),
+		newPulledChart("mariadb-recent", "mariadb", helmChartExpiration/2, true),
+		newPulledChart("redis", "redis", helmChartExpiration/2, true),
+	)
+
+	_, err = s.GetAvailablePackageDetail(context.Background(), &corev1.GetAvailablePackageDetailRequest{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Identifier: "bitnami-1/redis",
+			Context:    &corev1.Context{Namespace: "default"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	chartList, err := dynamicClient.Resource(helmChartsGvr).Namespace("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	names := []string{}
+	for _, c := range chartList.Items {
+		names = append(names, c.GetName())
+	}
+	sort.Strings(names)
+	if got, want := names, []string{"mariadb-recent", "mariadb-user", "redis"}; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestWatchInstalledPackages(t *testing.T) {
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			helmReleasesGvr: fluxHelmReleaseList,
+		})
+	watcher := watch.NewFake()
+	dynamicClient.Fake.PrependWatchReactor(fluxHelmReleases, k8stesting.DefaultWatchReactor(watcher, nil))
+	s := &Server{
+		clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
+			return nil, dynamicClient, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testWatchStream{ctx: ctx}
+	done := make(chan error)
+	go func() {
+		done <- s.WatchInstalledPackages(&corev1.WatchInstalledPackagesRequest{
+			Context: &corev1.Context{Namespace: "test"},
+		}, stream)
+	}()
+
+	spec := map[string]interface{}{
+		"chart": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"chart":   "redis",
+				"version": "14.4.0",
+			},
+		},
+	}
+	watcher.Add(newRelease("my-redis", "test", spec, nil))
+	watcher.Modify(newRelease("my-redis", "test", spec, map[string]interface{}{
+		"lastAppliedRevision": "14.4.0",
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True", "reason": "ReconciliationSucceeded"},
+		},
+	}))
+	watcher.Delete(newRelease("my-redis", "test", spec, map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False", "reason": "InstallFailed", "message": "install retries exhausted"},
+		},
+	}))
+	cancel()
+
+	if got, want := status.Code(<-done), codes.Canceled; got != want {
+		t.Fatalf("got: %+v, want: %+v", got, want)
+	}
+
+	summary := func(currentVersion string, pkgStatus *corev1.InstalledPackageStatus) *corev1.InstalledPackageSummary {
+		return &corev1.InstalledPackageSummary{
+			InstalledPackageRef: &corev1.InstalledPackageReference{
+				Context:    &corev1.Context{Namespace: "test"},
+				Identifier: "my-redis",
+			},
+			Name:                "my-redis",
+			PkgVersionReference: &corev1.VersionReference{Version: "14.4.0"},
+			CurrentPkgVersion:   currentVersion,
+			PkgDisplayName:      "redis",
+			Status:              pkgStatus,
+		}
+	}
+	expectedEvents := []*corev1.WatchInstalledPackagesResponse{
+		{
+			Type: corev1.WatchInstalledPackagesResponse_EVENT_TYPE_ADDED,
+			InstalledPackageSummary: summary("", &corev1.InstalledPackageStatus{
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+				UserReason: "Reconciling",
+			}),
+		},
+		{
+			Type: corev1.WatchInstalledPackagesResponse_EVENT_TYPE_MODIFIED,
+			InstalledPackageSummary: summary("14.4.0", &corev1.InstalledPackageStatus{
+				Ready:      true,
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+				UserReason: "ReconciliationSucceeded",
+			}),
+		},
+		{
+			Type: corev1.WatchInstalledPackagesResponse_EVENT_TYPE_DELETED,
+			InstalledPackageSummary: summary("", &corev1.InstalledPackageStatus{
+				Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+				UserReason: "install retries exhausted",
+			}),
+		},
+	}
+	opts := cmpopts.IgnoreUnexported(
+		corev1.WatchInstalledPackagesResponse{},
+		corev1.InstalledPackageSummary{},
+		corev1.InstalledPackageReference{},
+		corev1.InstalledPackageStatus{},
+		corev1.VersionReference{},
+		corev1.Context{},
+	)
+	if got, want := stream.events, expectedEvents; !cmp.Equal(want, got, opts) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+	}
+}
+
+func TestCreateInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name             string
+		request          *corev1.CreateInstalledPackageRequest
+		existingReleases []runtime.Object
+		expectedStatus   codes.Code
+		expectedSpec     map[string]interface{}
+	}{
+		{
+			name: "it creates a HelmRelease for the chart of the repository",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "flux-system"},
+					Identifier: "bitnami/redis",
+				},
+				TargetContext:       &corev1.Context{Namespace: "test"},
+				Name:                "my-redis",
+				PkgVersionReference: &corev1.VersionReference{Version: "~14.4"},
+				Values:              "replica:\n  replicaCount: 1\n",
+				ReconciliationOptions: &corev1.ReconciliationOptions{
+					Interval:           300,
+					ServiceAccountName: "flux-installer",
+				},
+			},
+			expectedStatus: codes.OK,
+			expectedSpec: map[string]interface{}{
+				"chart": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"chart":   "redis",
+						"version": "~14.4",
+						"sourceRef": map[string]interface{}{
+							"kind":      fluxHelmRepository,
+							"name":      "bitnami",
+							"namespace": "flux-system",
+						},
+					},
+				},
+				"interval":           "5m0s",
+				"releaseName":        "my-redis",
+				"targetNamespace":    "test",
+				"serviceAccountName": "flux-installer",
+				"suspend":            false,
+				"values": map[string]interface{}{
+					"replica": map[string]interface{}{"replicaCount": float64(1)},
+				},
+			},
+		},
+		{
+			name: "it creates a HelmRelease with the default interval",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "flux-system"},
+					Identifier: "bitnami/redis",
+				},
+				TargetContext: &corev1.Context{Namespace: "test"},
+				Name:          "my-redis",
+			},
+			expectedStatus: codes.OK,
+			expectedSpec: map[string]interface{}{
+				"chart": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"chart": "redis",
+						"sourceRef": map[string]interface{}{
+							"kind":      fluxHelmRepository,
+							"name":      "bitnami",
+							"namespace": "flux-system",
+						},
+					},
+				},
+				"interval":        defaultReleaseInterval,
+				"releaseName":     "my-redis",
+				"targetNamespace": "test",
+			},
+		},
+		{
+			name: "it returns an invalid argument error for an invalid identifier",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "flux-system"},
+					Identifier: "redis",
+				},
+				TargetContext: &corev1.Context{Namespace: "test"},
+				Name:          "my-redis",
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "it returns an invalid argument error without a name",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "flux-system"},
+					Identifier: "bitnami/redis",
+				},
+				TargetContext: &corev1.Context{Namespace: "test"},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "it returns an invalid argument error for invalid values",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "flux-system"},
+					Identifier: "bitnami/redis",
+				},
+				TargetContext: &corev1.Context{Namespace: "test"},
+				Name:          "my-redis",
+				Values:        "replicaCount: [1",
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "it returns an already exists error if the HelmRelease exists",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "flux-system"},
+					Identifier: "bitnami/redis",
+				},
+				TargetContext: &corev1.Context{Namespace: "test"},
+				Name:          "my-redis",
+			},
+			existingReleases: []runtime.Object{newRelease("my-redis", "test", nil, nil)},
+			expectedStatus:   codes.AlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, dynamicClient := newServerWithReleases(tc.existingReleases...)
+
+			response, err := s.CreateInstalledPackage(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			expectedRef := &corev1.InstalledPackageReference{
+				Context:    &corev1.Context{Namespace: "test"},
+				Identifier: "my-redis",
+			}
+			opts := cmpopts.IgnoreUnexported(corev1.InstalledPackageReference{}, corev1.Context{})
+			if got, want := response.GetInstalledPackageRef(), expectedRef; !cmp.Equal(want, got, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+			release, err := dynamicClient.Resource(helmReleasesGvr).Namespace("test").Get(context.Background(), "my-redis", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := release.Object["spec"], tc.expectedSpec; !cmp.Equal(want, got) {
+				t.Errorf("mismatch in spec (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestUpdateInstalledPackage(t *testing.T) {
+	existingSpec := map[string]interface{}{
+		"chart": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"chart":   "redis",
+				"version": "14.4.0",
+			},
+		},
+		"interval":           "1m",
+		"serviceAccountName": "flux-installer",
+		"suspend":            true,
+		"values": map[string]interface{}{
+			"replicaCount": float64(1),
+		},
+	}
 	testCases := []struct {
-		testName              string
-		request               *corev1.GetAvailablePackageDetailRequest
-		repoName              string
-		repoNamespace         string
-		chartName             string
-		chartTarGz            string
-		expectedPackageDetail *corev1.AvailablePackageDetail
+		name           string
+		request        *corev1.UpdateInstalledPackageRequest
+		expectedStatus codes.Code
+		expectedSpec   map[string]interface{}
 	}{
 		{
-			testName:      "it returns details about the redis package in bitnami repo",
-			repoName:      "bitnami-1",
-			repoNamespace: "default",
-			request: &corev1.GetAvailablePackageDetailRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Identifier: "bitnami-1/redis",
-					Context: &corev1.Context{
-						Namespace: "default",
+			name: "it updates the version and replaces the values",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "test"},
+					Identifier: "my-redis",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "14.5.0"},
+				Values:              "auth:\n  enabled: false\n",
+			},
+			expectedStatus: codes.OK,
+			expectedSpec: map[string]interface{}{
+				"chart": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"chart":   "redis",
+						"version": "14.5.0",
 					},
-				}},
-			chartName:  "redis",
-			chartTarGz: "testdata/redis-14.4.0.tgz",
-			expectedPackageDetail: &corev1.AvailablePackageDetail{
-				// TODO (gfichtenholt) other fields
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Identifier: "bitnami-1/redis",
-					Context: &corev1.Context{
-						Namespace: "default",
+				},
+				"interval":           "1m",
+				"serviceAccountName": "flux-installer",
+				"suspend":            true,
+				"values": map[string]interface{}{
+					"auth": map[string]interface{}{"enabled": false},
+				},
+			},
+		},
+		{
+			name: "it replaces the suspension and service account with the reconciliation options",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "test"},
+					Identifier: "my-redis",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{Interval: 300},
+			},
+			expectedStatus: codes.OK,
+			expectedSpec: map[string]interface{}{
+				"chart": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"chart":   "redis",
+						"version": "14.4.0",
 					},
 				},
-				Name:            "redis",
-				LongDescription: "Redis<sup>TM</sup> Chart packaged by Bitnami\n\n[Redis<sup>TM</sup>](http://redis.io/) is an advanced key-value cache",
+				"interval": "5m0s",
+				"suspend":  false,
 			},
 		},
-		// TODO (gfichtenholt) specific version
-		// TODO (gfichtenholt) negative test
+		{
+			name: "it suspends the reconciliation",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "test"},
+					Identifier: "my-redis",
+				},
+				ReconciliationOptions: &corev1.ReconciliationOptions{Suspend: true},
+			},
+			expectedStatus: codes.OK,
+			expectedSpec: map[string]interface{}{
+				"chart": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"chart":   "redis",
+						"version": "14.4.0",
+					},
+				},
+				"interval": "1m",
+				"suspend":  true,
+			},
+		},
+		{
+			name: "it returns a not found error if the HelmRelease does not exist",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "test"},
+					Identifier: "other-redis",
+				},
+			},
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name: "it returns an invalid argument error without a namespace",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Identifier: "my-redis",
+				},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.testName, func(t *testing.T) {
-			tarGzBytes, err := ioutil.ReadFile(tc.chartTarGz)
-			if err != nil {
-				t.Fatalf("%+v", err)
-			}
+		t.Run(tc.name, func(t *testing.T) {
+			s, dynamicClient := newServerWithReleases(newRelease("my-redis", "test", runtime.DeepCopyJSON(existingSpec), nil))
 
-			// stand up an http server just for the duration of this test
-			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(200)
-				w.Write(tarGzBytes)
-			}))
-			defer ts.Close()
+			_, err := s.UpdateInstalledPackage(context.Background(), tc.request)
 
-			chartSpec := map[string]interface{}{
-				"chart": tc.chartName,
-				"sourceRef": map[string]interface{}{
-					"name": tc.repoName,
-					"kind": fluxHelmRepository,
-				},
-				"interval": "10m",
-			}
-			chartStatus := map[string]interface{}{
-				"conditions": []interface{}{
-					map[string]interface{}{
-						"type":   "Ready",
-						"status": "True",
-						"reason": "ChartPullSucceeded",
-					},
-				},
-				"url": ts.URL,
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
 			}
-			chart := newChart(tc.chartName, tc.repoNamespace, chartSpec, chartStatus)
-
-			s, _, mock, err := newServerWithCharts(chart)
-			if err != nil {
-				t.Fatalf("%+v", err)
+			if tc.expectedStatus != codes.OK {
+				return
 			}
-
-			response, err := s.GetAvailablePackageDetail(context.Background(), tc.request)
+			release, err := dynamicClient.Resource(helmReleasesGvr).Namespace("test").Get(context.Background(), "my-redis", metav1.GetOptions{})
 			if err != nil {
 				t.Fatalf("%+v", err)
 			}
-
-			opt1 := cmpopts.IgnoreUnexported(corev1.AvailablePackageDetail{}, corev1.AvailablePackageReference{}, corev1.Context{})
-			opt2 := cmpopts.IgnoreFields(corev1.AvailablePackageDetail{}, "LongDescription")
-			if got, want := response.AvailablePackageDetail, tc.expectedPackageDetail; !cmp.Equal(got, want, opt1, opt2) {
-				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opt1, opt2))
-			}
-			if !strings.Contains(response.AvailablePackageDetail.LongDescription, tc.expectedPackageDetail.LongDescription) {
-				t.Errorf("substring mismatch (-want: %s\n+got: %s):\n", tc.expectedPackageDetail.LongDescription, response.AvailablePackageDetail.LongDescription)
-			}
-
-			err = mock.ExpectationsWereMet()
-			if err != nil {
-				t.Fatalf("%v", err)
+			if got, want := release.Object["spec"], tc.expectedSpec; !cmp.Equal(want, got) {
+				t.Errorf("mismatch in spec (-want +got):\n%s", cmp.Diff(want, got))
 			}
 		})
 	}
+}
 
-	negativeTestCases := []struct {
-		testName      string
-		request       *corev1.GetAvailablePackageDetailRequest
-		repoName      string
-		repoNamespace string
-		chartName     string
-		statusCode    codes.Code
+func TestDeleteInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *corev1.DeleteInstalledPackageRequest
+		expectedStatus codes.Code
 	}{
 		{
-			testName:      "it fails if request is missing namespace",
-			repoName:      "bitnami-1",
-			repoNamespace: "default",
-			request: &corev1.GetAvailablePackageDetailRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Identifier: "redis",
-				}},
-			chartName:  "redis",
-			statusCode: codes.InvalidArgument,
+			name: "it deletes the HelmRelease",
+			request: &corev1.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "test"},
+					Identifier: "my-redis",
+				},
+			},
+			expectedStatus: codes.OK,
 		},
 		{
-			testName:      "it fails if request has invalid identifier",
-			repoName:      "bitnami-1",
-			repoNamespace: "default",
-			request: &corev1.GetAvailablePackageDetailRequest{
-				AvailablePackageRef: &corev1.AvailablePackageReference{
-					Identifier: "redis",
-					Context: &corev1.Context{
-						Namespace: "default",
-					},
-				}},
-			chartName:  "redis",
-			statusCode: codes.InvalidArgument,
+			name: "it returns a not found error if the HelmRelease does not exist",
+			request: &corev1.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "test"},
+					Identifier: "other-redis",
+				},
+			},
+			expectedStatus: codes.NotFound,
 		},
 	}
 
-	for _, tc := range negativeTestCases {
-		t.Run(tc.testName, func(t *testing.T) {
-			chartSpec := map[string]interface{}{
-				"chart": tc.chartName,
-				"sourceRef": map[string]interface{}{
-					"name": "does-not-matter-for-now",
-					"kind": "HelmRepository",
-				},
-				"interval": "10m",
-			}
-			chartStatus := map[string]interface{}{
-				"conditions": []interface{}{
-					map[string]interface{}{
-						"type":   "Ready",
-						"status": "True",
-						"reason": "ChartPullSucceeded",
-					},
-				},
-				"url": "does-not-matter",
-			}
-			chart := newChart(tc.chartName, tc.repoNamespace, chartSpec, chartStatus)
-			s, _, mock, err := newServerWithCharts(chart)
-			if err != nil {
-				t.Fatalf("%+v", err)
-			}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, dynamicClient := newServerWithReleases(newRelease("my-redis", "test", nil, nil))
 
-			_, err = s.GetAvailablePackageDetail(context.Background(), tc.request)
-			if err == nil {
-				t.Fatalf("got nil, want error")
-			}
-			if got, want := status.Code(err), tc.statusCode; got != want {
+			_, err := s.DeleteInstalledPackage(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
 				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
 			}
-
-			err = mock.ExpectationsWereMet()
-			if err != nil {
-				t.Fatalf("%v", err)
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			_, err = dynamicClient.Resource(helmReleasesGvr).Namespace("test").Get(context.Background(), "my-redis", metav1.GetOptions{})
+			if !errors.IsNotFound(err) {
+				t.Errorf("got: %+v, want: not found error", err)
 			}
 		})
 	}
 }
 
+func TestGetInstalledPackageDetail(t *testing.T) {
+	spec := map[string]interface{}{
+		"chart": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"chart":   "redis",
+				"version": "~14.4",
+				"sourceRef": map[string]interface{}{
+					"kind":      fluxHelmRepository,
+					"name":      "bitnami",
+					"namespace": "flux-system",
+				},
+			},
+		},
+		"interval":           "5m",
+		"serviceAccountName": "flux-installer",
+		"values": map[string]interface{}{
+			"replicaCount": int64(2),
+		},
+	}
+	releaseStatus := map[string]interface{}{
+		"lastAppliedRevision": "14.4.0",
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False", "reason": "UpgradeFailed", "message": "upgrade retries exhausted"},
+		},
+	}
+	s, _ := newServerWithReleases(newRelease("my-redis", "test", spec, releaseStatus))
+
+	response, err := s.GetInstalledPackageDetail(context.Background(), &corev1.GetInstalledPackageDetailRequest{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Namespace: "test"},
+			Identifier: "my-redis",
+		},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expectedDetail := &corev1.InstalledPackageDetail{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Namespace: "test"},
+			Identifier: "my-redis",
+		},
+		PkgVersionReference: &corev1.VersionReference{Version: "~14.4"},
+		Name:                "my-redis",
+		CurrentPkgVersion:   "14.4.0",
+		ValuesApplied:       "replicaCount: 2\n",
+		ReconciliationOptions: &corev1.ReconciliationOptions{
+			Interval:           300,
+			ServiceAccountName: "flux-installer",
+		},
+		Status: &corev1.InstalledPackageStatus{
+			Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+			UserReason: "upgrade retries exhausted",
+		},
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Namespace: "flux-system"},
+			Identifier: "bitnami/redis",
+			Plugin:     GetPluginDetail(),
+		},
+	}
+	opts := cmpopts.IgnoreUnexported(
+		corev1.InstalledPackageDetail{},
+		corev1.InstalledPackageReference{},
+		corev1.AvailablePackageReference{},
+		corev1.InstalledPackageStatus{},
+		corev1.ReconciliationOptions{},
+		corev1.VersionReference{},
+		corev1.Context{},
+		plugins.Plugin{},
+	)
+	if got, want := response.GetInstalledPackageDetail(), expectedDetail; !cmp.Equal(want, got, opts) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+	}
+}
+
 //
 // utilities
 //
@@ -1045,6 +2915,29 @@ func newChart(name string, namespace string, spec map[string]interface{}, status
 	}
 }
 
+func newRelease(name string, namespace string, spec map[string]interface{}, status map[string]interface{}) *unstructured.Unstructured {
+	release := newChart(name, namespace, spec, status)
+	release.SetAPIVersion(fmt.Sprintf("%s/%s", fluxHelmReleaseGroup, fluxHelmReleaseVersion))
+	release.SetKind(fluxHelmRelease)
+	return release
+}
+
+// testWatchStream is a fake stream recording the events sent by the plugin.
+type testWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*corev1.WatchInstalledPackagesResponse
+}
+
+func (s *testWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testWatchStream) Send(event *corev1.WatchInstalledPackagesResponse) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
 func newServer(clientGetter server.KubernetesClientGetter) (*Server, redismock.ClientMock, error) {
 	redisCli, mock := redismock.NewClientMock()
 	if clientGetter != nil {
@@ -1075,14 +2968,20 @@ func newServer(clientGetter server.KubernetesClientGetter) (*Server, redismock.C
 }
 
 func newServerWithRepos(repos ...runtime.Object) (*Server, *fake.FakeDynamicClient, redismock.ClientMock, error) {
-	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(
+	return newServerWithDynamicClient(newRepositoriesDynamicClient(repos...))
+}
+
+func newRepositoriesDynamicClient(repos ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(
 		runtime.NewScheme(),
 		map[schema.GroupVersionResource]string{
 			{Group: fluxGroup, Version: fluxVersion, Resource: fluxHelmRepositories}: fluxHelmRepositoryList,
 		},
 		repos...)
+}
 
-	clientGetter := func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+func newServerWithDynamicClient(dynamicClient *fake.FakeDynamicClient) (*Server, *fake.FakeDynamicClient, redismock.ClientMock, error) {
+	clientGetter := func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 		return nil, dynamicClient, nil
 	}
 
@@ -1090,14 +2989,16 @@ func newServerWithRepos(repos ...runtime.Object) (*Server, *fake.FakeDynamicClie
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	// the redis mock can't be used concurrently, so the expectations of the tests are only
+	// set once the repositories have been listed
+	if err = waitForCacheSync(s.cache); err != nil {
+		return nil, nil, nil, err
+	}
 	return s, dynamicClient, mock, nil
 }
 
 func newServerWithWatcher(expectNil bool, repos ...runtime.Object) (*Server, redismock.ClientMock, *watch.FakeWatcher, error) {
-	s, dynamicClient, mock, err := newServerWithRepos(repos...)
-	if err != nil {
-		return s, mock, nil, err
-	}
+	dynamicClient := newRepositoriesDynamicClient(repos...)
 
 	// this is so we can emulate actual k8s server firing events
 	// see https://github.com/kubernetes/kubernetes/issues/54075 for explanation
@@ -1107,6 +3008,11 @@ func newServerWithWatcher(expectNil bool, repos ...runtime.Object) (*Server, red
 		"*",
 		k8stesting.DefaultWatchReactor(watcher, nil))
 
+	s, _, mock, err := newServerWithDynamicClient(dynamicClient)
+	if err != nil {
+		return s, mock, nil, err
+	}
+
 	mock.MatchExpectationsInOrder(false)
 	// first we need to mock all the SETs and only then all the GETs, otherwise
 	// redismock throws a fit
@@ -1130,7 +3036,11 @@ func newServerWithWatcher(expectNil bool, repos ...runtime.Object) (*Server, red
 			}
 			mapVals[key] = bytes
 			mock.ExpectSet(key, bytes, 0).SetVal("")
+		}
 
+		// the events are only fired once all the SETs are mocked, since the redis mock
+		// can't be used concurrently
+		for _, r := range repos {
 			// fire an ADD event for this repo as k8s server would do
 			watcher.Add(r)
 		}
@@ -1165,15 +3075,42 @@ func newServerWithWatcher(expectNil bool, repos ...runtime.Object) (*Server, red
 	return s, mock, watcher, nil
 }
 
+// newServerWithReleases returns a server, without cache, whose dynamic
+// client has the HelmReleases.
+func newServerWithReleases(releases ...runtime.Object) (*Server, *fake.FakeDynamicClient) {
+	return newServerWithoutCache(releases...)
+}
+
+// newServerWithoutCache returns a server, without cache, whose dynamic
+// client has the flux objects.
+func newServerWithoutCache(objects ...runtime.Object) (*Server, *fake.FakeDynamicClient) {
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			helmReleasesGvr:     fluxHelmReleaseList,
+			helmRepositoriesGvr: fluxHelmRepositoryList,
+			helmChartsGvr:       fluxHelmChartList,
+		},
+		objects...)
+	s := &Server{
+		clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
+			return nil, dynamicClient, nil
+		},
+	}
+	return s, dynamicClient
+}
+
 func newServerWithCharts(charts ...runtime.Object) (*Server, *fake.FakeDynamicClient, redismock.ClientMock, error) {
 	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(
 		runtime.NewScheme(),
 		map[schema.GroupVersionResource]string{
 			{Group: fluxGroup, Version: fluxVersion, Resource: fluxHelmCharts}: fluxHelmChartList,
+			// the cache lists the repositories when it starts
+			helmRepositoriesGvr: fluxHelmRepositoryList,
 		},
 		charts...)
 
-	clientGetter := func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+	clientGetter := func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 		return nil, dynamicClient, nil
 	}
 
@@ -1181,9 +3118,86 @@ func newServerWithCharts(charts ...runtime.Object) (*Server, *fake.FakeDynamicCl
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	// the redis mock can't be used concurrently, so the expectations of the tests are only
+	// set once the repositories have been listed
+	if err = waitForCacheSync(s.cache); err != nil {
+		return nil, nil, nil, err
+	}
 	return s, dynamicClient, mock, nil
 }
 
+// waitForCacheSync waits until the cache has listed the objects
+func waitForCacheSync(c *ResourceWatcherCache) error {
+	for i := 0; i < 100; i++ {
+		if c.status().synced {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("timed out waiting for the cache to sync")
+}
+
+// waitForWatch returns the next watcher, which is created once the objects are listed
+func waitForWatch(t *testing.T, watchers <-chan *watch.FakeWatcher) *watch.FakeWatcher {
+	select {
+	case watcher := <-watchers:
+		return watcher
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for the watch")
+		return nil
+	}
+}
+
+func newRepoWithResourceVersion(name, resourceVersion string) *unstructured.Unstructured {
+	repo := newRepo(name, "default", map[string]interface{}{"url": "https://example.repo.com/charts"}, nil)
+	repo.SetResourceVersion(resourceVersion)
+	return repo
+}
+
+// newTestCache returns a cache, whose watcher is not started, of the repositories
+// with hooks storing their resource version. The returned func returns the keys of
+// the values computed by the hooks.
+func newTestCache(store keyValueStore, repos ...runtime.Object) (*ResourceWatcherCache, func() []string) {
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			helmRepositoriesGvr: fluxHelmRepositoryList,
+		},
+		repos...)
+
+	var mutex sync.Mutex
+	indexed := []string{}
+	onAddOrModify := func(key string, unstructuredRepo map[string]interface{}) (interface{}, bool, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		indexed = append(indexed, key)
+		resourceVersion, _, _ := unstructured.NestedString(unstructuredRepo, "metadata", "resourceVersion")
+		return []byte(resourceVersion), true, nil
+	}
+	c := &ResourceWatcherCache{
+		config: cacheConfig{
+			gvr: helmRepositoriesGvr,
+			clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
+				return nil, dynamicClient, nil
+			},
+			resyncPeriod: defaultCacheResyncPeriod,
+			onAdd:        onAddOrModify,
+			onModify:     onAddOrModify,
+			onGet: func(key string, value interface{}) (interface{}, error) {
+				return value, nil
+			},
+			onDelete: onDeleteRepo,
+		},
+		store:            store,
+		resourceVersions: make(map[string]string),
+	}
+	return c, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return indexed
+	}
+}
+
 func redisKeyForRuntimeObject(r runtime.Object) string {
 	// redis convention on key format
 	// https://redis.io/topics/data-types-intro
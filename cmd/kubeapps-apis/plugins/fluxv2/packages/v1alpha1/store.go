@@ -0,0 +1,264 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// redisCacheBackend stores the cached values in a redis server, which can
+	// be shared by several replicas of kubeapps-apis.
+	redisCacheBackend = "redis"
+	// memoryCacheBackend stores the cached values in the process, which is
+	// enough for a single replica of kubeapps-apis.
+	memoryCacheBackend = "memory"
+
+	// defaultMemoryCacheMaxBytes is the size of the values kept by the
+	// in-memory cache when no limit is configured.
+	defaultMemoryCacheMaxBytes = 64 * 1024 * 1024
+)
+
+// cacheSettings select and configure the backend of the cache. The redis
+// backend is used by default.
+type cacheSettings struct {
+	Backend string `json:"backend,omitempty"`
+	// MaxEntries is the number of values kept by the memory backend, 0 meaning
+	// no limit on the number of values.
+	MaxEntries int `json:"maxEntries,omitempty"`
+	// MaxBytes is the size of the keys and values kept by the memory backend.
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+	// ResyncPeriod is the period, such as "10m", with which the watched
+	// objects are listed again.
+	ResyncPeriod string `json:"resyncPeriod,omitempty"`
+}
+
+// resyncPeriod returns the resync period of the settings, 0 meaning the
+// default one.
+func (settings cacheSettings) resyncPeriod() (time.Duration, error) {
+	if settings.ResyncPeriod == "" {
+		return 0, nil
+	}
+	period, err := time.ParseDuration(settings.ResyncPeriod)
+	if err != nil || period <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid cache resync period: [%s]", settings.ResyncPeriod)
+	}
+	return period, nil
+}
+
+// keyValueStore is the storage of the values of the cache. A missing key is
+// not an error.
+type keyValueStore interface {
+	get(ctx context.Context, key string) ([]byte, bool, error)
+	// set sets the value of the key, which expires after the expiration when
+	// not 0.
+	set(ctx context.Context, key string, value []byte, expiration time.Duration) error
+	del(ctx context.Context, key string) error
+	// keys returns the keys with the prefix
+	keys(ctx context.Context, prefix string) ([]string, error)
+	ping(ctx context.Context) error
+}
+
+// newKeyValueStore returns the store of the backend selected by the settings.
+func newKeyValueStore(settings cacheSettings, redisConfig redisSettings) (keyValueStore, error) {
+	switch settings.Backend {
+	case "", redisCacheBackend:
+		options, err := redisOptions(redisConfig)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("newKeyValueStore: redis addr: [%s], DB=[%d]", options.Addr, options.DB)
+		return newRedisStore(redis.NewClient(options))
+	case memoryCacheBackend:
+		log.Infof("newKeyValueStore: memory maxEntries: [%d], maxBytes=[%d]", settings.MaxEntries, settings.MaxBytes)
+		return newLRUStore(settings.MaxEntries, settings.MaxBytes)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported cache backend: [%s]", settings.Backend)
+	}
+}
+
+// redisStore stores the values in a redis server.
+type redisStore struct {
+	client *redis.Client
+}
+
+func newRedisStore(client *redis.Client) (*redisStore, error) {
+	if client == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "server not configured with redis Client")
+	}
+	return &redisStore{client: client}, nil
+}
+
+func (s *redisStore) get(ctx context.Context, key string) ([]byte, bool, error) {
+	// all results of the redis go client are returned as strings, which are
+	// converted to bytes here.
+	bytes, err := s.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		// this is normal if the key does not exist
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return bytes, true, nil
+}
+
+func (s *redisStore) set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	// Zero expiration means the key has no expiration time.
+	return s.client.Set(ctx, key, value, expiration).Err()
+}
+
+func (s *redisStore) del(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
+
+func (s *redisStore) keys(ctx context.Context, prefix string) ([]string, error) {
+	// SCAN, unlike KEYS, does not block the redis server while iterating
+	keys := []string{}
+	iter := s.client.Scan(ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (s *redisStore) ping(ctx context.Context) error {
+	if err := s.client.Ping(ctx).Err(); err != nil {
+		return status.Errorf(codes.Unavailable, "unable to reach redis: %v", err)
+	}
+	return nil
+}
+
+// lruStore stores the values in the process, evicting the least recently
+// used ones beyond its limits.
+type lruStore struct {
+	maxEntries int
+	maxBytes   int64
+
+	// mutex guards the fields below
+	mutex sync.Mutex
+	bytes int64
+	// entries has the most recently used entry at the front
+	entries *list.List
+	items   map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+	// expiresAt is zero when the entry does not expire
+	expiresAt time.Time
+}
+
+func (e *lruEntry) expired() bool {
+	return !e.expiresAt.IsZero() && time.Now().After(e.expiresAt)
+}
+
+func newLRUStore(maxEntries int, maxBytes int64) (*lruStore, error) {
+	if maxEntries < 0 || maxBytes < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memory cache limits: maxEntries [%d], maxBytes [%d]", maxEntries, maxBytes)
+	}
+	if maxBytes == 0 {
+		maxBytes = defaultMemoryCacheMaxBytes
+	}
+	return &lruStore{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    list.New(),
+		items:      make(map[string]*list.Element),
+	}, nil
+}
+
+func (s *lruStore) get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	element, ok := s.items[key]
+	if !ok {
+		return nil, false, nil
+	} else if element.Value.(*lruEntry).expired() {
+		s.removeElement(element)
+		return nil, false, nil
+	}
+	s.entries.MoveToFront(element)
+	return element.Value.(*lruEntry).value, true, nil
+}
+
+func (s *lruStore) set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	size := entrySize(key, value)
+	if size > s.maxBytes {
+		return status.Errorf(codes.ResourceExhausted, "value for key [%s] of %d bytes exceeds the cache size of %d bytes", key, size, s.maxBytes)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if element, ok := s.items[key]; ok {
+		s.removeElement(element)
+	}
+	entry := &lruEntry{key: key, value: value}
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+	s.items[key] = s.entries.PushFront(entry)
+	s.bytes += size
+	for s.bytes > s.maxBytes || (s.maxEntries > 0 && s.entries.Len() > s.maxEntries) {
+		s.removeElement(s.entries.Back())
+	}
+	return nil
+}
+
+func (s *lruStore) del(ctx context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if element, ok := s.items[key]; ok {
+		s.removeElement(element)
+	}
+	return nil
+}
+
+func (s *lruStore) keys(ctx context.Context, prefix string) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	keys := []string{}
+	for key, element := range s.items {
+		if strings.HasPrefix(key, prefix) && !element.Value.(*lruEntry).expired() {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *lruStore) ping(ctx context.Context) error {
+	return nil
+}
+
+// removeElement is expected to be called with the mutex held.
+func (s *lruStore) removeElement(element *list.Element) {
+	entry := s.entries.Remove(element).(*lruEntry)
+	delete(s.items, entry.key)
+	s.bytes -= entrySize(entry.key, entry.value)
+}
+
+func entrySize(key string, value []byte) int64 {
+	return int64(len(key) + len(value))
+}
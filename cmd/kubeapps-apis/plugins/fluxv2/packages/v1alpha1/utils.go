@@ -13,8 +13,10 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
@@ -22,9 +24,12 @@ import (
 	chart "github.com/kubeapps/kubeapps/pkg/chart/models"
 	"github.com/kubeapps/kubeapps/pkg/helm"
 	httpclient "github.com/kubeapps/kubeapps/pkg/http-client"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	log "k8s.io/klog/v2"
@@ -136,11 +141,20 @@ func newPackageRepository(unstructuredRepo map[string]interface{}) (*v1alpha1.Pa
 			codes.Internal,
 			"required field spec.url not found on HelmRepository: %v:\n%s", err, prettyPrintMap(unstructuredRepo))
 	}
-	return &v1alpha1.PackageRepository{
+	repo := &v1alpha1.PackageRepository{
 		Name:      name,
 		Namespace: namespace,
 		Url:       url,
-	}, nil
+		Status:    packageRepositoryStatus(unstructuredRepo),
+	}
+	if interval, found, err := unstructured.NestedString(unstructuredRepo, "spec", "interval"); err == nil && found {
+		if duration, err := time.ParseDuration(interval); err == nil {
+			repo.Interval = int32(duration.Seconds())
+		}
+	}
+	repo.SecretRef, _, _ = unstructured.NestedString(unstructuredRepo, "spec", "secretRef", "name")
+	repo.Suspend, _, _ = unstructured.NestedBool(unstructuredRepo, "spec", "suspend")
+	return repo, nil
 }
 
 // implements plug-in specific cache-related functionality
@@ -189,3 +203,33 @@ func onGetRepo(key string, value interface{}) (interface{}, error) {
 func onDeleteRepo(key string, unstructuredRepo map[string]interface{}) (bool, error) {
 	return true, nil
 }
+
+const (
+	// the response headers with the status of the cache of the packages
+	cacheStaleHeader    = "kubeapps-fluxv2-cache-stale"
+	cacheLastSyncHeader = "kubeapps-fluxv2-cache-last-sync"
+)
+
+func packageCacheStatus(cacheStatus cacheStatus) *v1alpha1.PackageCacheStatus {
+	pkgCacheStatus := &v1alpha1.PackageCacheStatus{
+		Synced: cacheStatus.synced,
+		Stale:  cacheStatus.stale,
+	}
+	if cacheStatus.synced {
+		pkgCacheStatus.LastSyncTime = timestamppb.New(cacheStatus.lastSyncTime)
+	}
+	return pkgCacheStatus
+}
+
+// setCacheStatusHeader reports the status of the cache in the headers of the
+// response, since the core responses have no field for it.
+func setCacheStatusHeader(ctx context.Context, cacheStatus cacheStatus) {
+	header := metadata.Pairs(cacheStaleHeader, strconv.FormatBool(cacheStatus.stale))
+	if cacheStatus.synced {
+		header.Set(cacheLastSyncHeader, cacheStatus.lastSyncTime.UTC().Format(time.RFC3339))
+	}
+	if err := grpc.SetHeader(ctx, header); err != nil {
+		// this is normal when not called by a grpc server, e.g. in unit tests
+		log.V(4).Infof("unable to set the cache status header due to: %v", err)
+	}
+}
@@ -0,0 +1,380 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/containerd/containerd/remotes/docker"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/helm"
+	httpclient "github.com/kubeapps/kubeapps/pkg/http-client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/credentialprovider"
+)
+
+// The installation of a chart from its URL must be allowed to the user in the
+// target namespace, as the verb of this resource, which only exists for RBAC:
+//
+//   rules:
+//     - apiGroups: ["kubeapps.com"]
+//       resources: ["charturls"]
+//       verbs: ["install"]
+const (
+	chartURLInstallGroup    = "kubeapps.com"
+	chartURLInstallResource = "charturls"
+	chartURLInstallVerb     = "install"
+)
+
+// digestAlgorithm is the only algorithm of the digests pinning the charts.
+const digestAlgorithm = "sha256"
+
+// defaultChartArchiveMaxBytes is the size of the chart archives fetched from
+// their URL when no limit is configured.
+const defaultChartArchiveMaxBytes = 20 * 1024 * 1024
+
+// chartURLPolicy is the policy of the installations of charts from their
+// URL, in the plugin settings. They are refused unless enabled.
+type chartURLPolicy struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// AllowedURLPrefixes restricts the chart URLs to those starting with one
+	// of the prefixes, when not empty.
+	AllowedURLPrefixes []string `json:"allowedURLPrefixes,omitempty"`
+	// RequireDigest refuses the chart URLs without a digest.
+	RequireDigest bool `json:"requireDigest,omitempty"`
+	// MaxArchiveBytes is the size of the chart archives which can be fetched,
+	// 20MiB by default.
+	MaxArchiveBytes int64 `json:"maxArchiveBytes,omitempty"`
+}
+
+// maxArchiveBytes returns the size of the chart archives allowed by the
+// policy.
+func (p *chartURLPolicy) maxArchiveBytes() int64 {
+	if p.MaxArchiveBytes <= 0 {
+		return defaultChartArchiveMaxBytes
+	}
+	return p.MaxArchiveBytes
+}
+
+// chartURL parses the chart URL of a request, checking that the policy
+// allows it together with the digest. The path of the returned URL is
+// cleaned, so that it is the one which was checked against the policy.
+func (p *chartURLPolicy) chartURL(rawURL, digest string) (*url.URL, error) {
+	if !p.Enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "The installation of charts from their URL is not enabled")
+	}
+	chartURL, err := parseChartURL(rawURL)
+	if err != nil || (chartURL.Scheme != "oci" && chartURL.Scheme != "https") || chartURL.Host == "" {
+		// the unparsable URL is not returned, as it may contain credentials
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse the chart URL: an oci or https URL is required")
+	}
+	if len(p.AllowedURLPrefixes) > 0 {
+		allowed := false
+		for _, prefix := range p.AllowedURLPrefixes {
+			if urlHasPrefix(chartURL, prefix) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, status.Errorf(codes.PermissionDenied, "The chart URL %q is not allowed by the plugin settings", chartURL.Redacted())
+		}
+	}
+	if digest == "" {
+		if p.RequireDigest {
+			return nil, status.Errorf(codes.InvalidArgument, "Required digest of the chart %q not provided", chartURL.Redacted())
+		}
+		return chartURL, nil
+	}
+	if err = validateDigest(digest); err != nil {
+		return nil, err
+	}
+	return chartURL, nil
+}
+
+// parseChartURL parses the URL, cleaning its path of any "." or ".."
+// segments and lower-casing its scheme and host.
+func parseChartURL(rawURL string) (*url.URL, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	parsedURL.Scheme = strings.ToLower(parsedURL.Scheme)
+	parsedURL.Host = strings.ToLower(parsedURL.Host)
+	if parsedURL.Path != "" {
+		parsedURL.Path = path.Clean("/" + parsedURL.Path)
+	}
+	// the escaped path would otherwise be used in place of the cleaned one
+	parsedURL.RawPath = ""
+	return parsedURL, nil
+}
+
+// urlHasPrefix returns whether the parsed chart URL has the scheme and host
+// of the allowed prefix and a path starting with all the segments of its
+// path, so that neither "https://charts.example.com.attacker.io" nor
+// "https://charts.example.com/charts-other" have the prefix
+// "https://charts.example.com/charts".
+func urlHasPrefix(chartURL *url.URL, prefix string) bool {
+	prefixURL, err := parseChartURL(prefix)
+	if err != nil {
+		log.Errorf("Unable to parse the allowed chart URL prefix %q: %v", prefix, err)
+		return false
+	}
+	if chartURL.Scheme != prefixURL.Scheme || chartURL.Host != prefixURL.Host {
+		return false
+	}
+	prefixSegments := pathSegments(prefixURL.Path)
+	urlSegments := pathSegments(chartURL.Path)
+	if len(urlSegments) < len(prefixSegments) {
+		return false
+	}
+	for i, segment := range prefixSegments {
+		if urlSegments[i] != segment {
+			return false
+		}
+	}
+	return true
+}
+
+// pathSegments returns the non-empty segments of the cleaned path.
+func pathSegments(cleanedPath string) []string {
+	segments := []string{}
+	for _, segment := range strings.Split(cleanedPath, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// validateDigest returns an error unless the digest is a sha256 digest.
+func validateDigest(digest string) error {
+	hexDigest := strings.TrimPrefix(digest, digestAlgorithm+":")
+	if decoded, err := hex.DecodeString(hexDigest); hexDigest == digest || err != nil || len(decoded) != sha256.Size {
+		return status.Errorf(codes.InvalidArgument, "Unable to parse the digest %q: a %s digest is required", digest, digestAlgorithm)
+	}
+	return nil
+}
+
+// archiveDigest returns the digest of the chart archive.
+func archiveDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%s:%s", digestAlgorithm, hex.EncodeToString(sum[:]))
+}
+
+// chartURLCredentials are the credentials fetching a chart from its URL.
+type chartURLCredentials struct {
+	username string
+	password string
+}
+
+// chartURLFetcher fetches the archive of a chart from its URL, returning it
+// together with its digest. It is switched in tests for a fake one.
+type chartURLFetcher interface {
+	FetchChartArchive(chartURL *url.URL, digest string, credentials *chartURLCredentials) ([]byte, string, error)
+}
+
+// remoteChartURLFetcher fetches the charts from OCI registries and HTTPS
+// servers.
+type remoteChartURLFetcher struct {
+	userAgent string
+	// maxBytes is the size of the archives which can be fetched
+	maxBytes int64
+}
+
+// FetchChartArchive pulls an OCI reference, by digest if any, returning the
+// digest of its manifest, or downloads the archive of an https URL,
+// returning the digest of the archive.
+func (f *remoteChartURLFetcher) FetchChartArchive(chartURL *url.URL, digest string, credentials *chartURLCredentials) ([]byte, string, error) {
+	if chartURL.Scheme == "oci" {
+		ref := path.Join(chartURL.Host, chartURL.Path)
+		if digest != "" {
+			ref = fmt.Sprintf("%s@%s", ref, digest)
+		}
+		puller := &helm.OCIPuller{Resolver: docker.NewResolver(docker.ResolverOptions{
+			Headers: http.Header{"User-Agent": []string{f.userAgent}},
+			Client:  httpclient.New(),
+			Credentials: func(string) (string, string, error) {
+				if credentials == nil {
+					return "", "", nil
+				}
+				return credentials.username, credentials.password, nil
+			},
+		})}
+		chartBuffer, manifestDigest, err := puller.PullOCIChart(ref)
+		if err != nil {
+			return nil, "", err
+		}
+		if int64(chartBuffer.Len()) > f.maxBytes {
+			return nil, "", fmt.Errorf("chart archive of [%s] exceeds the size of %d bytes", chartURL.Redacted(), f.maxBytes)
+		}
+		return chartBuffer.Bytes(), manifestDigest, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, chartURL.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	if credentials != nil {
+		req.SetBasicAuth(credentials.username, credentials.password)
+	}
+	res, err := httpclient.New().Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("GET request to [%s] failed due to status [%d]", chartURL.Redacted(), res.StatusCode)
+	}
+	if res.ContentLength > f.maxBytes {
+		return nil, "", fmt.Errorf("chart archive of [%s] exceeds the size of %d bytes", chartURL.Redacted(), f.maxBytes)
+	}
+	// one more byte than allowed is read to detect the archives exceeding
+	// the limit without a Content-Length
+	data, err := io.ReadAll(io.LimitReader(res.Body, f.maxBytes+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > f.maxBytes {
+		return nil, "", fmt.Errorf("chart archive of [%s] exceeds the size of %d bytes", chartURL.Redacted(), f.maxBytes)
+	}
+	return data, archiveDigest(data), nil
+}
+
+// CreateInstalledPackageFromURL installs the chart of an explicit URL, when
+// the plugin settings enable it and the user is allowed to in the target
+// namespace.
+func (s *Server) CreateInstalledPackageFromURL(ctx context.Context, request *v1alpha1.CreateInstalledPackageFromURLRequest) (*corev1.CreateInstalledPackageResponse, error) {
+	targetContext := request.GetTargetContext()
+	if targetContext.GetNamespace() == "" || request.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Required target namespace or name not provided")
+	}
+	chartURL, err := s.chartURLPolicy.chartURL(request.GetChartUrl(), request.GetDigest())
+	if err != nil {
+		return nil, err
+	}
+	// the chart URL is only logged redacted, as it may contain credentials
+	log.Infof("+helm CreateInstalledPackageFromURL (cluster=[%s], namespace=[%s], name=[%s], chartURL=[%s], digest=[%s])", targetContext.GetCluster(), targetContext.GetNamespace(), request.GetName(), chartURL.Redacted(), request.GetDigest())
+	if err = s.canInstallFromChartURL(ctx, targetContext.GetCluster(), targetContext.GetNamespace()); err != nil {
+		return nil, err
+	}
+	credentials, err := s.chartURLCredentials(ctx, targetContext.GetCluster(), targetContext.GetNamespace(), request.GetCredentialsSecretName(), chartURL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.chartURLFetcher == nil {
+		return nil, status.Errorf(codes.Internal, "server not configured with chartURLFetcher")
+	}
+	data, digest, err := s.chartURLFetcher.FetchChartArchive(chartURL, request.GetDigest(), credentials)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to fetch the chart %q: %v", chartURL.Redacted(), err)
+	}
+	if request.GetDigest() != "" && digest != request.GetDigest() {
+		return nil, status.Errorf(codes.FailedPrecondition, "Unable to install the chart %q: its digest %q is not the digest %q", chartURL.Redacted(), digest, request.GetDigest())
+	}
+	ch, err := loader.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to load the chart %q: %v", chartURL.Redacted(), err)
+	}
+	if err = validateChartValues(ch, request.GetValues()); err != nil {
+		return nil, err
+	}
+	return s.createRelease(ctx, targetContext, request.GetName(), request.GetValues(), ch, nil)
+}
+
+// canInstallFromChartURL returns an error if the user is not allowed by RBAC
+// to install charts from their URL in the namespace.
+func (s *Server) canInstallFromChartURL(ctx context.Context, cluster, namespace string) error {
+	typedClient, _, err := s.GetClients(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	res, err := typedClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:     chartURLInstallGroup,
+				Resource:  chartURLInstallResource,
+				Verb:      chartURLInstallVerb,
+				Namespace: namespace,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return status.Errorf(codes.Internal, "Unable to check if the user can install charts from their URL: %v", err)
+	}
+	if !res.Status.Allowed {
+		return status.Errorf(codes.PermissionDenied, "The current user is not allowed to install charts from their URL in the namespace %q", namespace)
+	}
+	return nil
+}
+
+// chartURLCredentials returns the credentials of the secret for the host of
+// the chart URL, or nil without a secret.
+func (s *Server) chartURLCredentials(ctx context.Context, cluster, namespace, secretName, host string) (*chartURLCredentials, error) {
+	if secretName == "" {
+		return nil, nil
+	}
+	typedClient, _, err := s.GetClients(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := typedClient.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "get", "secret", secretName)
+	}
+	return credentialsFromSecret(secret, host)
+}
+
+// credentialsFromSecret returns the credentials of a basic-auth secret, or
+// those of a dockerconfigjson secret for the host.
+func credentialsFromSecret(secret *k8scorev1.Secret, host string) (*chartURLCredentials, error) {
+	switch secret.Type {
+	case k8scorev1.SecretTypeBasicAuth:
+		return &chartURLCredentials{
+			username: string(secret.Data[k8scorev1.BasicAuthUsernameKey]),
+			password: string(secret.Data[k8scorev1.BasicAuthPasswordKey]),
+		}, nil
+	case k8scorev1.SecretTypeDockerConfigJson:
+		dockerConfigJSON := credentialprovider.DockerConfigJSON{}
+		if err := json.Unmarshal(secret.Data[k8scorev1.DockerConfigJsonKey], &dockerConfigJSON); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "Unable to parse the secret %q: %v", secret.Name, err)
+		}
+		for _, server := range []string{host, "https://" + host} {
+			if entry, ok := dockerConfigJSON.Auths[server]; ok {
+				return &chartURLCredentials{username: entry.Username, password: entry.Password}, nil
+			}
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "The secret %q has no credentials for %q", secret.Name, host)
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "The secret %q must be of type %q or %q but has type %q", secret.Name, k8scorev1.SecretTypeBasicAuth, k8scorev1.SecretTypeDockerConfigJson, secret.Type)
+	}
+}
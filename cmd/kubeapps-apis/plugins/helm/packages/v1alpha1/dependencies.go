@@ -0,0 +1,118 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/chart/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	log "k8s.io/klog/v2"
+)
+
+// chartDependencies returns the dependencies of the chart version stored with
+// its files during the sync, resolved against the charts of the catalog
+// available in the namespace. A dependency which cannot be looked up in the
+// catalog is left unresolved rather than failing the whole detail.
+func (s *Server) chartDependencies(namespace string, ch *models.Chart) ([]*v1alpha1.ChartDependency, error) {
+	if ch.Repo == nil || len(ch.ChartVersions) == 0 {
+		return nil, nil
+	}
+	filesID := fmt.Sprintf("%s-%s", ch.ID, ch.ChartVersions[0].Version)
+	files, err := s.manager.GetChartFiles(ch.Repo.Namespace, filesID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The files of the chart version are not synced yet.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to retrieve the files of the chart %q: %v", filesID, err)
+	}
+
+	// The catalog is queried once for each chart name, whatever the number
+	// of dependencies with the name.
+	catalogCharts := map[string][]*models.Chart{}
+	dependencies := make([]*v1alpha1.ChartDependency, 0, len(files.Dependencies))
+	for _, d := range files.Dependencies {
+		dependency := &v1alpha1.ChartDependency{
+			Name:       d.Name,
+			Version:    d.Version,
+			Repository: d.Repository,
+			Condition:  d.Condition,
+			Alias:      d.Alias,
+		}
+		dependencies = append(dependencies, dependency)
+		if !isRemoteDependency(d.Repository) {
+			continue
+		}
+
+		charts, ok := catalogCharts[d.Name]
+		if !ok {
+			if charts, err = s.getCatalogCharts(namespace, d.Name); err != nil {
+				log.Errorf("Unable to resolve the dependency %q of the chart %q: %v", d.Name, ch.ID, err)
+			}
+			catalogCharts[d.Name] = charts
+		}
+		resolveChartDependency(dependency, charts)
+	}
+	return dependencies, nil
+}
+
+// isRemoteDependency returns whether the dependency is fetched from a
+// repository, rather than stored within the chart.
+func isRemoteDependency(repository string) bool {
+	return repository != "" && !strings.HasPrefix(repository, "file://")
+}
+
+// resolveChartDependency sets the catalog package of the dependency, which is
+// the chart with its name from the repository with its URL or, for
+// repositories referenced with "@" or "alias:", its name. The resolved version
+// is the latest version of the package within the version range.
+func resolveChartDependency(dependency *v1alpha1.ChartDependency, charts []*models.Chart) {
+	for _, c := range charts {
+		if c.Repo == nil || !dependencyRepositoryMatches(dependency.Repository, c.Repo) {
+			continue
+		}
+		dependency.AvailablePackageRef = &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Namespace: c.Repo.Namespace},
+			Identifier: c.ID,
+			Plugin:     GetPluginDetail(),
+		}
+		versions := c.ChartVersions
+		if filter, err := newVersionFilter(dependency.Version, false); err != nil {
+			return
+		} else if filter != nil {
+			versions = filter.filter(versions)
+		}
+		if latest, latestVersion := latestChartVersion(versions, false); latest != nil {
+			dependency.ResolvedPkgVersion = latestVersion.Version
+		}
+		return
+	}
+}
+
+// dependencyRepositoryMatches returns whether the repository of a dependency
+// references the repository of a catalog chart.
+func dependencyRepositoryMatches(repository string, repo *models.Repo) bool {
+	for _, prefix := range []string{"@", "alias:"} {
+		if strings.HasPrefix(repository, prefix) {
+			return strings.TrimPrefix(repository, prefix) == repo.Name
+		}
+	}
+	return strings.TrimSuffix(repository, "/") == strings.TrimSuffix(repo.URL, "/")
+}
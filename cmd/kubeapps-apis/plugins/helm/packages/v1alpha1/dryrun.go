@@ -0,0 +1,174 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/agent"
+	"github.com/kubeapps/kubeapps/pkg/auth"
+	"github.com/kubeapps/kubeapps/pkg/yaml"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+// dryRunFieldManager is the field manager of the server-side dry-run applies.
+const dryRunFieldManager = "kubeapps-apis"
+
+// DryRunCreateInstalledPackage renders the chart of a CreateInstalledPackage
+// request without installing it, returning the manifest together with the
+// actions forbidden to the user and, if requested, the result of a
+// server-side dry-run apply of each resource.
+func (s *Server) DryRunCreateInstalledPackage(ctx context.Context, request *v1alpha1.DryRunCreateInstalledPackageRequest) (*v1alpha1.DryRunInstalledPackageResponse, error) {
+	createRequest := request.GetCreateRequest()
+	targetContext := createRequest.GetTargetContext()
+	log.Infof("+helm DryRunCreateInstalledPackage (cluster=[%s], namespace=[%s], name=[%s], serverSide=[%t])", targetContext.GetCluster(), targetContext.GetNamespace(), createRequest.GetName(), request.GetServerSide())
+
+	ch, registrySecrets, err := s.chartForCreate(ctx, createRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, targetContext.GetCluster(), targetContext.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	// A helm dry-run install does not check whether the release exists.
+	if _, err = agent.GetRelease(actionConfig, createRequest.GetName()); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "Unable to create the release %q: it already exists", createRequest.GetName())
+	}
+	manifest, err := agent.ResolveManifest(actionConfig, createRequest.GetName(), targetContext.GetNamespace(), createRequest.GetValues(), ch, registrySecrets)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to render the release %q: %v", createRequest.GetName(), err)
+	}
+
+	return s.dryRunResponse(ctx, targetContext.GetCluster(), targetContext.GetNamespace(), "create", manifest, request.GetServerSide())
+}
+
+// DryRunUpdateInstalledPackage renders the chart of an UpdateInstalledPackage
+// request without upgrading the release, returning the manifest together
+// with the actions forbidden to the user and, if requested, the result of a
+// server-side dry-run apply of each resource.
+func (s *Server) DryRunUpdateInstalledPackage(ctx context.Context, request *v1alpha1.DryRunUpdateInstalledPackageRequest) (*v1alpha1.DryRunInstalledPackageResponse, error) {
+	updateRequest := request.GetUpdateRequest()
+	ref := updateRequest.GetInstalledPackageRef()
+	log.Infof("+helm DryRunUpdateInstalledPackage (cluster=[%s], namespace=[%s], name=[%s], serverSide=[%t])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier(), request.GetServerSide())
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	ch, registrySecrets, err := s.chartForUpdate(ctx, actionConfig, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := agent.ResolveUpgradeManifest(actionConfig, ref.GetIdentifier(), updateRequest.GetValues(), ch, registrySecrets)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to render the release %q: %v", ref.GetIdentifier(), err)
+	}
+
+	return s.dryRunResponse(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), "upgrade", manifest, request.GetServerSide())
+}
+
+// dryRunResponse returns the response of a dry-run for the rendered manifest,
+// checking the permissions of the user for the helm action.
+func (s *Server) dryRunResponse(ctx context.Context, cluster, namespace, action, manifest string, serverSide bool) (*v1alpha1.DryRunInstalledPackageResponse, error) {
+	typedClient, dynamicClient, err := s.GetClients(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := auth.NewAuthForClient(typedClient).GetForbiddenActions(namespace, action, manifest)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to check the permissions for the manifest: %v", err)
+	}
+	forbiddenActions := make([]*v1alpha1.ForbiddenAction, 0, len(actions))
+	for _, a := range actions {
+		forbiddenActions = append(forbiddenActions, &v1alpha1.ForbiddenAction{
+			ApiVersion:  a.APIVersion,
+			Resource:    a.Resource,
+			Namespace:   a.Namespace,
+			ClusterWide: a.ClusterWide,
+			Verbs:       a.Verbs,
+		})
+	}
+
+	response := &v1alpha1.DryRunInstalledPackageResponse{
+		Manifest:         manifest,
+		ForbiddenActions: forbiddenActions,
+	}
+	if serverSide {
+		response.ServerDryRunResults, err = serverSideDryRun(ctx, typedClient, dynamicClient, namespace, manifest)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// serverSideDryRun applies each resource of the manifest with a server-side
+// dry-run, returning the error reported by the API server for each of them.
+func serverSideDryRun(ctx context.Context, typedClient kubernetes.Interface, dynamicClient dynamic.Interface, namespace, manifest string) ([]*v1alpha1.ServerDryRunResult, error) {
+	objects, err := yaml.ParseObjects(manifest)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to parse the manifest: %v", err)
+	}
+	mapper, err := restMapperForClient(typedClient)
+	if err != nil {
+		return nil, err
+	}
+
+	force := true
+	results := make([]*v1alpha1.ServerDryRunResult, 0, len(objects))
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		result := &v1alpha1.ServerDryRunResult{
+			ApiVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Name:       obj.GetName(),
+		}
+		results = append(results, result)
+
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		resourceClient := resourceClientForObject(dynamicClient, mapping, obj, namespace)
+		result.Namespace = obj.GetNamespace()
+
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Unable to serialize the %s %q: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		_, err = resourceClient.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+			DryRun:       []string{metav1.DryRunAll},
+			FieldManager: dryRunFieldManager,
+			Force:        &force,
+		})
+		if err != nil {
+			result.Error = err.Error()
+		}
+	}
+	return results, nil
+}
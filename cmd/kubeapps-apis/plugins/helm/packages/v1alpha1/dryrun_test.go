@@ -0,0 +1,308 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apprepov1alpha1 "github.com/kubeapps/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/chart/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	typfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// makeDryRunServer returns a releases server whose clients discover config
+// maps, allow the verbs of the user and fail the server-side dry-run with
+// the given error, if any.
+func makeDryRunServer(t *testing.T, actionConfig *action.Configuration, allowedVerbs []string, patchErr error) *Server {
+	s := makeReleasesServer(t, actionConfig, []*apprepov1alpha1.AppRepository{bitnamiAppRepository})
+	typedClient, dynamicClient, err := s.GetClients(context.Background(), "")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	clientSet := typedClient.(*typfake.Clientset)
+	clientSet.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+			},
+		},
+	}
+	clientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		for _, verb := range allowedVerbs {
+			if verb == review.Spec.ResourceAttributes.Verb {
+				review.Status.Allowed = true
+			}
+		}
+		return true, review, nil
+	})
+
+	// The fake dynamic client does not support apply patches.
+	dynamicClient.(*dynfake.FakeDynamicClient).PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, patchErr
+	})
+	return s
+}
+
+var ignoreUnexportedDryRun = cmpopts.IgnoreUnexported(
+	v1alpha1.DryRunInstalledPackageResponse{},
+	v1alpha1.ForbiddenAction{},
+	v1alpha1.ServerDryRunResult{},
+)
+
+func TestDryRunCreateInstalledPackage(t *testing.T) {
+	createRequest := &corev1.CreateInstalledPackageRequest{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Namespace: globalPackagingNamespace},
+			Identifier: "bitnami%2Fapache",
+		},
+		TargetContext:       &corev1.Context{Namespace: "default"},
+		Name:                "my-apache",
+		PkgVersionReference: &corev1.VersionReference{Version: "1.0.0"},
+		Values:              "greeting: hi",
+	}
+	manifest := `---
+# Source: apache/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-apache
+data:
+  greeting: hi
+`
+	testCases := []struct {
+		name             string
+		request          *v1alpha1.DryRunCreateInstalledPackageRequest
+		existingReleases []*release.Release
+		allowedVerbs     []string
+		patchErr         error
+		expectedStatus   codes.Code
+		expectedResponse *v1alpha1.DryRunInstalledPackageResponse
+	}{
+		{
+			name:           "it renders the manifest allowed to the user",
+			request:        &v1alpha1.DryRunCreateInstalledPackageRequest{CreateRequest: createRequest},
+			allowedVerbs:   []string{"create"},
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.DryRunInstalledPackageResponse{
+				Manifest:         manifest,
+				ForbiddenActions: []*v1alpha1.ForbiddenAction{},
+			},
+		},
+		{
+			name:           "it returns the actions forbidden to the user",
+			request:        &v1alpha1.DryRunCreateInstalledPackageRequest{CreateRequest: createRequest},
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.DryRunInstalledPackageResponse{
+				Manifest: manifest,
+				ForbiddenActions: []*v1alpha1.ForbiddenAction{
+					{
+						ApiVersion: "v1",
+						Resource:   "configmaps",
+						Namespace:  "default",
+						Verbs:      []string{"create"},
+					},
+				},
+			},
+		},
+		{
+			name:           "it returns the results of the server-side dry-run",
+			request:        &v1alpha1.DryRunCreateInstalledPackageRequest{CreateRequest: createRequest, ServerSide: true},
+			allowedVerbs:   []string{"create"},
+			patchErr:       fmt.Errorf("admission webhook denied the request"),
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.DryRunInstalledPackageResponse{
+				Manifest:         manifest,
+				ForbiddenActions: []*v1alpha1.ForbiddenAction{},
+				ServerDryRunResults: []*v1alpha1.ServerDryRunResult{
+					{
+						ApiVersion: "v1",
+						Kind:       "ConfigMap",
+						Namespace:  "default",
+						Name:       "my-apache",
+						Error:      "admission webhook denied the request",
+					},
+				},
+			},
+		},
+		{
+			name:             "it returns an already exists error if the release exists",
+			request:          &v1alpha1.DryRunCreateInstalledPackageRequest{CreateRequest: createRequest},
+			existingReleases: []*release.Release{newRelease("my-apache", "default", "apache", "1.0.0", 1, release.StatusDeployed)},
+			expectedStatus:   codes.AlreadyExists,
+		},
+		{
+			name: "it returns an invalid argument error with invalid values",
+			request: &v1alpha1.DryRunCreateInstalledPackageRequest{
+				CreateRequest: &corev1.CreateInstalledPackageRequest{
+					AvailablePackageRef: createRequest.AvailablePackageRef,
+					TargetContext:       createRequest.TargetContext,
+					Name:                "my-apache",
+					Values:              "not: valid: yaml",
+				},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name:           "it returns an invalid argument error without a create request",
+			request:        &v1alpha1.DryRunCreateInstalledPackageRequest{},
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newTestActionConfig(t, "default", tc.existingReleases...)
+			s := makeDryRunServer(t, actionConfig, tc.allowedVerbs, tc.patchErr)
+
+			response, err := s.DryRunCreateInstalledPackage(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if got, want := response, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedDryRun) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedDryRun))
+			}
+			if _, err := actionConfig.Releases.Last("my-apache"); tc.existingReleases == nil && err == nil {
+				t.Errorf("the dry-run installed the release")
+			}
+		})
+	}
+}
+
+func TestDryRunUpdateInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name             string
+		request          *v1alpha1.DryRunUpdateInstalledPackageRequest
+		allowedVerbs     []string
+		expectDBQuery    bool
+		expectedStatus   codes.Code
+		expectedResponse *v1alpha1.DryRunInstalledPackageResponse
+	}{
+		{
+			name: "it renders the upgraded manifest and the forbidden actions",
+			request: &v1alpha1.DryRunUpdateInstalledPackageRequest{
+				UpdateRequest: &corev1.UpdateInstalledPackageRequest{
+					InstalledPackageRef: &corev1.InstalledPackageReference{
+						Context:    &corev1.Context{Namespace: "default"},
+						Identifier: "my-apache",
+					},
+					PkgVersionReference: &corev1.VersionReference{Version: "2.0.0"},
+					Values:              "greeting: bye",
+				},
+			},
+			allowedVerbs:   []string{"create", "update"},
+			expectDBQuery:  true,
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.DryRunInstalledPackageResponse{
+				Manifest: `---
+# Source: apache/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-apache
+data:
+  greeting: bye
+`,
+				ForbiddenActions: []*v1alpha1.ForbiddenAction{
+					{
+						ApiVersion: "v1",
+						Resource:   "configmaps",
+						Namespace:  "default",
+						Verbs:      []string{"delete"},
+					},
+				},
+			},
+		},
+		{
+			name: "it returns a not found error if the release does not exist",
+			request: &v1alpha1.DryRunUpdateInstalledPackageRequest{
+				UpdateRequest: &corev1.UpdateInstalledPackageRequest{
+					InstalledPackageRef: &corev1.InstalledPackageReference{
+						Context:    &corev1.Context{Namespace: "default"},
+						Identifier: "my-wordpress",
+					},
+				},
+			},
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name:           "it returns an invalid argument error without an update request",
+			request:        &v1alpha1.DryRunUpdateInstalledPackageRequest{},
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newTestActionConfig(t, "default", newRelease("my-apache", "default", "apache", "1.0.0", 1, release.StatusDeployed))
+			s := makeDryRunServer(t, actionConfig, tc.allowedVerbs, nil)
+			mock, cleanup, manager := setMockManager(t)
+			defer cleanup()
+			s.manager = manager
+
+			if tc.expectDBQuery {
+				rows := sqlmock.NewRows([]string{"info"})
+				for _, row := range makeChartRowsJSON(t, []*models.Chart{makeChart("apache", "bitnami", globalPackagingNamespace, []string{"2.0.0", "1.0.0"})}, "", 0) {
+					rows.AddRow(row)
+				}
+				mock.ExpectQuery("SELECT info FROM").
+					WithArgs("default", globalPackagingNamespace, "apache").
+					WillReturnRows(rows)
+			}
+
+			response, err := s.DryRunUpdateInstalledPackage(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("%+v", err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if got, want := response, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedDryRun) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedDryRun))
+			}
+			rel, err := actionConfig.Releases.Last("my-apache")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := rel.Version, 1; got != want {
+				t.Errorf("the dry-run upgraded the release to the revision %d", got)
+			}
+		})
+	}
+}
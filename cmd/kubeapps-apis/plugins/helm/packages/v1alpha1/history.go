@@ -0,0 +1,174 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/pmezard/go-difflib/difflib"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// diffContextLines is the number of unchanged lines around each change of a
+// unified diff.
+const diffContextLines = 3
+
+// GetInstalledPackageRevisions returns the revisions of a helm release
+// recorded in the helm storage.
+func (s *Server) GetInstalledPackageRevisions(ctx context.Context, request *v1alpha1.GetInstalledPackageRevisionsRequest) (*v1alpha1.GetInstalledPackageRevisionsResponse, error) {
+	ref := request.GetInstalledPackageRef()
+	log.Infof("+helm GetInstalledPackageRevisions (cluster=[%s], namespace=[%s], name=[%s])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	releases, err := action.NewHistory(actionConfig).Run(ref.GetIdentifier())
+	if err != nil {
+		return nil, statusErrorFromHelmError(err, "get the history of", ref.GetIdentifier())
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Version < releases[j].Version
+	})
+
+	revisions := make([]*v1alpha1.InstalledPackageRevision, 0, len(releases))
+	for _, rel := range releases {
+		revisions = append(revisions, installedPackageRevisionFromRelease(rel))
+	}
+	return &v1alpha1.GetInstalledPackageRevisionsResponse{
+		Revisions: revisions,
+	}, nil
+}
+
+// GetInstalledPackageRevisionDiff returns the unified diffs of the rendered
+// manifests and of the user-supplied values between two revisions of a helm
+// release.
+func (s *Server) GetInstalledPackageRevisionDiff(ctx context.Context, request *v1alpha1.GetInstalledPackageRevisionDiffRequest) (*v1alpha1.GetInstalledPackageRevisionDiffResponse, error) {
+	ref := request.GetInstalledPackageRef()
+	log.Infof("+helm GetInstalledPackageRevisionDiff (cluster=[%s], namespace=[%s], name=[%s], from=[%d], to=[%d])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier(), request.GetFromRevision(), request.GetToRevision())
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+	if request.GetFromRevision() <= 0 || request.GetToRevision() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid release revisions %d and %d", request.GetFromRevision(), request.GetToRevision())
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	from, err := actionConfig.Releases.Get(ref.GetIdentifier(), int(request.GetFromRevision()))
+	if err != nil {
+		return nil, statusErrorFromHelmError(err, fmt.Sprintf("get the revision %d of", request.GetFromRevision()), ref.GetIdentifier())
+	}
+	to, err := actionConfig.Releases.Get(ref.GetIdentifier(), int(request.GetToRevision()))
+	if err != nil {
+		return nil, statusErrorFromHelmError(err, fmt.Sprintf("get the revision %d of", request.GetToRevision()), ref.GetIdentifier())
+	}
+
+	manifestDiff, err := unifiedDiff(from.Manifest, to.Manifest, "manifest", from.Version, to.Version)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to compute the manifest diff: %v", err)
+	}
+	fromValues, err := valuesYAML(from)
+	if err != nil {
+		return nil, err
+	}
+	toValues, err := valuesYAML(to)
+	if err != nil {
+		return nil, err
+	}
+	valuesDiff, err := unifiedDiff(fromValues, toValues, "values", from.Version, to.Version)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to compute the values diff: %v", err)
+	}
+
+	return &v1alpha1.GetInstalledPackageRevisionDiffResponse{
+		ManifestDiff: manifestDiff,
+		ValuesDiff:   valuesDiff,
+	}, nil
+}
+
+// installedPackageRevisionFromRelease returns the revision of a helm release.
+func installedPackageRevisionFromRelease(rel *release.Release) *v1alpha1.InstalledPackageRevision {
+	revision := &v1alpha1.InstalledPackageRevision{
+		ReleaseRevision: int32(rel.Version),
+	}
+	if rel.Info != nil {
+		revision.Status = rel.Info.Status.String()
+		revision.Description = rel.Info.Description
+		if !rel.Info.FirstDeployed.IsZero() {
+			revision.FirstDeployed = timestamppb.New(rel.Info.FirstDeployed.Time)
+		}
+		if !rel.Info.LastDeployed.IsZero() {
+			revision.LastDeployed = timestamppb.New(rel.Info.LastDeployed.Time)
+		}
+	}
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		revision.PkgVersion = rel.Chart.Metadata.Version
+		revision.AppVersion = rel.Chart.Metadata.AppVersion
+	}
+	return revision
+}
+
+// valuesYAML returns the values supplied by the user for a revision of a
+// release, serialized as YAML.
+func valuesYAML(rel *release.Release) (string, error) {
+	if len(rel.Config) == 0 {
+		return "", nil
+	}
+	b, err := yaml.Marshal(rel.Config)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "Unable to serialize the values of the revision %d: %v", rel.Version, err)
+	}
+	return string(b), nil
+}
+
+// unifiedDiff returns the unified diff between the content of two revisions.
+func unifiedDiff(from, to, name string, fromRevision, toRevision int) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(from),
+		B:        splitLines(to),
+		FromFile: fmt.Sprintf("revision-%d/%s", fromRevision, name),
+		ToFile:   fmt.Sprintf("revision-%d/%s", toRevision, name),
+		Context:  diffContextLines,
+	})
+}
+
+// splitLines splits the content in lines, each of them terminated by a new
+// line as expected by difflib.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n"
+	}
+	return lines
+}
@@ -0,0 +1,249 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+var (
+	firstDeployed = time.Date(2021, 7, 1, 10, 0, 0, 0, time.UTC)
+	lastDeployed  = time.Date(2021, 7, 2, 10, 0, 0, 0, time.UTC)
+)
+
+// newReleaseRevision returns a revision of the my-apache release with the
+// manifest and values.
+func newReleaseRevision(revision int, releaseStatus release.Status, version, description, manifest string, values map[string]interface{}) *release.Release {
+	rel := newRelease("my-apache", "default", "apache", version, revision, releaseStatus)
+	rel.Chart.Metadata.AppVersion = "2.4.48"
+	rel.Info.Description = description
+	rel.Info.FirstDeployed = helmtime.Time{Time: firstDeployed}
+	rel.Info.LastDeployed = helmtime.Time{Time: lastDeployed.Add(time.Duration(revision) * time.Hour)}
+	rel.Manifest = manifest
+	rel.Config = values
+	return rel
+}
+
+func TestGetInstalledPackageRevisions(t *testing.T) {
+	testCases := []struct {
+		name             string
+		request          *v1alpha1.GetInstalledPackageRevisionsRequest
+		expectedStatus   codes.Code
+		expectedResponse *v1alpha1.GetInstalledPackageRevisionsResponse
+	}{
+		{
+			name: "it returns the revisions of the release in order",
+			request: &v1alpha1.GetInstalledPackageRevisionsRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.GetInstalledPackageRevisionsResponse{
+				Revisions: []*v1alpha1.InstalledPackageRevision{
+					{
+						ReleaseRevision: 1,
+						Status:          "superseded",
+						PkgVersion:      "1.0.0",
+						AppVersion:      "2.4.48",
+						FirstDeployed:   timestamppb.New(firstDeployed),
+						LastDeployed:    timestamppb.New(lastDeployed.Add(time.Hour)),
+						Description:     "Install complete",
+					},
+					{
+						ReleaseRevision: 2,
+						Status:          "deployed",
+						PkgVersion:      "2.0.0",
+						AppVersion:      "2.4.48",
+						FirstDeployed:   timestamppb.New(firstDeployed),
+						LastDeployed:    timestamppb.New(lastDeployed.Add(2 * time.Hour)),
+						Description:     "Upgrade complete",
+					},
+				},
+			},
+		},
+		{
+			name: "it returns a not found error if the release does not exist",
+			request: &v1alpha1.GetInstalledPackageRevisionsRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-wordpress",
+				},
+			},
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name: "it returns an invalid argument error without a namespace",
+			request: &v1alpha1.GetInstalledPackageRevisionsRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Identifier: "my-apache",
+				},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newTestActionConfig(t, "default",
+				newReleaseRevision(2, release.StatusDeployed, "2.0.0", "Upgrade complete", "", nil),
+				newReleaseRevision(1, release.StatusSuperseded, "1.0.0", "Install complete", "", nil),
+			)
+			s := makeReleasesServer(t, actionConfig, nil)
+
+			response, err := s.GetInstalledPackageRevisions(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			opts := cmpopts.IgnoreUnexported(
+				v1alpha1.GetInstalledPackageRevisionsResponse{},
+				v1alpha1.InstalledPackageRevision{},
+				timestamppb.Timestamp{},
+			)
+			if got, want := response, tc.expectedResponse; !cmp.Equal(got, want, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageRevisionDiff(t *testing.T) {
+	manifest1 := `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-apache
+spec:
+  replicas: 1
+`
+	manifest2 := `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-apache
+spec:
+  replicas: 3
+`
+	testCases := []struct {
+		name             string
+		request          *v1alpha1.GetInstalledPackageRevisionDiffRequest
+		expectedStatus   codes.Code
+		expectedResponse *v1alpha1.GetInstalledPackageRevisionDiffResponse
+	}{
+		{
+			name: "it returns the diffs between two revisions",
+			request: &v1alpha1.GetInstalledPackageRevisionDiffRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				FromRevision: 1,
+				ToRevision:   2,
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.GetInstalledPackageRevisionDiffResponse{
+				ManifestDiff: `--- revision-1/manifest
++++ revision-2/manifest
+@@ -4,4 +4,4 @@
+ metadata:
+   name: my-apache
+ spec:
+-  replicas: 1
++  replicas: 3
+`,
+				ValuesDiff: `--- revision-1/values
++++ revision-2/values
+@@ -1 +1 @@
+-replicaCount: 1
++replicaCount: 3
+`,
+			},
+		},
+		{
+			name: "it returns empty diffs for the same revision",
+			request: &v1alpha1.GetInstalledPackageRevisionDiffRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				FromRevision: 2,
+				ToRevision:   2,
+			},
+			expectedStatus:   codes.OK,
+			expectedResponse: &v1alpha1.GetInstalledPackageRevisionDiffResponse{},
+		},
+		{
+			name: "it returns a not found error for a missing revision",
+			request: &v1alpha1.GetInstalledPackageRevisionDiffRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				FromRevision: 1,
+				ToRevision:   3,
+			},
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name: "it returns an invalid argument error without revisions",
+			request: &v1alpha1.GetInstalledPackageRevisionDiffRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				ToRevision: 2,
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newTestActionConfig(t, "default",
+				newReleaseRevision(1, release.StatusSuperseded, "1.0.0", "Install complete", manifest1, map[string]interface{}{"replicaCount": 1}),
+				newReleaseRevision(2, release.StatusDeployed, "1.0.0", "Upgrade complete", manifest2, map[string]interface{}{"replicaCount": 3}),
+			)
+			s := makeReleasesServer(t, actionConfig, nil)
+
+			response, err := s.GetInstalledPackageRevisionDiff(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			opts := cmpopts.IgnoreUnexported(v1alpha1.GetInstalledPackageRevisionDiffResponse{})
+			if got, want := response, tc.expectedResponse; !cmp.Equal(got, want, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
@@ -0,0 +1,386 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/agent"
+	"github.com/kubeapps/kubeapps/pkg/proxy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/release"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// ReleaseSecretLabelSelector selects the secrets in which helm stores
+	// each revision of a release.
+	ReleaseSecretLabelSelector = "owner=helm"
+	// ReleaseSecretKey is the key of a release secret holding the encoded release.
+	ReleaseSecretKey = "release"
+)
+
+// gzipMagic is the header of gzipped data, which helm uses to compress the
+// releases it stores.
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// GetInstalledPackageSummaries returns the latest revision of each helm
+// release of the requested namespace, or of all namespaces when none is
+// requested, sorted by name and paginated by page offset.
+func (s *Server) GetInstalledPackageSummaries(ctx context.Context, request *corev1.GetInstalledPackageSummariesRequest) (*corev1.GetInstalledPackageSummariesResponse, error) {
+	cluster, namespace := request.GetContext().GetCluster(), request.GetContext().GetNamespace()
+	log.Infof("+helm GetInstalledPackageSummaries (cluster=[%s], namespace=[%s])", cluster, namespace)
+
+	pageSize := int(request.GetPaginationOptions().GetPageSize())
+	pageOffset, err := pageOffsetFromPageToken(request.GetPaginationOptions().GetPageToken())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to intepret page token %q: %v", request.GetPaginationOptions().GetPageToken(), err)
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+	apps, err := agent.ListReleases(actionConfig, namespace, 0, "all")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to list the releases in the namespace %q: %v", namespace, err)
+	}
+	// Releases with the same name in different namespaces are ordered by
+	// namespace so that the pages are stable.
+	sort.SliceStable(apps, func(i, j int) bool {
+		if apps[i].ReleaseName != apps[j].ReleaseName {
+			return apps[i].ReleaseName < apps[j].ReleaseName
+		}
+		return apps[i].Namespace < apps[j].Namespace
+	})
+
+	nextPageToken := ""
+	if pageSize > 0 {
+		if pageOffset < 1 {
+			pageOffset = 1
+		}
+		start := (pageOffset - 1) * pageSize
+		if start > len(apps) {
+			start = len(apps)
+		}
+		end := start + pageSize
+		if end < len(apps) {
+			nextPageToken = fmt.Sprintf("%d", pageOffset+1)
+		} else {
+			end = len(apps)
+		}
+		apps = apps[start:end]
+	}
+
+	summaries := make([]*corev1.InstalledPackageSummary, 0, len(apps))
+	for _, app := range apps {
+		summaries = append(summaries, installedPackageSummaryFromAppOverview(app, cluster))
+	}
+	return &corev1.GetInstalledPackageSummariesResponse{
+		InstalledPackageSummaries: summaries,
+		NextPageToken:             nextPageToken,
+	}, nil
+}
+
+// GetInstalledPackageDetail returns the latest revision of a helm release,
+// with the values supplied by the user and the notes of the chart.
+func (s *Server) GetInstalledPackageDetail(ctx context.Context, request *corev1.GetInstalledPackageDetailRequest) (*corev1.GetInstalledPackageDetailResponse, error) {
+	ref := request.GetInstalledPackageRef()
+	log.Infof("+helm GetInstalledPackageDetail (cluster=[%s], namespace=[%s], name=[%s])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	rel, err := agent.GetRelease(actionConfig, ref.GetIdentifier())
+	if err != nil {
+		return nil, statusErrorFromHelmError(err, "get", ref.GetIdentifier())
+	}
+	values, err := valuesYAML(rel)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &corev1.InstalledPackageDetail{
+		InstalledPackageRef:   installedPackageRefFromRelease(rel, ref.GetContext().GetCluster()),
+		Name:                  rel.Name,
+		ValuesApplied:         values,
+		Status:                installedPackageStatusFromRelease(rel),
+		PostInstallationNotes: rel.Info.Notes,
+	}
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		metadata := rel.Chart.Metadata
+		detail.PkgVersionReference = &corev1.VersionReference{Version: metadata.Version}
+		detail.CurrentPkgVersion = metadata.Version
+		detail.CurrentAppVersion = metadata.AppVersion
+		detail.AvailablePackageRef = s.availablePackageRefForRelease(rel)
+	}
+	return &corev1.GetInstalledPackageDetailResponse{
+		InstalledPackageDetail: detail,
+	}, nil
+}
+
+// availablePackageRefForRelease returns the reference of the catalog chart
+// providing the version of the chart of the release, or nil when the
+// catalog has none, as the release is still installed without it.
+func (s *Server) availablePackageRefForRelease(rel *release.Release) *corev1.AvailablePackageReference {
+	charts, err := s.getCatalogCharts(rel.Namespace, rel.Chart.Metadata.Name)
+	if err != nil {
+		log.Errorf("Unable to find the chart of the release %q in the catalog: %v", rel.Name, err)
+		return nil
+	}
+	catalogChart := chartWithVersion(charts, rel.Chart.Metadata.Version)
+	if catalogChart == nil {
+		return nil
+	}
+	return &corev1.AvailablePackageReference{
+		Context:    &corev1.Context{Namespace: catalogChart.Repo.Namespace},
+		Identifier: catalogChart.ID,
+		Plugin:     GetPluginDetail(),
+	}
+}
+
+// WatchInstalledPackages streams the changes of the helm releases in the
+// requested namespace, as reported by the secrets in which helm stores each
+// revision of a release.
+func (s *Server) WatchInstalledPackages(request *corev1.WatchInstalledPackagesRequest, stream corev1.PackagesService_WatchInstalledPackagesServer) error {
+	namespace := request.GetContext().GetNamespace()
+	log.Infof("+helm WatchInstalledPackages (cluster=[%s], namespace=[%s])", request.GetContext().GetCluster(), namespace)
+
+	ctx := stream.Context()
+	typedClient, _, err := s.GetClients(ctx, request.GetContext().GetCluster())
+	if err != nil {
+		return err
+	}
+
+	watcher := &releaseWatcher{
+		context:   request.GetContext(),
+		revisions: map[string]int{},
+	}
+	// The watch is started again from the last seen resource version
+	// whenever the api server closes it.
+	resourceVersion := ""
+	for {
+		w, err := typedClient.CoreV1().Secrets(namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector:   ReleaseSecretLabelSelector,
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			return statusErrorFromK8sError(err, "watch", "release secrets in namespace", namespace)
+		}
+		resourceVersion, err = watcher.sendEvents(ctx, w.ResultChan(), stream, resourceVersion)
+		w.Stop()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// releaseWatcher converts the events of release secrets into the events of
+// the installed packages.
+type releaseWatcher struct {
+	context *corev1.Context
+	// revisions is the latest revision of each release seen by the watcher.
+	revisions map[string]int
+}
+
+// sendEvents sends the event of each secret change until the channel is
+// closed, returning the resource version to continue watching from.
+func (w *releaseWatcher) sendEvents(ctx context.Context, ch <-chan watch.Event, stream corev1.PackagesService_WatchInstalledPackagesServer, resourceVersion string) (string, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return "", status.FromContextError(ctx.Err()).Err()
+		case event, ok := <-ch:
+			if !ok {
+				return resourceVersion, nil
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified, watch.Deleted:
+				secret, ok := event.Object.(*k8scorev1.Secret)
+				if !ok {
+					log.Errorf("Unexpected object in release secrets watch: %v", event.Object)
+					continue
+				}
+				resourceVersion = secret.ResourceVersion
+				response, err := w.eventForSecret(event.Type, secret)
+				if err != nil {
+					log.Errorf("Unable to decode the release secret %q: %v", secret.Name, err)
+					continue
+				}
+				if response == nil {
+					continue
+				}
+				if err = stream.Send(response); err != nil {
+					return "", err
+				}
+			case watch.Error:
+				// The resource version is too old or the watch otherwise
+				// failed, so the watch is started again without it.
+				log.Errorf("Error event in release secrets watch: %v", event.Object)
+				return "", nil
+			}
+		}
+	}
+}
+
+// eventForSecret returns the event for the release stored in the secret, or
+// nil when the change does not concern the latest revision of the release.
+func (w *releaseWatcher) eventForSecret(eventType watch.EventType, secret *k8scorev1.Secret) (*corev1.WatchInstalledPackagesResponse, error) {
+	rel, err := releaseFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	latest, seen := w.revisions[rel.Name]
+	if rel.Version < latest || rel.Info.Status == release.StatusSuperseded {
+		// Previous revisions are updated when superseded or deleted when
+		// the history is pruned, which doesn't change the installed package.
+		return nil, nil
+	}
+
+	response := &corev1.WatchInstalledPackagesResponse{
+		InstalledPackageSummary: installedPackageSummaryFromRelease(rel, w.context),
+	}
+	switch {
+	case eventType == watch.Deleted:
+		delete(w.revisions, rel.Name)
+		response.Type = corev1.WatchInstalledPackagesResponse_EVENT_TYPE_DELETED
+	case seen:
+		w.revisions[rel.Name] = rel.Version
+		response.Type = corev1.WatchInstalledPackagesResponse_EVENT_TYPE_MODIFIED
+	default:
+		w.revisions[rel.Name] = rel.Version
+		response.Type = corev1.WatchInstalledPackagesResponse_EVENT_TYPE_ADDED
+	}
+	return response, nil
+}
+
+// releaseFromSecret decodes the release stored by helm in a secret, which is
+// base64 encoded and optionally gzipped JSON.
+func releaseFromSecret(secret *k8scorev1.Secret) (*release.Release, error) {
+	data, ok := secret.Data[ReleaseSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("missing %q key", ReleaseSecretKey)
+	}
+	b, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(b, gzipMagic) {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		if b, err = ioutil.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+	var rel release.Release
+	if err := json.Unmarshal(b, &rel); err != nil {
+		return nil, err
+	}
+	if rel.Info == nil {
+		rel.Info = &release.Info{}
+	}
+	return &rel, nil
+}
+
+// installedPackageSummaryFromRelease returns the summary of the installed
+// package for a helm release.
+func installedPackageSummaryFromRelease(rel *release.Release, pkgContext *corev1.Context) *corev1.InstalledPackageSummary {
+	summary := &corev1.InstalledPackageSummary{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context: &corev1.Context{
+				Cluster:   pkgContext.GetCluster(),
+				Namespace: rel.Namespace,
+			},
+			Identifier: rel.Name,
+		},
+		Name:   rel.Name,
+		Status: installedPackageStatusFromRelease(rel),
+	}
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		metadata := rel.Chart.Metadata
+		summary.PkgVersionReference = &corev1.VersionReference{Version: metadata.Version}
+		summary.CurrentPkgVersion = metadata.Version
+		summary.CurrentAppVersion = metadata.AppVersion
+		summary.IconUrl = metadata.Icon
+		summary.PkgDisplayName = metadata.Name
+		summary.ShortDescription = metadata.Description
+	}
+	return summary
+}
+
+// installedPackageSummaryFromAppOverview returns the summary of the installed
+// package for a helm release listed by the agent.
+func installedPackageSummaryFromAppOverview(app proxy.AppOverview, cluster string) *corev1.InstalledPackageSummary {
+	return &corev1.InstalledPackageSummary{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context: &corev1.Context{
+				Cluster:   cluster,
+				Namespace: app.Namespace,
+			},
+			Identifier: app.ReleaseName,
+		},
+		Name:                app.ReleaseName,
+		PkgVersionReference: &corev1.VersionReference{Version: app.Version},
+		CurrentPkgVersion:   app.Version,
+		CurrentAppVersion:   app.ChartMetadata.AppVersion,
+		IconUrl:             app.Icon,
+		PkgDisplayName:      app.Chart,
+		ShortDescription:    app.ChartMetadata.Description,
+		Status:              installedPackageStatus(release.Status(app.Status)),
+	}
+}
+
+// installedPackageStatusFromRelease maps the status of a helm release to the
+// status of the installed package.
+func installedPackageStatusFromRelease(rel *release.Release) *corev1.InstalledPackageStatus {
+	return installedPackageStatus(rel.Info.Status)
+}
+
+// installedPackageStatus maps a helm release status to the status of the
+// installed package.
+func installedPackageStatus(releaseStatus release.Status) *corev1.InstalledPackageStatus {
+	installedStatus := &corev1.InstalledPackageStatus{
+		UserReason: releaseStatus.String(),
+	}
+	switch releaseStatus {
+	case release.StatusDeployed:
+		installedStatus.Ready = true
+		installedStatus.Reason = corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED
+	case release.StatusUninstalled:
+		installedStatus.Reason = corev1.InstalledPackageStatus_STATUS_REASON_UNINSTALLED
+	case release.StatusFailed:
+		installedStatus.Reason = corev1.InstalledPackageStatus_STATUS_REASON_FAILED
+	case release.StatusPendingInstall, release.StatusPendingUpgrade, release.StatusPendingRollback, release.StatusUninstalling:
+		installedStatus.Reason = corev1.InstalledPackageStatus_STATUS_REASON_PENDING
+	}
+	return installedStatus
+}
@@ -0,0 +1,483 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/chart/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	typfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// testWatchStream is a fake stream recording the events sent by the plugin.
+type testWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*corev1.WatchInstalledPackagesResponse
+}
+
+func (s *testWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testWatchStream) Send(event *corev1.WatchInstalledPackagesResponse) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func newReleaseSecret(t *testing.T, name, namespace string, version int, releaseStatus release.Status, gzipped bool) *k8scorev1.Secret {
+	rel := &release.Release{
+		Name:      name,
+		Namespace: namespace,
+		Version:   version,
+		Info:      &release.Info{Status: releaseStatus},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				Name:       "apache",
+				Version:    fmt.Sprintf("1.0.%d", version),
+				AppVersion: "2.4.48",
+				Icon:       "https://example.com/icon.png",
+			},
+		},
+	}
+	b, err := json.Marshal(rel)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if gzipped {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err = w.Write(b); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		w.Close()
+		b = buf.Bytes()
+	}
+	return &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("sh.helm.release.v1.%s.v%d", name, version),
+			Namespace:       namespace,
+			Labels:          map[string]string{"owner": "helm", "name": name},
+			ResourceVersion: fmt.Sprintf("%d", version),
+		},
+		Data: map[string][]byte{
+			ReleaseSecretKey: []byte(base64.StdEncoding.EncodeToString(b)),
+		},
+		Type: "helm.sh/release.v1",
+	}
+}
+
+func installedSummary(name, version string, ready bool, reason corev1.InstalledPackageStatus_StatusReason, userReason string) *corev1.InstalledPackageSummary {
+	return &corev1.InstalledPackageSummary{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+			Identifier: name,
+		},
+		Name:                name,
+		PkgVersionReference: &corev1.VersionReference{Version: version},
+		CurrentPkgVersion:   version,
+		CurrentAppVersion:   "2.4.48",
+		IconUrl:             "https://example.com/icon.png",
+		PkgDisplayName:      "apache",
+		Status: &corev1.InstalledPackageStatus{
+			Ready:      ready,
+			Reason:     reason,
+			UserReason: userReason,
+		},
+	}
+}
+
+var ignoreUnexportedInstalled = cmpopts.IgnoreUnexported(
+	corev1.GetInstalledPackageSummariesResponse{},
+	corev1.GetInstalledPackageDetailResponse{},
+	corev1.InstalledPackageSummary{},
+	corev1.InstalledPackageDetail{},
+	corev1.InstalledPackageReference{},
+	corev1.InstalledPackageStatus{},
+	corev1.AvailablePackageReference{},
+	corev1.VersionReference{},
+	corev1.Context{},
+	plugins.Plugin{},
+)
+
+// listedSummary returns the summary of a deployed release created with
+// newRelease.
+func listedSummary(name, namespace, chartName, version string) *corev1.InstalledPackageSummary {
+	return &corev1.InstalledPackageSummary{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Cluster: "default", Namespace: namespace},
+			Identifier: name,
+		},
+		Name:                name,
+		PkgVersionReference: &corev1.VersionReference{Version: version},
+		CurrentPkgVersion:   version,
+		PkgDisplayName:      chartName,
+		Status: &corev1.InstalledPackageStatus{
+			Ready:      true,
+			Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+			UserReason: "deployed",
+		},
+	}
+}
+
+func TestGetInstalledPackageSummaries(t *testing.T) {
+	testCases := []struct {
+		name             string
+		request          *corev1.GetInstalledPackageSummariesRequest
+		expectedStatus   codes.Code
+		expectedResponse *corev1.GetInstalledPackageSummariesResponse
+	}{
+		{
+			name: "it returns the latest revision of the releases of all namespaces sorted by name",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{Cluster: "default"},
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					listedSummary("my-apache", "default", "apache", "1.0.1"),
+					listedSummary("my-apache", "other", "apache", "1.0.0"),
+					listedSummary("my-nginx", "default", "nginx", "1.2.0"),
+					listedSummary("my-redis", "other", "redis", "3.0.0"),
+				},
+			},
+		},
+		{
+			name: "it returns the first page with a token for the next one",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context:           &corev1.Context{Cluster: "default"},
+				PaginationOptions: &corev1.PaginationOptions{PageSize: 3},
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					listedSummary("my-apache", "default", "apache", "1.0.1"),
+					listedSummary("my-apache", "other", "apache", "1.0.0"),
+					listedSummary("my-nginx", "default", "nginx", "1.2.0"),
+				},
+				NextPageToken: "2",
+			},
+		},
+		{
+			name: "it returns the last page without a token",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context:           &corev1.Context{Cluster: "default"},
+				PaginationOptions: &corev1.PaginationOptions{PageSize: 3, PageToken: "2"},
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					listedSummary("my-redis", "other", "redis", "3.0.0"),
+				},
+			},
+		},
+		{
+			name: "it returns an empty page past the last one",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context:           &corev1.Context{Cluster: "default"},
+				PaginationOptions: &corev1.PaginationOptions{PageSize: 3, PageToken: "3"},
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{},
+			},
+		},
+		{
+			name: "it returns only the releases of the requested namespace",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context: &corev1.Context{Cluster: "default", Namespace: "other"},
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &corev1.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*corev1.InstalledPackageSummary{
+					listedSummary("my-apache", "other", "apache", "1.0.0"),
+					listedSummary("my-redis", "other", "redis", "3.0.0"),
+				},
+			},
+		},
+		{
+			name: "it returns invalid argument for an invalid page token",
+			request: &corev1.GetInstalledPackageSummariesRequest{
+				Context:           &corev1.Context{Cluster: "default"},
+				PaginationOptions: &corev1.PaginationOptions{PageSize: 3, PageToken: "not-a-page"},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newTestActionConfig(t, tc.request.GetContext().GetNamespace(),
+				newRelease("my-redis", "other", "redis", "3.0.0", 1, release.StatusDeployed),
+				newRelease("my-apache", "default", "apache", "1.0.0", 1, release.StatusSuperseded),
+				newRelease("my-apache", "default", "apache", "1.0.1", 2, release.StatusDeployed),
+				newRelease("my-nginx", "default", "nginx", "1.2.0", 1, release.StatusDeployed),
+				newRelease("my-apache", "other", "apache", "1.0.0", 1, release.StatusDeployed),
+			)
+			s := makeReleasesServer(t, actionConfig, nil)
+
+			response, err := s.GetInstalledPackageSummaries(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if got, want := response, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedInstalled) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedInstalled))
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageDetail(t *testing.T) {
+	deployed := newRelease("my-apache", "default", "apache", "1.0.1", 2, release.StatusDeployed)
+	deployed.Chart.Metadata.AppVersion = "2.4.48"
+	deployed.Config = map[string]interface{}{"replicaCount": 2}
+	deployed.Info.Notes = "Apache is running"
+
+	expectedDetail := func(availablePackageRef *corev1.AvailablePackageReference) *corev1.GetInstalledPackageDetailResponse {
+		return &corev1.GetInstalledPackageDetailResponse{
+			InstalledPackageDetail: &corev1.InstalledPackageDetail{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "1.0.1"},
+				Name:                "my-apache",
+				CurrentPkgVersion:   "1.0.1",
+				CurrentAppVersion:   "2.4.48",
+				ValuesApplied:       "replicaCount: 2\n",
+				Status: &corev1.InstalledPackageStatus{
+					Ready:      true,
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+					UserReason: "deployed",
+				},
+				PostInstallationNotes: "Apache is running",
+				AvailablePackageRef:   availablePackageRef,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		request          *corev1.GetInstalledPackageDetailRequest
+		catalogCharts    []*models.Chart
+		expectedStatus   codes.Code
+		expectedResponse *corev1.GetInstalledPackageDetailResponse
+	}{
+		{
+			name: "it returns the detail of the release with the catalog chart of its version",
+			request: &corev1.GetInstalledPackageDetailRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-apache",
+				},
+			},
+			catalogCharts:  []*models.Chart{makeChart("apache", "bitnami", globalPackagingNamespace, []string{"1.0.1", "1.0.0"})},
+			expectedStatus: codes.OK,
+			expectedResponse: expectedDetail(&corev1.AvailablePackageReference{
+				Context:    &corev1.Context{Namespace: globalPackagingNamespace},
+				Identifier: "bitnami/apache",
+				Plugin:     GetPluginDetail(),
+			}),
+		},
+		{
+			name: "it returns the detail of the release without a catalog chart of its version",
+			request: &corev1.GetInstalledPackageDetailRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-apache",
+				},
+			},
+			catalogCharts:    []*models.Chart{makeChart("apache", "bitnami", globalPackagingNamespace, []string{"1.0.0"})},
+			expectedStatus:   codes.OK,
+			expectedResponse: expectedDetail(nil),
+		},
+		{
+			name: "it returns not found for a missing release",
+			request: &corev1.GetInstalledPackageDetailRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-nginx",
+				},
+			},
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name: "it returns invalid argument without an identifier",
+			request: &corev1.GetInstalledPackageDetailRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{Cluster: "default", Namespace: "default"},
+				},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newTestActionConfig(t, "default",
+				newRelease("my-apache", "default", "apache", "1.0.0", 1, release.StatusSuperseded),
+				deployed,
+			)
+			s := makeReleasesServer(t, actionConfig, nil)
+			mock, cleanup, manager := setMockManager(t)
+			defer cleanup()
+			s.manager = manager
+
+			if tc.catalogCharts != nil {
+				rows := sqlmock.NewRows([]string{"info"})
+				for _, row := range makeChartRowsJSON(t, tc.catalogCharts, "", 0) {
+					rows.AddRow(row)
+				}
+				mock.ExpectQuery("SELECT info FROM").
+					WithArgs("default", globalPackagingNamespace, "apache").
+					WillReturnRows(rows)
+			}
+
+			response, err := s.GetInstalledPackageDetail(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("%+v", err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if got, want := response, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedInstalled) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedInstalled))
+			}
+		})
+	}
+}
+
+func TestReleaseFromSecret(t *testing.T) {
+	for _, gzipped := range []bool{false, true} {
+		t.Run(fmt.Sprintf("gzipped=%t", gzipped), func(t *testing.T) {
+			rel, err := releaseFromSecret(newReleaseSecret(t, "my-apache", "default", 2, release.StatusDeployed, gzipped))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := rel.Name, "my-apache"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := rel.Version, 2; got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+			if got, want := rel.Info.Status, release.StatusDeployed; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+
+	t.Run("it returns an error without a release", func(t *testing.T) {
+		if _, err := releaseFromSecret(&k8scorev1.Secret{}); err == nil {
+			t.Errorf("got: nil, want: error")
+		}
+	})
+}
+
+func TestWatchInstalledPackages(t *testing.T) {
+	clientSet := typfake.NewSimpleClientset()
+	watcher := watch.NewFake()
+	clientSet.PrependWatchReactor("secrets", k8stesting.DefaultWatchReactor(watcher, nil))
+	s := &Server{
+		clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
+			return clientSet, nil, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &testWatchStream{ctx: ctx}
+	done := make(chan error)
+	go func() {
+		done <- s.WatchInstalledPackages(&corev1.WatchInstalledPackagesRequest{
+			Context: &corev1.Context{Cluster: "default", Namespace: "default"},
+		}, stream)
+	}()
+
+	// Install, upgrade and uninstall a release, as helm does with the
+	// secret of each revision.
+	watcher.Add(newReleaseSecret(t, "my-apache", "default", 1, release.StatusPendingInstall, true))
+	watcher.Modify(newReleaseSecret(t, "my-apache", "default", 1, release.StatusDeployed, true))
+	watcher.Add(newReleaseSecret(t, "my-apache", "default", 2, release.StatusPendingUpgrade, true))
+	watcher.Modify(newReleaseSecret(t, "my-apache", "default", 1, release.StatusSuperseded, true))
+	watcher.Modify(newReleaseSecret(t, "my-apache", "default", 2, release.StatusFailed, true))
+	watcher.Delete(newReleaseSecret(t, "my-apache", "default", 2, release.StatusFailed, true))
+	watcher.Delete(newReleaseSecret(t, "my-apache", "default", 1, release.StatusSuperseded, true))
+	cancel()
+
+	if got, want := status.Code(<-done), codes.Canceled; got != want {
+		t.Fatalf("got: %+v, want: %+v", got, want)
+	}
+
+	expectedEvents := []*corev1.WatchInstalledPackagesResponse{
+		{
+			Type:                    corev1.WatchInstalledPackagesResponse_EVENT_TYPE_ADDED,
+			InstalledPackageSummary: installedSummary("my-apache", "1.0.1", false, corev1.InstalledPackageStatus_STATUS_REASON_PENDING, "pending-install"),
+		},
+		{
+			Type:                    corev1.WatchInstalledPackagesResponse_EVENT_TYPE_MODIFIED,
+			InstalledPackageSummary: installedSummary("my-apache", "1.0.1", true, corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED, "deployed"),
+		},
+		{
+			Type:                    corev1.WatchInstalledPackagesResponse_EVENT_TYPE_MODIFIED,
+			InstalledPackageSummary: installedSummary("my-apache", "1.0.2", false, corev1.InstalledPackageStatus_STATUS_REASON_PENDING, "pending-upgrade"),
+		},
+		{
+			Type:                    corev1.WatchInstalledPackagesResponse_EVENT_TYPE_MODIFIED,
+			InstalledPackageSummary: installedSummary("my-apache", "1.0.2", false, corev1.InstalledPackageStatus_STATUS_REASON_FAILED, "failed"),
+		},
+		{
+			Type:                    corev1.WatchInstalledPackagesResponse_EVENT_TYPE_DELETED,
+			InstalledPackageSummary: installedSummary("my-apache", "1.0.2", false, corev1.InstalledPackageStatus_STATUS_REASON_FAILED, "failed"),
+		},
+	}
+	opts := cmpopts.IgnoreUnexported(
+		corev1.WatchInstalledPackagesResponse{},
+		corev1.InstalledPackageSummary{},
+		corev1.InstalledPackageReference{},
+		corev1.InstalledPackageStatus{},
+		corev1.VersionReference{},
+		corev1.Context{},
+	)
+	if got, want := stream.events, expectedEvents; !cmp.Equal(want, got, opts) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+	}
+}
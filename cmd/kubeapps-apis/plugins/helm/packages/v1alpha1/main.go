@@ -34,18 +34,32 @@ func init() {
 	}
 }
 
+// pluginSettings are the settings of the plugin in the plugin config.
+type pluginSettings struct {
+	ChartURLInstall chartURLPolicy `json:"chartURLInstall"`
+}
+
 // RegisterWithGRPCServer enables a plugin to register with a gRPC server
 // returning the server implementation.
-func RegisterWithGRPCServer(s grpc.ServiceRegistrar, clientGetter server.KubernetesClientGetter) (interface{}, error) {
-	svr := NewServer(clientGetter)
+func RegisterWithGRPCServer(s grpc.ServiceRegistrar, clientGetter server.KubernetesClientGetter, configGetter server.KubernetesConfigGetter, pluginConfig *server.PluginConfig) (interface{}, error) {
+	var settings pluginSettings
+	if err := pluginConfig.DecodeSettings(&settings); err != nil {
+		return nil, err
+	}
+	svr := NewServer(clientGetter, configGetter, pluginConfig.KubeappsCluster, settings)
 	v1alpha1.RegisterHelmPackagesServiceServer(s, svr)
+	v1alpha1.RegisterHelmRepositoriesServiceServer(s, svr)
 	return svr, nil
 }
 
 // RegisterHTTPHandlerFromEndpoint enables a plugin to register an http
 // handler to translate to the gRPC request.
 func RegisterHTTPHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error {
-	return v1alpha1.RegisterHelmPackagesServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
+	err := v1alpha1.RegisterHelmPackagesServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
+	if err != nil {
+		return err
+	}
+	return v1alpha1.RegisterHelmRepositoriesServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
 }
 
 // GetPluginDetail returns a core.plugins.Plugin describing itself.
@@ -0,0 +1,456 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	apprepov1alpha1 "github.com/kubeapps/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/assetsvc/pkg/utils"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	repositories "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/repositories/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/server"
+	"github.com/kubeapps/kubeapps/pkg/agent"
+	chartutils "github.com/kubeapps/kubeapps/pkg/chart"
+	"github.com/kubeapps/kubeapps/pkg/chart/models"
+	"github.com/kubeapps/kubeapps/pkg/handlerutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/credentialprovider"
+)
+
+// UserAgent is the user agent of the requests fetching the charts.
+const UserAgent = "kubeapps-apis/plugins/helm.packages/v1alpha1"
+
+const (
+	// chartRepoNameAnnotation and chartRepoNamespaceAnnotation record, in the
+	// chart of each release, the AppRepository from which it was fetched.
+	chartRepoNameAnnotation      = "apprepositories.kubeapps.com/repo-name"
+	chartRepoNamespaceAnnotation = "apprepositories.kubeapps.com/repo-namespace"
+)
+
+// helmActionConfigGetter returns the helm action configuration to operate the
+// releases of a namespace of a cluster.
+type helmActionConfigGetter func(ctx context.Context, cluster, namespace string) (*action.Configuration, error)
+
+// newActionConfigGetter returns a helmActionConfigGetter storing the releases
+// in secrets, using the rest config of the request.
+func newActionConfigGetter(configGetter server.KubernetesConfigGetter) helmActionConfigGetter {
+	return func(ctx context.Context, cluster, namespace string) (*action.Configuration, error) {
+		if configGetter == nil {
+			return nil, status.Errorf(codes.Internal, "server not configured with configGetter")
+		}
+		config, err := configGetter(ctx, cluster)
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return nil, err
+			}
+			return nil, status.Errorf(codes.FailedPrecondition, "unable to get config : %v", err)
+		}
+		clientset, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "unable to create client : %v", err)
+		}
+		actionConfig, err := agent.NewActionConfig(agent.StorageForSecrets, config, clientset, namespace)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to create the helm action config : %v", err)
+		}
+		return actionConfig, nil
+	}
+}
+
+// getActionConfiguration ensures an action config getter is available and
+// uses it to return the helm action configuration for the namespace.
+func (s *Server) getActionConfiguration(ctx context.Context, cluster, namespace string) (*action.Configuration, error) {
+	if s.actionConfigGetter == nil {
+		return nil, status.Errorf(codes.Internal, "server not configured with actionConfigGetter")
+	}
+	return s.actionConfigGetter(ctx, cluster, namespace)
+}
+
+// CreateInstalledPackage installs the chart of an AppRepository as a helm
+// release in the target namespace.
+func (s *Server) CreateInstalledPackage(ctx context.Context, request *corev1.CreateInstalledPackageRequest) (*corev1.CreateInstalledPackageResponse, error) {
+	targetContext := request.GetTargetContext()
+	log.Infof("+helm CreateInstalledPackage (cluster=[%s], namespace=[%s], name=[%s])", targetContext.GetCluster(), targetContext.GetNamespace(), request.GetName())
+
+	ch, registrySecrets, err := s.chartForCreate(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return s.createRelease(ctx, targetContext, request.GetName(), request.GetValues(), ch, registrySecrets)
+}
+
+// createRelease installs the chart as a new release in the target context,
+// failing if a release with the name exists.
+func (s *Server) createRelease(ctx context.Context, targetContext *corev1.Context, name, values string, ch *chart.Chart, registrySecrets map[string]string) (*corev1.CreateInstalledPackageResponse, error) {
+	actionConfig, err := s.getActionConfiguration(ctx, targetContext.GetCluster(), targetContext.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	if _, err = agent.GetRelease(actionConfig, name); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "Unable to create the release %q: it already exists", name)
+	}
+	rel, err := agent.CreateRelease(actionConfig, name, targetContext.GetNamespace(), values, ch, registrySecrets)
+	if err != nil {
+		return nil, statusErrorFromHelmError(err, "create", name)
+	}
+
+	return &corev1.CreateInstalledPackageResponse{
+		InstalledPackageRef: installedPackageRefFromRelease(rel, targetContext.GetCluster()),
+	}, nil
+}
+
+// UpdateInstalledPackage upgrades a helm release to the requested version of
+// its chart, or to the same version when none is requested, with the values
+// of the request.
+func (s *Server) UpdateInstalledPackage(ctx context.Context, request *corev1.UpdateInstalledPackageRequest) (*corev1.UpdateInstalledPackageResponse, error) {
+	ref := request.GetInstalledPackageRef()
+	log.Infof("+helm UpdateInstalledPackage (cluster=[%s], namespace=[%s], name=[%s])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	ch, registrySecrets, err := s.chartForUpdate(ctx, actionConfig, request)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := agent.UpgradeRelease(actionConfig, ref.GetIdentifier(), request.GetValues(), ch, registrySecrets)
+	if err != nil {
+		return nil, statusErrorFromHelmError(err, "update", ref.GetIdentifier())
+	}
+
+	return &corev1.UpdateInstalledPackageResponse{
+		InstalledPackageRef: installedPackageRefFromRelease(rel, ref.GetContext().GetCluster()),
+	}, nil
+}
+
+// DeleteInstalledPackage uninstalls a helm release, without keeping its history.
+func (s *Server) DeleteInstalledPackage(ctx context.Context, request *corev1.DeleteInstalledPackageRequest) (*corev1.DeleteInstalledPackageResponse, error) {
+	ref := request.GetInstalledPackageRef()
+	log.Infof("+helm DeleteInstalledPackage (cluster=[%s], namespace=[%s], name=[%s])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	if err = agent.DeleteRelease(actionConfig, ref.GetIdentifier(), false); err != nil {
+		return nil, statusErrorFromHelmError(err, "delete", ref.GetIdentifier())
+	}
+	return &corev1.DeleteInstalledPackageResponse{}, nil
+}
+
+// RollbackInstalledPackage rolls back a helm release to a previous revision.
+func (s *Server) RollbackInstalledPackage(ctx context.Context, request *v1alpha1.RollbackInstalledPackageRequest) (*v1alpha1.RollbackInstalledPackageResponse, error) {
+	ref := request.GetInstalledPackageRef()
+	log.Infof("+helm RollbackInstalledPackage (cluster=[%s], namespace=[%s], name=[%s], revision=[%d])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier(), request.GetReleaseRevision())
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+	if request.GetReleaseRevision() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid release revision %d", request.GetReleaseRevision())
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	rel, err := agent.RollbackRelease(actionConfig, ref.GetIdentifier(), int(request.GetReleaseRevision()))
+	if err != nil {
+		return nil, statusErrorFromHelmError(err, "rollback", ref.GetIdentifier())
+	}
+
+	return &v1alpha1.RollbackInstalledPackageResponse{
+		InstalledPackageRef: installedPackageRefFromRelease(rel, ref.GetContext().GetCluster()),
+	}, nil
+}
+
+// validateInstalledPackageRef returns an InvalidArgument error if the
+// namespace or name of the release is missing.
+func validateInstalledPackageRef(ref *corev1.InstalledPackageReference) error {
+	if ref.GetContext().GetNamespace() == "" || ref.GetIdentifier() == "" {
+		return status.Errorf(codes.InvalidArgument, "Required context or identifier of the installed package not provided")
+	}
+	return nil
+}
+
+// installedPackageRefFromRelease returns the reference of the installed
+// package of a helm release.
+func installedPackageRefFromRelease(rel *release.Release, cluster string) *corev1.InstalledPackageReference {
+	return &corev1.InstalledPackageReference{
+		Context: &corev1.Context{
+			Cluster:   cluster,
+			Namespace: rel.Namespace,
+		},
+		Identifier: rel.Name,
+	}
+}
+
+// chartForCreate validates the request to create an installed package and
+// fetches the chart version to install, validating the values against its
+// schema before helm renders it.
+func (s *Server) chartForCreate(ctx context.Context, request *corev1.CreateInstalledPackageRequest) (*chart.Chart, map[string]string, error) {
+	availableRef := request.GetAvailablePackageRef()
+	if availableRef.GetContext().GetNamespace() == "" || availableRef.GetIdentifier() == "" {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Required context or identifier of the available package not provided")
+	}
+	targetContext := request.GetTargetContext()
+	if targetContext.GetNamespace() == "" || request.GetName() == "" {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Required target namespace or name not provided")
+	}
+	unescapedChartID, err := getUnescapedChartID(availableRef.GetIdentifier())
+	if err != nil {
+		return nil, nil, err
+	}
+	chartIDParts := strings.Split(unescapedChartID, "/")
+	ch, registrySecrets, err := s.fetchChart(ctx, availableRef.GetContext().GetNamespace(), chartIDParts[0], chartIDParts[1], request.GetPkgVersionReference().GetVersion())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = validateChartValues(ch, request.GetValues()); err != nil {
+		return nil, nil, err
+	}
+	return ch, registrySecrets, nil
+}
+
+// chartForUpdate fetches the chart version to which the release of the
+// request is upgraded, validating the values against its schema.
+func (s *Server) chartForUpdate(ctx context.Context, actionConfig *action.Configuration, request *corev1.UpdateInstalledPackageRequest) (*chart.Chart, map[string]string, error) {
+	ref := request.GetInstalledPackageRef()
+	rel, err := agent.GetRelease(actionConfig, ref.GetIdentifier())
+	if err != nil {
+		return nil, nil, statusErrorFromHelmError(err, "get", ref.GetIdentifier())
+	}
+	if rel.Chart == nil || rel.Chart.Metadata == nil {
+		return nil, nil, status.Errorf(codes.Internal, "Unable to find the chart of the release %q", ref.GetIdentifier())
+	}
+	version := request.GetPkgVersionReference().GetVersion()
+	if version == "" {
+		version = rel.Chart.Metadata.Version
+	}
+
+	// The chart version is fetched from the AppRepository recorded in the
+	// chart of the release. Releases installed without that record are
+	// looked up in the catalog instead.
+	repoNamespace, repoName, ok := chartAppRepository(rel.Chart)
+	if !ok {
+		catalogChart, err := s.getCatalogChart(ref.GetContext().GetNamespace(), rel.Chart.Metadata.Name, version)
+		if err != nil {
+			return nil, nil, err
+		}
+		repoNamespace, repoName = catalogChart.Repo.Namespace, catalogChart.Repo.Name
+	}
+	ch, registrySecrets, err := s.fetchChart(ctx, repoNamespace, repoName, rel.Chart.Metadata.Name, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = validateChartValues(ch, request.GetValues()); err != nil {
+		return nil, nil, err
+	}
+	return ch, registrySecrets, nil
+}
+
+// chartAppRepository returns the namespace and name of the AppRepository
+// recorded in the chart, if any.
+func chartAppRepository(ch *chart.Chart) (string, string, bool) {
+	if ch == nil || ch.Metadata == nil {
+		return "", "", false
+	}
+	name := ch.Metadata.Annotations[chartRepoNameAnnotation]
+	namespace := ch.Metadata.Annotations[chartRepoNamespaceAnnotation]
+	if name == "" || namespace == "" {
+		return "", "", false
+	}
+	return namespace, name, true
+}
+
+// getCatalogChart returns the chart of the catalog, available in the
+// namespace or the global packaging namespace, with the name and version.
+// It fails if several repositories provide the version of the chart.
+func (s *Server) getCatalogChart(namespace, chartName, version string) (*models.Chart, error) {
+	charts, err := s.getCatalogCharts(namespace, chartName)
+	if err != nil {
+		return nil, err
+	}
+	var found *models.Chart
+	for _, c := range charts {
+		if chartWithVersion([]*models.Chart{c}, version) == nil {
+			continue
+		}
+		if found != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "Unable to choose the repository of the version %q of the chart %q: it is provided by the repositories %q and %q", version, chartName, found.Repo.Name, c.Repo.Name)
+		}
+		found = c
+	}
+	if found == nil {
+		return nil, status.Errorf(codes.NotFound, "Unable to find the version %q of the chart %q available in the namespace %q", version, chartName, namespace)
+	}
+	return found, nil
+}
+
+// getCatalogCharts returns the charts of the catalog with the name, from any
+// repository available in the namespace or the global packaging namespace.
+func (s *Server) getCatalogCharts(namespace, chartName string) ([]*models.Chart, error) {
+	manager, err := s.GetManager()
+	if err != nil {
+		return nil, err
+	}
+	charts, _, err := manager.GetPaginatedChartListWithFilters(utils.ChartQuery{Namespace: namespace, ChartName: chartName}, 1, 0)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to retrieve the chart %q: %v", chartName, err)
+	}
+	return charts, nil
+}
+
+// chartWithVersion returns the first chart with a repository providing the
+// version, or nil if there is none.
+func chartWithVersion(charts []*models.Chart, version string) *models.Chart {
+	for _, c := range charts {
+		if c.Repo == nil {
+			continue
+		}
+		for _, v := range c.ChartVersions {
+			if v.Version == version {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// fetchChart fetches the chart version from the AppRepository, returning it
+// together with the dockerconfigjson secrets of the AppRepository per domain.
+func (s *Server) fetchChart(ctx context.Context, repoNamespace, repoName, chartName, version string) (*chart.Chart, map[string]string, error) {
+	appRepo, err := s.getAppRepository(ctx, &repositories.PackageRepositoryReference{
+		Context:    &corev1.Context{Namespace: repoNamespace},
+		Identifier: repoName,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	typedClient, _, err := s.GetClients(ctx, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	caCertSecret, authSecret, err := appRepositorySecrets(ctx, typedClient, appRepo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s.chartClientFactory == nil {
+		return nil, nil, status.Errorf(codes.Internal, "server not configured with chartClientFactory")
+	}
+	ch, err := handlerutil.GetChart(
+		&chartutils.Details{
+			AppRepositoryResourceName:      appRepo.Name,
+			AppRepositoryResourceNamespace: appRepo.Namespace,
+			ChartName:                      chartName,
+			Version:                        version,
+		},
+		appRepo,
+		caCertSecret, authSecret,
+		s.chartClientFactory.New(appRepo.Spec.Type, UserAgent),
+	)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Unable to fetch the chart %q from the AppRepository %q: %v", chartName, repoName, err)
+	}
+	if ch.Metadata.Annotations == nil {
+		ch.Metadata.Annotations = map[string]string{}
+	}
+	ch.Metadata.Annotations[chartRepoNameAnnotation] = appRepo.Name
+	ch.Metadata.Annotations[chartRepoNamespaceAnnotation] = appRepo.Namespace
+
+	registrySecrets, err := registrySecretsPerDomain(ctx, typedClient, appRepo.Spec.DockerRegistrySecrets, appRepo.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ch, registrySecrets, nil
+}
+
+// appRepositorySecrets returns the secrets holding the custom CA and the
+// authorization header of the AppRepository, if any.
+func appRepositorySecrets(ctx context.Context, client kubernetes.Interface, appRepo *apprepov1alpha1.AppRepository) (*k8scorev1.Secret, *k8scorev1.Secret, error) {
+	var caCertSecret, authSecret *k8scorev1.Secret
+	var err error
+	if customCA := appRepo.Spec.Auth.CustomCA; customCA != nil {
+		caCertSecret, err = client.CoreV1().Secrets(appRepo.Namespace).Get(ctx, customCA.SecretKeyRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, statusErrorFromK8sError(err, "get", "secret", customCA.SecretKeyRef.Name)
+		}
+	}
+	if header := appRepo.Spec.Auth.Header; header != nil {
+		authSecret, err = client.CoreV1().Secrets(appRepo.Namespace).Get(ctx, header.SecretKeyRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, statusErrorFromK8sError(err, "get", "secret", header.SecretKeyRef.Name)
+		}
+	}
+	return caCertSecret, authSecret, nil
+}
+
+// registrySecretsPerDomain returns the name of the dockerconfigjson secret
+// to use for each registry domain, so that they are added as image pull
+// secrets of the workloads pulling images from the domain.
+func registrySecretsPerDomain(ctx context.Context, client kubernetes.Interface, secretNames []string, namespace string) (map[string]string, error) {
+	secretsPerDomain := map[string]string{}
+	for _, secretName := range secretNames {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, statusErrorFromK8sError(err, "get", "secret", secretName)
+		}
+		if secret.Type != k8scorev1.SecretTypeDockerConfigJson {
+			return nil, status.Errorf(codes.FailedPrecondition, "AppRepository secret %q must be of type %q but has type %q", secretName, k8scorev1.SecretTypeDockerConfigJson, secret.Type)
+		}
+		dockerConfigJSONBytes, ok := secret.Data[AppRepositoryDockerConfigKey]
+		if !ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "AppRepository secret %q has no %q key", secretName, AppRepositoryDockerConfigKey)
+		}
+		dockerConfigJSON := credentialprovider.DockerConfigJSON{}
+		if err := json.Unmarshal(dockerConfigJSONBytes, &dockerConfigJSON); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "Unable to parse the AppRepository secret %q: %v", secretName, err)
+		}
+		for domain := range dockerConfigJSON.Auths {
+			secretsPerDomain[domain] = secretName
+		}
+	}
+	return secretsPerDomain, nil
+}
+
+// statusErrorFromHelmError returns a gRPC status error for the error of a
+// helm action on a release.
+func statusErrorFromHelmError(err error, verb, releaseName string) error {
+	code := codes.Internal
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		code = codes.NotFound
+	}
+	return status.Errorf(code, "Unable to %s the release %q: %v", verb, releaseName, err)
+}
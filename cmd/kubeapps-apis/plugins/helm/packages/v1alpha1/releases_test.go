@@ -0,0 +1,610 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apprepov1alpha1 "github.com/kubeapps/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	chartutils "github.com/kubeapps/kubeapps/pkg/chart"
+	"github.com/kubeapps/kubeapps/pkg/chart/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// fakeChartClient returns a chart with the requested name and version,
+// rendering a config map and validating its values with the schema, if any.
+type fakeChartClient struct {
+	schema []byte
+}
+
+const fakeChartTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+data:
+  greeting: {{ .Values.greeting | default "hello" }}
+`
+
+func (c *fakeChartClient) InitClient(appRepo *apprepov1alpha1.AppRepository, caCertSecret *k8scorev1.Secret, authSecret *k8scorev1.Secret) error {
+	return nil
+}
+
+func (c *fakeChartClient) GetChart(details *chartutils.Details, repoURL string) (*chart.Chart, error) {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       details.ChartName,
+			Version:    details.Version,
+		},
+		Templates: []*chart.File{
+			{Name: "templates/configmap.yaml", Data: []byte(fakeChartTemplate)},
+		},
+		Schema: c.schema,
+	}, nil
+}
+
+type fakeChartClientFactory struct {
+	schema []byte
+}
+
+func (f *fakeChartClientFactory) New(repoType, userAgent string) chartutils.Resolver {
+	return &fakeChartClient{schema: f.schema}
+}
+
+// newTestActionConfig returns a helm action configuration storing the
+// releases in memory.
+func newTestActionConfig(t *testing.T, namespace string, releases ...*release.Release) *action.Configuration {
+	memDriver := driver.NewMemory()
+	memDriver.SetNamespace(namespace)
+	actionConfig := &action.Configuration{
+		Releases:     storage.Init(memDriver),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: ioutil.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          t.Logf,
+	}
+	for _, rel := range releases {
+		if err := actionConfig.Releases.Create(rel); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	// The memory driver switches to the namespace of each created release.
+	memDriver.SetNamespace(namespace)
+	return actionConfig
+}
+
+func newRelease(name, namespace, chartName, version string, revision int, releaseStatus release.Status) *release.Release {
+	return &release.Release{
+		Name:      name,
+		Namespace: namespace,
+		Version:   revision,
+		Info:      &release.Info{Status: releaseStatus},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: chart.APIVersionV2,
+				Name:       chartName,
+				Version:    version,
+			},
+		},
+	}
+}
+
+// makeReleasesServer returns a server with the AppRepositories and secrets
+// and an action configuration storing the releases in memory.
+func makeReleasesServer(t *testing.T, actionConfig *action.Configuration, appRepos []*apprepov1alpha1.AppRepository, secrets ...runtime.Object) *Server {
+	s, _, _ := makeRepositoriesServer(t, appRepos, secrets...)
+	s.actionConfigGetter = func(context.Context, string, string) (*action.Configuration, error) {
+		return actionConfig, nil
+	}
+	s.chartClientFactory = &fakeChartClientFactory{}
+	return s
+}
+
+var bitnamiAppRepository = newAppRepository("bitnami", globalPackagingNamespace, apprepov1alpha1.AppRepositorySpec{
+	Type: "helm",
+	URL:  "https://charts.bitnami.com/bitnami",
+})
+
+var bitnamiMirrorAppRepository = newAppRepository("bitnami-mirror", globalPackagingNamespace, apprepov1alpha1.AppRepositorySpec{
+	Type: "helm",
+	URL:  "https://mirror.example.com/bitnami",
+})
+
+var ignoreUnexportedInstalledRef = cmpopts.IgnoreUnexported(
+	corev1.InstalledPackageReference{},
+	corev1.Context{},
+)
+
+func TestCreateInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name             string
+		request          *corev1.CreateInstalledPackageRequest
+		existingReleases []*release.Release
+		expectedStatus   codes.Code
+		expectedResponse *corev1.CreateInstalledPackageResponse
+	}{
+		{
+			name: "it installs the chart of the AppRepository",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: globalPackagingNamespace},
+					Identifier: "bitnami%2Fapache",
+				},
+				TargetContext:       &corev1.Context{Cluster: "default", Namespace: "default"},
+				Name:                "my-apache",
+				PkgVersionReference: &corev1.VersionReference{Version: "1.0.0"},
+				Values:              "replicaCount: 2",
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &corev1.CreateInstalledPackageResponse{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-apache",
+				},
+			},
+		},
+		{
+			name: "it returns an invalid argument error without a name",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: globalPackagingNamespace},
+					Identifier: "bitnami/apache",
+				},
+				TargetContext: &corev1.Context{Namespace: "default"},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "it returns an invalid argument error for an invalid identifier",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: globalPackagingNamespace},
+					Identifier: "apache",
+				},
+				TargetContext: &corev1.Context{Namespace: "default"},
+				Name:          "my-apache",
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "it returns a not found error if the AppRepository does not exist",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: globalPackagingNamespace},
+					Identifier: "stable/apache",
+				},
+				TargetContext: &corev1.Context{Namespace: "default"},
+				Name:          "my-apache",
+			},
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name: "it returns an already exists error if the release exists",
+			request: &corev1.CreateInstalledPackageRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: globalPackagingNamespace},
+					Identifier: "bitnami/apache",
+				},
+				TargetContext: &corev1.Context{Namespace: "default"},
+				Name:          "my-apache",
+			},
+			existingReleases: []*release.Release{
+				newRelease("my-apache", "default", "apache", "1.0.0", 1, release.StatusDeployed),
+			},
+			expectedStatus: codes.AlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newTestActionConfig(t, tc.request.GetTargetContext().GetNamespace(), tc.existingReleases...)
+			s := makeReleasesServer(t, actionConfig, []*apprepov1alpha1.AppRepository{bitnamiAppRepository})
+
+			response, err := s.CreateInstalledPackage(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if got, want := response, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedInstalledRef, cmpopts.IgnoreUnexported(corev1.CreateInstalledPackageResponse{})) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedInstalledRef, cmpopts.IgnoreUnexported(corev1.CreateInstalledPackageResponse{})))
+			}
+			rel, err := actionConfig.Releases.Last(tc.request.GetName())
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := rel.Chart.Metadata.Version, tc.request.GetPkgVersionReference().GetVersion(); got != want {
+				t.Errorf("got chart version: %q, want: %q", got, want)
+			}
+			if got, want := rel.Config, map[string]interface{}{"replicaCount": float64(2)}; !cmp.Equal(got, want) {
+				t.Errorf("mismatch in values (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestUpdateInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name             string
+		request          *corev1.UpdateInstalledPackageRequest
+		chartAnnotations map[string]string
+		catalogCharts    []*models.Chart
+		expectDBQuery    bool
+		expectedStatus   codes.Code
+		expectedVersion  string
+		expectedRepoName string
+	}{
+		{
+			name: "it upgrades the release to the requested version",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "2.0.0"},
+				Values:              "replicaCount: 3",
+			},
+			catalogCharts: []*models.Chart{
+				makeChart("apache", "bitnami", globalPackagingNamespace, []string{"2.0.0", "1.0.0"}),
+			},
+			expectDBQuery:    true,
+			expectedStatus:   codes.OK,
+			expectedVersion:  "2.0.0",
+			expectedRepoName: "bitnami",
+		},
+		{
+			name: "it upgrades the release with the same version if none is requested",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				Values: "replicaCount: 3",
+			},
+			catalogCharts: []*models.Chart{
+				makeChart("apache", "bitnami", globalPackagingNamespace, []string{"2.0.0", "1.0.0"}),
+			},
+			expectDBQuery:    true,
+			expectedStatus:   codes.OK,
+			expectedVersion:  "1.0.0",
+			expectedRepoName: "bitnami",
+		},
+		{
+			name: "it upgrades the release from the repository recorded in its chart",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "2.0.0"},
+			},
+			chartAnnotations: map[string]string{
+				chartRepoNameAnnotation:      "bitnami-mirror",
+				chartRepoNamespaceAnnotation: globalPackagingNamespace,
+			},
+			expectedStatus:   codes.OK,
+			expectedVersion:  "2.0.0",
+			expectedRepoName: "bitnami-mirror",
+		},
+		{
+			name: "it returns a failed precondition error if several repositories provide the version",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "2.0.0"},
+			},
+			catalogCharts: []*models.Chart{
+				makeChart("apache", "bitnami", globalPackagingNamespace, []string{"2.0.0", "1.0.0"}),
+				makeChart("apache", "bitnami-mirror", globalPackagingNamespace, []string{"2.0.0"}),
+			},
+			expectDBQuery:  true,
+			expectedStatus: codes.FailedPrecondition,
+		},
+		{
+			name: "it returns a not found error if the version is not in the catalog",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				PkgVersionReference: &corev1.VersionReference{Version: "3.0.0"},
+			},
+			catalogCharts: []*models.Chart{
+				makeChart("apache", "bitnami", globalPackagingNamespace, []string{"2.0.0", "1.0.0"}),
+			},
+			expectDBQuery:  true,
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name: "it returns a not found error if the release does not exist",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-wordpress",
+				},
+			},
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name: "it returns an invalid argument error without a namespace",
+			request: &corev1.UpdateInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Identifier: "my-apache",
+				},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rel := newRelease("my-apache", "default", "apache", "1.0.0", 1, release.StatusDeployed)
+			rel.Chart.Metadata.Annotations = tc.chartAnnotations
+			actionConfig := newTestActionConfig(t, "default", rel)
+			s := makeReleasesServer(t, actionConfig, []*apprepov1alpha1.AppRepository{bitnamiAppRepository, bitnamiMirrorAppRepository})
+			mock, cleanup, manager := setMockManager(t)
+			defer cleanup()
+			s.manager = manager
+
+			if tc.expectDBQuery {
+				rows := sqlmock.NewRows([]string{"info"})
+				for _, row := range makeChartRowsJSON(t, tc.catalogCharts, "", 0) {
+					rows.AddRow(row)
+				}
+				mock.ExpectQuery("SELECT info FROM").
+					WithArgs("default", globalPackagingNamespace, "apache").
+					WillReturnRows(rows)
+			}
+
+			response, err := s.UpdateInstalledPackage(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("%+v", err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if got, want := response.GetInstalledPackageRef().GetIdentifier(), "my-apache"; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			rel, err = actionConfig.Releases.Last("my-apache")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := rel.Version, 2; got != want {
+				t.Errorf("got revision: %d, want: %d", got, want)
+			}
+			if got, want := rel.Chart.Metadata.Version, tc.expectedVersion; got != want {
+				t.Errorf("got chart version: %q, want: %q", got, want)
+			}
+			if got, want := rel.Chart.Metadata.Annotations[chartRepoNameAnnotation], tc.expectedRepoName; got != want {
+				t.Errorf("got chart repository: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDeleteInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *corev1.DeleteInstalledPackageRequest
+		expectedStatus codes.Code
+	}{
+		{
+			name: "it uninstalls the release",
+			request: &corev1.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+			},
+			expectedStatus: codes.OK,
+		},
+		{
+			name: "it returns a not found error if the release does not exist",
+			request: &corev1.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-wordpress",
+				},
+			},
+			expectedStatus: codes.NotFound,
+		},
+		{
+			name: "it returns an invalid argument error without an identifier",
+			request: &corev1.DeleteInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context: &corev1.Context{Namespace: "default"},
+				},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newTestActionConfig(t, "default", newRelease("my-apache", "default", "apache", "1.0.0", 1, release.StatusDeployed))
+			s := makeReleasesServer(t, actionConfig, nil)
+
+			_, err := s.DeleteInstalledPackage(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if _, err = actionConfig.Releases.Last("my-apache"); err != driver.ErrReleaseNotFound {
+				t.Errorf("got: %+v, want: %+v", err, driver.ErrReleaseNotFound)
+			}
+		})
+	}
+}
+
+func TestRollbackInstalledPackage(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *v1alpha1.RollbackInstalledPackageRequest
+		expectedStatus codes.Code
+	}{
+		{
+			name: "it rolls back the release to the revision",
+			request: &v1alpha1.RollbackInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+					Identifier: "my-apache",
+				},
+				ReleaseRevision: 1,
+			},
+			expectedStatus: codes.OK,
+		},
+		{
+			name: "it returns an invalid argument error without a revision",
+			request: &v1alpha1.RollbackInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+			},
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "it returns a not found error if the release does not exist",
+			request: &v1alpha1.RollbackInstalledPackageRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-wordpress",
+				},
+				ReleaseRevision: 1,
+			},
+			expectedStatus: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newTestActionConfig(t, "default",
+				newRelease("my-apache", "default", "apache", "1.0.0", 1, release.StatusSuperseded),
+				newRelease("my-apache", "default", "apache", "2.0.0", 2, release.StatusDeployed),
+			)
+			s := makeReleasesServer(t, actionConfig, nil)
+
+			response, err := s.RollbackInstalledPackage(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			expectedRef := &corev1.InstalledPackageReference{
+				Context:    &corev1.Context{Cluster: "default", Namespace: "default"},
+				Identifier: "my-apache",
+			}
+			if got, want := response.GetInstalledPackageRef(), expectedRef; !cmp.Equal(got, want, ignoreUnexportedInstalledRef) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedInstalledRef))
+			}
+			rel, err := actionConfig.Releases.Last("my-apache")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := rel.Version, 3; got != want {
+				t.Errorf("got revision: %d, want: %d", got, want)
+			}
+			if got, want := rel.Chart.Metadata.Version, "1.0.0"; got != want {
+				t.Errorf("got chart version: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRegistrySecretsPerDomain(t *testing.T) {
+	testCases := []struct {
+		name            string
+		secrets         []runtime.Object
+		expectedStatus  codes.Code
+		expectedSecrets map[string]string
+		registrySecrets []string
+	}{
+		{
+			name: "it returns the secret of each domain",
+			secrets: []runtime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "registry-creds", Namespace: "default"},
+					Type:       k8scorev1.SecretTypeDockerConfigJson,
+					Data: map[string][]byte{
+						AppRepositoryDockerConfigKey: []byte(`{"auths":{"docker.io":{"auth":"Zm9vOmJhcg=="},"quay.io":{"auth":"Zm9vOmJhcg=="}}}`),
+					},
+				},
+			},
+			registrySecrets: []string{"registry-creds"},
+			expectedStatus:  codes.OK,
+			expectedSecrets: map[string]string{"docker.io": "registry-creds", "quay.io": "registry-creds"},
+		},
+		{
+			name: "it returns a failed precondition error for a secret of another type",
+			secrets: []runtime.Object{
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "registry-creds", Namespace: "default"},
+					Type:       k8scorev1.SecretTypeOpaque,
+				},
+			},
+			registrySecrets: []string{"registry-creds"},
+			expectedStatus:  codes.FailedPrecondition,
+		},
+		{
+			name:            "it returns a not found error for a missing secret",
+			registrySecrets: []string{"registry-creds"},
+			expectedStatus:  codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, clientSet, _ := makeRepositoriesServer(t, nil, tc.secrets...)
+
+			secrets, err := registrySecretsPerDomain(context.Background(), clientSet, tc.registrySecrets, "default")
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if got, want := secrets, tc.expectedSecrets; !cmp.Equal(got, want) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,721 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	apprepov1alpha1 "github.com/kubeapps/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	repositories "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/repositories/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/kube"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	k8scorev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	log "k8s.io/klog/v2"
+)
+
+// Compile-time statement to ensure this service implementation satisfies the core repositories API
+var _ repositories.RepositoriesServiceServer = (*Server)(nil)
+
+const (
+	// AppRepositoryAuthHeaderKey is the key of the secret holding the
+	// authorization header of an AppRepository.
+	AppRepositoryAuthHeaderKey = "authorizationHeader"
+	// AppRepositoryCustomCAKey is the key of the secret holding the custom CA
+	// of an AppRepository.
+	AppRepositoryCustomCAKey = "ca.crt"
+	// AppRepositoryDockerConfigKey is the key of a dockerconfigjson secret.
+	AppRepositoryDockerConfigKey = ".dockerconfigjson"
+)
+
+// appRepositoriesResource is the GroupVersionResource of the AppRepository CRD.
+var appRepositoriesResource = schema.GroupVersionResource{
+	Group:    apprepov1alpha1.SchemeGroupVersion.Group,
+	Version:  apprepov1alpha1.SchemeGroupVersion.Version,
+	Resource: "apprepositories",
+}
+
+// GetPackageRepositorySummaries returns the AppRepositories of the requested
+// namespace, or of every namespace accessible to the user if none is requested.
+func (s *Server) GetPackageRepositorySummaries(ctx context.Context, request *repositories.GetPackageRepositorySummariesRequest) (*repositories.GetPackageRepositorySummariesResponse, error) {
+	contextMsg := ""
+	if request.Context != nil {
+		contextMsg = fmt.Sprintf("(cluster=[%s], namespace=[%s])", request.Context.Cluster, request.Context.Namespace)
+	}
+	log.Infof("+helm GetPackageRepositorySummaries %s", contextMsg)
+
+	if err := s.checkAppRepositoriesCluster(request.GetContext().GetCluster()); err != nil {
+		return nil, err
+	}
+
+	client, err := s.getAppRepositoriesClient(ctx, request.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "list", "AppRepositories", "")
+	}
+
+	summaries := []*repositories.PackageRepositorySummary{}
+	for _, item := range list.Items {
+		appRepo, err := appRepositoryFromUnstructured(&item)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, packageRepositorySummaryFromAppRepository(appRepo))
+	}
+	return &repositories.GetPackageRepositorySummariesResponse{
+		PackageRepositorySummaries: summaries,
+	}, nil
+}
+
+// GetPackageRepositoryDetail returns the requested AppRepository.
+func (s *Server) GetPackageRepositoryDetail(ctx context.Context, request *repositories.GetPackageRepositoryDetailRequest) (*repositories.GetPackageRepositoryDetailResponse, error) {
+	log.Infof("+helm GetPackageRepositoryDetail %s", packageRepositoryRefMsg(request.GetPackageRepoRef()))
+
+	appRepo, err := s.getAppRepository(ctx, request.GetPackageRepoRef())
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := packageRepositoryDetailFromAppRepository(appRepo)
+	if err != nil {
+		return nil, err
+	}
+	return &repositories.GetPackageRepositoryDetailResponse{
+		PackageRepositoryDetail: detail,
+	}, nil
+}
+
+// CreatePackageRepository creates an AppRepository, together with the secret
+// holding its credentials when these are included in the request.
+func (s *Server) CreatePackageRepository(ctx context.Context, request *repositories.CreatePackageRepositoryRequest) (*repositories.CreatePackageRepositoryResponse, error) {
+	contextMsg := ""
+	if request.Context != nil {
+		contextMsg = fmt.Sprintf("(cluster=[%s], namespace=[%s])", request.Context.Cluster, request.Context.Namespace)
+	}
+	log.Infof("+helm CreatePackageRepository %s", contextMsg)
+
+	namespace := request.GetContext().GetNamespace()
+	if namespace == "" || request.GetName() == "" || request.GetUrl() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Required context namespace, name or url not provided")
+	}
+	if err := s.checkAppRepositoriesCluster(request.GetContext().GetCluster()); err != nil {
+		return nil, err
+	}
+	if request.GetInterval() != 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "The synchronization interval of AppRepositories cannot be configured per repository")
+	}
+
+	repoType := request.GetType()
+	if repoType == "" {
+		// Use helm type by default
+		repoType = "helm"
+	}
+	appRepo := &apprepov1alpha1.AppRepository{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apprepov1alpha1.SchemeGroupVersion.String(),
+			Kind:       "AppRepository",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      request.GetName(),
+			Namespace: namespace,
+		},
+		Spec: apprepov1alpha1.AppRepositorySpec{
+			Type:        repoType,
+			URL:         request.GetUrl(),
+			Description: request.GetDescription(),
+		},
+	}
+	if err := s.setAppRepositorySpec(appRepo, request.GetAuth(), request.GetCustomDetail()); err != nil {
+		return nil, err
+	}
+
+	client, err := s.getAppRepositoriesClient(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := appRepositoryToUnstructured(appRepo)
+	if err != nil {
+		return nil, err
+	}
+	obj, err = client.Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "create", "AppRepository", request.GetName())
+	}
+	if appRepo, err = appRepositoryFromUnstructured(obj); err != nil {
+		return nil, err
+	}
+
+	if err = s.applyAppRepositorySecret(ctx, appRepo, request.GetAuth()); err != nil {
+		return nil, err
+	}
+
+	return &repositories.CreatePackageRepositoryResponse{
+		PackageRepoRef: packageRepositoryReference(appRepo),
+	}, nil
+}
+
+// UpdatePackageRepository updates the spec of an existing AppRepository,
+// together with the secret holding its credentials when these are included
+// in the request. The auth of the AppRepository is kept when the request
+// doesn't include one.
+func (s *Server) UpdatePackageRepository(ctx context.Context, request *repositories.UpdatePackageRepositoryRequest) (*repositories.UpdatePackageRepositoryResponse, error) {
+	log.Infof("+helm UpdatePackageRepository %s", packageRepositoryRefMsg(request.GetPackageRepoRef()))
+
+	if request.GetUrl() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Required url not provided")
+	}
+	if request.GetInterval() != 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "The synchronization interval of AppRepositories cannot be configured per repository")
+	}
+
+	appRepo, err := s.getAppRepository(ctx, request.GetPackageRepoRef())
+	if err != nil {
+		return nil, err
+	}
+
+	previousAuth := appRepo.Spec.Auth
+	appRepo.Spec.URL = request.GetUrl()
+	appRepo.Spec.Description = request.GetDescription()
+	if err = s.setAppRepositorySpec(appRepo, request.GetAuth(), request.GetCustomDetail()); err != nil {
+		return nil, err
+	}
+
+	if appRepo, err = s.updateAppRepository(ctx, appRepo); err != nil {
+		return nil, err
+	}
+
+	if err = s.applyAppRepositorySecret(ctx, appRepo, request.GetAuth()); err != nil {
+		return nil, err
+	}
+	if err = s.deleteUnusedAppRepositorySecret(ctx, appRepo, previousAuth); err != nil {
+		return nil, err
+	}
+
+	return &repositories.UpdatePackageRepositoryResponse{
+		PackageRepoRef: packageRepositoryReference(appRepo),
+	}, nil
+}
+
+// DeletePackageRepository deletes an AppRepository, together with the copy of
+// its credentials kept in the global packaging namespace.
+func (s *Server) DeletePackageRepository(ctx context.Context, request *repositories.DeletePackageRepositoryRequest) (*repositories.DeletePackageRepositoryResponse, error) {
+	log.Infof("+helm DeletePackageRepository %s", packageRepositoryRefMsg(request.GetPackageRepoRef()))
+
+	appRepo, err := s.getAppRepository(ctx, request.GetPackageRepoRef())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.getAppRepositoriesClient(ctx, appRepo.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err = client.Delete(ctx, appRepo.Name, metav1.DeleteOptions{}); err != nil {
+		return nil, statusErrorFromK8sError(err, "delete", "AppRepository", appRepo.Name)
+	}
+
+	// The credentials in the namespace of the AppRepository are deleted
+	// with it (as it owns them), but not the copy in the global namespace.
+	if authReferencesAnySecret(appRepo.Spec.Auth) && appRepo.Namespace != s.globalPackagingNamespace {
+		typedClient, _, err := s.GetClients(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		secretName := kube.KubeappsSecretNameForRepo(appRepo.Name, appRepo.Namespace)
+		err = typedClient.CoreV1().Secrets(s.globalPackagingNamespace).Delete(ctx, secretName, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, statusErrorFromK8sError(err, "delete", "secret", secretName)
+		}
+	}
+
+	return &repositories.DeletePackageRepositoryResponse{}, nil
+}
+
+// RefreshPackageRepository forces the AppRepository to be synchronized again
+// by increasing its resyncRequests.
+func (s *Server) RefreshPackageRepository(ctx context.Context, request *repositories.RefreshPackageRepositoryRequest) (*repositories.RefreshPackageRepositoryResponse, error) {
+	log.Infof("+helm RefreshPackageRepository %s", packageRepositoryRefMsg(request.GetPackageRepoRef()))
+
+	appRepo, err := s.getAppRepository(ctx, request.GetPackageRepoRef())
+	if err != nil {
+		return nil, err
+	}
+
+	// An update is forced if the ResyncRequests property changes.
+	appRepo.Spec.ResyncRequests++
+	if appRepo, err = s.updateAppRepository(ctx, appRepo); err != nil {
+		return nil, err
+	}
+
+	return &repositories.RefreshPackageRepositoryResponse{
+		PackageRepoRef: packageRepositoryReference(appRepo),
+	}, nil
+}
+
+// checkAppRepositoriesCluster returns an Unimplemented error unless the
+// cluster is the one on which Kubeapps is installed, requested either by its
+// name or by the empty cluster.
+func (s *Server) checkAppRepositoriesCluster(cluster string) error {
+	if cluster != "" && cluster != s.kubeappsCluster {
+		return status.Errorf(codes.Unimplemented, "Not supported yet: request.Context.Cluster: [%v]", cluster)
+	}
+	return nil
+}
+
+// getAppRepositoriesClient returns the dynamic client for the AppRepositories
+// of a namespace, or of all namespaces if the namespace is empty. AppRepositories
+// are only managed in the cluster on which Kubeapps is installed.
+func (s *Server) getAppRepositoriesClient(ctx context.Context, namespace string) (dynamic.ResourceInterface, error) {
+	_, client, err := s.GetClients(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(appRepositoriesResource).Namespace(namespace), nil
+}
+
+// getAppRepository returns the AppRepository referenced by the request.
+func (s *Server) getAppRepository(ctx context.Context, ref *repositories.PackageRepositoryReference) (*apprepov1alpha1.AppRepository, error) {
+	namespace := ref.GetContext().GetNamespace()
+	if namespace == "" || ref.GetIdentifier() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Required context or identifier not provided")
+	}
+	if err := s.checkAppRepositoriesCluster(ref.GetContext().GetCluster()); err != nil {
+		return nil, err
+	}
+
+	client, err := s.getAppRepositoriesClient(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := client.Get(ctx, ref.GetIdentifier(), metav1.GetOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "get", "AppRepository", ref.GetIdentifier())
+	}
+	return appRepositoryFromUnstructured(obj)
+}
+
+// updateAppRepository updates the AppRepository, returning the updated result.
+func (s *Server) updateAppRepository(ctx context.Context, appRepo *apprepov1alpha1.AppRepository) (*apprepov1alpha1.AppRepository, error) {
+	client, err := s.getAppRepositoriesClient(ctx, appRepo.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := appRepositoryToUnstructured(appRepo)
+	if err != nil {
+		return nil, err
+	}
+	obj, err = client.Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "update", "AppRepository", appRepo.Name)
+	}
+	return appRepositoryFromUnstructured(obj)
+}
+
+// setAppRepositorySpec sets the auth and plugin specific fields of the
+// AppRepository spec from the request, leaving the auth unchanged when the
+// request has none.
+func (s *Server) setAppRepositorySpec(appRepo *apprepov1alpha1.AppRepository, auth *repositories.PackageRepositoryAuth, customDetail *anypb.Any) error {
+	if auth != nil {
+		repoAuth, err := appRepositoryAuth(appRepo.Name, auth)
+		if err != nil {
+			return err
+		}
+		appRepo.Spec.Auth = repoAuth
+	}
+
+	if customDetail != nil {
+		detail := &v1alpha1.HelmPackageRepositoryCustomDetail{}
+		if err := customDetail.UnmarshalTo(detail); err != nil {
+			return status.Errorf(codes.InvalidArgument, "Unable to unmarshal the custom detail: %v", err)
+		}
+		appRepo.Spec.OCIRepositories = detail.GetOciRepositories()
+		appRepo.Spec.TLSInsecureSkipVerify = detail.GetTlsInsecureSkipVerify()
+		appRepo.Spec.PassCredentials = detail.GetPassCredentials()
+		appRepo.Spec.DockerRegistrySecrets = detail.GetDockerRegistrySecrets()
+		appRepo.Spec.FilterRule = apprepov1alpha1.FilterRuleSpec{
+			JQ:        detail.GetFilterRule().GetJq(),
+			Variables: detail.GetFilterRule().GetVariables(),
+		}
+	}
+
+	if appRepo.Spec.Type == "oci" && len(appRepo.Spec.OCIRepositories) == 0 {
+		return status.Errorf(codes.InvalidArgument, "The list of OCI repositories is required for an OCI repository")
+	}
+	if len(appRepo.Spec.DockerRegistrySecrets) > 0 && appRepo.Namespace == s.globalPackagingNamespace {
+		return status.Errorf(codes.InvalidArgument, "Docker registry secrets cannot be set for AppRepositories available to all namespaces")
+	}
+	return nil
+}
+
+// appRepositoryAuth returns the AppRepositoryAuth referencing the secret with
+// the requested credentials, which is either an existing secret or the
+// secret created for the AppRepository.
+func appRepositoryAuth(repoName string, auth *repositories.PackageRepositoryAuth) (apprepov1alpha1.AppRepositoryAuth, error) {
+	repoAuth := apprepov1alpha1.AppRepositoryAuth{}
+	if auth == nil {
+		return repoAuth, nil
+	}
+
+	secretName := auth.GetSecretRef()
+	if secretName == "" {
+		secretName = secretNameForRepo(repoName)
+	}
+
+	switch auth.GetType() {
+	case repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_UNSPECIFIED:
+	case repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON:
+		if auth.GetSecretRef() == "" {
+			return repoAuth, status.Errorf(codes.InvalidArgument, "A secret reference is required for a docker config json authentication")
+		}
+		repoAuth.Header = &apprepov1alpha1.AppRepositoryAuthHeader{
+			SecretKeyRef: secretKeySelector(secretName, AppRepositoryDockerConfigKey),
+		}
+	default:
+		if auth.GetSecretRef() == "" && auth.GetHeader() == "" {
+			return repoAuth, status.Errorf(codes.InvalidArgument, "Either a secret reference or the credentials are required for the authentication")
+		}
+		repoAuth.Header = &apprepov1alpha1.AppRepositoryAuthHeader{
+			SecretKeyRef: secretKeySelector(secretName, AppRepositoryAuthHeaderKey),
+		}
+	}
+
+	if auth.GetCustomCa() != "" {
+		repoAuth.CustomCA = &apprepov1alpha1.AppRepositoryCustomCA{
+			SecretKeyRef: secretKeySelector(secretNameForRepo(repoName), AppRepositoryCustomCAKey),
+		}
+	}
+	return repoAuth, nil
+}
+
+// applyAppRepositorySecret creates or updates the secret with the credentials
+// included in the request, owned by the AppRepository. Unless the AppRepository
+// is in the global namespace, every key referenced by its auth is also copied to
+// the secret of the global namespace where the repository is synchronized, since
+// the apprepository-controller reads both the header and the custom CA from it,
+// whether they come from the created secret or from a referenced one.
+func (s *Server) applyAppRepositorySecret(ctx context.Context, appRepo *apprepov1alpha1.AppRepository, auth *repositories.PackageRepositoryAuth) error {
+	if auth == nil {
+		return nil
+	}
+	secret, err := appRepositorySecret(appRepo, auth)
+	if err != nil {
+		return err
+	}
+	copyToGlobal := appRepo.Namespace != s.globalPackagingNamespace && authReferencesAnySecret(appRepo.Spec.Auth)
+	if secret == nil && !copyToGlobal {
+		return nil
+	}
+	typedClient, _, err := s.GetClients(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	secrets := typedClient.CoreV1().Secrets(appRepo.Namespace)
+	if secret != nil {
+		if err = createOrUpdateSecret(ctx, secrets, secret); err != nil {
+			return statusErrorFromK8sError(err, "create", "secret", secret.Name)
+		}
+	}
+
+	if copyToGlobal {
+		data, err := appRepositoryAuthData(ctx, secrets, appRepo.Spec.Auth, secret)
+		if err != nil {
+			return err
+		}
+		globalSecret := &k8scorev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      kube.KubeappsSecretNameForRepo(appRepo.Name, appRepo.Namespace),
+				Namespace: s.globalPackagingNamespace,
+			},
+			StringData: data,
+		}
+		if err = createOrUpdateSecret(ctx, typedClient.CoreV1().Secrets(s.globalPackagingNamespace), globalSecret); err != nil {
+			return statusErrorFromK8sError(err, "create", "secret", globalSecret.Name)
+		}
+	}
+	return nil
+}
+
+// appRepositoryAuthData returns the value of every key referenced by the auth,
+// taken from the secret created for the credentials of the request, if any, or
+// read from the referenced secret of the namespace.
+func appRepositoryAuthData(ctx context.Context, secrets secretsClient, auth apprepov1alpha1.AppRepositoryAuth, created *k8scorev1.Secret) (map[string]string, error) {
+	refs := []k8scorev1.SecretKeySelector{}
+	if auth.Header != nil {
+		refs = append(refs, auth.Header.SecretKeyRef)
+	}
+	if auth.CustomCA != nil {
+		refs = append(refs, auth.CustomCA.SecretKeyRef)
+	}
+
+	data := map[string]string{}
+	for _, ref := range refs {
+		if created != nil && ref.Name == created.Name {
+			data[ref.Key] = created.StringData[ref.Key]
+			continue
+		}
+		secret, err := secrets.Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, statusErrorFromK8sError(err, "get", "secret", ref.Name)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "The secret %q does not have the key %q", ref.Name, ref.Key)
+		}
+		data[ref.Key] = string(value)
+	}
+	return data, nil
+}
+
+// deleteUnusedAppRepositorySecret deletes the secret created for the
+// credentials of the AppRepository when the updated auth no longer references
+// it, and its copy in the global namespace, holding every key referenced by the
+// auth, once the updated auth references no secret at all.
+func (s *Server) deleteUnusedAppRepositorySecret(ctx context.Context, appRepo *apprepov1alpha1.AppRepository, previousAuth apprepov1alpha1.AppRepositoryAuth) error {
+	secretName := secretNameForRepo(appRepo.Name)
+	deleteSecret := authReferencesSecret(previousAuth, secretName) && !authReferencesSecret(appRepo.Spec.Auth, secretName)
+	deleteCopy := authReferencesAnySecret(previousAuth) && !authReferencesAnySecret(appRepo.Spec.Auth)
+	if !deleteSecret && !deleteCopy {
+		return nil
+	}
+	typedClient, _, err := s.GetClients(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	if deleteSecret {
+		err = typedClient.CoreV1().Secrets(appRepo.Namespace).Delete(ctx, secretName, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return statusErrorFromK8sError(err, "delete", "secret", secretName)
+		}
+	}
+	if deleteCopy && appRepo.Namespace != s.globalPackagingNamespace {
+		copiedName := kube.KubeappsSecretNameForRepo(appRepo.Name, appRepo.Namespace)
+		err = typedClient.CoreV1().Secrets(s.globalPackagingNamespace).Delete(ctx, copiedName, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return statusErrorFromK8sError(err, "delete", "secret", copiedName)
+		}
+	}
+	return nil
+}
+
+// authReferencesSecret returns whether the header or the custom CA of the
+// auth are read from the secret.
+func authReferencesSecret(auth apprepov1alpha1.AppRepositoryAuth, secretName string) bool {
+	return (auth.Header != nil && auth.Header.SecretKeyRef.Name == secretName) ||
+		(auth.CustomCA != nil && auth.CustomCA.SecretKeyRef.Name == secretName)
+}
+
+// authReferencesAnySecret returns whether the header or the custom CA of the
+// auth are read from a secret.
+func authReferencesAnySecret(auth apprepov1alpha1.AppRepositoryAuth) bool {
+	return auth.Header != nil || auth.CustomCA != nil
+}
+
+// secretsClient is the subset of the typed secrets client used for applying
+// AppRepository secrets.
+type secretsClient interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*k8scorev1.Secret, error)
+	Create(ctx context.Context, secret *k8scorev1.Secret, opts metav1.CreateOptions) (*k8scorev1.Secret, error)
+	Update(ctx context.Context, secret *k8scorev1.Secret, opts metav1.UpdateOptions) (*k8scorev1.Secret, error)
+}
+
+func createOrUpdateSecret(ctx context.Context, client secretsClient, secret *k8scorev1.Secret) error {
+	_, err := client.Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil && k8serrors.IsAlreadyExists(err) {
+		_, err = client.Update(ctx, secret, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+// appRepositorySecret returns the secret holding the credentials included in
+// the request, or nil if the request only references an existing secret.
+func appRepositorySecret(appRepo *apprepov1alpha1.AppRepository, auth *repositories.PackageRepositoryAuth) (*k8scorev1.Secret, error) {
+	data := map[string]string{}
+	if auth.GetSecretRef() == "" && auth.GetHeader() != "" {
+		switch auth.GetType() {
+		case repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH:
+			data[AppRepositoryAuthHeaderKey] = "Basic " + base64.StdEncoding.EncodeToString([]byte(auth.GetHeader()))
+		case repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER:
+			data[AppRepositoryAuthHeaderKey] = "Bearer " + auth.GetHeader()
+		case repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_AUTHORIZATION_HEADER:
+			data[AppRepositoryAuthHeaderKey] = auth.GetHeader()
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "Unsupported authentication type %v with credentials", auth.GetType())
+		}
+	}
+	if auth.GetCustomCa() != "" {
+		data[AppRepositoryCustomCAKey] = auth.GetCustomCa()
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	blockOwnerDeletion := true
+	return &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretNameForRepo(appRepo.Name),
+			Namespace: appRepo.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         apprepov1alpha1.SchemeGroupVersion.String(),
+					Kind:               "AppRepository",
+					Name:               appRepo.Name,
+					UID:                appRepo.UID,
+					BlockOwnerDeletion: &blockOwnerDeletion,
+				},
+			},
+		},
+		StringData: data,
+	}, nil
+}
+
+// secretNameForRepo returns the name of the secret created for the
+// credentials of an AppRepository.
+func secretNameForRepo(repoName string) string {
+	return fmt.Sprintf("apprepo-%s", repoName)
+}
+
+func secretKeySelector(name, key string) k8scorev1.SecretKeySelector {
+	return k8scorev1.SecretKeySelector{
+		Key: key,
+		LocalObjectReference: k8scorev1.LocalObjectReference{
+			Name: name,
+		},
+	}
+}
+
+// packageRepositoryReference returns the reference of an AppRepository.
+func packageRepositoryReference(appRepo *apprepov1alpha1.AppRepository) *repositories.PackageRepositoryReference {
+	return &repositories.PackageRepositoryReference{
+		Context:    &corev1.Context{Namespace: appRepo.Namespace},
+		Identifier: appRepo.Name,
+		Plugin:     GetPluginDetail(),
+	}
+}
+
+// packageRepositorySummaryFromAppRepository builds a PackageRepositorySummary
+// from an AppRepository. AppRepositories do not report a status.
+func packageRepositorySummaryFromAppRepository(appRepo *apprepov1alpha1.AppRepository) *repositories.PackageRepositorySummary {
+	return &repositories.PackageRepositorySummary{
+		PackageRepoRef: packageRepositoryReference(appRepo),
+		Name:           appRepo.Name,
+		Description:    appRepo.Spec.Description,
+		Type:           appRepo.Spec.Type,
+		Url:            appRepo.Spec.URL,
+	}
+}
+
+// packageRepositoryDetailFromAppRepository builds a PackageRepositoryDetail
+// from an AppRepository, including only the references of its secrets.
+func packageRepositoryDetailFromAppRepository(appRepo *apprepov1alpha1.AppRepository) (*repositories.PackageRepositoryDetail, error) {
+	customDetail, err := anypb.New(&v1alpha1.HelmPackageRepositoryCustomDetail{
+		OciRepositories:       appRepo.Spec.OCIRepositories,
+		TlsInsecureSkipVerify: appRepo.Spec.TLSInsecureSkipVerify,
+		PassCredentials:       appRepo.Spec.PassCredentials,
+		DockerRegistrySecrets: appRepo.Spec.DockerRegistrySecrets,
+		FilterRule: &v1alpha1.RepositoryFilterRule{
+			Jq:        appRepo.Spec.FilterRule.JQ,
+			Variables: appRepo.Spec.FilterRule.Variables,
+		},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to marshal the custom detail: %v", err)
+	}
+
+	detail := &repositories.PackageRepositoryDetail{
+		PackageRepoRef: packageRepositoryReference(appRepo),
+		Name:           appRepo.Name,
+		Description:    appRepo.Spec.Description,
+		Type:           appRepo.Spec.Type,
+		Url:            appRepo.Spec.URL,
+		CustomDetail:   customDetail,
+	}
+	if header := appRepo.Spec.Auth.Header; header != nil {
+		detail.Auth = &repositories.PackageRepositoryAuth{
+			Type:      repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_AUTHORIZATION_HEADER,
+			SecretRef: header.SecretKeyRef.Name,
+		}
+		if header.SecretKeyRef.Key == AppRepositoryDockerConfigKey {
+			detail.Auth.Type = repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON
+		}
+	}
+	return detail, nil
+}
+
+func appRepositoryFromUnstructured(obj *unstructured.Unstructured) (*apprepov1alpha1.AppRepository, error) {
+	appRepo := &apprepov1alpha1.AppRepository{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), appRepo); err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to convert the AppRepository %q: %v", obj.GetName(), err)
+	}
+	return appRepo, nil
+}
+
+// appRepositoryToUnstructured converts the AppRepository through its JSON
+// representation, since the unstructured converter would keep unsigned
+// integers which cannot be deep copied.
+func appRepositoryToUnstructured(appRepo *apprepov1alpha1.AppRepository) (*unstructured.Unstructured, error) {
+	bytes, err := json.Marshal(appRepo)
+	if err == nil {
+		obj := &unstructured.Unstructured{}
+		if err = obj.UnmarshalJSON(bytes); err == nil {
+			return obj, nil
+		}
+	}
+	return nil, status.Errorf(codes.Internal, "Unable to convert the AppRepository %q: %v", appRepo.Name, err)
+}
+
+// statusErrorFromK8sError returns the gRPC status error corresponding to an
+// error returned by the k8s API.
+func statusErrorFromK8sError(err error, verb, resource, name string) error {
+	code := codes.Internal
+	switch {
+	case k8serrors.IsNotFound(err):
+		code = codes.NotFound
+	case k8serrors.IsAlreadyExists(err):
+		code = codes.AlreadyExists
+	case k8serrors.IsForbidden(err):
+		code = codes.PermissionDenied
+	case k8serrors.IsUnauthorized(err):
+		code = codes.Unauthenticated
+	case k8serrors.IsInvalid(err), k8serrors.IsBadRequest(err):
+		code = codes.InvalidArgument
+	case k8serrors.IsConflict(err):
+		code = codes.Aborted
+	}
+	if name == "" {
+		return status.Errorf(code, "Unable to %s the %s: %v", verb, resource, err)
+	}
+	return status.Errorf(code, "Unable to %s the %s %q: %v", verb, resource, name, err)
+}
+
+// packageRepositoryRefMsg returns a string describing the package repository
+// reference for logging.
+func packageRepositoryRefMsg(ref *repositories.PackageRepositoryReference) string {
+	return fmt.Sprintf("(cluster=[%s], namespace=[%s], identifier=[%s])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+}
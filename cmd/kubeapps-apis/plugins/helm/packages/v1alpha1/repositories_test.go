@@ -0,0 +1,715 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apprepov1alpha1 "github.com/kubeapps/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	repositories "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/repositories/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	typfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newAppRepository(name, namespace string, spec apprepov1alpha1.AppRepositorySpec) *apprepov1alpha1.AppRepository {
+	return &apprepov1alpha1.AppRepository{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apprepov1alpha1.SchemeGroupVersion.String(),
+			Kind:       "AppRepository",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+}
+
+// makeRepositoriesServer returns a server with fake clients including the
+// AppRepositories and secrets.
+func makeRepositoriesServer(t *testing.T, appRepos []*apprepov1alpha1.AppRepository, secrets ...runtime.Object) (*Server, *typfake.Clientset, dynamic.Interface) {
+	objects := []runtime.Object{}
+	for _, appRepo := range appRepos {
+		obj, err := appRepositoryToUnstructured(appRepo)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		objects = append(objects, obj)
+	}
+	dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			appRepositoriesResource: "AppRepositoryList",
+		},
+		objects...,
+	)
+	clientSet := typfake.NewSimpleClientset(secrets...)
+	return &Server{
+		clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
+			return clientSet, dynamicClient, nil
+		},
+		globalPackagingNamespace: globalPackagingNamespace,
+		kubeappsCluster:          "default",
+	}, clientSet, dynamicClient
+}
+
+func getAppRepository(t *testing.T, client dynamic.Interface, name, namespace string) *apprepov1alpha1.AppRepository {
+	obj, err := client.Resource(appRepositoriesResource).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	appRepo, err := appRepositoryFromUnstructured(obj)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return appRepo
+}
+
+var ignoreUnexportedRepositories = cmpopts.IgnoreUnexported(
+	repositories.PackageRepositorySummary{},
+	repositories.PackageRepositoryDetail{},
+	repositories.PackageRepositoryReference{},
+	repositories.PackageRepositoryAuth{},
+	corev1.Context{},
+	plugins.Plugin{},
+)
+
+func TestGetPackageRepositorySummaries(t *testing.T) {
+	appRepos := []*apprepov1alpha1.AppRepository{
+		newAppRepository("bitnami", "default", apprepov1alpha1.AppRepositorySpec{Type: "helm", URL: "https://charts.bitnami.com/bitnami", Description: "Bitnami charts"}),
+		newAppRepository("jetstack", "default", apprepov1alpha1.AppRepositorySpec{Type: "helm", URL: "https://charts.jetstack.io"}),
+		newAppRepository("other", "other-ns", apprepov1alpha1.AppRepositorySpec{Type: "helm", URL: "https://charts.example.com"}),
+	}
+
+	testCases := []struct {
+		name              string
+		request           *repositories.GetPackageRepositorySummariesRequest
+		statusCode        codes.Code
+		expectedSummaries []*repositories.PackageRepositorySummary
+	}{
+		{
+			name:       "it returns the AppRepositories of the requested namespace",
+			request:    &repositories.GetPackageRepositorySummariesRequest{Context: &corev1.Context{Namespace: "default"}},
+			statusCode: codes.OK,
+			expectedSummaries: []*repositories.PackageRepositorySummary{
+				{
+					PackageRepoRef: &repositories.PackageRepositoryReference{Context: &corev1.Context{Namespace: "default"}, Identifier: "bitnami", Plugin: GetPluginDetail()},
+					Name:           "bitnami",
+					Description:    "Bitnami charts",
+					Type:           "helm",
+					Url:            "https://charts.bitnami.com/bitnami",
+				},
+				{
+					PackageRepoRef: &repositories.PackageRepositoryReference{Context: &corev1.Context{Namespace: "default"}, Identifier: "jetstack", Plugin: GetPluginDetail()},
+					Name:           "jetstack",
+					Type:           "helm",
+					Url:            "https://charts.jetstack.io",
+				},
+			},
+		},
+		{
+			name:       "it returns the AppRepositories of the Kubeapps cluster requested by its name",
+			request:    &repositories.GetPackageRepositorySummariesRequest{Context: &corev1.Context{Cluster: "default", Namespace: "other-ns"}},
+			statusCode: codes.OK,
+			expectedSummaries: []*repositories.PackageRepositorySummary{
+				{
+					PackageRepoRef: &repositories.PackageRepositoryReference{Context: &corev1.Context{Namespace: "other-ns"}, Identifier: "other", Plugin: GetPluginDetail()},
+					Name:           "other",
+					Type:           "helm",
+					Url:            "https://charts.example.com",
+				},
+			},
+		},
+		{
+			name:       "it returns unimplemented for another cluster",
+			request:    &repositories.GetPackageRepositorySummariesRequest{Context: &corev1.Context{Cluster: "other", Namespace: "default"}},
+			statusCode: codes.Unimplemented,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, _ := makeRepositoriesServer(t, appRepos)
+
+			response, err := s.GetPackageRepositorySummaries(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != codes.OK {
+				return
+			}
+
+			opts := []cmp.Option{ignoreUnexportedRepositories, cmpopts.SortSlices(func(a, b *repositories.PackageRepositorySummary) bool { return a.Name < b.Name })}
+			if got, want := response.PackageRepositorySummaries, tc.expectedSummaries; !cmp.Equal(want, got, opts...) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts...))
+			}
+		})
+	}
+}
+
+func TestGetPackageRepositoryDetail(t *testing.T) {
+	appRepos := []*apprepov1alpha1.AppRepository{
+		newAppRepository("private", "default", apprepov1alpha1.AppRepositorySpec{
+			Type: "oci",
+			URL:  "https://registry.example.com",
+			Auth: apprepov1alpha1.AppRepositoryAuth{
+				Header: &apprepov1alpha1.AppRepositoryAuthHeader{SecretKeyRef: secretKeySelector("my-creds", AppRepositoryDockerConfigKey)},
+			},
+			OCIRepositories: []string{"apache", "nginx"},
+		}),
+	}
+
+	testCases := []struct {
+		name           string
+		ref            *repositories.PackageRepositoryReference
+		statusCode     codes.Code
+		expectedDetail *repositories.PackageRepositoryDetail
+		expectedCustom *v1alpha1.HelmPackageRepositoryCustomDetail
+	}{
+		{
+			name:       "it returns the AppRepository detail with its secret reference",
+			ref:        &repositories.PackageRepositoryReference{Context: &corev1.Context{Namespace: "default"}, Identifier: "private"},
+			statusCode: codes.OK,
+			expectedDetail: &repositories.PackageRepositoryDetail{
+				PackageRepoRef: &repositories.PackageRepositoryReference{Context: &corev1.Context{Namespace: "default"}, Identifier: "private", Plugin: GetPluginDetail()},
+				Name:           "private",
+				Type:           "oci",
+				Url:            "https://registry.example.com",
+				Auth: &repositories.PackageRepositoryAuth{
+					Type:      repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON,
+					SecretRef: "my-creds",
+				},
+			},
+			expectedCustom: &v1alpha1.HelmPackageRepositoryCustomDetail{
+				OciRepositories: []string{"apache", "nginx"},
+				FilterRule:      &v1alpha1.RepositoryFilterRule{},
+			},
+		},
+		{
+			name:       "it returns not found for a missing AppRepository",
+			ref:        &repositories.PackageRepositoryReference{Context: &corev1.Context{Namespace: "default"}, Identifier: "missing"},
+			statusCode: codes.NotFound,
+		},
+		{
+			name:       "it returns invalid argument without an identifier",
+			ref:        &repositories.PackageRepositoryReference{Context: &corev1.Context{Namespace: "default"}},
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, _ := makeRepositoriesServer(t, appRepos)
+
+			response, err := s.GetPackageRepositoryDetail(context.Background(), &repositories.GetPackageRepositoryDetailRequest{PackageRepoRef: tc.ref})
+
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != codes.OK {
+				return
+			}
+
+			detail := response.PackageRepositoryDetail
+			customDetail := &v1alpha1.HelmPackageRepositoryCustomDetail{}
+			if err := detail.CustomDetail.UnmarshalTo(customDetail); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			opts := []cmp.Option{ignoreUnexportedRepositories, cmpopts.IgnoreFields(repositories.PackageRepositoryDetail{}, "CustomDetail")}
+			if got, want := detail, tc.expectedDetail; !cmp.Equal(want, got, opts...) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts...))
+			}
+			customOpts := cmpopts.IgnoreUnexported(v1alpha1.HelmPackageRepositoryCustomDetail{}, v1alpha1.RepositoryFilterRule{})
+			if got, want := customDetail, tc.expectedCustom; !cmp.Equal(want, got, customOpts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, customOpts))
+			}
+		})
+	}
+}
+
+// newCredentialsSecret returns an existing secret referenced by the auth of
+// AppRepositories, with the data returned by the API server.
+func newCredentialsSecret() *k8scorev1.Secret {
+	return &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-creds", Namespace: "default"},
+		Data: map[string][]byte{
+			AppRepositoryDockerConfigKey: []byte(`{"auths":{}}`),
+			AppRepositoryAuthHeaderKey:   []byte("Bearer my-token"),
+		},
+	}
+}
+
+func TestCreatePackageRepository(t *testing.T) {
+	ociDetail, err := anypb.New(&v1alpha1.HelmPackageRepositoryCustomDetail{OciRepositories: []string{"apache"}})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	testCases := []struct {
+		name            string
+		request         *repositories.CreatePackageRepositoryRequest
+		statusCode      codes.Code
+		expectedSpec    apprepov1alpha1.AppRepositorySpec
+		expectedSecrets map[string]map[string]string
+	}{
+		{
+			name: "it creates a helm AppRepository by default",
+			request: &repositories.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "bitnami",
+				Url:     "https://charts.bitnami.com/bitnami",
+			},
+			statusCode:      codes.OK,
+			expectedSpec:    apprepov1alpha1.AppRepositorySpec{Type: "helm", URL: "https://charts.bitnami.com/bitnami"},
+			expectedSecrets: map[string]map[string]string{"default/my-creds": nil},
+		},
+		{
+			name: "it creates the secret for basic auth, copied to the global namespace",
+			request: &repositories.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "private",
+				Url:     "https://charts.example.com",
+				Auth: &repositories.PackageRepositoryAuth{
+					Type:     repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BASIC_AUTH,
+					Header:   "foo:bar",
+					CustomCa: "my-ca",
+				},
+			},
+			statusCode: codes.OK,
+			expectedSpec: apprepov1alpha1.AppRepositorySpec{
+				Type: "helm",
+				URL:  "https://charts.example.com",
+				Auth: apprepov1alpha1.AppRepositoryAuth{
+					Header:   &apprepov1alpha1.AppRepositoryAuthHeader{SecretKeyRef: secretKeySelector("apprepo-private", AppRepositoryAuthHeaderKey)},
+					CustomCA: &apprepov1alpha1.AppRepositoryCustomCA{SecretKeyRef: secretKeySelector("apprepo-private", AppRepositoryCustomCAKey)},
+				},
+			},
+			expectedSecrets: map[string]map[string]string{
+				"default/my-creds": nil,
+				"default/apprepo-private": {
+					AppRepositoryAuthHeaderKey: "Basic Zm9vOmJhcg==",
+					AppRepositoryCustomCAKey:   "my-ca",
+				},
+				globalPackagingNamespace + "/default-apprepo-private": {
+					AppRepositoryAuthHeaderKey: "Basic Zm9vOmJhcg==",
+					AppRepositoryCustomCAKey:   "my-ca",
+				},
+			},
+		},
+		{
+			name: "it references an existing docker config secret",
+			request: &repositories.CreatePackageRepositoryRequest{
+				Context:      &corev1.Context{Namespace: "default"},
+				Name:         "oci",
+				Type:         "oci",
+				Url:          "https://registry.example.com",
+				Auth:         &repositories.PackageRepositoryAuth{Type: repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON, SecretRef: "my-creds"},
+				CustomDetail: ociDetail,
+			},
+			statusCode: codes.OK,
+			expectedSpec: apprepov1alpha1.AppRepositorySpec{
+				Type: "oci",
+				URL:  "https://registry.example.com",
+				Auth: apprepov1alpha1.AppRepositoryAuth{
+					Header: &apprepov1alpha1.AppRepositoryAuthHeader{SecretKeyRef: secretKeySelector("my-creds", AppRepositoryDockerConfigKey)},
+				},
+				OCIRepositories: []string{"apache"},
+			},
+			expectedSecrets: map[string]map[string]string{
+				"default/my-creds": nil,
+				globalPackagingNamespace + "/default-apprepo-oci": {
+					AppRepositoryDockerConfigKey: `{"auths":{}}`,
+				},
+			},
+		},
+		{
+			name: "it copies the referenced secret with the custom CA to the global namespace",
+			request: &repositories.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "private",
+				Url:     "https://charts.example.com",
+				Auth: &repositories.PackageRepositoryAuth{
+					Type:      repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER,
+					SecretRef: "my-creds",
+					CustomCa:  "my-ca",
+				},
+			},
+			statusCode: codes.OK,
+			expectedSpec: apprepov1alpha1.AppRepositorySpec{
+				Type: "helm",
+				URL:  "https://charts.example.com",
+				Auth: apprepov1alpha1.AppRepositoryAuth{
+					Header:   &apprepov1alpha1.AppRepositoryAuthHeader{SecretKeyRef: secretKeySelector("my-creds", AppRepositoryAuthHeaderKey)},
+					CustomCA: &apprepov1alpha1.AppRepositoryCustomCA{SecretKeyRef: secretKeySelector("apprepo-private", AppRepositoryCustomCAKey)},
+				},
+			},
+			expectedSecrets: map[string]map[string]string{
+				"default/my-creds": nil,
+				"default/apprepo-private": {
+					AppRepositoryCustomCAKey: "my-ca",
+				},
+				globalPackagingNamespace + "/default-apprepo-private": {
+					AppRepositoryAuthHeaderKey: "Bearer my-token",
+					AppRepositoryCustomCAKey:   "my-ca",
+				},
+			},
+		},
+		{
+			name: "it does not copy a referenced secret of an AppRepository in the global namespace",
+			request: &repositories.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: globalPackagingNamespace},
+				Name:    "private",
+				Url:     "https://charts.example.com",
+				Auth: &repositories.PackageRepositoryAuth{
+					Type:      repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER,
+					SecretRef: "my-creds",
+				},
+			},
+			statusCode: codes.OK,
+			expectedSpec: apprepov1alpha1.AppRepositorySpec{
+				Type: "helm",
+				URL:  "https://charts.example.com",
+				Auth: apprepov1alpha1.AppRepositoryAuth{
+					Header: &apprepov1alpha1.AppRepositoryAuthHeader{SecretKeyRef: secretKeySelector("my-creds", AppRepositoryAuthHeaderKey)},
+				},
+			},
+			expectedSecrets: map[string]map[string]string{
+				"default/my-creds": nil,
+			},
+		},
+		{
+			name: "it returns invalid argument for an OCI repository without repositories",
+			request: &repositories.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "oci",
+				Type:    "oci",
+				Url:     "https://registry.example.com",
+			},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "it returns invalid argument for a docker config without a secret reference",
+			request: &repositories.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "private",
+				Url:     "https://charts.example.com",
+				Auth:    &repositories.PackageRepositoryAuth{Type: repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON},
+			},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "it returns invalid argument for an interval",
+			request: &repositories.CreatePackageRepositoryRequest{
+				Context:  &corev1.Context{Namespace: "default"},
+				Name:     "bitnami",
+				Url:      "https://charts.bitnami.com/bitnami",
+				Interval: 60,
+			},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "it returns already exists for an existing AppRepository",
+			request: &repositories.CreatePackageRepositoryRequest{
+				Context: &corev1.Context{Namespace: "default"},
+				Name:    "existing",
+				Url:     "https://charts.example.com",
+			},
+			statusCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, clientSet, dynamicClient := makeRepositoriesServer(t, []*apprepov1alpha1.AppRepository{
+				newAppRepository("existing", "default", apprepov1alpha1.AppRepositorySpec{Type: "helm", URL: "https://charts.example.com"}),
+			}, newCredentialsSecret())
+
+			response, err := s.CreatePackageRepository(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != codes.OK {
+				return
+			}
+
+			expectedRef := &repositories.PackageRepositoryReference{Context: tc.request.Context, Identifier: tc.request.Name, Plugin: GetPluginDetail()}
+			if got, want := response.PackageRepoRef, expectedRef; !cmp.Equal(want, got, ignoreUnexportedRepositories) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedRepositories))
+			}
+
+			appRepo := getAppRepository(t, dynamicClient, tc.request.Name, tc.request.Context.Namespace)
+			if got, want := appRepo.Spec, tc.expectedSpec; !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, cmpopts.EquateEmpty()))
+			}
+
+			secrets, err := clientSet.CoreV1().Secrets("").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			gotSecrets := map[string]map[string]string{}
+			for _, secret := range secrets.Items {
+				gotSecrets[secret.Namespace+"/"+secret.Name] = secret.StringData
+			}
+			if got, want := gotSecrets, tc.expectedSecrets; !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, cmpopts.EquateEmpty()))
+			}
+		})
+	}
+}
+
+func TestUpdatePackageRepository(t *testing.T) {
+	basicAuth := apprepov1alpha1.AppRepositoryAuth{
+		Header:   &apprepov1alpha1.AppRepositoryAuthHeader{SecretKeyRef: secretKeySelector("apprepo-private", AppRepositoryAuthHeaderKey)},
+		CustomCA: &apprepov1alpha1.AppRepositoryCustomCA{SecretKeyRef: secretKeySelector("apprepo-private", AppRepositoryCustomCAKey)},
+	}
+	existingSecrets := map[string]map[string]string{
+		"default/my-creds": nil,
+		"default/apprepo-private": {
+			AppRepositoryAuthHeaderKey: "Basic Zm9vOmJhcg==",
+			AppRepositoryCustomCAKey:   "my-ca",
+		},
+		globalPackagingNamespace + "/default-apprepo-private": {
+			AppRepositoryAuthHeaderKey: "Basic Zm9vOmJhcg==",
+			AppRepositoryCustomCAKey:   "my-ca",
+		},
+	}
+	ref := &repositories.PackageRepositoryReference{Context: &corev1.Context{Namespace: "default"}, Identifier: "private"}
+
+	testCases := []struct {
+		name            string
+		request         *repositories.UpdatePackageRepositoryRequest
+		statusCode      codes.Code
+		expectedSpec    apprepov1alpha1.AppRepositorySpec
+		expectedSecrets map[string]map[string]string
+	}{
+		{
+			name: "it keeps the auth and its secrets when the request has none",
+			request: &repositories.UpdatePackageRepositoryRequest{
+				PackageRepoRef: ref,
+				Url:            "https://charts.example.com/v2",
+				Description:    "Private charts",
+			},
+			statusCode: codes.OK,
+			expectedSpec: apprepov1alpha1.AppRepositorySpec{
+				Type:        "helm",
+				URL:         "https://charts.example.com/v2",
+				Description: "Private charts",
+				Auth:        basicAuth,
+			},
+			expectedSecrets: existingSecrets,
+		},
+		{
+			name: "it updates the credentials in the secrets",
+			request: &repositories.UpdatePackageRepositoryRequest{
+				PackageRepoRef: ref,
+				Url:            "https://charts.example.com",
+				Auth: &repositories.PackageRepositoryAuth{
+					Type:   repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_BEARER,
+					Header: "my-token",
+				},
+			},
+			statusCode: codes.OK,
+			expectedSpec: apprepov1alpha1.AppRepositorySpec{
+				Type: "helm",
+				URL:  "https://charts.example.com",
+				Auth: apprepov1alpha1.AppRepositoryAuth{
+					Header: &apprepov1alpha1.AppRepositoryAuthHeader{SecretKeyRef: secretKeySelector("apprepo-private", AppRepositoryAuthHeaderKey)},
+				},
+			},
+			expectedSecrets: map[string]map[string]string{
+				"default/my-creds": nil,
+				"default/apprepo-private": {
+					AppRepositoryAuthHeaderKey: "Bearer my-token",
+				},
+				globalPackagingNamespace + "/default-apprepo-private": {
+					AppRepositoryAuthHeaderKey: "Bearer my-token",
+				},
+			},
+		},
+		{
+			name: "it deletes the created secret no longer referenced and copies the referenced one",
+			request: &repositories.UpdatePackageRepositoryRequest{
+				PackageRepoRef: ref,
+				Url:            "https://charts.example.com",
+				Auth: &repositories.PackageRepositoryAuth{
+					Type:      repositories.PackageRepositoryAuth_PACKAGE_REPOSITORY_AUTH_TYPE_DOCKER_CONFIG_JSON,
+					SecretRef: "my-creds",
+				},
+			},
+			statusCode: codes.OK,
+			expectedSpec: apprepov1alpha1.AppRepositorySpec{
+				Type: "helm",
+				URL:  "https://charts.example.com",
+				Auth: apprepov1alpha1.AppRepositoryAuth{
+					Header: &apprepov1alpha1.AppRepositoryAuthHeader{SecretKeyRef: secretKeySelector("my-creds", AppRepositoryDockerConfigKey)},
+				},
+			},
+			expectedSecrets: map[string]map[string]string{
+				"default/my-creds": nil,
+				globalPackagingNamespace + "/default-apprepo-private": {
+					AppRepositoryDockerConfigKey: `{"auths":{}}`,
+				},
+			},
+		},
+		{
+			name: "it removes the auth and deletes its secrets with an empty auth",
+			request: &repositories.UpdatePackageRepositoryRequest{
+				PackageRepoRef: ref,
+				Url:            "https://charts.example.com",
+				Auth:           &repositories.PackageRepositoryAuth{},
+			},
+			statusCode:   codes.OK,
+			expectedSpec: apprepov1alpha1.AppRepositorySpec{Type: "helm", URL: "https://charts.example.com"},
+			expectedSecrets: map[string]map[string]string{
+				"default/my-creds": nil,
+			},
+		},
+		{
+			name: "it updates an AppRepository of the Kubeapps cluster requested by its name",
+			request: &repositories.UpdatePackageRepositoryRequest{
+				PackageRepoRef: &repositories.PackageRepositoryReference{Context: &corev1.Context{Cluster: "default", Namespace: "default"}, Identifier: "private"},
+				Url:            "https://charts.example.com/v2",
+			},
+			statusCode: codes.OK,
+			expectedSpec: apprepov1alpha1.AppRepositorySpec{
+				Type: "helm",
+				URL:  "https://charts.example.com/v2",
+				Auth: basicAuth,
+			},
+			expectedSecrets: existingSecrets,
+		},
+		{
+			name: "it returns unimplemented for another cluster",
+			request: &repositories.UpdatePackageRepositoryRequest{
+				PackageRepoRef: &repositories.PackageRepositoryReference{Context: &corev1.Context{Cluster: "other", Namespace: "default"}, Identifier: "private"},
+				Url:            "https://charts.example.com",
+			},
+			statusCode: codes.Unimplemented,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, clientSet, dynamicClient := makeRepositoriesServer(t, []*apprepov1alpha1.AppRepository{
+				newAppRepository("private", "default", apprepov1alpha1.AppRepositorySpec{Type: "helm", URL: "https://charts.example.com", Auth: basicAuth}),
+			},
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "apprepo-private", Namespace: "default"},
+					StringData: existingSecrets["default/apprepo-private"],
+				},
+				&k8scorev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "default-apprepo-private", Namespace: globalPackagingNamespace},
+					StringData: existingSecrets[globalPackagingNamespace+"/default-apprepo-private"],
+				},
+				newCredentialsSecret(),
+			)
+
+			_, err := s.UpdatePackageRepository(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != codes.OK {
+				return
+			}
+
+			appRepo := getAppRepository(t, dynamicClient, "private", "default")
+			if got, want := appRepo.Spec, tc.expectedSpec; !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, cmpopts.EquateEmpty()))
+			}
+
+			list, err := clientSet.CoreV1().Secrets("").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			gotSecrets := map[string]map[string]string{}
+			for _, secret := range list.Items {
+				gotSecrets[secret.Namespace+"/"+secret.Name] = secret.StringData
+			}
+			if got, want := gotSecrets, tc.expectedSecrets; !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, cmpopts.EquateEmpty()))
+			}
+		})
+	}
+}
+
+func TestRefreshPackageRepository(t *testing.T) {
+	s, _, dynamicClient := makeRepositoriesServer(t, []*apprepov1alpha1.AppRepository{
+		newAppRepository("bitnami", "default", apprepov1alpha1.AppRepositorySpec{Type: "helm", URL: "https://charts.bitnami.com/bitnami", ResyncRequests: 2}),
+	})
+
+	_, err := s.RefreshPackageRepository(context.Background(), &repositories.RefreshPackageRepositoryRequest{
+		PackageRepoRef: &repositories.PackageRepositoryReference{Context: &corev1.Context{Namespace: "default"}, Identifier: "bitnami"},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if got, want := getAppRepository(t, dynamicClient, "bitnami", "default").Spec.ResyncRequests, uint(3); got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestDeletePackageRepository(t *testing.T) {
+	copiedSecret := &k8scorev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-apprepo-private", Namespace: globalPackagingNamespace},
+	}
+	s, clientSet, dynamicClient := makeRepositoriesServer(t, []*apprepov1alpha1.AppRepository{
+		newAppRepository("private", "default", apprepov1alpha1.AppRepositorySpec{
+			Type: "helm",
+			URL:  "https://charts.example.com",
+			Auth: apprepov1alpha1.AppRepositoryAuth{
+				Header: &apprepov1alpha1.AppRepositoryAuthHeader{SecretKeyRef: secretKeySelector("apprepo-private", AppRepositoryAuthHeaderKey)},
+			},
+		}),
+	}, copiedSecret)
+
+	_, err := s.DeletePackageRepository(context.Background(), &repositories.DeletePackageRepositoryRequest{
+		PackageRepoRef: &repositories.PackageRepositoryReference{Context: &corev1.Context{Namespace: "default"}, Identifier: "private"},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	list, err := dynamicClient.Resource(appRepositoriesResource).Namespace("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(list.Items) != 0 {
+		t.Errorf("got %d AppRepositories, want none", len(list.Items))
+	}
+	secrets, err := clientSet.CoreV1().Secrets(globalPackagingNamespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(secrets.Items) != 0 {
+		t.Errorf("got %d secrets in the global namespace, want none", len(secrets.Items))
+	}
+}
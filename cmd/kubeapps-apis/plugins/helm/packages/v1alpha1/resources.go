@@ -0,0 +1,380 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/agent"
+	"github.com/kubeapps/kubeapps/pkg/yaml"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+	log "k8s.io/klog/v2"
+)
+
+var (
+	podsResource        = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	endpointsResource   = schema.GroupVersionResource{Version: "v1", Resource: "endpoints"}
+	replicaSetsResource = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+	jobsResource        = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+)
+
+// ownedResources are the resources which can be owned by a resource of each
+// kind, followed to build the resource tree.
+var ownedResources = map[schema.GroupKind][]schema.GroupVersionResource{
+	{Group: "apps", Kind: "Deployment"}:  {replicaSetsResource},
+	{Group: "apps", Kind: "ReplicaSet"}:  {podsResource},
+	{Group: "apps", Kind: "StatefulSet"}: {podsResource},
+	{Group: "apps", Kind: "DaemonSet"}:   {podsResource},
+	{Group: "batch", Kind: "Job"}:        {podsResource},
+	{Group: "batch", Kind: "CronJob"}:    {jobsResource},
+}
+
+// unhealthyWaitingReasons are the reasons of waiting containers which won't
+// start without an intervention.
+var unhealthyWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+}
+
+// GetInstalledPackageResourceTree returns the resources of the manifest of a
+// helm release, with the resources they own, and the health of each of them.
+func (s *Server) GetInstalledPackageResourceTree(ctx context.Context, request *v1alpha1.GetInstalledPackageResourceTreeRequest) (*v1alpha1.GetInstalledPackageResourceTreeResponse, error) {
+	ref := request.GetInstalledPackageRef()
+	log.Infof("+helm GetInstalledPackageResourceTree (cluster=[%s], namespace=[%s], name=[%s])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+	if err := validateInstalledPackageRef(ref); err != nil {
+		return nil, err
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, ref.GetContext().GetCluster(), ref.GetContext().GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	rel, err := agent.GetRelease(actionConfig, ref.GetIdentifier())
+	if err != nil {
+		return nil, statusErrorFromHelmError(err, "get", ref.GetIdentifier())
+	}
+	objects, err := yaml.ParseObjects(rel.Manifest)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to parse the manifest of the release %q: %v", ref.GetIdentifier(), err)
+	}
+
+	typedClient, dynamicClient, err := s.GetClients(ctx, ref.GetContext().GetCluster())
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := restMapperForClient(typedClient)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := &resourceTreeBuilder{
+		ctx:    ctx,
+		client: dynamicClient,
+		lists:  map[string][]unstructured.Unstructured{},
+	}
+	response := &v1alpha1.GetInstalledPackageResourceTreeResponse{
+		Resources: make([]*v1alpha1.ResourceNode, 0, len(objects)),
+		Health:    v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY,
+	}
+	for _, obj := range objects {
+		node, err := builder.nodeForManifestObject(mapper, obj, ref.GetContext().GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		response.Resources = append(response.Resources, node)
+		response.Health = worstHealth(response.Health, node)
+	}
+	return response, nil
+}
+
+// restMapperForClient returns a REST mapper of the resources discovered
+// with the client.
+func restMapperForClient(typedClient kubernetes.Interface) (meta.RESTMapper, error) {
+	groupResources, err := restmapper.GetAPIGroupResources(typedClient.Discovery())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to discover the API resources: %v", err)
+	}
+	return restmapper.NewDiscoveryRESTMapper(groupResources), nil
+}
+
+// resourceClientForObject returns the client of the resource of the object,
+// setting its namespace to the default one if it is namespaced and has none.
+func resourceClientForObject(dynamicClient dynamic.Interface, mapping *meta.RESTMapping, obj *unstructured.Unstructured, namespace string) dynamic.ResourceInterface {
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return dynamicClient.Resource(mapping.Resource)
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(namespace)
+	}
+	return dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+}
+
+// resourceTreeBuilder builds the nodes of a resource tree, listing the
+// resources which can be owned only once per namespace.
+type resourceTreeBuilder struct {
+	ctx    context.Context
+	client dynamic.Interface
+	lists  map[string][]unstructured.Unstructured
+}
+
+// nodeForManifestObject returns the node of an object of a manifest, fetched
+// from the cluster, or a missing node if it does not exist.
+func (b *resourceTreeBuilder) nodeForManifestObject(mapper meta.RESTMapper, obj *unstructured.Unstructured, namespace string) (*v1alpha1.ResourceNode, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return &v1alpha1.ResourceNode{
+			ApiVersion:    obj.GetAPIVersion(),
+			Kind:          obj.GetKind(),
+			Namespace:     obj.GetNamespace(),
+			Name:          obj.GetName(),
+			Health:        v1alpha1.ResourceHealth_RESOURCE_HEALTH_MISSING,
+			HealthMessage: fmt.Sprintf("Unknown resource: %v", err),
+		}, nil
+	}
+
+	resourceClient := resourceClientForObject(b.client, mapping, obj, namespace)
+	current, err := resourceClient.Get(b.ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return &v1alpha1.ResourceNode{
+				ApiVersion:    obj.GetAPIVersion(),
+				Kind:          obj.GetKind(),
+				Namespace:     obj.GetNamespace(),
+				Name:          obj.GetName(),
+				Health:        v1alpha1.ResourceHealth_RESOURCE_HEALTH_MISSING,
+				HealthMessage: "The resource does not exist",
+			}, nil
+		}
+		return nil, statusErrorFromK8sError(err, "get", mapping.Resource.Resource, obj.GetName())
+	}
+	return b.node(current)
+}
+
+// node returns the node of an existing object, with the nodes of the objects
+// it owns as children.
+func (b *resourceTreeBuilder) node(obj *unstructured.Unstructured) (*v1alpha1.ResourceNode, error) {
+	node := &v1alpha1.ResourceNode{
+		ApiVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+	var err error
+	node.Health, node.HealthMessage, err = b.health(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, gvr := range ownedResources[obj.GroupVersionKind().GroupKind()] {
+		candidates, err := b.list(gvr, obj.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		for i := range candidates {
+			if !isOwnedBy(&candidates[i], obj.GetUID()) {
+				continue
+			}
+			child, err := b.node(&candidates[i])
+			if err != nil {
+				return nil, err
+			}
+			node.Children = append(node.Children, child)
+		}
+	}
+	return node, nil
+}
+
+// list returns the resources of a namespace, listing them only once.
+func (b *resourceTreeBuilder) list(gvr schema.GroupVersionResource, namespace string) ([]unstructured.Unstructured, error) {
+	key := gvr.String() + "/" + namespace
+	if items, ok := b.lists[key]; ok {
+		return items, nil
+	}
+	list, err := b.client.Resource(gvr).Namespace(namespace).List(b.ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, statusErrorFromK8sError(err, "list", gvr.Resource, "")
+	}
+	b.lists[key] = list.Items
+	return list.Items, nil
+}
+
+// isOwnedBy returns whether the object has an owner reference to the uid.
+func isOwnedBy(obj *unstructured.Unstructured, uid types.UID) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// health returns the health of an object computed from its status, together
+// with a message when it is not healthy.
+func (b *resourceTreeBuilder) health(obj *unstructured.Unstructured) (v1alpha1.ResourceHealth, string, error) {
+	switch obj.GroupVersionKind().GroupKind() {
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		schema.GroupKind{Group: "apps", Kind: "ReplicaSet"},
+		schema.GroupKind{Group: "apps", Kind: "StatefulSet"}:
+		desired, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			desired = 1
+		}
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		return replicasHealth(ready, desired)
+	case schema.GroupKind{Group: "apps", Kind: "DaemonSet"}:
+		desired, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberReady")
+		return replicasHealth(ready, desired)
+	case schema.GroupKind{Group: "batch", Kind: "Job"}:
+		return jobHealth(obj)
+	case schema.GroupKind{Kind: "Pod"}:
+		return podHealth(obj)
+	case schema.GroupKind{Kind: "PersistentVolumeClaim"}:
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		switch phase {
+		case "Bound":
+			return v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY, "", nil
+		case "Lost":
+			return v1alpha1.ResourceHealth_RESOURCE_HEALTH_DEGRADED, "The claim lost its volume", nil
+		}
+		return v1alpha1.ResourceHealth_RESOURCE_HEALTH_PROGRESSING, "The claim is not bound", nil
+	case schema.GroupKind{Kind: "Service"}:
+		return b.serviceHealth(obj)
+	}
+	return v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY, "", nil
+}
+
+// replicasHealth returns the health of a workload from its ready replicas.
+func replicasHealth(ready, desired int64) (v1alpha1.ResourceHealth, string, error) {
+	if ready >= desired {
+		return v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY, "", nil
+	}
+	return v1alpha1.ResourceHealth_RESOURCE_HEALTH_PROGRESSING, fmt.Sprintf("%d/%d replicas ready", ready, desired), nil
+}
+
+// jobHealth returns the health of a job from its completions.
+func jobHealth(obj *unstructured.Unstructured) (v1alpha1.ResourceHealth, string, error) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["status"] != "True" {
+			continue
+		}
+		switch condition["type"] {
+		case "Complete":
+			return v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY, "", nil
+		case "Failed":
+			return v1alpha1.ResourceHealth_RESOURCE_HEALTH_DEGRADED, fmt.Sprintf("The job failed: %v", condition["message"]), nil
+		}
+	}
+	completions, found, _ := unstructured.NestedInt64(obj.Object, "spec", "completions")
+	if !found {
+		completions = 1
+	}
+	succeeded, _, _ := unstructured.NestedInt64(obj.Object, "status", "succeeded")
+	if succeeded >= completions {
+		return v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY, "", nil
+	}
+	return v1alpha1.ResourceHealth_RESOURCE_HEALTH_PROGRESSING, fmt.Sprintf("%d/%d completions", succeeded, completions), nil
+}
+
+// podHealth returns the health of a pod from its phase, its readiness and
+// the state of its containers.
+func podHealth(obj *unstructured.Unstructured) (v1alpha1.ResourceHealth, string, error) {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	switch phase {
+	case "Succeeded":
+		return v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY, "", nil
+	case "Failed":
+		reason, _, _ := unstructured.NestedString(obj.Object, "status", "reason")
+		return v1alpha1.ResourceHealth_RESOURCE_HEALTH_DEGRADED, fmt.Sprintf("The pod failed: %s", reason), nil
+	}
+
+	containerStatuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "containerStatuses")
+	for _, cs := range containerStatuses {
+		containerStatus, ok := cs.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		reason, _, _ := unstructured.NestedString(containerStatus, "state", "waiting", "reason")
+		if unhealthyWaitingReasons[reason] {
+			return v1alpha1.ResourceHealth_RESOURCE_HEALTH_DEGRADED, fmt.Sprintf("The container %v is waiting: %s", containerStatus["name"], reason), nil
+		}
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Ready" && condition["status"] == "True" {
+			return v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY, "", nil
+		}
+	}
+	return v1alpha1.ResourceHealth_RESOURCE_HEALTH_PROGRESSING, "The pod is not ready", nil
+}
+
+// serviceHealth returns the health of a service from its endpoints. Services
+// without a selector manage their own endpoints and are considered healthy.
+func (b *resourceTreeBuilder) serviceHealth(obj *unstructured.Unstructured) (v1alpha1.ResourceHealth, string, error) {
+	serviceType, _, _ := unstructured.NestedString(obj.Object, "spec", "type")
+	selector, _, _ := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if serviceType == "ExternalName" || len(selector) == 0 {
+		return v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY, "", nil
+	}
+
+	endpoints, err := b.client.Resource(endpointsResource).Namespace(obj.GetNamespace()).Get(b.ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return v1alpha1.ResourceHealth_RESOURCE_HEALTH_PROGRESSING, "The service has no endpoints", nil
+		}
+		return v1alpha1.ResourceHealth_RESOURCE_HEALTH_UNSPECIFIED, "", statusErrorFromK8sError(err, "get", endpointsResource.Resource, obj.GetName())
+	}
+	subsets, _, _ := unstructured.NestedSlice(endpoints.Object, "subsets")
+	for _, s := range subsets {
+		subset, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if addresses, ok := subset["addresses"].([]interface{}); ok && len(addresses) > 0 {
+			return v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY, "", nil
+		}
+	}
+	return v1alpha1.ResourceHealth_RESOURCE_HEALTH_PROGRESSING, "The service has no ready endpoints", nil
+}
+
+// worstHealth returns the worst of the health and those of the node and its
+// descendants.
+func worstHealth(health v1alpha1.ResourceHealth, node *v1alpha1.ResourceNode) v1alpha1.ResourceHealth {
+	if node.Health > health {
+		health = node.Health
+	}
+	for _, child := range node.Children {
+		health = worstHealth(health, child)
+	}
+	return health
+}
@@ -0,0 +1,333 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	typfake "k8s.io/client-go/kubernetes/fake"
+)
+
+const resourceTreeManifest = `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-apache
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: my-apache
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: my-apache-data
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: my-apache-migrate
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-apache-config
+`
+
+// newObject returns an object of the default namespace, owned by the owner
+// uid if any, with the fields.
+func newObject(apiVersion, kind, name, uid, ownerUID string, fields map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: fields}
+	if obj.Object == nil {
+		obj.Object = map[string]interface{}{}
+	}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetUID(types.UID(uid))
+	if ownerUID != "" {
+		obj.SetOwnerReferences([]metav1.OwnerReference{{UID: types.UID(ownerUID), Name: "owner"}})
+	}
+	return obj
+}
+
+// makeResourceTreeServer returns a server whose release my-apache has the
+// resource tree manifest, and whose clients return the objects.
+func makeResourceTreeServer(t *testing.T, objects ...runtime.Object) *Server {
+	rel := newRelease("my-apache", "default", "apache", "1.0.0", 1, release.StatusDeployed)
+	rel.Manifest = resourceTreeManifest
+	actionConfig := newTestActionConfig(t, "default", rel)
+
+	dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			podsResource:        "PodList",
+			replicaSetsResource: "ReplicaSetList",
+			jobsResource:        "JobList",
+		},
+		objects...,
+	)
+	clientSet := typfake.NewSimpleClientset()
+	clientSet.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+				{Name: "endpoints", Kind: "Endpoints", Namespaced: true},
+				{Name: "persistentvolumeclaims", Kind: "PersistentVolumeClaim", Namespaced: true},
+				{Name: "pods", Kind: "Pod", Namespaced: true},
+				{Name: "services", Kind: "Service", Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true},
+				{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: "batch/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "jobs", Kind: "Job", Namespaced: true},
+			},
+		},
+	}
+
+	return &Server{
+		clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
+			return clientSet, dynamicClient, nil
+		},
+		actionConfigGetter: func(context.Context, string, string) (*action.Configuration, error) {
+			return actionConfig, nil
+		},
+	}
+}
+
+func TestGetInstalledPackageResourceTree(t *testing.T) {
+	healthyService := newObject("v1", "Service", "my-apache", "svc-uid", "", map[string]interface{}{
+		"spec": map[string]interface{}{"selector": map[string]interface{}{"app": "apache"}},
+	})
+	readyEndpoints := newObject("v1", "Endpoints", "my-apache", "ep-uid", "", map[string]interface{}{
+		"subsets": []interface{}{
+			map[string]interface{}{"addresses": []interface{}{map[string]interface{}{"ip": "10.0.0.1"}}},
+		},
+	})
+	completedJob := newObject("batch/v1", "Job", "my-apache-migrate", "job-uid", "", map[string]interface{}{
+		"status": map[string]interface{}{"succeeded": int64(1)},
+	})
+	readyPod := newObject("v1", "Pod", "my-apache-abc-1", "pod-1-uid", "rs-uid", map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase":      "Running",
+			"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}},
+		},
+	})
+
+	testCases := []struct {
+		name             string
+		objects          []runtime.Object
+		request          *v1alpha1.GetInstalledPackageResourceTreeRequest
+		expectedStatus   codes.Code
+		expectedResponse *v1alpha1.GetInstalledPackageResourceTreeResponse
+	}{
+		{
+			name: "it returns the resource tree with the health of each resource",
+			objects: []runtime.Object{
+				newObject("apps/v1", "Deployment", "my-apache", "dep-uid", "", map[string]interface{}{
+					"spec":   map[string]interface{}{"replicas": int64(2)},
+					"status": map[string]interface{}{"readyReplicas": int64(1)},
+				}),
+				newObject("apps/v1", "ReplicaSet", "my-apache-abc", "rs-uid", "dep-uid", map[string]interface{}{
+					"spec":   map[string]interface{}{"replicas": int64(2)},
+					"status": map[string]interface{}{"readyReplicas": int64(1)},
+				}),
+				readyPod,
+				newObject("v1", "Pod", "my-apache-abc-2", "pod-2-uid", "rs-uid", map[string]interface{}{
+					"status": map[string]interface{}{
+						"phase": "Pending",
+						"containerStatuses": []interface{}{
+							map[string]interface{}{
+								"name":  "apache",
+								"state": map[string]interface{}{"waiting": map[string]interface{}{"reason": "ImagePullBackOff"}},
+							},
+						},
+					},
+				}),
+				newObject("v1", "Pod", "other", "other-uid", "other-rs-uid", nil),
+				healthyService,
+				readyEndpoints,
+				newObject("v1", "PersistentVolumeClaim", "my-apache-data", "pvc-uid", "", map[string]interface{}{
+					"status": map[string]interface{}{"phase": "Pending"},
+				}),
+				completedJob,
+			},
+			request: &v1alpha1.GetInstalledPackageResourceTreeRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.GetInstalledPackageResourceTreeResponse{
+				Resources: []*v1alpha1.ResourceNode{
+					{
+						ApiVersion:    "apps/v1",
+						Kind:          "Deployment",
+						Namespace:     "default",
+						Name:          "my-apache",
+						Health:        v1alpha1.ResourceHealth_RESOURCE_HEALTH_PROGRESSING,
+						HealthMessage: "1/2 replicas ready",
+						Children: []*v1alpha1.ResourceNode{
+							{
+								ApiVersion:    "apps/v1",
+								Kind:          "ReplicaSet",
+								Namespace:     "default",
+								Name:          "my-apache-abc",
+								Health:        v1alpha1.ResourceHealth_RESOURCE_HEALTH_PROGRESSING,
+								HealthMessage: "1/2 replicas ready",
+								Children: []*v1alpha1.ResourceNode{
+									{
+										ApiVersion: "v1",
+										Kind:       "Pod",
+										Namespace:  "default",
+										Name:       "my-apache-abc-1",
+										Health:     v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY,
+									},
+									{
+										ApiVersion:    "v1",
+										Kind:          "Pod",
+										Namespace:     "default",
+										Name:          "my-apache-abc-2",
+										Health:        v1alpha1.ResourceHealth_RESOURCE_HEALTH_DEGRADED,
+										HealthMessage: "The container apache is waiting: ImagePullBackOff",
+									},
+								},
+							},
+						},
+					},
+					{
+						ApiVersion: "v1",
+						Kind:       "Service",
+						Namespace:  "default",
+						Name:       "my-apache",
+						Health:     v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY,
+					},
+					{
+						ApiVersion:    "v1",
+						Kind:          "PersistentVolumeClaim",
+						Namespace:     "default",
+						Name:          "my-apache-data",
+						Health:        v1alpha1.ResourceHealth_RESOURCE_HEALTH_PROGRESSING,
+						HealthMessage: "The claim is not bound",
+					},
+					{
+						ApiVersion: "batch/v1",
+						Kind:       "Job",
+						Namespace:  "default",
+						Name:       "my-apache-migrate",
+						Health:     v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY,
+					},
+					{
+						ApiVersion:    "v1",
+						Kind:          "ConfigMap",
+						Namespace:     "default",
+						Name:          "my-apache-config",
+						Health:        v1alpha1.ResourceHealth_RESOURCE_HEALTH_MISSING,
+						HealthMessage: "The resource does not exist",
+					},
+				},
+				Health: v1alpha1.ResourceHealth_RESOURCE_HEALTH_DEGRADED,
+			},
+		},
+		{
+			name: "it returns a healthy tree when all the resources are healthy",
+			objects: []runtime.Object{
+				newObject("apps/v1", "Deployment", "my-apache", "dep-uid", "", map[string]interface{}{
+					"status": map[string]interface{}{"readyReplicas": int64(1)},
+				}),
+				newObject("apps/v1", "ReplicaSet", "my-apache-abc", "rs-uid", "dep-uid", map[string]interface{}{
+					"status": map[string]interface{}{"readyReplicas": int64(1)},
+				}),
+				readyPod,
+				healthyService,
+				readyEndpoints,
+				newObject("v1", "PersistentVolumeClaim", "my-apache-data", "pvc-uid", "", map[string]interface{}{
+					"status": map[string]interface{}{"phase": "Bound"},
+				}),
+				completedJob,
+				newObject("v1", "ConfigMap", "my-apache-config", "cm-uid", "", nil),
+			},
+			request: &v1alpha1.GetInstalledPackageResourceTreeRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-apache",
+				},
+			},
+			expectedStatus: codes.OK,
+		},
+		{
+			name: "it returns a not found error if the release does not exist",
+			request: &v1alpha1.GetInstalledPackageResourceTreeRequest{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "my-wordpress",
+				},
+			},
+			expectedStatus: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := makeResourceTreeServer(t, tc.objects...)
+
+			response, err := s.GetInstalledPackageResourceTree(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if tc.expectedResponse == nil {
+				if got, want := response.GetHealth(), v1alpha1.ResourceHealth_RESOURCE_HEALTH_HEALTHY; got != want {
+					t.Errorf("got: %s, want: %s, resources: %+v", got, want, response.GetResources())
+				}
+				return
+			}
+			opts := cmpopts.IgnoreUnexported(v1alpha1.GetInstalledPackageResourceTreeResponse{}, v1alpha1.ResourceNode{})
+			if got, want := response, tc.expectedResponse; !cmp.Equal(got, want, opts) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
@@ -27,8 +27,10 @@ import (
 	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
 	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/server"
 	"github.com/kubeapps/kubeapps/pkg/chart/models"
+	"github.com/kubeapps/kubeapps/pkg/handlerutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
@@ -45,20 +47,34 @@ const (
 	PatchVersionsInSummary = 3
 )
 
-// Server implements the helm packages v1alpha1 interface.
+// Server implements the helm packages and repositories v1alpha1 interfaces.
 type Server struct {
 	v1alpha1.UnimplementedHelmPackagesServiceServer
+	v1alpha1.UnimplementedHelmRepositoriesServiceServer
 	// clientGetter is a field so that it can be switched in tests for
 	// a fake client. NewServer() below sets this automatically with the
 	// non-test implementation.
-	clientGetter             server.KubernetesClientGetter
+	clientGetter server.KubernetesClientGetter
+	// actionConfigGetter returns the helm action configuration, switched
+	// in tests for one storing the releases in memory.
+	actionConfigGetter helmActionConfigGetter
+	// chartClientFactory returns the client fetching the charts of an
+	// AppRepository, switched in tests for a fake one.
+	chartClientFactory handlerutil.ResolverFactory
+	// chartURLFetcher fetches the charts installed from their URL, switched
+	// in tests for a fake one.
+	chartURLFetcher          chartURLFetcher
+	chartURLPolicy           chartURLPolicy
 	globalPackagingNamespace string
-	manager                  utils.AssetManager
+	// kubeappsCluster is the name of the cluster on which Kubeapps is
+	// installed, which can be requested as well as the empty cluster.
+	kubeappsCluster string
+	manager         utils.AssetManager
 }
 
 // NewServer returns a Server automatically configured with a function to obtain
 // the k8s client config.
-func NewServer(clientGetter server.KubernetesClientGetter) *Server {
+func NewServer(clientGetter server.KubernetesClientGetter, configGetter server.KubernetesConfigGetter, kubeappsCluster string, settings pluginSettings) *Server {
 	var kubeappsNamespace = os.Getenv("POD_NAMESPACE")
 	var ASSET_SYNCER_DB_URL = os.Getenv("ASSET_SYNCER_DB_URL")
 	var ASSET_SYNCER_DB_NAME = os.Getenv("ASSET_SYNCER_DB_NAME")
@@ -78,18 +94,29 @@ func NewServer(clientGetter server.KubernetesClientGetter) *Server {
 
 	return &Server{
 		clientGetter:             clientGetter,
+		actionConfigGetter:       newActionConfigGetter(configGetter),
+		chartClientFactory:       &handlerutil.ClientResolver{},
+		chartURLFetcher:          &remoteChartURLFetcher{userAgent: UserAgent, maxBytes: settings.ChartURLInstall.maxArchiveBytes()},
+		chartURLPolicy:           settings.ChartURLInstall,
 		manager:                  manager,
 		globalPackagingNamespace: kubeappsNamespace,
+		kubeappsCluster:          kubeappsCluster,
 	}
 }
 
-// GetClients ensures a client getter is available and uses it to return both a typed and dynamic k8s client.
-func (s *Server) GetClients(ctx context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+// GetClients ensures a client getter is available and uses it to return both a typed and dynamic k8s client
+// for the requested cluster, or the cluster on which Kubeapps is installed if empty.
+func (s *Server) GetClients(ctx context.Context, cluster string) (kubernetes.Interface, dynamic.Interface, error) {
 	if s.clientGetter == nil {
 		return nil, nil, status.Errorf(codes.Internal, "server not configured with configGetter")
 	}
-	typedClient, dynamicClient, err := s.clientGetter(ctx)
+	typedClient, dynamicClient, err := s.clientGetter(ctx, cluster)
 	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			// The client getter already returns a gRPC error for an invalid
+			// cluster or authorization.
+			return nil, nil, err
+		}
 		return nil, nil, status.Errorf(codes.FailedPrecondition, fmt.Sprintf("unable to get client : %v", err))
 	}
 	return typedClient, dynamicClient, nil
@@ -146,23 +173,50 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *core
 		cq.AppVersion = request.FilterOptions.AppVersion
 	}
 
+	versionFilter, err := newVersionFilter(request.GetFilterOptions().GetVersionConstraint(), request.GetFilterOptions().GetIncludePrereleases())
+	if err != nil {
+		return nil, err
+	}
+
 	pageSize := request.GetPaginationOptions().GetPageSize()
 	pageOffset, err := pageOffsetFromPageToken(request.GetPaginationOptions().GetPageToken())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Unable to intepret page token %q: %v", request.GetPaginationOptions().GetPageToken(), err)
 	}
-	charts, _, err := s.manager.GetPaginatedChartListWithFilters(cq, pageOffset, int(pageSize))
+	// The version constraint cannot be checked by the database, so every
+	// chart is retrieved to be filtered and paginated here.
+	queryPageOffset, queryPageSize := pageOffset, int(pageSize)
+	if versionFilter != nil {
+		queryPageOffset, queryPageSize = 1, 0
+	}
+	// A search query is matched with a ranked full-text search, returning
+	// the relevance and a highlighted snippet of each chart.
+	var results []*utils.ChartSearchResult
+	if cq.SearchQuery != "" {
+		results, _, err = s.manager.SearchPaginatedChartList(cq, queryPageOffset, queryPageSize)
+	} else {
+		var charts []*models.Chart
+		charts, _, err = s.manager.GetPaginatedChartListWithFilters(cq, queryPageOffset, queryPageSize)
+		for _, chart := range charts {
+			results = append(results, &utils.ChartSearchResult{Chart: chart})
+		}
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Unable to retrieve charts: %v", err)
 	}
+	if versionFilter != nil {
+		results = filterChartVersions(results, versionFilter, pageOffset, int(pageSize))
+	}
 
 	// Convert the charts response into a GetAvailablePackageSummariesResponse
 	responsePackages := []*corev1.AvailablePackageSummary{}
-	for _, chart := range charts {
-		pkg, err := AvailablePackageSummaryFromChart(chart)
+	for _, result := range results {
+		pkg, err := AvailablePackageSummaryFromChart(result.Chart)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "Unable to parse chart to an AvailablePackageSummary: %v", err)
 		}
+		pkg.SearchSnippet = result.Snippet
+		pkg.Relevance = result.Relevance
 		responsePackages = append(responsePackages, pkg)
 	}
 
@@ -178,6 +232,35 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *core
 	}, nil
 }
 
+// filterChartVersions keeps the versions of the charts matching the version
+// filter, dropping the charts without any, and returns the requested page.
+func filterChartVersions(results []*utils.ChartSearchResult, versionFilter *versionFilter, pageOffset, pageSize int) []*utils.ChartSearchResult {
+	filtered := []*utils.ChartSearchResult{}
+	for _, result := range results {
+		versions := versionFilter.filter(result.Chart.ChartVersions)
+		if len(versions) == 0 {
+			continue
+		}
+		result.Chart.ChartVersions = versions
+		filtered = append(filtered, result)
+	}
+	if pageSize <= 0 {
+		return filtered
+	}
+	if pageOffset < 1 {
+		pageOffset = 1
+	}
+	start := (pageOffset - 1) * pageSize
+	if start >= len(filtered) {
+		return []*utils.ChartSearchResult{}
+	}
+	end := start + pageSize
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+	return filtered[start:end]
+}
+
 // pageOffsetFromPageToken converts a page token to an integer offset
 // representing the page of results.
 // TODO(mnelson): When aggregating results from different plugins, we'll
@@ -276,6 +359,18 @@ func (s *Server) GetAvailablePackageDetail(ctx context.Context, request *corev1.
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Unable to parse chart to an availablePackageDetail: %v", err)
 	}
+	dependencies, err := s.chartDependencies(namespace, &chart)
+	if err != nil {
+		return nil, err
+	}
+	if len(dependencies) > 0 {
+		availablePackageDetail.CustomDetail, err = anypb.New(&v1alpha1.HelmAvailablePackageCustomDetail{
+			Dependencies: dependencies,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Unable to serialize the dependencies of the chart %q: %v", unescapedChartID, err)
+		}
+	}
 
 	return &corev1.GetAvailablePackageDetailResponse{
 		AvailablePackageDetail: availablePackageDetail,
@@ -303,11 +398,21 @@ func (s *Server) GetAvailablePackageVersions(ctx context.Context, request *corev
 		return nil, err
 	}
 
+	versionFilter, err := newVersionFilter(request.GetVersionConstraint(), request.GetIncludePrereleases())
+	if err != nil {
+		return nil, err
+	}
+
 	log.Infof("Requesting chart '%s' (latest version) in ns '%s'", unescapedChartID, namespace)
 	chart, err := s.manager.GetChart(namespace, unescapedChartID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Unable to retrieve chart: %v", err)
 	}
+	if versionFilter != nil {
+		return &corev1.GetAvailablePackageVersionsResponse{
+			PackageAppVersions: packageAppVersions(versionFilter.filter(chart.ChartVersions)),
+		}, nil
+	}
 	return &corev1.GetAvailablePackageVersionsResponse{
 		PackageAppVersions: packageAppVersionsSummary(chart.ChartVersions),
 	}, nil
@@ -400,12 +505,13 @@ func AvailablePackageDetailFromChart(chart *models.Chart) (*corev1.AvailablePack
 }
 
 // hasAccessToNamespace returns an error if the client does not have read access to a given namespace
+// of the cluster on which Kubeapps is installed, where the charts are synchronized.
 func (s *Server) hasAccessToNamespace(ctx context.Context, namespace string) error {
 	// If checking the global namespace, allow access always
 	if namespace == s.globalPackagingNamespace {
 		return nil
 	}
-	client, _, err := s.GetClients(ctx)
+	client, _, err := s.GetClients(ctx, "")
 	if err != nil {
 		return err
 	}
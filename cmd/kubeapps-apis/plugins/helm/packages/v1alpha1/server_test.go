@@ -26,6 +26,7 @@ import (
 	"github.com/kubeapps/kubeapps/cmd/assetsvc/pkg/utils"
 	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
 	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/server"
 	"github.com/kubeapps/kubeapps/pkg/chart/models"
 	"github.com/kubeapps/kubeapps/pkg/dbutils"
@@ -66,7 +67,7 @@ func TestGetClient(t *testing.T) {
 	if err != nil {
 		log.Fatalf("%s", err)
 	}
-	clientGetter := func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+	clientGetter := func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 		return typfake.NewSimpleClientset(), dynfake.NewSimpleDynamicClientWithCustomListKinds(
 			runtime.NewScheme(),
 			map[schema.GroupVersionResource]string{
@@ -106,7 +107,7 @@ func TestGetClient(t *testing.T) {
 		{
 			name:    "it returns failed-precondition when configGetter itself errors",
 			manager: manager,
-			clientGetter: func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+			clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 				return nil, nil, fmt.Errorf("Bang!")
 			},
 			statusCodeClient:  codes.FailedPrecondition,
@@ -123,7 +124,7 @@ func TestGetClient(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			s := Server{clientGetter: tc.clientGetter, manager: tc.manager}
 
-			typedClient, dynamicClient, errClient := s.GetClients(context.Background())
+			typedClient, dynamicClient, errClient := s.GetClients(context.Background(), "")
 
 			if got, want := status.Code(errClient), tc.statusCodeClient; got != want {
 				t.Errorf("got: %+v, want: %+v", got, want)
@@ -406,6 +407,12 @@ func makeChart(chart_name, repo_name, namespace string, chart_versions []string)
 }
 
 // makeChartRowsJSON returns a slice of paginated JSON chart info data.
+// withRepoURL sets the URL of the repository of the chart.
+func withRepoURL(ch *models.Chart, url string) *models.Chart {
+	ch.Repo.URL = url
+	return ch
+}
+
 func makeChartRowsJSON(t *testing.T, charts []*models.Chart, pageToken string, pageSize int) []string {
 	// Simulate the pagination by reducing the rows of JSON based on the offset and limit.
 	rowsJSON := []string{}
@@ -453,7 +460,7 @@ func makeServer(t *testing.T, authorized bool) (*Server, sqlmock.Sqlmock, func()
 			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: authorized},
 		}, nil
 	})
-	clientGetter := func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+	clientGetter := func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 		return clientSet, dynamicClient, nil
 	}
 
@@ -735,6 +742,53 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 	}
 }
 
+func TestGetAvailablePackageSummariesWithSearchQuery(t *testing.T) {
+	server, mock, cleanup := makeServer(t, true)
+	defer cleanup()
+
+	chartJSON, err := json.Marshal(makeChart("apache", "bitnami", "my-ns", []string{"3.0.0"}))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	mock.ExpectQuery("SELECT info, ts_rank_cd").
+		WithArgs("my-ns", server.globalPackagingNamespace, "apach:* & serv:*").
+		WillReturnRows(sqlmock.NewRows([]string{"info", "rank", "snippet"}).
+			AddRow(string(chartJSON), 0.4, "The <mark>Apache</mark> HTTP <mark>Server</mark>"))
+
+	response, err := server.GetAvailablePackageSummaries(context.Background(), &corev1.GetAvailablePackageSummariesRequest{
+		Context:       &corev1.Context{Namespace: "my-ns"},
+		FilterOptions: &corev1.FilterOptions{Query: "Apach Serv"},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expectedResponse := &corev1.GetAvailablePackageSummariesResponse{
+		AvailablePackagesSummaries: []*corev1.AvailablePackageSummary{
+			{
+				DisplayName:      "apache",
+				LatestPkgVersion: "3.0.0",
+				IconUrl:          DefaultChartIconURL,
+				ShortDescription: DefaultChartDescription,
+				SearchSnippet:    "The <mark>Apache</mark> HTTP <mark>Server</mark>",
+				Relevance:        0.4,
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "my-ns"},
+					Identifier: "bitnami/apache",
+					Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+				},
+			},
+		},
+	}
+	opt1 := cmpopts.IgnoreUnexported(corev1.GetAvailablePackageSummariesResponse{}, corev1.AvailablePackageSummary{}, corev1.AvailablePackageReference{}, corev1.Context{}, plugins.Plugin{})
+	if got, want := response, expectedResponse; !cmp.Equal(got, want, opt1) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opt1))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestAvailablePackageDetailFromChart(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -804,6 +858,10 @@ func TestGetAvailablePackageDetail(t *testing.T) {
 		statusCode       codes.Code
 		request          *corev1.GetAvailablePackageDetailRequest
 		authorized       bool
+		chartFiles       *models.ChartFiles
+		catalogCharts    map[string][]*models.Chart
+		catalogErrors    []string
+		expectedCustom   *v1alpha1.HelmAvailablePackageCustomDetail
 	}{
 		{
 			name:       "it returns an availablePackageDetail from the database (latest version)",
@@ -866,6 +924,141 @@ func TestGetAvailablePackageDetail(t *testing.T) {
 			},
 			statusCode: codes.OK,
 		},
+		{
+			name:       "it returns the dependencies resolved against the catalog",
+			authorized: true,
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "my-ns"},
+					Identifier: "foo/bar",
+				},
+			},
+			charts: []*models.Chart{makeChart("foo", "repo-1", "my-ns", []string{"3.0.0"})},
+			chartFiles: &models.ChartFiles{
+				ID: "repo-1/foo-3.0.0",
+				Dependencies: []models.ChartDependency{
+					{Name: "mariadb", Version: "9.x.x", Repository: "https://example.com/charts/", Condition: "mariadb.enabled"},
+					{Name: "common", Version: "1.x.x", Repository: "@other", Alias: "foo-common"},
+					{Name: "bundled", Repository: "file://charts/bundled"},
+				},
+			},
+			catalogCharts: map[string][]*models.Chart{
+				"mariadb": {
+					makeChart("mariadb", "other", "my-ns", []string{"10.0.0"}),
+					withRepoURL(makeChart("mariadb", "repo-1", "my-ns", []string{"10.0.0", "9.4.1", "9.4.0"}), "https://example.com/charts"),
+				},
+				"common": {makeChart("common", "other", "my-ns", []string{"2.0.0"})},
+			},
+			expectedPackage: &corev1.AvailablePackageDetail{
+				Name:             "foo",
+				DisplayName:      "foo",
+				IconUrl:          DefaultChartIconURL,
+				ShortDescription: DefaultChartDescription,
+				PkgVersion:       "3.0.0",
+				AppVersion:       DefaultAppVersion,
+				Readme:           "chart readme",
+				DefaultValues:    "chart values",
+				ValuesSchema:     "chart schema",
+				Maintainers:      []*corev1.Maintainer{{Name: "me", Email: "me@me.me"}},
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "my-ns"},
+					Identifier: "repo-1/foo",
+					Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+				},
+			},
+			expectedCustom: &v1alpha1.HelmAvailablePackageCustomDetail{
+				Dependencies: []*v1alpha1.ChartDependency{
+					{
+						Name:       "mariadb",
+						Version:    "9.x.x",
+						Repository: "https://example.com/charts/",
+						Condition:  "mariadb.enabled",
+						AvailablePackageRef: &corev1.AvailablePackageReference{
+							Context:    &corev1.Context{Namespace: "my-ns"},
+							Identifier: "repo-1/mariadb",
+							Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+						},
+						ResolvedPkgVersion: "9.4.1",
+					},
+					{
+						Name:       "common",
+						Version:    "1.x.x",
+						Repository: "@other",
+						Alias:      "foo-common",
+						AvailablePackageRef: &corev1.AvailablePackageReference{
+							Context:    &corev1.Context{Namespace: "my-ns"},
+							Identifier: "other/common",
+							Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+						},
+					},
+					{
+						Name:       "bundled",
+						Repository: "file://charts/bundled",
+					},
+				},
+			},
+			statusCode: codes.OK,
+		},
+		{
+			name:       "it leaves a dependency unresolved if the catalog lookup fails",
+			authorized: true,
+			request: &corev1.GetAvailablePackageDetailRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "my-ns"},
+					Identifier: "foo/bar",
+				},
+			},
+			charts: []*models.Chart{makeChart("foo", "repo-1", "my-ns", []string{"3.0.0"})},
+			chartFiles: &models.ChartFiles{
+				ID: "repo-1/foo-3.0.0",
+				Dependencies: []models.ChartDependency{
+					{Name: "mariadb", Version: "9.x.x", Repository: "https://example.com/charts/"},
+					{Name: "common", Version: "1.x.x", Repository: "@other"},
+				},
+			},
+			catalogCharts: map[string][]*models.Chart{
+				"common": {makeChart("common", "other", "my-ns", []string{"1.2.0"})},
+			},
+			catalogErrors: []string{"mariadb"},
+			expectedPackage: &corev1.AvailablePackageDetail{
+				Name:             "foo",
+				DisplayName:      "foo",
+				IconUrl:          DefaultChartIconURL,
+				ShortDescription: DefaultChartDescription,
+				PkgVersion:       "3.0.0",
+				AppVersion:       DefaultAppVersion,
+				Readme:           "chart readme",
+				DefaultValues:    "chart values",
+				ValuesSchema:     "chart schema",
+				Maintainers:      []*corev1.Maintainer{{Name: "me", Email: "me@me.me"}},
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "my-ns"},
+					Identifier: "repo-1/foo",
+					Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+				},
+			},
+			expectedCustom: &v1alpha1.HelmAvailablePackageCustomDetail{
+				Dependencies: []*v1alpha1.ChartDependency{
+					{
+						Name:       "mariadb",
+						Version:    "9.x.x",
+						Repository: "https://example.com/charts/",
+					},
+					{
+						Name:       "common",
+						Version:    "1.x.x",
+						Repository: "@other",
+						AvailablePackageRef: &corev1.AvailablePackageReference{
+							Context:    &corev1.Context{Namespace: "my-ns"},
+							Identifier: "other/common",
+							Plugin:     &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"},
+						},
+						ResolvedPkgVersion: "1.2.0",
+					},
+				},
+			},
+			statusCode: codes.OK,
+		},
 		{
 			name:       "it returns an internal error status if the chart is invalid",
 			authorized: true,
@@ -928,6 +1121,42 @@ func TestGetAvailablePackageDetail(t *testing.T) {
 					WithArgs(tc.request.AvailablePackageRef.Context.Namespace, tc.request.AvailablePackageRef.Identifier).
 					WillReturnRows(rows)
 			}
+			if tc.statusCode == codes.OK {
+				filesRows := sqlmock.NewRows([]string{"info"})
+				if tc.chartFiles != nil {
+					filesJSON, err := json.Marshal(tc.chartFiles)
+					if err != nil {
+						t.Fatalf("%+v", err)
+					}
+					filesRows.AddRow(string(filesJSON))
+				}
+				mock.ExpectQuery("SELECT info FROM files").
+					WithArgs("my-ns", "repo-1/foo-"+tc.expectedPackage.PkgVersion).
+					WillReturnRows(filesRows)
+				if tc.chartFiles == nil {
+					// The files are looked up again with the fallback query.
+					mock.ExpectQuery("SELECT info FROM files").
+						WillReturnRows(sqlmock.NewRows([]string{"info"}))
+				}
+				for _, name := range tc.catalogErrors {
+					mock.ExpectQuery("SELECT info FROM charts").
+						WithArgs("my-ns", server.globalPackagingNamespace, name).
+						WillReturnError(fmt.Errorf("boom"))
+				}
+				for _, name := range []string{"mariadb", "common"} {
+					catalogCharts, ok := tc.catalogCharts[name]
+					if !ok {
+						continue
+					}
+					catalogRows := sqlmock.NewRows([]string{"info"})
+					for _, row := range makeChartRowsJSON(t, catalogCharts, "", 0) {
+						catalogRows.AddRow(row)
+					}
+					mock.ExpectQuery("SELECT info FROM charts").
+						WithArgs("my-ns", server.globalPackagingNamespace, name).
+						WillReturnRows(catalogRows)
+				}
+			}
 			req := &corev1.GetAvailablePackageDetailRequest{
 				AvailablePackageRef: &corev1.AvailablePackageReference{
 					Context:    &corev1.Context{Namespace: "my-ns"},
@@ -943,9 +1172,26 @@ func TestGetAvailablePackageDetail(t *testing.T) {
 
 			if tc.statusCode == codes.OK {
 				opt1 := cmpopts.IgnoreUnexported(corev1.AvailablePackageDetail{}, corev1.AvailablePackageSummary{}, corev1.AvailablePackageReference{}, corev1.Context{}, plugins.Plugin{}, corev1.Maintainer{})
-				if got, want := availablePackageDetails.AvailablePackageDetail, tc.expectedPackage; !cmp.Equal(got, want, opt1) {
+				detail := availablePackageDetails.AvailablePackageDetail
+				customDetail := detail.CustomDetail
+				detail.CustomDetail = nil
+				if got, want := detail, tc.expectedPackage; !cmp.Equal(got, want, opt1) {
 					t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opt1))
 				}
+				if tc.expectedCustom == nil {
+					if customDetail != nil {
+						t.Errorf("got: %+v, want no custom detail", customDetail)
+					}
+				} else {
+					dependencies := &v1alpha1.HelmAvailablePackageCustomDetail{}
+					if err := customDetail.UnmarshalTo(dependencies); err != nil {
+						t.Fatalf("%+v", err)
+					}
+					customOpts := cmpopts.IgnoreUnexported(v1alpha1.HelmAvailablePackageCustomDetail{}, v1alpha1.ChartDependency{}, corev1.AvailablePackageReference{}, corev1.Context{}, plugins.Plugin{})
+					if got, want := dependencies, tc.expectedCustom; !cmp.Equal(got, want, customOpts) {
+						t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, customOpts))
+					}
+				}
 			}
 
 			// we make sure that all expectations were met
@@ -1019,6 +1265,55 @@ func TestGetAvailablePackageVersions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "it returns every version matching the constraint",
+			charts: []*models.Chart{makeChart("apache", "bitnami", "kubeapps", []string{"1.5.0", "1.4.3-rc.1", "1.4.2", "1.4.1", "1.3.0"})},
+			request: &corev1.GetAvailablePackageVersionsRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "kubeapps"},
+					Identifier: "bitnami/apache",
+				},
+				VersionConstraint: "~1.4",
+			},
+			expectedStatusCode: codes.OK,
+			expectedResponse: &corev1.GetAvailablePackageVersionsResponse{
+				PackageAppVersions: []*corev1.GetAvailablePackageVersionsResponse_PackageAppVersion{
+					{PkgVersion: "1.4.2", AppVersion: DefaultAppVersion},
+					{PkgVersion: "1.4.1", AppVersion: DefaultAppVersion},
+				},
+			},
+		},
+		{
+			name:   "it returns the prereleases matching the constraint if requested",
+			charts: []*models.Chart{makeChart("apache", "bitnami", "kubeapps", []string{"1.5.0", "1.4.3-rc.1", "1.4.2", "1.4.1", "1.3.0"})},
+			request: &corev1.GetAvailablePackageVersionsRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "kubeapps"},
+					Identifier: "bitnami/apache",
+				},
+				VersionConstraint:  "~1.4",
+				IncludePrereleases: true,
+			},
+			expectedStatusCode: codes.OK,
+			expectedResponse: &corev1.GetAvailablePackageVersionsResponse{
+				PackageAppVersions: []*corev1.GetAvailablePackageVersionsResponse_PackageAppVersion{
+					{PkgVersion: "1.4.3-rc.1", AppVersion: DefaultAppVersion},
+					{PkgVersion: "1.4.2", AppVersion: DefaultAppVersion},
+					{PkgVersion: "1.4.1", AppVersion: DefaultAppVersion},
+				},
+			},
+		},
+		{
+			name: "it returns invalid argument for an invalid constraint",
+			request: &corev1.GetAvailablePackageVersionsRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "kubeapps"},
+					Identifier: "bitnami/apache",
+				},
+				VersionConstraint: "not a constraint",
+			},
+			expectedStatusCode: codes.InvalidArgument,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,162 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Masterminds/semver"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/chart/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	log "k8s.io/klog/v2"
+)
+
+// GetInstalledPackageUpgrades reports, for each release of a namespace,
+// whether the catalog has a newer version of its chart and which kind of
+// version bump upgrading to it is.
+func (s *Server) GetInstalledPackageUpgrades(ctx context.Context, request *v1alpha1.GetInstalledPackageUpgradesRequest) (*v1alpha1.GetInstalledPackageUpgradesResponse, error) {
+	cluster, namespace := request.GetContext().GetCluster(), request.GetContext().GetNamespace()
+	log.Infof("+helm GetInstalledPackageUpgrades (cluster=[%s], namespace=[%s], summaryOnly=[%t])", cluster, namespace, request.GetSummaryOnly())
+	if namespace == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Required context namespace not provided")
+	}
+
+	actionConfig, err := s.getActionConfiguration(ctx, cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := action.NewList(actionConfig).Run()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to list the releases in the namespace %q: %v", namespace, err)
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Name < releases[j].Name
+	})
+
+	// The catalog is queried once for each chart, whatever the number of
+	// releases of the chart.
+	catalogCharts := map[string][]*models.Chart{}
+	upgrades := make([]*v1alpha1.InstalledPackageUpgrade, 0, len(releases))
+	summary := &v1alpha1.InstalledPackageUpgradesSummary{}
+	for _, rel := range releases {
+		if rel.Namespace != namespace || rel.Chart == nil || rel.Chart.Metadata == nil {
+			continue
+		}
+		chartName := rel.Chart.Metadata.Name
+		charts, ok := catalogCharts[chartName]
+		if !ok {
+			if charts, err = s.getCatalogCharts(namespace, chartName); err != nil {
+				return nil, err
+			}
+			catalogCharts[chartName] = charts
+		}
+
+		upgrade := installedPackageUpgrade(rel, cluster, chartWithVersion(charts, rel.Chart.Metadata.Version))
+		addToUpgradesSummary(summary, upgrade.UpgradeType)
+		if !request.GetSummaryOnly() {
+			upgrades = append(upgrades, upgrade)
+		}
+	}
+
+	return &v1alpha1.GetInstalledPackageUpgradesResponse{
+		Upgrades: upgrades,
+		Summary:  summary,
+	}, nil
+}
+
+// installedPackageUpgrade returns the upgrade of the release to the latest
+// version of the catalog chart, which is nil if the chart is not available.
+func installedPackageUpgrade(rel *release.Release, cluster string, catalogChart *models.Chart) *v1alpha1.InstalledPackageUpgrade {
+	upgrade := &v1alpha1.InstalledPackageUpgrade{
+		InstalledPackageRef: installedPackageRefFromRelease(rel, cluster),
+		CurrentPkgVersion:   rel.Chart.Metadata.Version,
+		CurrentAppVersion:   rel.Chart.Metadata.AppVersion,
+		UpgradeType:         v1alpha1.UpgradeType_UPGRADE_TYPE_UNSPECIFIED,
+	}
+	if catalogChart == nil {
+		return upgrade
+	}
+	upgrade.AvailablePackageRef = &corev1.AvailablePackageReference{
+		Context:    &corev1.Context{Namespace: catalogChart.Repo.Namespace},
+		Identifier: catalogChart.ID,
+		Plugin:     GetPluginDetail(),
+	}
+
+	current, err := semver.NewVersion(upgrade.CurrentPkgVersion)
+	if err != nil {
+		return upgrade
+	}
+	latest, latestVersion := latestChartVersion(catalogChart.ChartVersions, current.Prerelease() != "")
+	if latest == nil {
+		return upgrade
+	}
+	upgrade.LatestPkgVersion = latestVersion.Version
+	upgrade.LatestAppVersion = latestVersion.AppVersion
+	upgrade.UpgradeType = upgradeType(current, latest)
+	return upgrade
+}
+
+// latestChartVersion returns the greatest semantic version of the chart
+// versions, considering prereleases only if requested.
+func latestChartVersion(versions []models.ChartVersion, includePrereleases bool) (*semver.Version, models.ChartVersion) {
+	var latest *semver.Version
+	var latestVersion models.ChartVersion
+	for _, v := range versions {
+		version, err := semver.NewVersion(v.Version)
+		if err != nil || (version.Prerelease() != "" && !includePrereleases) {
+			continue
+		}
+		if latest == nil || version.GreaterThan(latest) {
+			latest, latestVersion = version, v
+		}
+	}
+	return latest, latestVersion
+}
+
+// upgradeType returns the kind of version bump from the current version to
+// the latest one. A release of a prerelease is a patch.
+func upgradeType(current, latest *semver.Version) v1alpha1.UpgradeType {
+	switch {
+	case !latest.GreaterThan(current):
+		return v1alpha1.UpgradeType_UPGRADE_TYPE_NONE
+	case latest.Major() != current.Major():
+		return v1alpha1.UpgradeType_UPGRADE_TYPE_MAJOR
+	case latest.Minor() != current.Minor():
+		return v1alpha1.UpgradeType_UPGRADE_TYPE_MINOR
+	default:
+		return v1alpha1.UpgradeType_UPGRADE_TYPE_PATCH
+	}
+}
+
+// addToUpgradesSummary counts a release with the upgrade type in the summary.
+func addToUpgradesSummary(summary *v1alpha1.InstalledPackageUpgradesSummary, upgradeType v1alpha1.UpgradeType) {
+	summary.Total++
+	switch upgradeType {
+	case v1alpha1.UpgradeType_UPGRADE_TYPE_NONE:
+		summary.UpToDate++
+	case v1alpha1.UpgradeType_UPGRADE_TYPE_PATCH:
+		summary.Patch++
+	case v1alpha1.UpgradeType_UPGRADE_TYPE_MINOR:
+		summary.Minor++
+	case v1alpha1.UpgradeType_UPGRADE_TYPE_MAJOR:
+		summary.Major++
+	default:
+		summary.Unknown++
+	}
+}
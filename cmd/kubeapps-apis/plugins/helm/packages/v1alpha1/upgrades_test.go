@@ -0,0 +1,197 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Masterminds/semver"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apprepov1alpha1 "github.com/kubeapps/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/chart/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+var ignoreUnexportedUpgrades = cmpopts.IgnoreUnexported(
+	v1alpha1.GetInstalledPackageUpgradesResponse{},
+	v1alpha1.InstalledPackageUpgrade{},
+	v1alpha1.InstalledPackageUpgradesSummary{},
+	corev1.InstalledPackageReference{},
+	corev1.AvailablePackageReference{},
+	corev1.Context{},
+	plugins.Plugin{},
+)
+
+func TestUpgradeType(t *testing.T) {
+	testCases := []struct {
+		current  string
+		latest   string
+		expected v1alpha1.UpgradeType
+	}{
+		{current: "1.2.3", latest: "1.2.3", expected: v1alpha1.UpgradeType_UPGRADE_TYPE_NONE},
+		{current: "1.2.3", latest: "1.2.0", expected: v1alpha1.UpgradeType_UPGRADE_TYPE_NONE},
+		{current: "1.2.3", latest: "1.2.4", expected: v1alpha1.UpgradeType_UPGRADE_TYPE_PATCH},
+		{current: "1.2.3", latest: "1.3.0", expected: v1alpha1.UpgradeType_UPGRADE_TYPE_MINOR},
+		{current: "1.2.3", latest: "2.0.0", expected: v1alpha1.UpgradeType_UPGRADE_TYPE_MAJOR},
+		{current: "2.0.0-rc.1", latest: "2.0.0", expected: v1alpha1.UpgradeType_UPGRADE_TYPE_PATCH},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.current+" to "+tc.latest, func(t *testing.T) {
+			got := upgradeType(semver.MustParse(tc.current), semver.MustParse(tc.latest))
+			if got != tc.expected {
+				t.Errorf("got: %s, want: %s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageUpgrades(t *testing.T) {
+	upgradeRef := func(name string) *corev1.InstalledPackageReference {
+		return &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Namespace: "default"},
+			Identifier: name,
+		}
+	}
+	availableRef := func(id string) *corev1.AvailablePackageReference {
+		return &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Namespace: globalPackagingNamespace},
+			Identifier: id,
+			Plugin:     GetPluginDetail(),
+		}
+	}
+	allUpgrades := []*v1alpha1.InstalledPackageUpgrade{
+		{
+			InstalledPackageRef: upgradeRef("my-apache"),
+			CurrentPkgVersion:   "1.0.0",
+			AvailablePackageRef: availableRef("bitnami/apache"),
+			LatestPkgVersion:    "1.0.2",
+			LatestAppVersion:    DefaultAppVersion,
+			UpgradeType:         v1alpha1.UpgradeType_UPGRADE_TYPE_PATCH,
+		},
+		{
+			InstalledPackageRef: upgradeRef("my-nginx"),
+			CurrentPkgVersion:   "1.2.0",
+			AvailablePackageRef: availableRef("bitnami/nginx"),
+			LatestPkgVersion:    "2.0.0",
+			LatestAppVersion:    DefaultAppVersion,
+			UpgradeType:         v1alpha1.UpgradeType_UPGRADE_TYPE_MAJOR,
+		},
+		{
+			InstalledPackageRef: upgradeRef("my-redis"),
+			CurrentPkgVersion:   "3.0.0",
+			UpgradeType:         v1alpha1.UpgradeType_UPGRADE_TYPE_UNSPECIFIED,
+		},
+		{
+			InstalledPackageRef: upgradeRef("other-apache"),
+			CurrentPkgVersion:   "1.0.2",
+			AvailablePackageRef: availableRef("bitnami/apache"),
+			LatestPkgVersion:    "1.0.2",
+			LatestAppVersion:    DefaultAppVersion,
+			UpgradeType:         v1alpha1.UpgradeType_UPGRADE_TYPE_NONE,
+		},
+	}
+	summary := &v1alpha1.InstalledPackageUpgradesSummary{Total: 4, UpToDate: 1, Patch: 1, Major: 1, Unknown: 1}
+
+	testCases := []struct {
+		name             string
+		request          *v1alpha1.GetInstalledPackageUpgradesRequest
+		expectedStatus   codes.Code
+		expectedResponse *v1alpha1.GetInstalledPackageUpgradesResponse
+	}{
+		{
+			name: "it returns the upgrade available for each release",
+			request: &v1alpha1.GetInstalledPackageUpgradesRequest{
+				Context: &corev1.Context{Namespace: "default"},
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.GetInstalledPackageUpgradesResponse{
+				Upgrades: allUpgrades,
+				Summary:  summary,
+			},
+		},
+		{
+			name: "it returns only the summary of the namespace if requested",
+			request: &v1alpha1.GetInstalledPackageUpgradesRequest{
+				Context:     &corev1.Context{Namespace: "default"},
+				SummaryOnly: true,
+			},
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.GetInstalledPackageUpgradesResponse{
+				Upgrades: []*v1alpha1.InstalledPackageUpgrade{},
+				Summary:  summary,
+			},
+		},
+		{
+			name:           "it returns invalid argument without a namespace",
+			request:        &v1alpha1.GetInstalledPackageUpgradesRequest{},
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actionConfig := newTestActionConfig(t, "default",
+				newRelease("my-apache", "default", "apache", "1.0.0", 1, release.StatusDeployed),
+				newRelease("my-nginx", "default", "nginx", "1.2.0", 1, release.StatusDeployed),
+				newRelease("my-redis", "default", "redis", "3.0.0", 1, release.StatusDeployed),
+				newRelease("other-apache", "default", "apache", "1.0.2", 1, release.StatusDeployed),
+			)
+			s := makeReleasesServer(t, actionConfig, []*apprepov1alpha1.AppRepository{bitnamiAppRepository})
+			mock, cleanup, manager := setMockManager(t)
+			defer cleanup()
+			s.manager = manager
+
+			if tc.expectedStatus == codes.OK {
+				// The catalog is queried once for each chart.
+				for chartName, catalogCharts := range map[string][]*models.Chart{
+					"apache": {makeChart("apache", "bitnami", globalPackagingNamespace, []string{"1.1.0-rc.1", "1.0.2", "1.0.0"})},
+					"nginx":  {makeChart("nginx", "bitnami", globalPackagingNamespace, []string{"2.0.0", "1.2.0"})},
+					"redis":  {makeChart("redis", "bitnami", globalPackagingNamespace, []string{"4.0.0"})},
+				} {
+					rows := sqlmock.NewRows([]string{"info"})
+					for _, row := range makeChartRowsJSON(t, catalogCharts, "", 0) {
+						rows.AddRow(row)
+					}
+					mock.ExpectQuery("SELECT info FROM").
+						WithArgs("default", globalPackagingNamespace, chartName).
+						WillReturnRows(rows)
+				}
+				mock.MatchExpectationsInOrder(false)
+			}
+
+			response, err := s.GetInstalledPackageUpgrades(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("%+v", err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if got, want := response, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedUpgrades) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedUpgrades))
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/chart/models"
+	"github.com/xeipuuv/gojsonschema"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	log "k8s.io/klog/v2"
+)
+
+// schemaKeywords maps the error types of gojsonschema to the JSON schema
+// keywords which failed.
+var schemaKeywords = map[string]string{
+	"invalid_type":                    "type",
+	"number_any_of":                   "anyOf",
+	"number_one_of":                   "oneOf",
+	"number_all_of":                   "allOf",
+	"number_not":                      "not",
+	"missing_dependency":              "dependencies",
+	"array_no_additional_items":       "additionalItems",
+	"array_min_items":                 "minItems",
+	"array_max_items":                 "maxItems",
+	"unique":                          "uniqueItems",
+	"array_min_properties":            "minProperties",
+	"array_max_properties":            "maxProperties",
+	"additional_property_not_allowed": "additionalProperties",
+	"invalid_property_pattern":        "patternProperties",
+	"invalid_property_name":           "propertyNames",
+	"string_gte":                      "minLength",
+	"string_lte":                      "maxLength",
+	"multiple_of":                     "multipleOf",
+	"number_gte":                      "minimum",
+	"number_gt":                       "exclusiveMinimum",
+	"number_lte":                      "maximum",
+	"number_lt":                       "exclusiveMaximum",
+	"condition_then":                  "then",
+	"condition_else":                  "else",
+}
+
+// ValidatePackageValues validates the values against the values.schema.json
+// stored in the catalog for the available package version.
+func (s *Server) ValidatePackageValues(ctx context.Context, request *v1alpha1.ValidatePackageValuesRequest) (*v1alpha1.ValidatePackageValuesResponse, error) {
+	availableRef := request.GetAvailablePackageRef()
+	log.Infof("+helm ValidatePackageValues (cluster=[%s], namespace=[%s], id=[%s], version=[%s])", availableRef.GetContext().GetCluster(), availableRef.GetContext().GetNamespace(), availableRef.GetIdentifier(), request.GetPkgVersion())
+	if availableRef.GetContext().GetNamespace() == "" || availableRef.GetIdentifier() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Required context or identifier of the available package not provided")
+	}
+
+	namespace := availableRef.GetContext().GetNamespace()
+	if err := s.hasAccessToNamespace(ctx, namespace); err != nil {
+		return nil, err
+	}
+	unescapedChartID, err := getUnescapedChartID(availableRef.GetIdentifier())
+	if err != nil {
+		return nil, err
+	}
+	manager, err := s.GetManager()
+	if err != nil {
+		return nil, err
+	}
+	var catalogChart models.Chart
+	if request.GetPkgVersion() == "" {
+		catalogChart, err = manager.GetChart(namespace, unescapedChartID)
+	} else {
+		catalogChart, err = manager.GetChartVersion(namespace, unescapedChartID, request.GetPkgVersion())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to retrieve chart: %v", err)
+	}
+	if len(catalogChart.ChartVersions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Unable to find the version %q of the chart %q", request.GetPkgVersion(), unescapedChartID)
+	}
+
+	chartVersion := catalogChart.ChartVersions[0]
+	defaultValues, err := chartutil.ReadValues([]byte(chartVersion.Values))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to parse the default values of the chart %q: %v", unescapedChartID, err)
+	}
+	values, err := readValues(request.GetValues())
+	if err != nil {
+		return nil, err
+	}
+	validationErrors, err := validateAgainstSchema([]byte(chartVersion.Schema), chartutil.CoalesceTables(values, defaultValues), "")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to validate the values against the schema of the chart %q: %v", unescapedChartID, err)
+	}
+
+	return &v1alpha1.ValidatePackageValuesResponse{
+		Valid:  len(validationErrors) == 0,
+		Errors: validationErrors,
+	}, nil
+}
+
+// validateChartValues validates the values against the schemas of the chart
+// and of its dependencies, as helm does, returning an InvalidArgument error
+// with the validation errors as details if they are invalid.
+func validateChartValues(ch *chart.Chart, valuesYAML string) error {
+	values, err := readValues(valuesYAML)
+	if err != nil {
+		return err
+	}
+	coalesced, err := chartutil.CoalesceValues(ch, values)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Unable to coalesce the values with the defaults of the chart %q: %v", ch.Name(), err)
+	}
+	validationErrors, err := validateChartAgainstSchemas(ch, coalesced, "")
+	if err != nil {
+		return status.Errorf(codes.Internal, "Unable to validate the values against the schema of the chart %q: %v", ch.Name(), err)
+	}
+	if len(validationErrors) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(validationErrors))
+	details := make([]protoiface.MessageV1, 0, len(validationErrors))
+	for _, e := range validationErrors {
+		messages = append(messages, fmt.Sprintf("%s: %s", e.Pointer, e.Message))
+		details = append(details, e)
+	}
+	st := status.Newf(codes.InvalidArgument, "Invalid values for the chart %q: %s", ch.Name(), strings.Join(messages, "; "))
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// validateChartAgainstSchemas validates the values of a chart, and those of
+// each of its dependencies, against their schemas.
+func validateChartAgainstSchemas(ch *chart.Chart, values map[string]interface{}, pointer string) ([]*v1alpha1.ValuesValidationError, error) {
+	validationErrors, err := validateAgainstSchema(ch.Schema, values, pointer)
+	if err != nil {
+		return nil, err
+	}
+	for _, dependency := range ch.Dependencies() {
+		dependencyValues, ok := values[dependency.Name()].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		dependencyErrors, err := validateChartAgainstSchemas(dependency, dependencyValues, pointer+"/"+escapeJSONPointerToken(dependency.Name()))
+		if err != nil {
+			return nil, err
+		}
+		validationErrors = append(validationErrors, dependencyErrors...)
+	}
+	return validationErrors, nil
+}
+
+// validateAgainstSchema validates the values against the JSON schema,
+// returning the errors with their pointers relative to the given one.
+func validateAgainstSchema(schema []byte, values map[string]interface{}, pointer string) ([]*v1alpha1.ValuesValidationError, error) {
+	if len(schema) == 0 {
+		return nil, nil
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(valuesJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	validationErrors := make([]*v1alpha1.ValuesValidationError, 0, len(result.Errors()))
+	for _, resultError := range result.Errors() {
+		keyword, ok := schemaKeywords[resultError.Type()]
+		if !ok {
+			keyword = resultError.Type()
+		}
+		validationErrors = append(validationErrors, &v1alpha1.ValuesValidationError{
+			Pointer: pointer + jsonPointer(resultError),
+			Message: resultError.Description(),
+			Keyword: keyword,
+		})
+	}
+	// gojsonschema validates the properties in the random order of a map
+	sort.SliceStable(validationErrors, func(i, j int) bool {
+		return validationErrors[i].Pointer < validationErrors[j].Pointer
+	})
+	return validationErrors, nil
+}
+
+// jsonPointer returns the JSON pointer of the value of a validation error.
+// The errors of properties which are missing or not allowed point to the
+// property rather than to its parent object.
+func jsonPointer(resultError gojsonschema.ResultError) string {
+	// The context is split with a delimiter which cannot appear in the keys
+	// so that these are escaped individually.
+	tokens := strings.Split(resultError.Context().String("\x00"), "\x00")[1:]
+	switch resultError.Type() {
+	case "required", "additional_property_not_allowed":
+		if property, ok := resultError.Details()["property"].(string); ok {
+			tokens = append(tokens, property)
+		}
+	}
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteString("/")
+		sb.WriteString(escapeJSONPointerToken(token))
+	}
+	return sb.String()
+}
+
+// escapeJSONPointerToken escapes a reference token of a JSON pointer.
+func escapeJSONPointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// readValues parses the YAML values supplied by the user.
+func readValues(valuesYAML string) (map[string]interface{}, error) {
+	values, err := chartutil.ReadValues([]byte(valuesYAML))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse the values: %v", err)
+	}
+	return values, nil
+}
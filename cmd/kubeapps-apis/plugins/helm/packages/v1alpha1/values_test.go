@@ -0,0 +1,302 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apprepov1alpha1 "github.com/kubeapps/kubeapps/cmd/apprepository-controller/pkg/apis/apprepository/v1alpha1"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/helm/packages/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+const testValuesSchema = `{
+  "$schema": "http://json-schema.org/schema#",
+  "type": "object",
+  "required": ["image"],
+  "properties": {
+    "replicaCount": {"type": "integer", "minimum": 1},
+    "image": {
+      "type": "object",
+      "required": ["tag"],
+      "properties": {
+        "tag": {"type": "string"}
+      }
+    },
+    "annotations": {
+      "type": "object",
+      "additionalProperties": {"type": "string"}
+    }
+  }
+}`
+
+var ignoreUnexportedValuesValidation = cmpopts.IgnoreUnexported(
+	v1alpha1.ValidatePackageValuesResponse{},
+	v1alpha1.ValuesValidationError{},
+)
+
+func TestValidateAgainstSchema(t *testing.T) {
+	testCases := []struct {
+		name           string
+		schema         string
+		values         map[string]interface{}
+		expectedErrors []*v1alpha1.ValuesValidationError
+	}{
+		{
+			name:   "it returns no errors for valid values",
+			schema: testValuesSchema,
+			values: map[string]interface{}{
+				"replicaCount": 2,
+				"image":        map[string]interface{}{"tag": "2.4.48"},
+			},
+			expectedErrors: []*v1alpha1.ValuesValidationError{},
+		},
+		{
+			name:   "it returns the pointer and keyword of an invalid type",
+			schema: testValuesSchema,
+			values: map[string]interface{}{
+				"replicaCount": "two",
+				"image":        map[string]interface{}{"tag": "2.4.48"},
+			},
+			expectedErrors: []*v1alpha1.ValuesValidationError{
+				{Pointer: "/replicaCount", Message: "Invalid type. Expected: integer, given: string", Keyword: "type"},
+			},
+		},
+		{
+			name:   "it points to a missing required property",
+			schema: testValuesSchema,
+			values: map[string]interface{}{
+				"image": map[string]interface{}{},
+			},
+			expectedErrors: []*v1alpha1.ValuesValidationError{
+				{Pointer: "/image/tag", Message: "tag is required", Keyword: "required"},
+			},
+		},
+		{
+			name:   "it escapes the tokens of the pointer",
+			schema: testValuesSchema,
+			values: map[string]interface{}{
+				"image":       map[string]interface{}{"tag": "2.4.48"},
+				"annotations": map[string]interface{}{"example.com/replicas": 2},
+			},
+			expectedErrors: []*v1alpha1.ValuesValidationError{
+				{Pointer: "/annotations/example.com~1replicas", Message: "Invalid type. Expected: string, given: integer", Keyword: "type"},
+			},
+		},
+		{
+			name:   "it returns the keyword of a failed minimum",
+			schema: testValuesSchema,
+			values: map[string]interface{}{
+				"replicaCount": 0,
+				"image":        map[string]interface{}{"tag": "2.4.48"},
+			},
+			expectedErrors: []*v1alpha1.ValuesValidationError{
+				{Pointer: "/replicaCount", Message: "Must be greater than or equal to 1", Keyword: "minimum"},
+			},
+		},
+		{
+			name:   "it returns no errors without a schema",
+			values: map[string]interface{}{"replicaCount": "two"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validationErrors, err := validateAgainstSchema([]byte(tc.schema), tc.values, "")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := validationErrors, tc.expectedErrors; !cmp.Equal(got, want, ignoreUnexportedValuesValidation) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedValuesValidation))
+			}
+		})
+	}
+}
+
+func TestValidateChartValuesOfDependencies(t *testing.T) {
+	dependency := &chart.Chart{
+		Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "postgresql", Version: "1.0.0"},
+		Schema:   []byte(`{"properties": {"port": {"type": "integer"}}}`),
+	}
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "apache", Version: "1.0.0"},
+	}
+	ch.SetDependencies(dependency)
+
+	err := validateChartValues(ch, "postgresql:\n  port: default")
+
+	if got, want := status.Code(err), codes.InvalidArgument; got != want {
+		t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+	expectedDetails := []interface{}{
+		&v1alpha1.ValuesValidationError{Pointer: "/postgresql/port", Message: "Invalid type. Expected: integer, given: string", Keyword: "type"},
+	}
+	if got, want := status.Convert(err).Details(), expectedDetails; !cmp.Equal(got, want, ignoreUnexportedValuesValidation) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedValuesValidation))
+	}
+}
+
+func TestCreateInstalledPackageWithInvalidValues(t *testing.T) {
+	actionConfig := newTestActionConfig(t, "default")
+	s := makeReleasesServer(t, actionConfig, []*apprepov1alpha1.AppRepository{bitnamiAppRepository})
+	s.chartClientFactory = &fakeChartClientFactory{schema: []byte(testValuesSchema)}
+
+	_, err := s.CreateInstalledPackage(context.Background(), &corev1.CreateInstalledPackageRequest{
+		AvailablePackageRef: &corev1.AvailablePackageReference{
+			Context:    &corev1.Context{Namespace: globalPackagingNamespace},
+			Identifier: "bitnami%2Fapache",
+		},
+		TargetContext: &corev1.Context{Namespace: "default"},
+		Name:          "my-apache",
+		Values:        "replicaCount: two",
+	})
+
+	if got, want := status.Code(err), codes.InvalidArgument; got != want {
+		t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+	expectedDetails := []interface{}{
+		&v1alpha1.ValuesValidationError{Pointer: "/image", Message: "image is required", Keyword: "required"},
+		&v1alpha1.ValuesValidationError{Pointer: "/replicaCount", Message: "Invalid type. Expected: integer, given: string", Keyword: "type"},
+	}
+	if got, want := status.Convert(err).Details(), expectedDetails; !cmp.Equal(got, want, ignoreUnexportedValuesValidation) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedValuesValidation))
+	}
+	if _, err := actionConfig.Releases.Last("my-apache"); err == nil {
+		t.Errorf("the release was created with invalid values")
+	}
+}
+
+func TestValidatePackageValues(t *testing.T) {
+	testCases := []struct {
+		name             string
+		request          *v1alpha1.ValidatePackageValuesRequest
+		authorized       bool
+		expectDBQuery    bool
+		expectedStatus   codes.Code
+		expectedResponse *v1alpha1.ValidatePackageValuesResponse
+	}{
+		{
+			name: "it validates values completed with the chart defaults",
+			request: &v1alpha1.ValidatePackageValuesRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "my-ns"},
+					Identifier: "foo/bar",
+				},
+				Values: "replicaCount: 3",
+			},
+			authorized:     true,
+			expectDBQuery:  true,
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.ValidatePackageValuesResponse{
+				Valid:  true,
+				Errors: []*v1alpha1.ValuesValidationError{},
+			},
+		},
+		{
+			name: "it returns the errors of invalid values",
+			request: &v1alpha1.ValidatePackageValuesRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "my-ns"},
+					Identifier: "foo/bar",
+				},
+				PkgVersion: "3.0.0",
+				Values:     "replicaCount: 0\nimage:\n  tag: 1",
+			},
+			authorized:     true,
+			expectDBQuery:  true,
+			expectedStatus: codes.OK,
+			expectedResponse: &v1alpha1.ValidatePackageValuesResponse{
+				Errors: []*v1alpha1.ValuesValidationError{
+					{Pointer: "/image/tag", Message: "Invalid type. Expected: string, given: integer", Keyword: "type"},
+					{Pointer: "/replicaCount", Message: "Must be greater than or equal to 1", Keyword: "minimum"},
+				},
+			},
+		},
+		{
+			name: "it returns an invalid argument error for unparseable values",
+			request: &v1alpha1.ValidatePackageValuesRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "my-ns"},
+					Identifier: "foo/bar",
+				},
+				Values: "not: valid: yaml",
+			},
+			authorized:     true,
+			expectDBQuery:  true,
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "it returns an invalid argument error without an identifier",
+			request: &v1alpha1.ValidatePackageValuesRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context: &corev1.Context{Namespace: "my-ns"},
+				},
+			},
+			authorized:     true,
+			expectedStatus: codes.InvalidArgument,
+		},
+		{
+			name: "it returns an unauthenticated error without access to the namespace",
+			request: &v1alpha1.ValidatePackageValuesRequest{
+				AvailablePackageRef: &corev1.AvailablePackageReference{
+					Context:    &corev1.Context{Namespace: "my-ns"},
+					Identifier: "foo/bar",
+				},
+			},
+			expectedStatus: codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, mock, cleanup := makeServer(t, tc.authorized)
+			defer cleanup()
+
+			if tc.expectDBQuery {
+				ch := makeChart("bar", "foo", "my-ns", []string{"3.0.0"})
+				ch.ChartVersions[0].Schema = testValuesSchema
+				ch.ChartVersions[0].Values = "replicaCount: 1\nimage:\n  tag: 2.4.48\n"
+				chartJSON, err := json.Marshal(ch)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				mock.ExpectQuery("SELECT info FROM").
+					WithArgs("my-ns", "foo/bar").
+					WillReturnRows(sqlmock.NewRows([]string{"info"}).AddRow(string(chartJSON)))
+			}
+
+			response, err := server.ValidatePackageValues(context.Background(), tc.request)
+
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+			if tc.expectedStatus != codes.OK {
+				return
+			}
+			if got, want := response, tc.expectedResponse; !cmp.Equal(got, want, ignoreUnexportedValuesValidation) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexportedValuesValidation))
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"github.com/kubeapps/kubeapps/pkg/chart/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// versionFilter matches the chart versions satisfying a semantic version
+// constraint.
+type versionFilter struct {
+	constraints *semver.Constraints
+	// prereleaseConstraints are the constraints which prereleases are checked
+	// against when they are included, or nil if they are not.
+	prereleaseConstraints *semver.Constraints
+}
+
+// constraintVersionRegex matches a version of a constraint with its operator.
+var constraintVersionRegex = regexp.MustCompile(`(!=|>=|=>|<=|=<|~>|=|>|<|~|\^)?\s*(v?[0-9xX*]+(?:\.[0-9xX*]+)?(?:\.[0-9xX*]+)?)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?`)
+
+// newVersionFilter returns the filter for the constraint, or nil if there is
+// no constraint to filter the versions with.
+func newVersionFilter(constraint string, includePrereleases bool) (*versionFilter, error) {
+	if constraint == "" {
+		return nil, nil
+	}
+	constraints, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse the version constraint %q: %v", constraint, err)
+	}
+	filter := &versionFilter{constraints: constraints}
+	if includePrereleases {
+		filter.prereleaseConstraints, err = semver.NewConstraint(prereleaseConstraint(constraint))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Unable to parse the version constraint %q: %v", constraint, err)
+		}
+	}
+	return filter, nil
+}
+
+// prereleaseConstraint rewrites the constraint so that prereleases are checked
+// against it in their semantic version order. The constraints only match
+// prereleases when their own version has a prerelease, so every version of the
+// constraint is given one: "b" for a release, which is greater than any
+// prerelease of the same version once mapped by prereleaseVersion, and "a."
+// before the prerelease otherwise. A wildcard matching any version becomes
+// the lowest mapped prerelease.
+func prereleaseConstraint(constraint string) string {
+	return constraintVersionRegex.ReplaceAllStringFunc(constraint, func(term string) string {
+		m := constraintVersionRegex.FindStringSubmatch(term)
+		op, version, prerelease, metadata := m[1], m[2], m[3], m[4]
+		switch strings.Split(strings.TrimPrefix(version, "v"), ".")[0] {
+		case "x", "X", "*":
+			switch op {
+			case "", "=", "~", "~>", "^", ">=", "=>":
+				return ">=0.0.0-a"
+			}
+			return term
+		}
+		if prerelease == "" {
+			prerelease = "-b"
+		} else {
+			prerelease = "-a." + strings.TrimPrefix(prerelease, "-")
+		}
+		return op + version + prerelease + metadata
+	})
+}
+
+// prereleaseVersion maps a prerelease to the version checked against the
+// constraint rewritten by prereleaseConstraint, which keeps its order.
+func prereleaseVersion(version *semver.Version) (*semver.Version, error) {
+	mapped, err := version.SetPrerelease("a." + version.Prerelease())
+	if err != nil {
+		return nil, err
+	}
+	return &mapped, nil
+}
+
+// matches returns whether the version satisfies the constraint. Versions which
+// are not semantic versions never do.
+func (f *versionFilter) matches(v string) bool {
+	version, err := semver.NewVersion(v)
+	if err != nil {
+		return false
+	}
+	if version.Prerelease() == "" || f.prereleaseConstraints == nil {
+		return f.constraints.Check(version)
+	}
+	mapped, err := prereleaseVersion(version)
+	if err != nil {
+		return false
+	}
+	return f.prereleaseConstraints.Check(mapped)
+}
+
+// filter returns the versions satisfying the constraint, in the same order.
+func (f *versionFilter) filter(versions []models.ChartVersion) []models.ChartVersion {
+	filtered := []models.ChartVersion{}
+	for _, v := range versions {
+		if f.matches(v.Version) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// packageAppVersions converts every model chart version into a version of
+// the response, without summarizing them.
+func packageAppVersions(versions []models.ChartVersion) []*corev1.GetAvailablePackageVersionsResponse_PackageAppVersion {
+	pav := make([]*corev1.GetAvailablePackageVersionsResponse_PackageAppVersion, 0, len(versions))
+	for _, v := range versions {
+		pav = append(pav, &corev1.GetAvailablePackageVersionsResponse_PackageAppVersion{
+			PkgVersion: v.Version,
+			AppVersion: v.AppVersion,
+		})
+	}
+	return pav
+}
@@ -0,0 +1,218 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVersionFilterMatches(t *testing.T) {
+	testCases := []struct {
+		name               string
+		constraint         string
+		includePrereleases bool
+		version            string
+		expected           bool
+	}{
+		{
+			name:       "it matches a patch release of the pinned minor",
+			constraint: "~1.4",
+			version:    "1.4.7",
+			expected:   true,
+		},
+		{
+			name:       "it does not match the next minor",
+			constraint: "~1.4",
+			version:    "1.5.0",
+		},
+		{
+			name:       "it does not match prereleases by default",
+			constraint: "*",
+			version:    "2.0.0-beta.1",
+		},
+		{
+			name:               "it matches prereleases if requested",
+			constraint:         "*",
+			includePrereleases: true,
+			version:            "2.0.0-beta.1",
+			expected:           true,
+		},
+		{
+			name:               "it does not match prereleases outside the constraint",
+			constraint:         "~1.4",
+			includePrereleases: true,
+			version:            "1.5.0-rc.1",
+		},
+		{
+			name:               "it does not match prereleases below a lower bound",
+			constraint:         ">=1.4.0",
+			includePrereleases: true,
+			version:            "1.4.0-rc.1",
+		},
+		{
+			name:               "it matches prereleases above a lower bound",
+			constraint:         ">=1.4.0",
+			includePrereleases: true,
+			version:            "1.4.1-rc.1",
+			expected:           true,
+		},
+		{
+			name:               "it matches prereleases below an upper bound",
+			constraint:         "<2.0.0",
+			includePrereleases: true,
+			version:            "2.0.0-rc.1",
+			expected:           true,
+		},
+		{
+			name:               "it does not match prereleases of a version above an upper bound",
+			constraint:         "<2.0.0",
+			includePrereleases: true,
+			version:            "2.0.1-rc.1",
+		},
+		{
+			name:               "it matches prereleases within a range",
+			constraint:         ">=1.4.0, <2.0.0",
+			includePrereleases: true,
+			version:            "1.9.0-beta.2",
+			expected:           true,
+		},
+		{
+			name:               "it compares prereleases with a prerelease bound",
+			constraint:         ">=2.0.0-beta.2",
+			includePrereleases: true,
+			version:            "2.0.0-beta.1",
+		},
+		{
+			name:               "it still matches releases if prereleases are included",
+			constraint:         "~1.4",
+			includePrereleases: true,
+			version:            "1.4.7",
+			expected:           true,
+		},
+		{
+			name:       "it matches prereleases of a prerelease constraint",
+			constraint: ">=2.0.0-0",
+			version:    "2.0.0-beta.1",
+			expected:   true,
+		},
+		{
+			name:       "it does not match versions which are not semantic",
+			constraint: "*",
+			version:    "latest",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := newVersionFilter(tc.constraint, tc.includePrereleases)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := filter.matches(tc.version), tc.expected; got != want {
+				t.Errorf("got: %t, want: %t", got, want)
+			}
+		})
+	}
+}
+
+func TestNewVersionFilter(t *testing.T) {
+	filter, err := newVersionFilter("", true)
+	if err != nil || filter != nil {
+		t.Errorf("got: %+v, %+v, want no filter without a constraint", filter, err)
+	}
+
+	_, err = newVersionFilter(">= foo", false)
+	if got, want := status.Code(err), codes.InvalidArgument; got != want {
+		t.Errorf("got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+}
+
+func TestGetAvailablePackageSummariesWithVersionConstraint(t *testing.T) {
+	testCases := []struct {
+		name             string
+		pageToken        string
+		pageSize         int32
+		expectedVersions map[string]string
+		expectedToken    string
+	}{
+		{
+			name: "it returns the latest matching version of the matching charts",
+			expectedVersions: map[string]string{
+				"bitnami/apache":    "1.4.2",
+				"bitnami/wordpress": "1.4.0",
+			},
+		},
+		{
+			name:             "it paginates the matching charts",
+			pageToken:        "2",
+			pageSize:         1,
+			expectedVersions: map[string]string{"bitnami/wordpress": "1.4.0"},
+			expectedToken:    "3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, mock, cleanup := makeServer(t, true)
+			defer cleanup()
+
+			rows := sqlmock.NewRows([]string{"info"})
+			for _, chart := range []interface{}{
+				makeChart("apache", "bitnami", "my-ns", []string{"1.5.0", "1.4.2", "1.4.1"}),
+				makeChart("nginx", "bitnami", "my-ns", []string{"2.0.0", "1.3.0"}),
+				makeChart("wordpress", "bitnami", "my-ns", []string{"1.4.0"}),
+			} {
+				chartJSON, err := json.Marshal(chart)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				rows.AddRow(string(chartJSON))
+			}
+			// Every chart is retrieved to check the constraint.
+			mock.ExpectQuery("SELECT info FROM charts WHERE .+ ORDER BY \\(info->>'name'\\) ASC$").
+				WithArgs("my-ns", server.globalPackagingNamespace).
+				WillReturnRows(rows)
+
+			response, err := server.GetAvailablePackageSummaries(context.Background(), &corev1.GetAvailablePackageSummariesRequest{
+				Context:           &corev1.Context{Namespace: "my-ns"},
+				FilterOptions:     &corev1.FilterOptions{VersionConstraint: "~1.4"},
+				PaginationOptions: &corev1.PaginationOptions{PageToken: tc.pageToken, PageSize: tc.pageSize},
+			})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			versions := map[string]string{}
+			for _, summary := range response.AvailablePackagesSummaries {
+				versions[summary.AvailablePackageRef.Identifier] = summary.LatestPkgVersion
+			}
+			if got, want := versions, tc.expectedVersions; !cmp.Equal(got, want) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got, want := response.NextPageToken, tc.expectedToken; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	log "k8s.io/klog/v2"
+)
+
+// WatchInstalledPackages streams the changes of the kapp-controller
+// PackageInstalls in the requested namespace.
+func (s *Server) WatchInstalledPackages(request *corev1.WatchInstalledPackagesRequest, stream corev1.PackagesService_WatchInstalledPackagesServer) error {
+	contextMsg := ""
+	if request.Context != nil {
+		contextMsg = fmt.Sprintf("(cluster=[%s], namespace=[%s])", request.Context.Cluster, request.Context.Namespace)
+	}
+
+	log.Infof("+kapp_controller WatchInstalledPackages %s", contextMsg)
+
+	cluster := request.GetContext().GetCluster()
+	namespace := request.GetContext().GetNamespace()
+
+	ctx := stream.Context()
+	_, client, err := s.GetClients(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	packageInstallResource := schema.GroupVersionResource{Group: packagingGroup, Version: packageVersion, Resource: packagesResource}
+
+	// The watch is started again from the last seen resource version
+	// whenever the api server closes it.
+	resourceVersion := ""
+	for {
+		watcher, err := client.Resource(packageInstallResource).Namespace(namespace).Watch(ctx, metav1.ListOptions{
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "unable to watch kapp-controller package installs: %v", err)
+		}
+		resourceVersion, err = sendPackageInstallEvents(ctx, watcher.ResultChan(), stream, cluster, resourceVersion)
+		watcher.Stop()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// sendPackageInstallEvents sends the event of each PackageInstall change until
+// the channel is closed, returning the resource version to continue watching from.
+func sendPackageInstallEvents(ctx context.Context, ch <-chan watch.Event, stream corev1.PackagesService_WatchInstalledPackagesServer, cluster string, resourceVersion string) (string, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return "", status.FromContextError(ctx.Err()).Err()
+		case event, ok := <-ch:
+			if !ok {
+				return resourceVersion, nil
+			}
+			var eventType corev1.WatchInstalledPackagesResponse_EventType
+			switch event.Type {
+			case watch.Added:
+				eventType = corev1.WatchInstalledPackagesResponse_EVENT_TYPE_ADDED
+			case watch.Modified:
+				eventType = corev1.WatchInstalledPackagesResponse_EVENT_TYPE_MODIFIED
+			case watch.Deleted:
+				eventType = corev1.WatchInstalledPackagesResponse_EVENT_TYPE_DELETED
+			case watch.Error:
+				// The resource version is too old or the watch otherwise
+				// failed, so the watch is started again without it.
+				log.Errorf("error event watching kapp-controller package installs: %v", event.Object)
+				return "", nil
+			default:
+				continue
+			}
+			pkgInstall, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				log.Errorf("unexpected object watching kapp-controller package installs: %v", event.Object)
+				continue
+			}
+			resourceVersion = pkgInstall.GetResourceVersion()
+			err := stream.Send(&corev1.WatchInstalledPackagesResponse{
+				Type:                    eventType,
+				InstalledPackageSummary: InstalledPackageSummaryFromUnstructured(pkgInstall, cluster),
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+}
+
+// InstalledPackageSummaryFromUnstructured returns the summary of the
+// installed package for a PackageInstall in the cluster.
+func InstalledPackageSummaryFromUnstructured(pkgInstall *unstructured.Unstructured, cluster string) *corev1.InstalledPackageSummary {
+	// https://carvel.dev/kapp-controller/docs/latest/packaging/#packageinstall-cr
+	refName, _, _ := unstructured.NestedString(pkgInstall.Object, "spec", "packageRef", "refName")
+	constraints, _, _ := unstructured.NestedString(pkgInstall.Object, "spec", "packageRef", "versionSelection", "constraints")
+	version, _, _ := unstructured.NestedString(pkgInstall.Object, "status", "version")
+
+	return &corev1.InstalledPackageSummary{
+		InstalledPackageRef: &corev1.InstalledPackageReference{
+			Context:    &corev1.Context{Cluster: cluster, Namespace: pkgInstall.GetNamespace()},
+			Identifier: pkgInstall.GetName(),
+		},
+		Name:                pkgInstall.GetName(),
+		PkgVersionReference: &corev1.VersionReference{Version: constraints},
+		CurrentPkgVersion:   version,
+		PkgDisplayName:      refName,
+		Status:              installedPackageStatusFromUnstructured(pkgInstall),
+	}
+}
+
+// installedPackageStatusFromUnstructured maps the conditions of a
+// PackageInstall to the status of the installed package.
+func installedPackageStatusFromUnstructured(pkgInstall *unstructured.Unstructured) *corev1.InstalledPackageStatus {
+	pkgStatus := &corev1.InstalledPackageStatus{
+		Reason: corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+	}
+	conditions, _, _ := unstructured.NestedSlice(pkgInstall.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["status"] != "True" {
+			continue
+		}
+		conditionType, _ := condition["type"].(string)
+		switch conditionType {
+		case "ReconcileSucceeded":
+			pkgStatus.Ready = true
+			pkgStatus.Reason = corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED
+			pkgStatus.UserReason = conditionType
+		case "ReconcileFailed":
+			pkgStatus.Reason = corev1.InstalledPackageStatus_STATUS_REASON_FAILED
+			pkgStatus.UserReason = conditionType
+			if msg, _, _ := unstructured.NestedString(pkgInstall.Object, "status", "usefulErrorMessage"); msg != "" {
+				pkgStatus.UserReason = msg
+			}
+		case "Reconciling":
+			pkgStatus.UserReason = conditionType
+		}
+	}
+	return pkgStatus
+}
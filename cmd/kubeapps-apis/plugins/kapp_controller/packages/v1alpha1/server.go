@@ -76,13 +76,19 @@ func NewServer(clientGetter server.KubernetesClientGetter) *Server {
 	}
 }
 
-// GetClients ensures a client getter is available and uses it to return both a typed and dynamic k8s client.
-func (s *Server) GetClients(ctx context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+// GetClients ensures a client getter is available and uses it to return both a typed and dynamic k8s client
+// for the requested cluster, or the cluster on which Kubeapps is installed if empty.
+func (s *Server) GetClients(ctx context.Context, cluster string) (kubernetes.Interface, dynamic.Interface, error) {
 	if s.clientGetter == nil {
 		return nil, nil, status.Errorf(codes.Internal, "server not configured with configGetter")
 	}
-	typedClient, dynamicClient, err := s.clientGetter(ctx)
+	typedClient, dynamicClient, err := s.clientGetter(ctx, cluster)
 	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			// The client getter already returns a gRPC error for an invalid
+			// cluster or authorization.
+			return nil, nil, err
+		}
 		return nil, nil, status.Errorf(codes.FailedPrecondition, fmt.Sprintf("unable to get client : %v", err))
 	}
 	return typedClient, dynamicClient, nil
@@ -99,15 +105,12 @@ func (s *Server) GetAvailablePackageSummaries(ctx context.Context, request *core
 
 	namespace := ""
 	if request.Context != nil {
-		if request.Context.Cluster != "" {
-			return nil, status.Errorf(codes.Unimplemented, "Not supported yet: request.Context.Cluster: [%v]", request.Context.Cluster)
-		}
 		if request.Context.Namespace != "" {
 			namespace = request.Context.Namespace
 		}
 	}
 
-	_, client, err := s.GetClients(ctx)
+	_, client, err := s.GetClients(ctx, request.GetContext().GetCluster())
 	if err != nil {
 		return nil, err
 	}
@@ -162,15 +165,12 @@ func (s *Server) GetPackageRepositories(ctx context.Context, request *v1alpha1.G
 
 	namespace := globalPackagingNamespace
 	if request.Context != nil {
-		if request.Context.Cluster != "" {
-			return nil, status.Errorf(codes.Unimplemented, "Not supported yet: request.Context.Cluster: [%v]", request.Context.Cluster)
-		}
 		if request.Context.Namespace != "" {
 			namespace = request.Context.Namespace
 		}
 	}
 
-	_, client, err := s.GetClients(ctx)
+	_, client, err := s.GetClients(ctx, request.GetContext().GetCluster())
 	if err != nil {
 		return nil, err
 	}
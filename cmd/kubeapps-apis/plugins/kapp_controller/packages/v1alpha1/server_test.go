@@ -23,15 +23,18 @@ import (
 	corev1 "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/plugins/kapp_controller/packages/v1alpha1"
 	"github.com/kubeapps/kubeapps/cmd/kubeapps-apis/server"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 	dynfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	typfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestGetClient(t *testing.T) {
@@ -48,14 +51,21 @@ func TestGetClient(t *testing.T) {
 		},
 		{
 			name: "returns failed-precondition when configGetter itself errors",
-			clientGetter: func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+			clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 				return nil, nil, fmt.Errorf("Bang!")
 			},
 			statusCode: codes.FailedPrecondition,
 		},
+		{
+			name: "returns the status of the configGetter error for an invalid cluster",
+			clientGetter: func(_ context.Context, cluster string) (kubernetes.Interface, dynamic.Interface, error) {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "cluster %q has no configuration", cluster)
+			},
+			statusCode: codes.InvalidArgument,
+		},
 		{
 			name: "returns client without error when configured correctly",
-			clientGetter: func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+			clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 				return typfake.NewSimpleClientset(), dynfake.NewSimpleDynamicClientWithCustomListKinds(
 					runtime.NewScheme(),
 					map[schema.GroupVersionResource]string{
@@ -70,7 +80,7 @@ func TestGetClient(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			s := Server{clientGetter: tc.clientGetter}
 
-			typedClient, dynamicClient, err := s.GetClients(context.Background())
+			typedClient, dynamicClient, err := s.GetClients(context.Background(), "")
 
 			if got, want := status.Code(err), tc.statusCode; got != want {
 				t.Errorf("got: %+v, want: %+v", got, want)
@@ -98,7 +108,7 @@ func TestGetAvailablePackagesStatus(t *testing.T) {
 	}{
 		{
 			name: "returns an internal error status if response does not contain packageRef.refName",
-			clientGetter: func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+			clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 				return nil, dynfake.NewSimpleDynamicClientWithCustomListKinds(
 					runtime.NewScheme(),
 					map[schema.GroupVersionResource]string{
@@ -113,7 +123,7 @@ func TestGetAvailablePackagesStatus(t *testing.T) {
 		},
 		{
 			name: "returns an internal error status if response does not contain version",
-			clientGetter: func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+			clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 				return nil, dynfake.NewSimpleDynamicClientWithCustomListKinds(
 					runtime.NewScheme(),
 					map[schema.GroupVersionResource]string{
@@ -130,7 +140,7 @@ func TestGetAvailablePackagesStatus(t *testing.T) {
 		},
 		{
 			name: "returns OK status if items contain required fields",
-			clientGetter: func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+			clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 				return nil, dynfake.NewSimpleDynamicClientWithCustomListKinds(
 					runtime.NewScheme(),
 					map[schema.GroupVersionResource]string{
@@ -240,7 +250,7 @@ func TestGetAvailablePackageSummaries(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pkgs := packagesFromSpecs(tc.packageSpecs, t)
 			s := Server{
-				clientGetter: func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+				clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 					return nil, dynfake.NewSimpleDynamicClientWithCustomListKinds(
 						runtime.NewScheme(),
 						map[schema.GroupVersionResource]string{
@@ -347,7 +357,7 @@ func TestGetPackageRepositories(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			s := Server{
-				clientGetter: func(context.Context) (kubernetes.Interface, dynamic.Interface, error) {
+				clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
 					return nil, dynfake.NewSimpleDynamicClientWithCustomListKinds(
 						runtime.NewScheme(),
 						map[schema.GroupVersionResource]string{
@@ -479,3 +489,131 @@ func TestPackageRepositoryFromUnstructured(t *testing.T) {
 		})
 	}
 }
+
+// testWatchStream is a fake stream recording the events sent by the plugin.
+type testWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*corev1.WatchInstalledPackagesResponse
+}
+
+func (s *testWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testWatchStream) Send(event *corev1.WatchInstalledPackagesResponse) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func packageInstall(name string, conditionType string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": fmt.Sprintf("%s/%s", packagingGroup, packageVersion),
+			"kind":       packageResource,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "default",
+			},
+			"spec": map[string]interface{}{
+				"packageRef": map[string]interface{}{
+					"refName": "tetris.foo.example.com",
+					"versionSelection": map[string]interface{}{
+						"constraints": "1.2.3",
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"version": "1.2.3",
+				"conditions": []interface{}{
+					map[string]interface{}{"type": conditionType, "status": "True"},
+				},
+				"usefulErrorMessage": "kapp: Error: waiting on reconcile",
+			},
+		},
+	}
+}
+
+func TestWatchInstalledPackages(t *testing.T) {
+	dynamicClient := dynfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: packagingGroup, Version: packageVersion, Resource: packagesResource}: "PackageInstallList",
+		})
+	watcher := watch.NewFake()
+	dynamicClient.Fake.PrependWatchReactor(packagesResource, k8stesting.DefaultWatchReactor(watcher, nil))
+	s := Server{
+		clientGetter: func(context.Context, string) (kubernetes.Interface, dynamic.Interface, error) {
+			return nil, dynamicClient, nil
+		},
+	}
+
+	t.Run("it streams the changes of package installs", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &testWatchStream{ctx: ctx}
+		done := make(chan error)
+		go func() {
+			done <- s.WatchInstalledPackages(&corev1.WatchInstalledPackagesRequest{
+				Context: &corev1.Context{Namespace: "default"},
+			}, stream)
+		}()
+
+		watcher.Add(packageInstall("tetris", "Reconciling"))
+		watcher.Modify(packageInstall("tetris", "ReconcileSucceeded"))
+		watcher.Delete(packageInstall("tetris", "ReconcileFailed"))
+		cancel()
+
+		if got, want := status.Code(<-done), codes.Canceled; got != want {
+			t.Fatalf("got: %+v, want: %+v", got, want)
+		}
+
+		summary := func(pkgStatus *corev1.InstalledPackageStatus) *corev1.InstalledPackageSummary {
+			return &corev1.InstalledPackageSummary{
+				InstalledPackageRef: &corev1.InstalledPackageReference{
+					Context:    &corev1.Context{Namespace: "default"},
+					Identifier: "tetris",
+				},
+				Name:                "tetris",
+				PkgVersionReference: &corev1.VersionReference{Version: "1.2.3"},
+				CurrentPkgVersion:   "1.2.3",
+				PkgDisplayName:      "tetris.foo.example.com",
+				Status:              pkgStatus,
+			}
+		}
+		expectedEvents := []*corev1.WatchInstalledPackagesResponse{
+			{
+				Type: corev1.WatchInstalledPackagesResponse_EVENT_TYPE_ADDED,
+				InstalledPackageSummary: summary(&corev1.InstalledPackageStatus{
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_PENDING,
+					UserReason: "Reconciling",
+				}),
+			},
+			{
+				Type: corev1.WatchInstalledPackagesResponse_EVENT_TYPE_MODIFIED,
+				InstalledPackageSummary: summary(&corev1.InstalledPackageStatus{
+					Ready:      true,
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_INSTALLED,
+					UserReason: "ReconcileSucceeded",
+				}),
+			},
+			{
+				Type: corev1.WatchInstalledPackagesResponse_EVENT_TYPE_DELETED,
+				InstalledPackageSummary: summary(&corev1.InstalledPackageStatus{
+					Reason:     corev1.InstalledPackageStatus_STATUS_REASON_FAILED,
+					UserReason: "kapp: Error: waiting on reconcile",
+				}),
+			},
+		}
+		opts := cmpopts.IgnoreUnexported(
+			corev1.WatchInstalledPackagesResponse{},
+			corev1.InstalledPackageSummary{},
+			corev1.InstalledPackageReference{},
+			corev1.InstalledPackageStatus{},
+			corev1.VersionReference{},
+			corev1.Context{},
+		)
+		if got, want := stream.events, expectedEvents; !cmp.Equal(want, got, opts) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+		}
+	})
+}
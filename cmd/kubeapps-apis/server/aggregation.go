@@ -0,0 +1,104 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	packages "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/grpc/status"
+	log "k8s.io/klog/v2"
+)
+
+// pluginResult holds the response or error returned by a single plugin
+// when aggregating results from all plugins.
+type pluginResult struct {
+	plugin   *plugins.Plugin
+	response interface{}
+	err      error
+}
+
+// callAllPlugins calls fn with the index of each plugin concurrently, applying
+// the timeout to each call, and returns the results in the order of the plugins.
+func callAllPlugins(ctx context.Context, timeout time.Duration, pluginDetails []*plugins.Plugin, fn func(context.Context, int) (interface{}, error)) []pluginResult {
+	results := make([]pluginResult, len(pluginDetails))
+	var wg sync.WaitGroup
+	for i, p := range pluginDetails {
+		wg.Add(1)
+		go func(i int, p *plugins.Plugin) {
+			defer wg.Done()
+			results[i] = callPlugin(ctx, timeout, p, func(ctx context.Context) (interface{}, error) {
+				return fn(ctx, i)
+			})
+		}(i, p)
+	}
+	wg.Wait()
+	return results
+}
+
+// callPlugin calls fn for the plugin, applying the timeout and recording
+// the metrics of the call.
+func callPlugin(ctx context.Context, timeout time.Duration, p *plugins.Plugin, fn func(context.Context) (interface{}, error)) (result pluginResult) {
+	start := time.Now()
+	defer func() {
+		observePluginCall(ctx, p, start, result.err)
+	}()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	// The plugin is called in its own go routine so that a plugin
+	// ignoring the context deadline cannot block the other results.
+	done := make(chan pluginResult, 1)
+	go func() {
+		response, err := fn(ctx)
+		done <- pluginResult{plugin: p, response: response, err: err}
+	}()
+	select {
+	case result := <-done:
+		return result
+	case <-ctx.Done():
+		return pluginResult{plugin: p, err: status.FromContextError(ctx.Err()).Err()}
+	}
+}
+
+// pluginErrors returns a PluginError for each failed plugin result, together
+// with an error when every plugin failed, in which case there are no partial
+// results to return.
+func pluginErrors(results []pluginResult) ([]*packages.PluginError, error) {
+	pluginErrors := []*packages.PluginError{}
+	for _, r := range results {
+		if r.err != nil {
+			pluginErrors = append(pluginErrors, newPluginError(r))
+		}
+	}
+	if len(results) > 0 && len(pluginErrors) == len(results) {
+		return nil, results[0].err
+	}
+	return pluginErrors, nil
+}
+
+// newPluginError returns the PluginError reported for a failed plugin result.
+func newPluginError(r pluginResult) *packages.PluginError {
+	log.Errorf("plugin %v failed: %v", r.plugin, r.err)
+	st, _ := status.FromError(r.err)
+	return &packages.PluginError{
+		Plugin:     r.plugin,
+		StatusCode: st.Code().String(),
+		Message:    st.Message(),
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// AuthModeNone only checks that the bearer token is well formed, leaving
+	// the authentication to the clusters on which the token is used.
+	AuthModeNone = "none"
+	// AuthModeTokenReview validates the bearer token with a TokenReview
+	// against the cluster on which Kubeapps is installed.
+	AuthModeTokenReview = "token-review"
+	// AuthModeOIDC validates the bearer token as an OIDC ID token signed by
+	// one of the keys of the configured JWKS file.
+	AuthModeOIDC = "oidc"
+)
+
+// UserIdentity is the identity of the user authenticated for a request.
+type UserIdentity struct {
+	Username string
+	UID      string
+	Groups   []string
+}
+
+type userIdentityKey struct{}
+
+// NewContextWithUserIdentity returns a copy of the context carrying the identity.
+func NewContextWithUserIdentity(ctx context.Context, identity *UserIdentity) context.Context {
+	return context.WithValue(ctx, userIdentityKey{}, identity)
+}
+
+// UserIdentityFromContext returns the identity of the user authenticated for
+// the request, if any. Requests served without authentication have no
+// identity.
+func UserIdentityFromContext(ctx context.Context) (*UserIdentity, bool) {
+	identity, ok := ctx.Value(userIdentityKey{}).(*UserIdentity)
+	return identity, ok && identity != nil
+}
+
+// Authenticator returns the identity of the user owning a bearer token.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*UserIdentity, error)
+}
+
+// newAuthenticator returns the authenticator for the mode requested in the
+// serve options, or nil when the tokens are not authenticated by this server.
+func newAuthenticator(serveOpts ServeOptions) (Authenticator, error) {
+	switch serveOpts.AuthMode {
+	case "", AuthModeNone:
+		return nil, nil
+	case AuthModeTokenReview:
+		restConfig, err := getRESTConfig(serveOpts)
+		if err != nil {
+			return nil, err
+		}
+		clientset, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the client for token reviews: %w", err)
+		}
+		return &tokenReviewAuthenticator{client: clientset}, nil
+	case AuthModeOIDC:
+		return newOIDCAuthenticator(serveOpts)
+	default:
+		return nil, fmt.Errorf("unknown authentication mode %q", serveOpts.AuthMode)
+	}
+}
+
+// tokenReviewAuthenticator authenticates tokens with a TokenReview, which
+// requires the service account to be allowed to create tokenreviews.
+type tokenReviewAuthenticator struct {
+	client kubernetes.Interface
+}
+
+func (a *tokenReviewAuthenticator) Authenticate(ctx context.Context, token string) (*UserIdentity, error) {
+	review, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "unable to review the token: %v", err)
+	}
+	if !review.Status.Authenticated {
+		if review.Status.Error != "" {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %s", review.Status.Error)
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+	return &UserIdentity{
+		Username: review.Status.User.Username,
+		UID:      review.Status.User.UID,
+		Groups:   review.Status.User.Groups,
+	}, nil
+}
+
+// authenticate authenticates the bearer token of the request, returning the
+// context to be used when handling it. Without an authenticator, requests
+// are handled unauthenticated, delegating the decision to the RBAC of the
+// clusters. Otherwise a token is required.
+func authenticate(ctx context.Context, authenticator Authenticator) (context.Context, error) {
+	token, err := extractToken(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid authorization metadata: %v", err)
+	}
+	if authenticator == nil {
+		return ctx, nil
+	}
+	if token == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "missing bearer token")
+	}
+	identity, err := authenticator.Authenticate(ctx, token)
+	if err != nil {
+		if _, ok := status.FromError(err); !ok {
+			err = status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+		}
+		return nil, err
+	}
+	log.V(4).Infof("+core authenticated request for user %q", identity.Username)
+	return NewContextWithUserIdentity(ctx, identity), nil
+}
+
+// authUnaryInterceptor authenticates each unary request before handling it.
+func authUnaryInterceptor(authenticator Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticator)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authStreamInterceptor authenticates each streaming request before handling it.
+func authStreamInterceptor(authenticator Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), authenticator)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticatedStream is a server stream carrying the authenticated context.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,432 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	typfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const testIssuer = "https://issuer.example.com"
+
+// fakeAuthenticator accepts a single token.
+type fakeAuthenticator struct {
+	token    string
+	identity *UserIdentity
+}
+
+func (a *fakeAuthenticator) Authenticate(ctx context.Context, token string) (*UserIdentity, error) {
+	if token != a.token {
+		return nil, fmt.Errorf("unknown token")
+	}
+	return a.identity, nil
+}
+
+type testAuthStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testAuthStream) Context() context.Context {
+	return s.ctx
+}
+
+func contextWithAuthorization(authorization string) context.Context {
+	if authorization == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"authorization": authorization,
+	}))
+}
+
+func TestAuthInterceptors(t *testing.T) {
+	identity := &UserIdentity{Username: "jane", Groups: []string{"devs"}}
+
+	testCases := []struct {
+		name             string
+		authenticator    Authenticator
+		authorization    string
+		expectedStatus   codes.Code
+		expectedIdentity *UserIdentity
+	}{
+		{
+			name:             "it adds the identity of a valid token to the context",
+			authenticator:    &fakeAuthenticator{token: "abc", identity: identity},
+			authorization:    "Bearer abc",
+			expectedStatus:   codes.OK,
+			expectedIdentity: identity,
+		},
+		{
+			name:           "it rejects an invalid token",
+			authenticator:  &fakeAuthenticator{token: "abc", identity: identity},
+			authorization:  "Bearer def",
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a malformed authorization",
+			authenticator:  &fakeAuthenticator{token: "abc", identity: identity},
+			authorization:  "Basic abc",
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a malformed authorization without an authenticator",
+			authorization:  "Bearer ",
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a request without a token",
+			authenticator:  &fakeAuthenticator{token: "abc", identity: identity},
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a request without a token for the token reviews",
+			authenticator:  &tokenReviewAuthenticator{client: typfake.NewSimpleClientset()},
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it handles a request without a token without an authenticator",
+			expectedStatus: codes.OK,
+		},
+		{
+			name:           "it handles a token without identity without an authenticator",
+			authorization:  "Bearer abc",
+			expectedStatus: codes.OK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unaryIdentity, streamIdentity *UserIdentity
+			unaryHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				unaryIdentity, _ = UserIdentityFromContext(ctx)
+				return nil, nil
+			}
+			streamHandler := func(srv interface{}, stream grpc.ServerStream) error {
+				streamIdentity, _ = UserIdentityFromContext(stream.Context())
+				return nil
+			}
+			ctx := contextWithAuthorization(tc.authorization)
+
+			_, err := authUnaryInterceptor(tc.authenticator)(ctx, nil, &grpc.UnaryServerInfo{}, unaryHandler)
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			err = authStreamInterceptor(tc.authenticator)(nil, &testAuthStream{ctx: ctx}, &grpc.StreamServerInfo{}, streamHandler)
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+
+			if got, want := unaryIdentity, tc.expectedIdentity; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got, want := streamIdentity, tc.expectedIdentity; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestTokenReviewAuthenticator(t *testing.T) {
+	clientSet := typfake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "valid" {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User: authenticationv1.UserInfo{
+					Username: "system:serviceaccount:default:jane",
+					UID:      "1234",
+					Groups:   []string{"system:serviceaccounts"},
+				},
+			}
+		} else {
+			review.Status = authenticationv1.TokenReviewStatus{Error: "token expired"}
+		}
+		return true, review, nil
+	})
+	authenticator := &tokenReviewAuthenticator{client: clientSet}
+
+	identity, err := authenticator.Authenticate(context.Background(), "valid")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expectedIdentity := &UserIdentity{
+		Username: "system:serviceaccount:default:jane",
+		UID:      "1234",
+		Groups:   []string{"system:serviceaccounts"},
+	}
+	if got, want := identity, expectedIdentity; !cmp.Equal(want, got) {
+		t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	_, err = authenticator.Authenticate(context.Background(), "invalid")
+	if got, want := status.Code(err), codes.Unauthenticated; got != want {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+}
+
+// signToken returns a jwt with the claims signed by the key.
+func signToken(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(signed))
+
+	var signature []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest.Sum(nil))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest.Sum(nil))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	default:
+		// unsigned tokens have an empty signature
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func writeJWKS(t *testing.T, rsaKey *rsa.PublicKey, ecKey *ecdsa.PublicKey) string {
+	encode := func(i *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(i.Bytes())
+	}
+	// the coordinates of an EC key are padded to the size of the curve
+	encodeCoordinate := func(i *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(i.FillBytes(make([]byte, 32)))
+	}
+	jwks, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-key", "use": "sig", "n": encode(rsaKey.N), "e": encode(big.NewInt(int64(rsaKey.E)))},
+			{"kty": "EC", "kid": "ec-key", "crv": "P-256", "x": encodeCoordinate(ecKey.X), "y": encodeCoordinate(ecKey.Y)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err = os.WriteFile(path, jwks, 0600); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return path
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	authenticator, err := newOIDCAuthenticator(ServeOptions{
+		OIDCIssuerURL: testIssuer,
+		OIDCJWKSFile:  writeJWKS(t, &rsaKey.PublicKey, &ecKey.PublicKey),
+		OIDCClientID:  "kubeapps",
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	now := time.Unix(1600000000, 0)
+	authenticator.now = func() time.Time { return now }
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss":    testIssuer,
+			"sub":    "1234",
+			"aud":    []string{"kubeapps", "other"},
+			"exp":    now.Add(time.Hour).Unix(),
+			"email":  "jane@example.com",
+			"groups": []string{"devs", "ops"},
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+	expectedIdentity := &UserIdentity{
+		Username: "jane@example.com",
+		UID:      "1234",
+		Groups:   []string{"devs", "ops"},
+	}
+
+	testCases := []struct {
+		name             string
+		token            string
+		expectedStatus   codes.Code
+		expectedIdentity *UserIdentity
+	}{
+		{
+			name:             "it authenticates a token signed with an rsa key",
+			token:            signToken(t, "RS256", "rsa-key", rsaKey, claims(nil)),
+			expectedStatus:   codes.OK,
+			expectedIdentity: expectedIdentity,
+		},
+		{
+			name:             "it authenticates a token signed with an ec key",
+			token:            signToken(t, "ES256", "ec-key", ecKey, claims(nil)),
+			expectedStatus:   codes.OK,
+			expectedIdentity: expectedIdentity,
+		},
+		{
+			name:             "it accepts a single audience and group",
+			token:            signToken(t, "RS256", "rsa-key", rsaKey, claims(map[string]interface{}{"aud": "kubeapps", "groups": "devs"})),
+			expectedStatus:   codes.OK,
+			expectedIdentity: &UserIdentity{Username: "jane@example.com", UID: "1234", Groups: []string{"devs"}},
+		},
+		{
+			name:           "it rejects a token which is not a jwt",
+			token:          "abc",
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a token with a malformed payload",
+			token:          "eyJhbGciOiJSUzI1NiJ9.!!!.abc",
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a token signed with another key",
+			token:          signToken(t, "RS256", "rsa-key", otherKey, claims(nil)),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a token signed with an unknown key",
+			token:          signToken(t, "RS256", "other-key", otherKey, claims(nil)),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a token whose algorithm does not match the key",
+			token:          signToken(t, "ES256", "rsa-key", rsaKey, claims(nil)),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a token whose algorithm does not match the curve of the key",
+			token:          signToken(t, "ES384", "ec-key", ecKey, claims(nil)),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a token signed with an algorithm which is not allowed",
+			token:          signToken(t, "PS256", "rsa-key", rsaKey, claims(nil)),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects an unsigned token",
+			token:          signToken(t, "none", "rsa-key", nil, claims(nil)),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a token from another issuer",
+			token:          signToken(t, "RS256", "rsa-key", rsaKey, claims(map[string]interface{}{"iss": "https://other.example.com"})),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a token for another client",
+			token:          signToken(t, "RS256", "rsa-key", rsaKey, claims(map[string]interface{}{"aud": "other"})),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects an expired token",
+			token:          signToken(t, "RS256", "rsa-key", rsaKey, claims(map[string]interface{}{"exp": now.Add(-time.Minute).Unix()})),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a token which is not valid yet",
+			token:          signToken(t, "RS256", "rsa-key", rsaKey, claims(map[string]interface{}{"nbf": now.Add(5 * time.Minute).Unix()})),
+			expectedStatus: codes.Unauthenticated,
+		},
+		{
+			name:           "it rejects a token without username",
+			token:          signToken(t, "RS256", "rsa-key", rsaKey, claims(map[string]interface{}{"email": nil})),
+			expectedStatus: codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			identity, err := authenticator.Authenticate(context.Background(), tc.token)
+			if got, want := status.Code(err), tc.expectedStatus; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if got, want := identity, tc.expectedIdentity; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("it rejects a tampered token", func(t *testing.T) {
+		token := signToken(t, "RS256", "rsa-key", rsaKey, claims(nil))
+		parts := strings.Split(token, ".")
+		payload, _ := json.Marshal(claims(map[string]interface{}{"email": "admin@example.com"}))
+		parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+		_, err := authenticator.Authenticate(context.Background(), strings.Join(parts, "."))
+		if got, want := status.Code(err), codes.Unauthenticated; got != want {
+			t.Errorf("got: %+v, want: %+v", got, want)
+		}
+	})
+}
+
+func TestNewOIDCAuthenticatorErrors(t *testing.T) {
+	if _, err := newOIDCAuthenticator(ServeOptions{OIDCIssuerURL: testIssuer}); err == nil {
+		t.Errorf("got: nil, want: error without jwks file")
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, []byte(`{"keys": []}`), 0600); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := newOIDCAuthenticator(ServeOptions{OIDCIssuerURL: testIssuer, OIDCJWKSFile: path}); err == nil {
+		t.Errorf("got: nil, want: error without signing keys")
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/heptiolabs/healthcheck"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// readinessCheckTimeout is the deadline of each readiness check.
+const readinessCheckTimeout = 5 * time.Second
+
+// ReadinessChecker is implemented by the plugins depending on other services,
+// such as a cache, to report whether they are able to serve requests.
+type ReadinessChecker interface {
+	CheckReadiness(ctx context.Context) error
+}
+
+// pluginWithReadinessChecker stores the plugin detail together with its
+// readiness check.
+type pluginWithReadinessChecker struct {
+	plugin  *plugins.Plugin
+	checker ReadinessChecker
+}
+
+// checkPluginsRegistered fails until at least one plugin has been registered.
+func (s *pluginsServer) checkPluginsRegistered() error {
+	if len(s.plugins) == 0 {
+		return fmt.Errorf("no plugins registered")
+	}
+	return nil
+}
+
+// newHealthHandler returns the handler of the liveness and readiness
+// endpoints, the service being ready once the plugins are registered and
+// each of them reports being ready.
+func newHealthHandler(pluginsServer *pluginsServer) healthcheck.Handler {
+	health := healthcheck.NewHandler()
+	health.AddReadinessCheck("plugins", pluginsServer.checkPluginsRegistered)
+	for _, p := range pluginsServer.readinessCheckers {
+		checker := p.checker
+		health.AddReadinessCheck(p.plugin.GetName(), healthcheck.Timeout(func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), readinessCheckTimeout)
+			defer cancel()
+			return checker.CheckReadiness(ctx)
+		}, readinessCheckTimeout))
+	}
+	return health
+}
+
+// registerHealthHandlers registers the liveness, readiness and metrics
+// endpoints on the gateway mux, so that they are served on the same port.
+func registerHealthHandlers(mux *runtime.ServeMux, health healthcheck.Handler) error {
+	handlers := map[string]http.Handler{
+		"/live":    http.HandlerFunc(health.LiveEndpoint),
+		"/ready":   http.HandlerFunc(health.ReadyEndpoint),
+		"/metrics": promhttp.Handler(),
+	}
+	for path, handler := range handlers {
+		h := handler
+		err := mux.HandlePath(http.MethodGet, path, runtime.HandlerFunc(func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			h.ServeHTTP(w, r)
+		}))
+		if err != nil {
+			return fmt.Errorf("unable to register the %q endpoint: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+)
+
+type testReadinessChecker struct {
+	err error
+}
+
+func (c *testReadinessChecker) CheckReadiness(ctx context.Context) error {
+	return c.err
+}
+
+func TestHealthHandlers(t *testing.T) {
+	fluxPlugin := &plugins.Plugin{Name: "fluxv2.packages", Version: "v1alpha1"}
+
+	testCases := []struct {
+		name              string
+		pluginsServer     *pluginsServer
+		expectedLiveCode  int
+		expectedReadyCode int
+	}{
+		{
+			name: "it is ready once plugins are registered and ready",
+			pluginsServer: &pluginsServer{
+				plugins:           []*plugins.Plugin{fluxPlugin},
+				readinessCheckers: []*pluginWithReadinessChecker{{plugin: fluxPlugin, checker: &testReadinessChecker{}}},
+			},
+			expectedLiveCode:  http.StatusOK,
+			expectedReadyCode: http.StatusOK,
+		},
+		{
+			name:              "it is not ready without registered plugins",
+			pluginsServer:     &pluginsServer{},
+			expectedLiveCode:  http.StatusOK,
+			expectedReadyCode: http.StatusServiceUnavailable,
+		},
+		{
+			name: "it is not ready while a plugin is not ready",
+			pluginsServer: &pluginsServer{
+				plugins:           []*plugins.Plugin{fluxPlugin},
+				readinessCheckers: []*pluginWithReadinessChecker{{plugin: fluxPlugin, checker: &testReadinessChecker{err: fmt.Errorf("redis unavailable")}}},
+			},
+			expectedLiveCode:  http.StatusOK,
+			expectedReadyCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := gatewayMux()
+			if err := registerHealthHandlers(mux, newHealthHandler(tc.pluginsServer)); err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			for path, expectedCode := range map[string]int{
+				"/live":    tc.expectedLiveCode,
+				"/ready":   tc.expectedReadyCode,
+				"/metrics": http.StatusOK,
+			} {
+				recorder := httptest.NewRecorder()
+				mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+				if got, want := recorder.Code, expectedCode; got != want {
+					t.Errorf("%s: got: %d, want: %d", path, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	metricsNamespace = "kubeapps_apis"
+	// corePluginLabel is the plugin label of the requests served by the
+	// core services rather than by a plugin service.
+	corePluginLabel = "core"
+	// pluginServicePrefix is the prefix of the services defined by plugins,
+	// followed by the plugin name and version.
+	pluginServicePrefix = "kubeappsapis.plugins."
+)
+
+var (
+	grpcRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "grpc_requests_total",
+		Help:      "Total number of gRPC requests handled, by service, method, plugin and status code.",
+	}, []string{"service", "method", "plugin", "code"})
+
+	grpcRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "Latency of the gRPC requests handled, by service, method and plugin.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "plugin"})
+
+	pluginCallsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "plugin_calls_total",
+		Help:      "Total number of plugin calls made by the core services when aggregating results, by service, method, plugin and status code.",
+	}, []string{"service", "method", "plugin", "code"})
+
+	pluginCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "plugin_call_duration_seconds",
+		Help:      "Latency of the plugin calls made by the core services when aggregating results, by service, method and plugin.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "plugin"})
+)
+
+func init() {
+	prometheus.MustRegister(grpcRequestsTotal, grpcRequestDuration, pluginCallsTotal, pluginCallDuration)
+}
+
+// splitMethodName returns the service and method of a full gRPC method name,
+// such as "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageSummaries".
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
+// pluginForService returns the name of the plugin defining the service, such
+// as "helm.packages" for "kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService",
+// or "core" for the core services.
+func pluginForService(service string) string {
+	if !strings.HasPrefix(service, pluginServicePrefix) {
+		return corePluginLabel
+	}
+	parts := strings.Split(strings.TrimPrefix(service, pluginServicePrefix), ".")
+	if len(parts) < 2 {
+		return parts[0]
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// observeRequest records the status code and latency of a gRPC request.
+func observeRequest(fullMethod string, start time.Time, err error) {
+	service, method := splitMethodName(fullMethod)
+	plugin := pluginForService(service)
+	grpcRequestsTotal.WithLabelValues(service, method, plugin, status.Code(err).String()).Inc()
+	grpcRequestDuration.WithLabelValues(service, method, plugin).Observe(time.Since(start).Seconds())
+}
+
+// observePluginCall records the status code and latency of a call to a plugin
+// made by a core service while handling the gRPC request of the context.
+func observePluginCall(ctx context.Context, p *plugins.Plugin, start time.Time, err error) {
+	fullMethod, ok := grpc.Method(ctx)
+	if !ok {
+		return
+	}
+	service, method := splitMethodName(fullMethod)
+	pluginCallsTotal.WithLabelValues(service, method, p.GetName(), status.Code(err).String()).Inc()
+	pluginCallDuration.WithLabelValues(service, method, p.GetName()).Observe(time.Since(start).Seconds())
+}
+
+// metricsUnaryInterceptor records the metrics of each unary request.
+func metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeRequest(info.FullMethod, start, err)
+	return resp, err
+}
+
+// metricsStreamInterceptor records the metrics of each streaming request,
+// whose latency is the lifetime of the stream.
+func metricsStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	observeRequest(info.FullMethod, start, err)
+	return err
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testServerTransportStream provides the method of a request to the context,
+// as the grpc server does.
+type testServerTransportStream struct {
+	grpc.ServerTransportStream
+	method string
+}
+
+func (s *testServerTransportStream) Method() string {
+	return s.method
+}
+
+func TestPluginForService(t *testing.T) {
+	testCases := []struct {
+		fullMethod      string
+		expectedService string
+		expectedMethod  string
+		expectedPlugin  string
+	}{
+		{
+			fullMethod:      "/kubeappsapis.core.packages.v1alpha1.PackagesService/GetAvailablePackageSummaries",
+			expectedService: "kubeappsapis.core.packages.v1alpha1.PackagesService",
+			expectedMethod:  "GetAvailablePackageSummaries",
+			expectedPlugin:  "core",
+		},
+		{
+			fullMethod:      "/kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService/GetAvailablePackageDetail",
+			expectedService: "kubeappsapis.plugins.helm.packages.v1alpha1.HelmPackagesService",
+			expectedMethod:  "GetAvailablePackageDetail",
+			expectedPlugin:  "helm.packages",
+		},
+		{
+			fullMethod:      "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+			expectedService: "grpc.reflection.v1alpha.ServerReflection",
+			expectedMethod:  "ServerReflectionInfo",
+			expectedPlugin:  "core",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.fullMethod, func(t *testing.T) {
+			service, method := splitMethodName(tc.fullMethod)
+			if got, want := service, tc.expectedService; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := method, tc.expectedMethod; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+			if got, want := pluginForService(service), tc.expectedPlugin; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
+
+func TestMetricsUnaryInterceptor(t *testing.T) {
+	service := "kubeappsapis.plugins.fluxv2.packages.v1alpha1.FluxV2PackagesService"
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + service + "/GetPackageRepositories"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Errorf(codes.NotFound, "not found")
+	}
+
+	okCounter := grpcRequestsTotal.WithLabelValues(service, "GetPackageRepositories", "fluxv2.packages", codes.OK.String())
+	notFoundCounter := grpcRequestsTotal.WithLabelValues(service, "GetPackageRepositories", "fluxv2.packages", codes.NotFound.String())
+	okBefore, notFoundBefore := testutil.ToFloat64(okCounter), testutil.ToFloat64(notFoundCounter)
+
+	_, err := metricsUnaryInterceptor(context.Background(), nil, info, handler)
+	if got, want := status.Code(err), codes.NotFound; got != want {
+		t.Fatalf("got: %+v, want: %+v", got, want)
+	}
+
+	if got, want := testutil.ToFloat64(notFoundCounter)-notFoundBefore, 1.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := testutil.ToFloat64(okCounter)-okBefore, 0.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestObservePluginCall(t *testing.T) {
+	service := "kubeappsapis.core.packages.v1alpha1.PackagesService"
+	plugin := &plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &testServerTransportStream{
+		method: "/" + service + "/GetAvailablePackageSummaries",
+	})
+	counter := pluginCallsTotal.WithLabelValues(service, "GetAvailablePackageSummaries", plugin.Name, codes.DeadlineExceeded.String())
+	before := testutil.ToFloat64(counter)
+
+	callPlugin(ctx, time.Millisecond, plugin, func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	if got, want := testutil.ToFloat64(counter)-before, 1.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
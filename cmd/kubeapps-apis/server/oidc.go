@@ -0,0 +1,171 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+const (
+	defaultOIDCUsernameClaim = "email"
+	defaultOIDCGroupsClaim   = "groups"
+)
+
+// oidcSigningAlgorithms are the only algorithms accepted for the signature
+// of the ID tokens. go-jose checks that they match the type and curve of the
+// verifying key.
+var oidcSigningAlgorithms = []string{
+	oidc.RS256, oidc.RS384, oidc.RS512,
+	oidc.ES256, oidc.ES384, oidc.ES512,
+}
+
+// oidcAuthenticator authenticates OIDC ID tokens issued by the configured
+// issuer, verifying their signature with the keys of a JWKS file.
+type oidcAuthenticator struct {
+	verifier      *oidc.IDTokenVerifier
+	usernameClaim string
+	groupsClaim   string
+	now           func() time.Time
+}
+
+func newOIDCAuthenticator(serveOpts ServeOptions) (*oidcAuthenticator, error) {
+	if serveOpts.OIDCIssuerURL == "" || serveOpts.OIDCJWKSFile == "" {
+		return nil, fmt.Errorf("the oidc authentication requires both an issuer url and a jwks file")
+	}
+	jwks, err := os.ReadFile(serveOpts.OIDCJWKSFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the jwks file: %w", err)
+	}
+	keySet, err := parseJWKS(jwks)
+	if err != nil {
+		return nil, err
+	}
+	a := &oidcAuthenticator{
+		usernameClaim: serveOpts.OIDCUsernameClaim,
+		groupsClaim:   serveOpts.OIDCGroupsClaim,
+		now:           time.Now,
+	}
+	a.verifier = oidc.NewVerifier(serveOpts.OIDCIssuerURL, keySet, &oidc.Config{
+		ClientID: serveOpts.OIDCClientID,
+		// any audience is accepted without a client id
+		SkipClientIDCheck:    serveOpts.OIDCClientID == "",
+		SupportedSigningAlgs: oidcSigningAlgorithms,
+		Now:                  func() time.Time { return a.now() },
+	})
+	if a.usernameClaim == "" {
+		a.usernameClaim = defaultOIDCUsernameClaim
+	}
+	if a.groupsClaim == "" {
+		a.groupsClaim = defaultOIDCGroupsClaim
+	}
+	return a, nil
+}
+
+// jwksKeySet verifies the signatures of the ID tokens with the public
+// signing keys of a JSON Web Key Set.
+type jwksKeySet struct {
+	keys jose.JSONWebKeySet
+}
+
+// parseJWKS returns the key set of the signing keys of a JSON Web Key Set.
+func parseJWKS(data []byte) (*jwksKeySet, error) {
+	var jwks jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("unable to parse the jwks: %w", err)
+	}
+	keySet := &jwksKeySet{}
+	for _, key := range jwks.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if !key.IsPublic() {
+			return nil, fmt.Errorf("the key %q of the jwks is not a public key", key.KeyID)
+		}
+		keySet.keys.Keys = append(keySet.keys.Keys, key)
+	}
+	if len(keySet.keys.Keys) == 0 {
+		return nil, fmt.Errorf("the jwks has no signing keys")
+	}
+	return keySet, nil
+}
+
+// VerifySignature verifies the signature of the token with the key of the
+// issuer identified in its header, returning its payload. The algorithm and
+// claims are checked by the oidc verifier.
+func (s *jwksKeySet) VerifySignature(ctx context.Context, token string) ([]byte, error) {
+	jws, err := jose.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %w", err)
+	}
+	if len(jws.Signatures) != 1 {
+		return nil, fmt.Errorf("expected a single signature, got %d", len(jws.Signatures))
+	}
+	header := jws.Signatures[0].Header
+	for _, key := range s.keys.Key(header.KeyID) {
+		if key.Algorithm != "" && key.Algorithm != header.Algorithm {
+			continue
+		}
+		if payload, err := jws.Verify(key); err == nil {
+			return payload, nil
+		}
+	}
+	return nil, fmt.Errorf("no key %q of the jwks verifies the signature", header.KeyID)
+}
+
+func (a *oidcAuthenticator) Authenticate(ctx context.Context, token string) (*UserIdentity, error) {
+	idToken, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	claims := map[string]interface{}{}
+	if err = idToken.Claims(&claims); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "malformed token payload: %v", err)
+	}
+
+	username, _ := claims[a.usernameClaim].(string)
+	if username == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: missing the %q claim", a.usernameClaim)
+	}
+	return &UserIdentity{
+		Username: username,
+		UID:      idToken.Subject,
+		Groups:   stringsClaim(claims[a.groupsClaim]),
+	}, nil
+}
+
+// stringsClaim returns the values of a claim which can be either a single
+// string or an array of strings.
+func stringsClaim(claim interface{}) []string {
+	switch claim := claim.(type) {
+	case string:
+		return []string{claim}
+	case []interface{}:
+		values := []string{}
+		for _, v := range claim {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
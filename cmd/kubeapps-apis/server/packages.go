@@ -15,8 +15,14 @@ package server
 import (
 	"context"
 	"fmt"
+	"time"
 
 	packages "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	log "k8s.io/klog/v2"
 )
 
@@ -27,15 +33,48 @@ type packagesServer struct {
 	// plugins is a slice of all registered plugins which satisfy the core.packages.v1alpha1
 	// interface.
 	plugins []*pkgsPluginWithServer
+
+	// pluginTimeout is the deadline applied to each plugin when aggregating
+	// results from all plugins.
+	pluginTimeout time.Duration
 }
 
-func NewPackagesServer(plugins []*pkgsPluginWithServer) *packagesServer {
+func NewPackagesServer(plugins []*pkgsPluginWithServer, pluginTimeout time.Duration) *packagesServer {
 	return &packagesServer{
-		plugins: plugins,
+		plugins:       plugins,
+		pluginTimeout: pluginTimeout,
+	}
+}
+
+// callAllPlugins calls fn for each registered plugin concurrently, applying the
+// per-plugin timeout, and returns the results in the order of the registered plugins.
+func (s packagesServer) callAllPlugins(ctx context.Context, fn func(context.Context, *pkgsPluginWithServer) (interface{}, error)) []pluginResult {
+	pluginDetails := make([]*plugins.Plugin, len(s.plugins))
+	for i, p := range s.plugins {
+		pluginDetails[i] = p.plugin
 	}
+	return callAllPlugins(ctx, s.pluginTimeout, pluginDetails, func(ctx context.Context, i int) (interface{}, error) {
+		return fn(ctx, s.plugins[i])
+	})
+}
+
+// callPlugin calls fn for the plugin, applying the per-plugin timeout.
+func (s packagesServer) callPlugin(ctx context.Context, p *pkgsPluginWithServer, fn func(context.Context, *pkgsPluginWithServer) (interface{}, error)) pluginResult {
+	return callPlugin(ctx, s.pluginTimeout, p.plugin, func(ctx context.Context) (interface{}, error) {
+		return fn(ctx, p)
+	})
 }
 
 // GetAvailablePackages returns the packages based on the request.
+//
+// The results of every plugin are merged in the requested sort order. When a
+// page size is requested, the returned next page token encodes the position
+// within the results of each plugin, so that following pages continue the
+// single ordered list. Without sort options, the results of a search query
+// are sorted by relevance. As the merge is only exact when plugins return their
+// pages in the requested order, the paginated results can only be sorted by
+// name or relevance. A plugin failing is reported in the plugin errors of
+// the page and not requested for the following pages.
 func (s packagesServer) GetAvailablePackageSummaries(ctx context.Context, request *packages.GetAvailablePackageSummariesRequest) (*packages.GetAvailablePackageSummariesResponse, error) {
 	contextMsg := ""
 	if request.Context != nil {
@@ -44,27 +83,341 @@ func (s packagesServer) GetAvailablePackageSummaries(ctx context.Context, reques
 
 	log.Infof("+core GetAvailablePackageSummaries %s", contextMsg)
 
-	pkgs := []*packages.AvailablePackageSummary{}
-	// TODO: We can do these in parallel in separate go routines.
-	for _, p := range s.plugins {
-		response, err := p.server.GetAvailablePackageSummaries(ctx, request)
-		if err != nil {
+	pageSize := int(request.GetPaginationOptions().GetPageSize())
+	sortOptions := requestSortOptions(request)
+	if pageSize > 0 {
+		if err := validatePaginatedSortOptions(sortOptions); err != nil {
 			return nil, err
 		}
+	}
+	less := availableSummaryLess(sortOptions)
 
-		// Add the plugin for the pkgs
-		pluginPkgs := response.AvailablePackagesSummaries
-		for _, r := range pluginPkgs {
-			if r.AvailablePackageRef == nil {
-				r.AvailablePackageRef = &packages.AvailablePackageReference{}
+	fetchPage := func(ctx context.Context, p *pkgsPluginWithServer, pageToken string) (pluginPageResponse, error) {
+		pluginRequest := proto.Clone(request).(*packages.GetAvailablePackageSummariesRequest)
+		pluginRequest.PaginationOptions = nil
+		if pageSize > 0 {
+			pluginRequest.PaginationOptions = &packages.PaginationOptions{
+				PageToken: pageToken,
+				PageSize:  int32(pageSize),
 			}
-			r.AvailablePackageRef.Plugin = p.plugin
 		}
-		pkgs = append(pkgs, pluginPkgs...)
+		response, err := p.server.GetAvailablePackageSummaries(ctx, pluginRequest)
+		if err != nil {
+			return pluginPageResponse{}, err
+		}
+		items := make([]interface{}, 0, len(response.GetAvailablePackagesSummaries()))
+		for _, pkg := range response.GetAvailablePackagesSummaries() {
+			if pkg.AvailablePackageRef == nil {
+				pkg.AvailablePackageRef = &packages.AvailablePackageReference{}
+			}
+			pkg.AvailablePackageRef.Plugin = p.plugin
+			items = append(items, pkg)
+		}
+		return pluginPageResponse{items: items, nextPageToken: response.GetNextPageToken()}, nil
+	}
+
+	items, nextPageToken, pluginErrors, err := s.mergePluginPages(ctx, request.GetPaginationOptions().GetPageToken(), pageSize, fetchPage, func(a, b interface{}) bool {
+		return less(a.(*packages.AvailablePackageSummary), b.(*packages.AvailablePackageSummary))
+	})
+	if err != nil {
+		return nil, err
+	}
+	pkgs := make([]*packages.AvailablePackageSummary, 0, len(items))
+	for _, item := range items {
+		pkgs = append(pkgs, item.(*packages.AvailablePackageSummary))
 	}
 
-	// TODO: Sort via default sort order or that specified in request.
 	return &packages.GetAvailablePackageSummariesResponse{
 		AvailablePackagesSummaries: pkgs,
+		NextPageToken:              nextPageToken,
+		PluginErrors:               pluginErrors,
+	}, nil
+}
+
+// GetAvailablePackageDetail returns the package detail from the plugin of the
+// requested available package.
+func (s packagesServer) GetAvailablePackageDetail(ctx context.Context, request *packages.GetAvailablePackageDetailRequest) (*packages.GetAvailablePackageDetailResponse, error) {
+	log.Infof("+core GetAvailablePackageDetail %s", availablePackageRefMsg(request.GetAvailablePackageRef()))
+
+	p, err := s.getPluginWithServer(request.GetAvailablePackageRef().GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.server.GetAvailablePackageDetail(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the plugin is set on the returned reference.
+	if detail := response.GetAvailablePackageDetail(); detail != nil {
+		if detail.AvailablePackageRef == nil {
+			detail.AvailablePackageRef = &packages.AvailablePackageReference{}
+		}
+		detail.AvailablePackageRef.Plugin = p.plugin
+	}
+	return response, nil
+}
+
+// GetAvailablePackageVersions returns the package versions from the plugin of
+// the requested available package.
+func (s packagesServer) GetAvailablePackageVersions(ctx context.Context, request *packages.GetAvailablePackageVersionsRequest) (*packages.GetAvailablePackageVersionsResponse, error) {
+	log.Infof("+core GetAvailablePackageVersions %s", availablePackageRefMsg(request.GetAvailablePackageRef()))
+
+	p, err := s.getPluginWithServer(request.GetAvailablePackageRef().GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.server.GetAvailablePackageVersions(ctx, request)
+}
+
+// GetInstalledPackageSummaries returns the installed packages managed by any
+// plugin, sorted by name.
+//
+// As for the available packages, the results of every plugin are merged and
+// the next page token encodes the position within the results of each
+// plugin when a page size is requested.
+func (s packagesServer) GetInstalledPackageSummaries(ctx context.Context, request *packages.GetInstalledPackageSummariesRequest) (*packages.GetInstalledPackageSummariesResponse, error) {
+	contextMsg := ""
+	if request.Context != nil {
+		contextMsg = fmt.Sprintf("(cluster=[%s], namespace=[%s])", request.Context.Cluster, request.Context.Namespace)
+	}
+
+	log.Infof("+core GetInstalledPackageSummaries %s", contextMsg)
+
+	pageSize := int(request.GetPaginationOptions().GetPageSize())
+	fetchPage := func(ctx context.Context, p *pkgsPluginWithServer, pageToken string) (pluginPageResponse, error) {
+		pluginRequest := proto.Clone(request).(*packages.GetInstalledPackageSummariesRequest)
+		pluginRequest.PaginationOptions = nil
+		if pageSize > 0 {
+			pluginRequest.PaginationOptions = &packages.PaginationOptions{
+				PageToken: pageToken,
+				PageSize:  int32(pageSize),
+			}
+		}
+		response, err := p.server.GetInstalledPackageSummaries(ctx, pluginRequest)
+		if err != nil {
+			return pluginPageResponse{}, err
+		}
+		items := make([]interface{}, 0, len(response.GetInstalledPackageSummaries()))
+		for _, pkg := range response.GetInstalledPackageSummaries() {
+			if pkg.InstalledPackageRef == nil {
+				pkg.InstalledPackageRef = &packages.InstalledPackageReference{}
+			}
+			pkg.InstalledPackageRef.Plugin = p.plugin
+			items = append(items, pkg)
+		}
+		return pluginPageResponse{items: items, nextPageToken: response.GetNextPageToken()}, nil
+	}
+
+	items, nextPageToken, pluginErrors, err := s.mergePluginPages(ctx, request.GetPaginationOptions().GetPageToken(), pageSize, fetchPage, func(a, b interface{}) bool {
+		return installedSummaryLess(a.(*packages.InstalledPackageSummary), b.(*packages.InstalledPackageSummary))
+	})
+	if err != nil {
+		return nil, err
+	}
+	pkgs := make([]*packages.InstalledPackageSummary, 0, len(items))
+	for _, item := range items {
+		pkgs = append(pkgs, item.(*packages.InstalledPackageSummary))
+	}
+
+	return &packages.GetInstalledPackageSummariesResponse{
+		InstalledPackageSummaries: pkgs,
+		NextPageToken:             nextPageToken,
+		PluginErrors:              pluginErrors,
 	}, nil
 }
+
+// GetInstalledPackageDetail returns the package detail from the plugin managing the installed package.
+func (s packagesServer) GetInstalledPackageDetail(ctx context.Context, request *packages.GetInstalledPackageDetailRequest) (*packages.GetInstalledPackageDetailResponse, error) {
+	log.Infof("+core GetInstalledPackageDetail %s", installedPackageRefMsg(request.GetInstalledPackageRef()))
+
+	p, err := s.getPluginWithServer(request.GetInstalledPackageRef().GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.server.GetInstalledPackageDetail(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the plugin is set on the returned reference.
+	if detail := response.GetInstalledPackageDetail(); detail != nil {
+		if detail.InstalledPackageRef == nil {
+			detail.InstalledPackageRef = &packages.InstalledPackageReference{}
+		}
+		detail.InstalledPackageRef.Plugin = p.plugin
+	}
+	return response, nil
+}
+
+// CreateInstalledPackage creates the installed package using the plugin of the
+// requested available package.
+func (s packagesServer) CreateInstalledPackage(ctx context.Context, request *packages.CreateInstalledPackageRequest) (*packages.CreateInstalledPackageResponse, error) {
+	contextMsg := ""
+	if request.TargetContext != nil {
+		contextMsg = fmt.Sprintf("(cluster=[%s], namespace=[%s])", request.TargetContext.Cluster, request.TargetContext.Namespace)
+	}
+	log.Infof("+core CreateInstalledPackage %s", contextMsg)
+
+	p, err := s.getPluginWithServer(request.GetAvailablePackageRef().GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.server.CreateInstalledPackage(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.InstalledPackageRef == nil {
+		response.InstalledPackageRef = &packages.InstalledPackageReference{}
+	}
+	response.InstalledPackageRef.Plugin = p.plugin
+	return response, nil
+}
+
+// UpdateInstalledPackage updates the installed package using the plugin managing it.
+func (s packagesServer) UpdateInstalledPackage(ctx context.Context, request *packages.UpdateInstalledPackageRequest) (*packages.UpdateInstalledPackageResponse, error) {
+	log.Infof("+core UpdateInstalledPackage %s", installedPackageRefMsg(request.GetInstalledPackageRef()))
+
+	p, err := s.getPluginWithServer(request.GetInstalledPackageRef().GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.server.UpdateInstalledPackage(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.InstalledPackageRef == nil {
+		response.InstalledPackageRef = &packages.InstalledPackageReference{}
+	}
+	response.InstalledPackageRef.Plugin = p.plugin
+	return response, nil
+}
+
+// DeleteInstalledPackage deletes the installed package using the plugin managing it.
+func (s packagesServer) DeleteInstalledPackage(ctx context.Context, request *packages.DeleteInstalledPackageRequest) (*packages.DeleteInstalledPackageResponse, error) {
+	log.Infof("+core DeleteInstalledPackage %s", installedPackageRefMsg(request.GetInstalledPackageRef()))
+
+	p, err := s.getPluginWithServer(request.GetInstalledPackageRef().GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.server.DeleteInstalledPackage(ctx, request)
+}
+
+// WatchInstalledPackages streams the changes of the installed packages managed
+// by every plugin. The failure of a single plugin is sent as an event with the
+// plugin error so that the events of the other plugins continue to be sent,
+// while an error is returned only when every plugin failed.
+func (s packagesServer) WatchInstalledPackages(request *packages.WatchInstalledPackagesRequest, stream packages.PackagesService_WatchInstalledPackagesServer) error {
+	contextMsg := ""
+	if request.Context != nil {
+		contextMsg = fmt.Sprintf("(cluster=[%s], namespace=[%s])", request.Context.Cluster, request.Context.Namespace)
+	}
+
+	log.Infof("+core WatchInstalledPackages %s", contextMsg)
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	events := make(chan *packages.WatchInstalledPackagesResponse)
+	results := make(chan pluginResult, len(s.plugins))
+	for _, p := range s.plugins {
+		go func(p *pkgsPluginWithServer) {
+			err := p.server.WatchInstalledPackages(request, &pluginWatchStream{
+				ServerStream: stream,
+				ctx:          ctx,
+				plugin:       p.plugin,
+				events:       events,
+			})
+			results <- pluginResult{plugin: p.plugin, err: err}
+		}(p)
+	}
+
+	failed := 0
+	for remaining := len(s.plugins); remaining > 0; {
+		select {
+		case event := <-events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case r := <-results:
+			remaining--
+			if r.err == nil {
+				continue
+			}
+			failed++
+			if failed == len(s.plugins) {
+				return r.err
+			}
+			if err := stream.Send(&packages.WatchInstalledPackagesResponse{PluginError: newPluginError(r)}); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	return nil
+}
+
+// pluginWatchStream is the stream passed to a plugin watching installed
+// packages, which sets the plugin on each event before forwarding it to the
+// stream of the core server.
+type pluginWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	plugin *plugins.Plugin
+	events chan<- *packages.WatchInstalledPackagesResponse
+}
+
+func (w *pluginWatchStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *pluginWatchStream) Send(event *packages.WatchInstalledPackagesResponse) error {
+	if summary := event.GetInstalledPackageSummary(); summary != nil {
+		if summary.InstalledPackageRef == nil {
+			summary.InstalledPackageRef = &packages.InstalledPackageReference{}
+		}
+		summary.InstalledPackageRef.Plugin = w.plugin
+	}
+	select {
+	case w.events <- event:
+		return nil
+	case <-w.ctx.Done():
+		return status.FromContextError(w.ctx.Err()).Err()
+	}
+}
+
+// getPluginWithServer returns the registered plugin matching the requested
+// plugin name and version.
+func (s packagesServer) getPluginWithServer(plugin *plugins.Plugin) (*pkgsPluginWithServer, error) {
+	if plugin == nil || plugin.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "A plugin must be specified for the request")
+	}
+	for _, p := range s.plugins {
+		if p.plugin.Name == plugin.Name && p.plugin.Version == plugin.Version {
+			return p, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "Unable to find the plugin %q (version %q)", plugin.Name, plugin.Version)
+}
+
+// installedPackageRefMsg returns a string describing the installed package
+// reference for logging.
+func installedPackageRefMsg(ref *packages.InstalledPackageReference) string {
+	return fmt.Sprintf("(cluster=[%s], namespace=[%s], identifier=[%s])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+}
+
+// availablePackageRefMsg returns a string describing the available package
+// reference for logging.
+func availablePackageRefMsg(ref *packages.AvailablePackageReference) string {
+	return fmt.Sprintf("(cluster=[%s], namespace=[%s], identifier=[%s])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+}
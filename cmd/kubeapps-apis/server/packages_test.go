@@ -0,0 +1,1210 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	packages "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var mockedPlugin1 = &plugins.Plugin{Name: "mock1.packages", Version: "v1alpha1"}
+var mockedPlugin2 = &plugins.Plugin{Name: "mock2.packages", Version: "v1alpha1"}
+
+// testPackagesServer is a fake plugin implementation of the core packages API
+// returning pre-configured results.
+type testPackagesServer struct {
+	packages.UnimplementedPackagesServiceServer
+
+	availablePackageSummaries []*packages.AvailablePackageSummary
+	installedPackageSummaries []*packages.InstalledPackageSummary
+	// delay, if set, is the time the fake plugin takes to respond.
+	delay time.Duration
+	// lastIdentifier records the identifier of the last installed package
+	// reference received so that tests can verify the routing.
+	lastIdentifier string
+	err            error
+	// pageTokenErr, if set, is returned for the page of the pageTokenWithErr.
+	pageTokenWithErr string
+	pageTokenErr     error
+}
+
+func (s *testPackagesServer) GetAvailablePackageSummaries(ctx context.Context, request *packages.GetAvailablePackageSummariesRequest) (*packages.GetAvailablePackageSummariesResponse, error) {
+	if s.delay > 0 {
+		time.Sleep(s.delay)
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	pageSize := int(request.GetPaginationOptions().GetPageSize())
+	if pageSize == 0 {
+		return &packages.GetAvailablePackageSummariesResponse{
+			AvailablePackagesSummaries: s.availablePackageSummaries,
+		}, nil
+	}
+	if s.pageTokenErr != nil && request.GetPaginationOptions().GetPageToken() == s.pageTokenWithErr {
+		return nil, s.pageTokenErr
+	}
+	start, end, nextPageToken, err := fakePage(request.GetPaginationOptions(), len(s.availablePackageSummaries))
+	if err != nil {
+		return nil, err
+	}
+	return &packages.GetAvailablePackageSummariesResponse{
+		AvailablePackagesSummaries: append([]*packages.AvailablePackageSummary{}, s.availablePackageSummaries[start:end]...),
+		NextPageToken:              nextPageToken,
+	}, nil
+}
+
+// fakePage returns the bounds of the requested page of the results of the
+// fake plugin, whose page token is the offset of the first item of the page,
+// together with the token of the next page.
+func fakePage(opts *packages.PaginationOptions, total int) (int, int, string, error) {
+	start := 0
+	if token := opts.GetPageToken(); token != "" {
+		var err error
+		if start, err = strconv.Atoi(token); err != nil {
+			return 0, 0, "", status.Errorf(codes.InvalidArgument, "invalid page token %q", token)
+		}
+	}
+	end := start + int(opts.GetPageSize())
+	nextPageToken := strconv.Itoa(end)
+	if end >= total {
+		end = total
+		nextPageToken = ""
+	}
+	return start, end, nextPageToken, nil
+}
+
+func (s *testPackagesServer) GetAvailablePackageDetail(ctx context.Context, request *packages.GetAvailablePackageDetailRequest) (*packages.GetAvailablePackageDetailResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetAvailablePackageRef().GetIdentifier()
+	return &packages.GetAvailablePackageDetailResponse{
+		AvailablePackageDetail: &packages.AvailablePackageDetail{
+			AvailablePackageRef: &packages.AvailablePackageReference{
+				Identifier: request.GetAvailablePackageRef().GetIdentifier(),
+			},
+		},
+	}, nil
+}
+
+func (s *testPackagesServer) GetAvailablePackageVersions(ctx context.Context, request *packages.GetAvailablePackageVersionsRequest) (*packages.GetAvailablePackageVersionsResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetAvailablePackageRef().GetIdentifier()
+	return &packages.GetAvailablePackageVersionsResponse{}, nil
+}
+
+func (s *testPackagesServer) GetInstalledPackageSummaries(ctx context.Context, request *packages.GetInstalledPackageSummariesRequest) (*packages.GetInstalledPackageSummariesResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if request.GetPaginationOptions().GetPageSize() == 0 {
+		return &packages.GetInstalledPackageSummariesResponse{
+			InstalledPackageSummaries: s.installedPackageSummaries,
+		}, nil
+	}
+	start, end, nextPageToken, err := fakePage(request.GetPaginationOptions(), len(s.installedPackageSummaries))
+	if err != nil {
+		return nil, err
+	}
+	return &packages.GetInstalledPackageSummariesResponse{
+		InstalledPackageSummaries: append([]*packages.InstalledPackageSummary{}, s.installedPackageSummaries[start:end]...),
+		NextPageToken:             nextPageToken,
+	}, nil
+}
+
+func (s *testPackagesServer) GetInstalledPackageDetail(ctx context.Context, request *packages.GetInstalledPackageDetailRequest) (*packages.GetInstalledPackageDetailResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetInstalledPackageRef().GetIdentifier()
+	return &packages.GetInstalledPackageDetailResponse{
+		InstalledPackageDetail: &packages.InstalledPackageDetail{
+			InstalledPackageRef: &packages.InstalledPackageReference{
+				Identifier: request.GetInstalledPackageRef().GetIdentifier(),
+			},
+		},
+	}, nil
+}
+
+func (s *testPackagesServer) CreateInstalledPackage(ctx context.Context, request *packages.CreateInstalledPackageRequest) (*packages.CreateInstalledPackageResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetName()
+	return &packages.CreateInstalledPackageResponse{
+		InstalledPackageRef: &packages.InstalledPackageReference{
+			Context:    request.GetTargetContext(),
+			Identifier: request.GetName(),
+		},
+	}, nil
+}
+
+func (s *testPackagesServer) UpdateInstalledPackage(ctx context.Context, request *packages.UpdateInstalledPackageRequest) (*packages.UpdateInstalledPackageResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetInstalledPackageRef().GetIdentifier()
+	return &packages.UpdateInstalledPackageResponse{
+		InstalledPackageRef: &packages.InstalledPackageReference{
+			Identifier: request.GetInstalledPackageRef().GetIdentifier(),
+		},
+	}, nil
+}
+
+func (s *testPackagesServer) DeleteInstalledPackage(ctx context.Context, request *packages.DeleteInstalledPackageRequest) (*packages.DeleteInstalledPackageResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetInstalledPackageRef().GetIdentifier()
+	return &packages.DeleteInstalledPackageResponse{}, nil
+}
+
+func (s *testPackagesServer) WatchInstalledPackages(request *packages.WatchInstalledPackagesRequest, stream packages.PackagesService_WatchInstalledPackagesServer) error {
+	if s.err != nil {
+		return s.err
+	}
+	for _, pkg := range s.installedPackageSummaries {
+		err := stream.Send(&packages.WatchInstalledPackagesResponse{
+			Type:                    packages.WatchInstalledPackagesResponse_EVENT_TYPE_ADDED,
+			InstalledPackageSummary: pkg,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testWatchStream is a fake client stream recording the events sent by the
+// core server.
+type testWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*packages.WatchInstalledPackagesResponse
+}
+
+func (s *testWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testWatchStream) Send(event *packages.WatchInstalledPackagesResponse) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestGetAvailablePackageSummaries(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configuredPlugins []*pkgsPluginWithServer
+		pluginTimeout     time.Duration
+		statusCode        codes.Code
+		expectedResponse  *packages.GetAvailablePackageSummariesResponse
+	}{
+		{
+			name: "it aggregates the available packages of every plugin in plugin order",
+			configuredPlugins: []*pkgsPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testPackagesServer{
+						// The first plugin responds last but its results are still first.
+						delay: 10 * time.Millisecond,
+						availablePackageSummaries: []*packages.AvailablePackageSummary{
+							{Name: "pkg-1"},
+						},
+					},
+				},
+				{
+					plugin: mockedPlugin2,
+					server: &testPackagesServer{
+						availablePackageSummaries: []*packages.AvailablePackageSummary{
+							{Name: "pkg-2"},
+						},
+					},
+				},
+			},
+			statusCode: codes.OK,
+			expectedResponse: &packages.GetAvailablePackageSummariesResponse{
+				AvailablePackagesSummaries: []*packages.AvailablePackageSummary{
+					{Name: "pkg-1", AvailablePackageRef: &packages.AvailablePackageReference{Plugin: mockedPlugin1}},
+					{Name: "pkg-2", AvailablePackageRef: &packages.AvailablePackageReference{Plugin: mockedPlugin2}},
+				},
+				PluginErrors: []*packages.PluginError{},
+			},
+		},
+		{
+			name: "it returns partial results together with the plugin errors",
+			configuredPlugins: []*pkgsPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testPackagesServer{err: status.Errorf(codes.Internal, "Bang!")},
+				},
+				{
+					plugin: mockedPlugin2,
+					server: &testPackagesServer{
+						availablePackageSummaries: []*packages.AvailablePackageSummary{
+							{Name: "pkg-2"},
+						},
+					},
+				},
+			},
+			statusCode: codes.OK,
+			expectedResponse: &packages.GetAvailablePackageSummariesResponse{
+				AvailablePackagesSummaries: []*packages.AvailablePackageSummary{
+					{Name: "pkg-2", AvailablePackageRef: &packages.AvailablePackageReference{Plugin: mockedPlugin2}},
+				},
+				PluginErrors: []*packages.PluginError{
+					{Plugin: mockedPlugin1, StatusCode: codes.Internal.String(), Message: "Bang!"},
+				},
+			},
+		},
+		{
+			name: "it reports a plugin not responding before the deadline",
+			configuredPlugins: []*pkgsPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testPackagesServer{
+						delay: time.Second,
+						availablePackageSummaries: []*packages.AvailablePackageSummary{
+							{Name: "pkg-1"},
+						},
+					},
+				},
+				{
+					plugin: mockedPlugin2,
+					server: &testPackagesServer{
+						availablePackageSummaries: []*packages.AvailablePackageSummary{
+							{Name: "pkg-2"},
+						},
+					},
+				},
+			},
+			pluginTimeout: 10 * time.Millisecond,
+			statusCode:    codes.OK,
+			expectedResponse: &packages.GetAvailablePackageSummariesResponse{
+				AvailablePackagesSummaries: []*packages.AvailablePackageSummary{
+					{Name: "pkg-2", AvailablePackageRef: &packages.AvailablePackageReference{Plugin: mockedPlugin2}},
+				},
+				PluginErrors: []*packages.PluginError{
+					{Plugin: mockedPlugin1, StatusCode: codes.DeadlineExceeded.String(), Message: context.DeadlineExceeded.Error()},
+				},
+			},
+		},
+		{
+			name: "it returns an error if every plugin returns an error",
+			configuredPlugins: []*pkgsPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testPackagesServer{err: status.Errorf(codes.Internal, "Bang!")},
+				},
+				{
+					plugin: mockedPlugin2,
+					server: &testPackagesServer{err: status.Errorf(codes.Unavailable, "Bang!")},
+				},
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	ignoreUnexported := cmpopts.IgnoreUnexported(
+		packages.GetAvailablePackageSummariesResponse{},
+		packages.AvailablePackageSummary{},
+		packages.AvailablePackageReference{},
+		packages.PluginError{},
+		plugins.Plugin{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := NewPackagesServer(tc.configuredPlugins, tc.pluginTimeout)
+
+			response, err := server.GetAvailablePackageSummaries(context.Background(), &packages.GetAvailablePackageSummariesRequest{})
+
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != codes.OK {
+				return
+			}
+
+			if got, want := response, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestGetAvailablePackageSummariesPagination(t *testing.T) {
+	summary := func(displayName string, plugin *plugins.Plugin) *packages.AvailablePackageSummary {
+		return &packages.AvailablePackageSummary{
+			DisplayName:         displayName,
+			AvailablePackageRef: &packages.AvailablePackageReference{Identifier: displayName, Plugin: plugin},
+		}
+	}
+	newServer := func() *packagesServer {
+		return NewPackagesServer([]*pkgsPluginWithServer{
+			{
+				plugin: mockedPlugin1,
+				server: &testPackagesServer{
+					availablePackageSummaries: []*packages.AvailablePackageSummary{
+						summary("a", nil), summary("c", nil), summary("e", nil),
+					},
+				},
+			},
+			{
+				plugin: mockedPlugin2,
+				server: &testPackagesServer{
+					availablePackageSummaries: []*packages.AvailablePackageSummary{
+						summary("b", nil), summary("d", nil),
+					},
+				},
+			},
+		}, 0)
+	}
+
+	ignoreUnexported := cmpopts.IgnoreUnexported(
+		packages.AvailablePackageSummary{},
+		packages.AvailablePackageReference{},
+		plugins.Plugin{},
+	)
+
+	t.Run("it returns a single ordered list across pages of all plugins", func(t *testing.T) {
+		server := newServer()
+		expectedPages := [][]*packages.AvailablePackageSummary{
+			{summary("a", mockedPlugin1), summary("b", mockedPlugin2)},
+			{summary("c", mockedPlugin1), summary("d", mockedPlugin2)},
+			{summary("e", mockedPlugin1)},
+		}
+
+		pageToken := ""
+		for i, want := range expectedPages {
+			response, err := server.GetAvailablePackageSummaries(context.Background(), &packages.GetAvailablePackageSummariesRequest{
+				PaginationOptions: &packages.PaginationOptions{PageToken: pageToken, PageSize: 2},
+			})
+			if err != nil {
+				t.Fatalf("page %d: %+v", i, err)
+			}
+			if got := response.AvailablePackagesSummaries; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("page %d: mismatch (-want +got):\n%s", i, cmp.Diff(want, got, ignoreUnexported))
+			}
+			pageToken = response.NextPageToken
+			if last := i == len(expectedPages)-1; last != (pageToken == "") {
+				t.Fatalf("page %d: got next page token %q", i, pageToken)
+			}
+		}
+	})
+
+	t.Run("it returns all results without pagination options", func(t *testing.T) {
+		response, err := newServer().GetAvailablePackageSummaries(context.Background(), &packages.GetAvailablePackageSummariesRequest{
+			SortOptions: &packages.SortOptions{Descending: true},
+		})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		want := []*packages.AvailablePackageSummary{
+			summary("e", mockedPlugin1),
+			summary("d", mockedPlugin2),
+			summary("c", mockedPlugin1),
+			summary("b", mockedPlugin2),
+			summary("a", mockedPlugin1),
+		}
+		if got := response.AvailablePackagesSummaries; !cmp.Equal(want, got, ignoreUnexported) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+		}
+		if response.NextPageToken != "" {
+			t.Errorf("got next page token %q, want none", response.NextPageToken)
+		}
+	})
+
+	// getAllPages returns the pages of results until the next page token is
+	// empty, failing after more pages than expected.
+	getAllPages := func(t *testing.T, server *packagesServer, maxPages int) []*packages.GetAvailablePackageSummariesResponse {
+		responses := []*packages.GetAvailablePackageSummariesResponse{}
+		pageToken := ""
+		for {
+			if len(responses) == maxPages {
+				t.Fatalf("got more than %d pages", maxPages)
+			}
+			response, err := server.GetAvailablePackageSummaries(context.Background(), &packages.GetAvailablePackageSummariesRequest{
+				PaginationOptions: &packages.PaginationOptions{PageToken: pageToken, PageSize: 2},
+			})
+			if err != nil {
+				t.Fatalf("page %d: %+v", len(responses), err)
+			}
+			responses = append(responses, response)
+			if pageToken = response.NextPageToken; pageToken == "" {
+				return responses
+			}
+		}
+	}
+	ignoreUnexportedPluginError := cmpopts.IgnoreUnexported(packages.PluginError{}, plugins.Plugin{})
+
+	t.Run("it ends the pages of a plugin failing on every page", func(t *testing.T) {
+		server := NewPackagesServer([]*pkgsPluginWithServer{
+			{
+				plugin: mockedPlugin1,
+				server: &testPackagesServer{
+					availablePackageSummaries: []*packages.AvailablePackageSummary{
+						summary("a", nil), summary("c", nil), summary("e", nil),
+					},
+				},
+			},
+			{
+				plugin: mockedPlugin2,
+				server: &testPackagesServer{err: status.Errorf(codes.Unavailable, "Bang!")},
+			},
+		}, 0)
+
+		responses := getAllPages(t, server, 3)
+
+		got := []*packages.AvailablePackageSummary{}
+		for _, response := range responses {
+			got = append(got, response.AvailablePackagesSummaries...)
+		}
+		want := []*packages.AvailablePackageSummary{
+			summary("a", mockedPlugin1), summary("c", mockedPlugin1), summary("e", mockedPlugin1),
+		}
+		if !cmp.Equal(want, got, ignoreUnexported) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+		}
+		wantErrors := []*packages.PluginError{
+			{Plugin: mockedPlugin2, StatusCode: codes.Unavailable.String(), Message: "Bang!"},
+		}
+		if got := responses[0].PluginErrors; !cmp.Equal(wantErrors, got, ignoreUnexportedPluginError) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(wantErrors, got, ignoreUnexportedPluginError))
+		}
+	})
+
+	t.Run("it ends the pages of a plugin failing on a following page", func(t *testing.T) {
+		server := NewPackagesServer([]*pkgsPluginWithServer{
+			{
+				plugin: mockedPlugin1,
+				server: &testPackagesServer{
+					availablePackageSummaries: []*packages.AvailablePackageSummary{
+						summary("a", nil), summary("b", nil), summary("e", nil), summary("f", nil),
+					},
+				},
+			},
+			{
+				plugin: mockedPlugin2,
+				server: &testPackagesServer{
+					availablePackageSummaries: []*packages.AvailablePackageSummary{
+						summary("c", nil), summary("d", nil), summary("g", nil),
+					},
+					pageTokenWithErr: "2",
+					pageTokenErr:     status.Errorf(codes.Internal, "Bang!"),
+				},
+			},
+		}, 0)
+
+		responses := getAllPages(t, server, 4)
+
+		got := []*packages.AvailablePackageSummary{}
+		pluginErrors := []*packages.PluginError{}
+		for _, response := range responses {
+			got = append(got, response.AvailablePackagesSummaries...)
+			pluginErrors = append(pluginErrors, response.PluginErrors...)
+		}
+		want := []*packages.AvailablePackageSummary{
+			summary("a", mockedPlugin1), summary("b", mockedPlugin1),
+			summary("c", mockedPlugin2), summary("d", mockedPlugin2),
+			summary("e", mockedPlugin1), summary("f", mockedPlugin1),
+		}
+		if !cmp.Equal(want, got, ignoreUnexported) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+		}
+		wantErrors := []*packages.PluginError{
+			{Plugin: mockedPlugin2, StatusCode: codes.Internal.String(), Message: "Bang!"},
+		}
+		if !cmp.Equal(wantErrors, pluginErrors, ignoreUnexportedPluginError) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(wantErrors, pluginErrors, ignoreUnexportedPluginError))
+		}
+	})
+
+	t.Run("it merges the pages by name when the display names sort differently", func(t *testing.T) {
+		named := func(name, displayName string, plugin *plugins.Plugin) *packages.AvailablePackageSummary {
+			return &packages.AvailablePackageSummary{
+				Name:                name,
+				DisplayName:         displayName,
+				AvailablePackageRef: &packages.AvailablePackageReference{Identifier: "repo/" + name, Plugin: plugin},
+			}
+		}
+		// The plugins return their pages sorted by name, whatever the display names.
+		server := NewPackagesServer([]*pkgsPluginWithServer{
+			{
+				plugin: mockedPlugin1,
+				server: &testPackagesServer{
+					availablePackageSummaries: []*packages.AvailablePackageSummary{
+						named("a", "Zulu", nil), named("c", "Alpha", nil), named("e", "Mike", nil),
+					},
+				},
+			},
+			{
+				plugin: mockedPlugin2,
+				server: &testPackagesServer{
+					availablePackageSummaries: []*packages.AvailablePackageSummary{
+						named("b", "Bravo", nil), named("d", "Yankee", nil),
+					},
+				},
+			},
+		}, 0)
+
+		got := []*packages.AvailablePackageSummary{}
+		for _, response := range getAllPages(t, server, 3) {
+			got = append(got, response.AvailablePackagesSummaries...)
+		}
+		want := []*packages.AvailablePackageSummary{
+			named("a", "Zulu", mockedPlugin1), named("b", "Bravo", mockedPlugin2),
+			named("c", "Alpha", mockedPlugin1), named("d", "Yankee", mockedPlugin2),
+			named("e", "Mike", mockedPlugin1),
+		}
+		if !cmp.Equal(want, got, ignoreUnexported) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+		}
+	})
+
+	t.Run("it returns invalid argument for a sort which cannot be paginated", func(t *testing.T) {
+		for _, sortOptions := range []*packages.SortOptions{
+			{Field: packages.SortOptions_SORT_FIELD_DISPLAY_NAME},
+			{Field: packages.SortOptions_SORT_FIELD_LATEST_VERSION},
+			{Field: packages.SortOptions_SORT_FIELD_REPOSITORY},
+			{Field: packages.SortOptions_SORT_FIELD_NAME, Descending: true},
+		} {
+			_, err := newServer().GetAvailablePackageSummaries(context.Background(), &packages.GetAvailablePackageSummariesRequest{
+				PaginationOptions: &packages.PaginationOptions{PageSize: 2},
+				SortOptions:       sortOptions,
+			})
+			if got, want := status.Code(err), codes.InvalidArgument; got != want {
+				t.Errorf("%v: got: %+v, want: %+v, err: %+v", sortOptions, got, want, err)
+			}
+		}
+	})
+
+	t.Run("it returns invalid argument for an invalid page token", func(t *testing.T) {
+		_, err := newServer().GetAvailablePackageSummaries(context.Background(), &packages.GetAvailablePackageSummariesRequest{
+			PaginationOptions: &packages.PaginationOptions{PageToken: "not-a-token", PageSize: 2},
+		})
+		if got, want := status.Code(err), codes.InvalidArgument; got != want {
+			t.Errorf("got: %+v, want: %+v, err: %+v", got, want, err)
+		}
+	})
+
+	t.Run("it returns invalid argument for a page token with a negative offset", func(t *testing.T) {
+		pageToken, err := encodePageToken(map[string]pluginCursor{
+			pluginKey(mockedPlugin1): {Offset: -1},
+		})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		_, err = newServer().GetAvailablePackageSummaries(context.Background(), &packages.GetAvailablePackageSummariesRequest{
+			PaginationOptions: &packages.PaginationOptions{PageToken: pageToken, PageSize: 2},
+		})
+		if got, want := status.Code(err), codes.InvalidArgument; got != want {
+			t.Errorf("got: %+v, want: %+v, err: %+v", got, want, err)
+		}
+	})
+}
+
+func TestGetAvailablePackageSummariesSearchRelevance(t *testing.T) {
+	summary := func(displayName string, relevance float32, plugin *plugins.Plugin) *packages.AvailablePackageSummary {
+		return &packages.AvailablePackageSummary{
+			DisplayName:         displayName,
+			Relevance:           relevance,
+			AvailablePackageRef: &packages.AvailablePackageReference{Identifier: displayName, Plugin: plugin},
+		}
+	}
+	// each plugin returns its search results ranked by relevance
+	server := NewPackagesServer([]*pkgsPluginWithServer{
+		{
+			plugin: mockedPlugin1,
+			server: &testPackagesServer{
+				availablePackageSummaries: []*packages.AvailablePackageSummary{
+					summary("wordpress", 0.9, nil), summary("apache", 0.3, nil),
+				},
+			},
+		},
+		{
+			plugin: mockedPlugin2,
+			server: &testPackagesServer{
+				availablePackageSummaries: []*packages.AvailablePackageSummary{
+					summary("nginx", 0.6, nil), summary("mariadb", 0.1, nil),
+				},
+			},
+		},
+	}, 0)
+	want := []*packages.AvailablePackageSummary{
+		summary("wordpress", 0.9, mockedPlugin1),
+		summary("nginx", 0.6, mockedPlugin2),
+		summary("apache", 0.3, mockedPlugin1),
+		summary("mariadb", 0.1, mockedPlugin2),
+	}
+	ignoreUnexported := cmpopts.IgnoreUnexported(
+		packages.AvailablePackageSummary{},
+		packages.AvailablePackageReference{},
+		plugins.Plugin{},
+	)
+
+	testCases := []struct {
+		name              string
+		paginationOptions *packages.PaginationOptions
+	}{
+		{
+			name: "it sorts the search results by relevance by default",
+		},
+		{
+			name:              "it sorts the paginated search results by relevance by default",
+			paginationOptions: &packages.PaginationOptions{PageSize: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []*packages.AvailablePackageSummary{}
+			request := &packages.GetAvailablePackageSummariesRequest{
+				FilterOptions:     &packages.FilterOptions{Query: "web"},
+				PaginationOptions: tc.paginationOptions,
+			}
+			for {
+				response, err := server.GetAvailablePackageSummaries(context.Background(), request)
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				got = append(got, response.AvailablePackagesSummaries...)
+				if response.NextPageToken == "" {
+					break
+				}
+				if len(got) > len(want) {
+					t.Fatalf("got more than %d results", len(want))
+				}
+				request.PaginationOptions.PageToken = response.NextPageToken
+			}
+
+			if !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestAvailableSummaryLess(t *testing.T) {
+	apache := &packages.AvailablePackageSummary{
+		Name:             "apache",
+		DisplayName:      "Zeta",
+		LatestPkgVersion: "10.0.0",
+		Relevance:        0.8,
+		AvailablePackageRef: &packages.AvailablePackageReference{
+			Context:    &packages.Context{Namespace: "kubeapps"},
+			Identifier: "repo-b/apache",
+			Plugin:     mockedPlugin1,
+		},
+	}
+	nginx := &packages.AvailablePackageSummary{
+		Name:             "nginx",
+		DisplayName:      "Alpha",
+		LatestPkgVersion: "9.1.0",
+		Relevance:        0.2,
+		AvailablePackageRef: &packages.AvailablePackageReference{
+			Context:    &packages.Context{Namespace: "kubeapps"},
+			Identifier: "repo-a/nginx",
+			Plugin:     mockedPlugin2,
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		sortOptions *packages.SortOptions
+		expectFirst *packages.AvailablePackageSummary
+	}{
+		{
+			name:        "it sorts by name by default",
+			expectFirst: apache,
+		},
+		{
+			name:        "it sorts by display name",
+			sortOptions: &packages.SortOptions{Field: packages.SortOptions_SORT_FIELD_DISPLAY_NAME},
+			expectFirst: nginx,
+		},
+		{
+			name:        "it sorts by name",
+			sortOptions: &packages.SortOptions{Field: packages.SortOptions_SORT_FIELD_NAME},
+			expectFirst: apache,
+		},
+		{
+			name:        "it sorts by latest version semantically",
+			sortOptions: &packages.SortOptions{Field: packages.SortOptions_SORT_FIELD_LATEST_VERSION},
+			expectFirst: nginx,
+		},
+		{
+			name:        "it sorts by repository",
+			sortOptions: &packages.SortOptions{Field: packages.SortOptions_SORT_FIELD_REPOSITORY},
+			expectFirst: nginx,
+		},
+		{
+			name:        "it sorts the most relevant first",
+			sortOptions: &packages.SortOptions{Field: packages.SortOptions_SORT_FIELD_RELEVANCE},
+			expectFirst: apache,
+		},
+		{
+			name:        "it sorts in descending order",
+			sortOptions: &packages.SortOptions{Field: packages.SortOptions_SORT_FIELD_NAME, Descending: true},
+			expectFirst: nginx,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			less := availableSummaryLess(tc.sortOptions)
+			other := apache
+			if tc.expectFirst == apache {
+				other = nginx
+			}
+			if !less(tc.expectFirst, other) || less(other, tc.expectFirst) {
+				t.Errorf("expected %q to sort before %q", tc.expectFirst.Name, other.Name)
+			}
+		})
+	}
+
+	t.Run("it breaks relevance ties by name, as the plugins do", func(t *testing.T) {
+		// nginx has the lower display name but the greater name.
+		tied := &packages.AvailablePackageSummary{
+			Name:                nginx.Name,
+			DisplayName:         nginx.DisplayName,
+			Relevance:           apache.Relevance,
+			AvailablePackageRef: nginx.AvailablePackageRef,
+		}
+		less := availableSummaryLess(&packages.SortOptions{Field: packages.SortOptions_SORT_FIELD_RELEVANCE})
+		if !less(apache, tied) || less(tied, apache) {
+			t.Errorf("expected %q to sort before %q", apache.Name, tied.Name)
+		}
+	})
+}
+
+func TestAvailablePackageRouting(t *testing.T) {
+	testCases := []struct {
+		name               string
+		plugin             *plugins.Plugin
+		statusCode         codes.Code
+		expectedIdentifier string
+	}{
+		{
+			name:               "it routes the request to the referenced plugin",
+			plugin:             mockedPlugin2,
+			statusCode:         codes.OK,
+			expectedIdentifier: "bitnami/apache",
+		},
+		{
+			name:       "it returns invalid argument when no plugin is referenced",
+			plugin:     nil,
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "it returns not found when the referenced plugin is not registered",
+			plugin:     &plugins.Plugin{Name: "unknown.packages", Version: "v1alpha1"},
+			statusCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pluginServer1 := &testPackagesServer{}
+			pluginServer2 := &testPackagesServer{}
+			server := NewPackagesServer([]*pkgsPluginWithServer{
+				{plugin: mockedPlugin1, server: pluginServer1},
+				{plugin: mockedPlugin2, server: pluginServer2},
+			}, 0)
+
+			ref := &packages.AvailablePackageReference{
+				Context:    &packages.Context{Namespace: "kubeapps"},
+				Identifier: "bitnami/apache",
+				Plugin:     tc.plugin,
+			}
+
+			checkResult := func(op string, err error) {
+				if got, want := status.Code(err), tc.statusCode; got != want {
+					t.Fatalf("%s: got: %+v, want: %+v, err: %+v", op, got, want, err)
+				}
+				if tc.statusCode != codes.OK {
+					return
+				}
+				if got, want := pluginServer2.lastIdentifier, tc.expectedIdentifier; got != want {
+					t.Errorf("%s: got: %q, want: %q", op, got, want)
+				}
+				if pluginServer1.lastIdentifier != "" {
+					t.Errorf("%s: unexpected request to plugin %v", op, mockedPlugin1)
+				}
+				pluginServer2.lastIdentifier = ""
+			}
+
+			detail, err := server.GetAvailablePackageDetail(context.Background(), &packages.GetAvailablePackageDetailRequest{AvailablePackageRef: ref})
+			checkResult("GetAvailablePackageDetail", err)
+			if err == nil && !pluginEqual(detail.GetAvailablePackageDetail().GetAvailablePackageRef().GetPlugin(), tc.plugin) {
+				t.Errorf("got plugin: %v, want: %v", detail.GetAvailablePackageDetail().GetAvailablePackageRef().GetPlugin(), tc.plugin)
+			}
+
+			_, err = server.GetAvailablePackageVersions(context.Background(), &packages.GetAvailablePackageVersionsRequest{AvailablePackageRef: ref})
+			checkResult("GetAvailablePackageVersions", err)
+		})
+	}
+}
+
+func TestGetInstalledPackageSummaries(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configuredPlugins []*pkgsPluginWithServer
+		statusCode        codes.Code
+		expectedResponse  *packages.GetInstalledPackageSummariesResponse
+	}{
+		{
+			name: "it aggregates the installed packages of every plugin, setting the plugin on each reference",
+			configuredPlugins: []*pkgsPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testPackagesServer{
+						installedPackageSummaries: []*packages.InstalledPackageSummary{
+							{Name: "pkg-1", InstalledPackageRef: &packages.InstalledPackageReference{Identifier: "pkg-1"}},
+						},
+					},
+				},
+				{
+					plugin: mockedPlugin2,
+					server: &testPackagesServer{
+						installedPackageSummaries: []*packages.InstalledPackageSummary{
+							{Name: "pkg-2"},
+						},
+					},
+				},
+			},
+			statusCode: codes.OK,
+			expectedResponse: &packages.GetInstalledPackageSummariesResponse{
+				InstalledPackageSummaries: []*packages.InstalledPackageSummary{
+					{Name: "pkg-1", InstalledPackageRef: &packages.InstalledPackageReference{Identifier: "pkg-1", Plugin: mockedPlugin1}},
+					{Name: "pkg-2", InstalledPackageRef: &packages.InstalledPackageReference{Plugin: mockedPlugin2}},
+				},
+				PluginErrors: []*packages.PluginError{},
+			},
+		},
+		{
+			name: "it returns an error if every plugin returns an error",
+			configuredPlugins: []*pkgsPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testPackagesServer{err: status.Errorf(codes.Internal, "Bang!")},
+				},
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	ignoreUnexported := cmpopts.IgnoreUnexported(
+		packages.GetInstalledPackageSummariesResponse{},
+		packages.InstalledPackageSummary{},
+		packages.InstalledPackageReference{},
+		plugins.Plugin{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := NewPackagesServer(tc.configuredPlugins, 0)
+
+			response, err := server.GetInstalledPackageSummaries(context.Background(), &packages.GetInstalledPackageSummariesRequest{})
+
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != codes.OK {
+				return
+			}
+
+			if got, want := response, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestGetInstalledPackageSummariesPagination(t *testing.T) {
+	summary := func(name string, plugin *plugins.Plugin) *packages.InstalledPackageSummary {
+		return &packages.InstalledPackageSummary{
+			Name: name,
+			InstalledPackageRef: &packages.InstalledPackageReference{
+				Context:    &packages.Context{Namespace: "default"},
+				Identifier: name,
+				Plugin:     plugin,
+			},
+		}
+	}
+	server := NewPackagesServer([]*pkgsPluginWithServer{
+		{
+			plugin: mockedPlugin1,
+			server: &testPackagesServer{
+				installedPackageSummaries: []*packages.InstalledPackageSummary{
+					summary("a", nil), summary("c", nil), summary("e", nil),
+				},
+			},
+		},
+		{
+			plugin: mockedPlugin2,
+			server: &testPackagesServer{
+				installedPackageSummaries: []*packages.InstalledPackageSummary{
+					summary("b", nil), summary("d", nil),
+				},
+			},
+		},
+	}, 0)
+	expectedPages := [][]*packages.InstalledPackageSummary{
+		{summary("a", mockedPlugin1), summary("b", mockedPlugin2)},
+		{summary("c", mockedPlugin1), summary("d", mockedPlugin2)},
+		{summary("e", mockedPlugin1)},
+	}
+	ignoreUnexported := cmpopts.IgnoreUnexported(
+		packages.InstalledPackageSummary{},
+		packages.InstalledPackageReference{},
+		packages.Context{},
+		plugins.Plugin{},
+	)
+
+	pageToken := ""
+	for i, want := range expectedPages {
+		response, err := server.GetInstalledPackageSummaries(context.Background(), &packages.GetInstalledPackageSummariesRequest{
+			PaginationOptions: &packages.PaginationOptions{PageToken: pageToken, PageSize: 2},
+		})
+		if err != nil {
+			t.Fatalf("page %d: %+v", i, err)
+		}
+		if got := response.InstalledPackageSummaries; !cmp.Equal(want, got, ignoreUnexported) {
+			t.Errorf("page %d: mismatch (-want +got):\n%s", i, cmp.Diff(want, got, ignoreUnexported))
+		}
+		pageToken = response.NextPageToken
+		if last := i == len(expectedPages)-1; last != (pageToken == "") {
+			t.Fatalf("page %d: got next page token %q", i, pageToken)
+		}
+	}
+
+	_, err := server.GetInstalledPackageSummaries(context.Background(), &packages.GetInstalledPackageSummariesRequest{
+		PaginationOptions: &packages.PaginationOptions{PageToken: "not-a-token", PageSize: 2},
+	})
+	if got, want := status.Code(err), codes.InvalidArgument; got != want {
+		t.Errorf("got: %+v, want: %+v, err: %+v", got, want, err)
+	}
+}
+
+func TestInstalledPackageRouting(t *testing.T) {
+	testCases := []struct {
+		name               string
+		plugin             *plugins.Plugin
+		statusCode         codes.Code
+		expectedIdentifier string
+	}{
+		{
+			name:               "it routes the request to the referenced plugin",
+			plugin:             mockedPlugin2,
+			statusCode:         codes.OK,
+			expectedIdentifier: "my-pkg",
+		},
+		{
+			name:       "it returns invalid argument when no plugin is referenced",
+			plugin:     nil,
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "it returns not found when the referenced plugin is not registered",
+			plugin:     &plugins.Plugin{Name: "unknown.packages", Version: "v1alpha1"},
+			statusCode: codes.NotFound,
+		},
+		{
+			name:       "it returns not found when the referenced plugin version is not registered",
+			plugin:     &plugins.Plugin{Name: mockedPlugin2.Name, Version: "v1"},
+			statusCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pluginServer1 := &testPackagesServer{}
+			pluginServer2 := &testPackagesServer{}
+			server := NewPackagesServer([]*pkgsPluginWithServer{
+				{plugin: mockedPlugin1, server: pluginServer1},
+				{plugin: mockedPlugin2, server: pluginServer2},
+			}, 0)
+
+			ref := &packages.InstalledPackageReference{
+				Context:    &packages.Context{Namespace: "default"},
+				Identifier: "my-pkg",
+				Plugin:     tc.plugin,
+			}
+
+			checkResult := func(op string, err error, responseRef *packages.InstalledPackageReference) {
+				if got, want := status.Code(err), tc.statusCode; got != want {
+					t.Fatalf("%s: got: %+v, want: %+v, err: %+v", op, got, want, err)
+				}
+				if tc.statusCode != codes.OK {
+					return
+				}
+				if got, want := pluginServer2.lastIdentifier, tc.expectedIdentifier; got != want {
+					t.Errorf("%s: got: %q, want: %q", op, got, want)
+				}
+				if pluginServer1.lastIdentifier != "" {
+					t.Errorf("%s: unexpected request to plugin %v", op, mockedPlugin1)
+				}
+				if responseRef != nil && !pluginEqual(responseRef.GetPlugin(), tc.plugin) {
+					t.Errorf("%s: got plugin: %v, want: %v", op, responseRef.GetPlugin(), tc.plugin)
+				}
+				pluginServer2.lastIdentifier = ""
+			}
+
+			detail, err := server.GetInstalledPackageDetail(context.Background(), &packages.GetInstalledPackageDetailRequest{InstalledPackageRef: ref})
+			checkResult("GetInstalledPackageDetail", err, detail.GetInstalledPackageDetail().GetInstalledPackageRef())
+
+			created, err := server.CreateInstalledPackage(context.Background(), &packages.CreateInstalledPackageRequest{
+				AvailablePackageRef: &packages.AvailablePackageReference{
+					Context:    &packages.Context{Namespace: "kubeapps"},
+					Identifier: "bitnami/apache",
+					Plugin:     tc.plugin,
+				},
+				TargetContext: ref.Context,
+				Name:          "my-pkg",
+			})
+			checkResult("CreateInstalledPackage", err, created.GetInstalledPackageRef())
+
+			updated, err := server.UpdateInstalledPackage(context.Background(), &packages.UpdateInstalledPackageRequest{InstalledPackageRef: ref})
+			checkResult("UpdateInstalledPackage", err, updated.GetInstalledPackageRef())
+
+			_, err = server.DeleteInstalledPackage(context.Background(), &packages.DeleteInstalledPackageRequest{InstalledPackageRef: ref})
+			checkResult("DeleteInstalledPackage", err, nil)
+		})
+	}
+}
+
+func TestWatchInstalledPackages(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configuredPlugins []*pkgsPluginWithServer
+		statusCode        codes.Code
+		expectedEvents    []*packages.WatchInstalledPackagesResponse
+	}{
+		{
+			name: "it streams the events of every plugin, setting the plugin on each reference",
+			configuredPlugins: []*pkgsPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testPackagesServer{
+						installedPackageSummaries: []*packages.InstalledPackageSummary{
+							{Name: "pkg-1", InstalledPackageRef: &packages.InstalledPackageReference{Identifier: "pkg-1"}},
+						},
+					},
+				},
+				{
+					plugin: mockedPlugin2,
+					server: &testPackagesServer{
+						installedPackageSummaries: []*packages.InstalledPackageSummary{
+							{Name: "pkg-2"},
+						},
+					},
+				},
+			},
+			statusCode: codes.OK,
+			expectedEvents: []*packages.WatchInstalledPackagesResponse{
+				{
+					Type:                    packages.WatchInstalledPackagesResponse_EVENT_TYPE_ADDED,
+					InstalledPackageSummary: &packages.InstalledPackageSummary{Name: "pkg-1", InstalledPackageRef: &packages.InstalledPackageReference{Identifier: "pkg-1", Plugin: mockedPlugin1}},
+				},
+				{
+					Type:                    packages.WatchInstalledPackagesResponse_EVENT_TYPE_ADDED,
+					InstalledPackageSummary: &packages.InstalledPackageSummary{Name: "pkg-2", InstalledPackageRef: &packages.InstalledPackageReference{Plugin: mockedPlugin2}},
+				},
+			},
+		},
+		{
+			name: "it sends the error of a plugin as an event while streaming the events of other plugins",
+			configuredPlugins: []*pkgsPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testPackagesServer{err: status.Errorf(codes.PermissionDenied, "Bang!")},
+				},
+				{
+					plugin: mockedPlugin2,
+					server: &testPackagesServer{
+						installedPackageSummaries: []*packages.InstalledPackageSummary{
+							{Name: "pkg-2"},
+						},
+					},
+				},
+			},
+			statusCode: codes.OK,
+			expectedEvents: []*packages.WatchInstalledPackagesResponse{
+				{
+					PluginError: &packages.PluginError{Plugin: mockedPlugin1, StatusCode: codes.PermissionDenied.String(), Message: "Bang!"},
+				},
+				{
+					Type:                    packages.WatchInstalledPackagesResponse_EVENT_TYPE_ADDED,
+					InstalledPackageSummary: &packages.InstalledPackageSummary{Name: "pkg-2", InstalledPackageRef: &packages.InstalledPackageReference{Plugin: mockedPlugin2}},
+				},
+			},
+		},
+		{
+			name: "it returns an error if every plugin returns an error",
+			configuredPlugins: []*pkgsPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testPackagesServer{err: status.Errorf(codes.Internal, "Bang!")},
+				},
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	ignoreUnexported := cmpopts.IgnoreUnexported(
+		packages.WatchInstalledPackagesResponse{},
+		packages.InstalledPackageSummary{},
+		packages.InstalledPackageReference{},
+		packages.PluginError{},
+		plugins.Plugin{},
+	)
+	// Events of different plugins are sent concurrently, so are compared
+	// regardless of their order.
+	sortEvents := cmpopts.SortSlices(func(a, b *packages.WatchInstalledPackagesResponse) bool {
+		return a.GetInstalledPackageSummary().GetName() < b.GetInstalledPackageSummary().GetName()
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := NewPackagesServer(tc.configuredPlugins, 0)
+			stream := &testWatchStream{ctx: context.Background()}
+
+			err := server.WatchInstalledPackages(&packages.WatchInstalledPackagesRequest{}, stream)
+
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != codes.OK {
+				return
+			}
+
+			if got, want := stream.events, tc.expectedEvents; !cmp.Equal(want, got, ignoreUnexported, sortEvents) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported, sortEvents))
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	packages "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pluginCursor is the position within the results of a single plugin, as
+// encoded in the core page token.
+type pluginCursor struct {
+	// PageToken is the plugin's own token for the page currently being consumed.
+	PageToken string `json:"pageToken,omitempty"`
+	// Offset is the number of (sorted) items of that page already returned.
+	Offset int `json:"offset,omitempty"`
+	// Done is set once all the results of the plugin have been returned.
+	Done bool `json:"done,omitempty"`
+}
+
+// pluginKey returns the key identifying a plugin in the core page token.
+func pluginKey(p *plugins.Plugin) string {
+	return fmt.Sprintf("%s/%s", p.GetName(), p.GetVersion())
+}
+
+// decodePageToken returns the plugin cursors encoded in an opaque core page token.
+// An empty token returns an empty set of cursors, ie. the first page of each plugin.
+// The token comes from the client, so a cursor with a negative offset is rejected.
+func decodePageToken(token string) (map[string]pluginCursor, error) {
+	cursors := map[string]pluginCursor{}
+	if token == "" {
+		return cursors, nil
+	}
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(bytes, &cursors); err != nil {
+		return nil, err
+	}
+	for key, c := range cursors {
+		if c.Offset < 0 {
+			return nil, fmt.Errorf("negative offset %d for plugin %q", c.Offset, key)
+		}
+	}
+	return cursors, nil
+}
+
+// encodePageToken returns the opaque core page token for the plugin cursors, or
+// an empty token if every plugin is done.
+func encodePageToken(cursors map[string]pluginCursor) (string, error) {
+	done := true
+	for _, c := range cursors {
+		if !c.Done {
+			done = false
+			break
+		}
+	}
+	if done {
+		return "", nil
+	}
+	bytes, err := json.Marshal(cursors)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// pluginPage is a page of results returned by a single plugin, sorted with
+// the requested sort options, being consumed while merging the results of
+// all plugins.
+type pluginPage struct {
+	plugin *pkgsPluginWithServer
+	// pageToken is the plugin token used to request the page.
+	pageToken     string
+	nextPageToken string
+	items         []interface{}
+	// index is the position of the next item to be returned.
+	index int
+	// failed is set when requesting the page failed, so that the plugin is
+	// not requested again for the following pages of the core results.
+	failed bool
+}
+
+// head returns the next item of the page, or nil if the page is consumed.
+func (p *pluginPage) head() interface{} {
+	if p.index < len(p.items) {
+		return p.items[p.index]
+	}
+	return nil
+}
+
+// cursor returns the cursor to continue consuming the plugin results on the
+// next page of the core results.
+func (p *pluginPage) cursor() pluginCursor {
+	if p.failed {
+		// A failed plugin, reported in the plugin errors, is done so that
+		// the core results always end.
+		return pluginCursor{Done: true}
+	}
+	if p.index < len(p.items) {
+		return pluginCursor{PageToken: p.pageToken, Offset: p.index}
+	}
+	if p.nextPageToken != "" {
+		return pluginCursor{PageToken: p.nextPageToken}
+	}
+	return pluginCursor{Done: true}
+}
+
+// newPluginPage returns the sorted page of the items returned by a plugin,
+// skipping the items already consumed.
+func newPluginPage(p *pkgsPluginWithServer, pageToken string, offset int, response pluginPageResponse, less lessFunc) *pluginPage {
+	items := response.items
+	sort.SliceStable(items, func(i, j int) bool {
+		return less(items[i], items[j])
+	})
+	if offset > len(items) {
+		offset = len(items)
+	}
+	return &pluginPage{
+		plugin:        p,
+		pageToken:     pageToken,
+		nextPageToken: response.nextPageToken,
+		items:         items,
+		index:         offset,
+	}
+}
+
+// pluginPageResponse is a page of the results of a plugin, with the plugin
+// set on the reference of each item.
+type pluginPageResponse struct {
+	items         []interface{}
+	nextPageToken string
+}
+
+// fetchPluginPageFunc requests the page of the page token from a plugin, or
+// all its results when no page size is requested.
+type fetchPluginPageFunc func(ctx context.Context, p *pkgsPluginWithServer, pageToken string) (pluginPageResponse, error)
+
+// lessFunc reports whether the item a sorts before b.
+type lessFunc func(a, b interface{}) bool
+
+// mergePluginPages returns a page of the results of every plugin, merged in
+// the order of less from the position encoded in the core page token,
+// together with the token of the next page and the errors of the plugins
+// which failed. Without a page size, all the results are returned.
+func (s packagesServer) mergePluginPages(ctx context.Context, pageToken string, pageSize int, fetch fetchPluginPageFunc, less lessFunc) ([]interface{}, string, []*packages.PluginError, error) {
+	cursors, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", nil, status.Errorf(codes.InvalidArgument, "Unable to intepret page token %q: %v", pageToken, err)
+	}
+
+	results := s.callAllPlugins(ctx, func(ctx context.Context, p *pkgsPluginWithServer) (interface{}, error) {
+		cursor := cursors[pluginKey(p.plugin)]
+		if cursor.Done {
+			return pluginPageResponse{}, nil
+		}
+		return fetch(ctx, p, cursor.PageToken)
+	})
+	pluginErrors, err := pluginErrors(results)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	pages := []*pluginPage{}
+	for i, r := range results {
+		if r.err != nil {
+			// The failed plugin, reported in the plugin errors, is done.
+			pages = append(pages, &pluginPage{plugin: s.plugins[i], failed: true})
+			continue
+		}
+		cursor := cursors[pluginKey(r.plugin)]
+		pages = append(pages, newPluginPage(s.plugins[i], cursor.PageToken, cursor.Offset, r.response.(pluginPageResponse), less))
+	}
+
+	items := []interface{}{}
+	for pageSize == 0 || len(items) < pageSize {
+		var next *pluginPage
+		for _, page := range pages {
+			if page.head() == nil && page.nextPageToken != "" && pageSize > 0 {
+				// The plugin page is consumed, so fetch the following one to
+				// keep comparing its results.
+				nextPageToken := page.nextPageToken
+				r := s.callPlugin(ctx, page.plugin, func(ctx context.Context, p *pkgsPluginWithServer) (interface{}, error) {
+					return fetch(ctx, p, nextPageToken)
+				})
+				if r.err != nil {
+					pluginErrors = append(pluginErrors, newPluginError(r))
+					*page = pluginPage{plugin: page.plugin, failed: true}
+					continue
+				}
+				*page = *newPluginPage(page.plugin, nextPageToken, 0, r.response.(pluginPageResponse), less)
+			}
+			if head := page.head(); head != nil && (next == nil || less(head, next.head())) {
+				next = page
+			}
+		}
+		if next == nil {
+			break
+		}
+		items = append(items, next.head())
+		next.index++
+	}
+
+	nextPageToken := ""
+	if pageSize > 0 {
+		for _, page := range pages {
+			cursors[pluginKey(page.plugin.plugin)] = page.cursor()
+		}
+		nextPageToken, err = encodePageToken(cursors)
+		if err != nil {
+			return nil, "", nil, status.Errorf(codes.Internal, "Unable to create the next page token: %v", err)
+		}
+	}
+	return items, nextPageToken, pluginErrors, nil
+}
+
+// requestSortOptions returns the sort options of the request, which sorts by
+// relevance by default when searching, as ranked by the plugins.
+func requestSortOptions(request *packages.GetAvailablePackageSummariesRequest) *packages.SortOptions {
+	opts := request.GetSortOptions()
+	if opts.GetField() == packages.SortOptions_SORT_FIELD_UNSPECIFIED && request.GetFilterOptions().GetQuery() != "" {
+		return &packages.SortOptions{
+			Field:      packages.SortOptions_SORT_FIELD_RELEVANCE,
+			Descending: opts.GetDescending(),
+		}
+	}
+	return opts
+}
+
+// validatePaginatedSortOptions returns an error unless the paginated results
+// can be sorted with the sort options. The plugins return their pages sorted
+// by name, or by relevance for a search query, so only these orders can be
+// merged across pages: the default order is the name. Without pagination,
+// every result of every plugin is sorted, whatever the sort options.
+func validatePaginatedSortOptions(opts *packages.SortOptions) error {
+	switch opts.GetField() {
+	case packages.SortOptions_SORT_FIELD_UNSPECIFIED,
+		packages.SortOptions_SORT_FIELD_NAME,
+		packages.SortOptions_SORT_FIELD_RELEVANCE:
+		if !opts.GetDescending() {
+			return nil
+		}
+	}
+	return status.Errorf(codes.InvalidArgument, "Unable to sort paginated results by %v (descending=%t): only the name or relevance in ascending order are supported", opts.GetField(), opts.GetDescending())
+}
+
+// availableSummaryLessFunc reports whether the summary a sorts before b.
+type availableSummaryLessFunc func(a, b *packages.AvailablePackageSummary) bool
+
+// availableSummaryLess returns the ordering of available package summaries
+// for the requested sort options, by name by default as the plugins sort their
+// pages. Ties are broken by name, as the plugins do for relevance, then by
+// identifier and plugin so that the ordering is stable across requests.
+func availableSummaryLess(opts *packages.SortOptions) availableSummaryLessFunc {
+	return func(a, b *packages.AvailablePackageSummary) bool {
+		var c int
+		switch opts.GetField() {
+		case packages.SortOptions_SORT_FIELD_UNSPECIFIED, packages.SortOptions_SORT_FIELD_NAME:
+			c = strings.Compare(summaryName(a), summaryName(b))
+		case packages.SortOptions_SORT_FIELD_DISPLAY_NAME:
+			c = strings.Compare(a.GetDisplayName(), b.GetDisplayName())
+		case packages.SortOptions_SORT_FIELD_LATEST_VERSION:
+			c = compareVersions(a.GetLatestPkgVersion(), b.GetLatestPkgVersion())
+		case packages.SortOptions_SORT_FIELD_REPOSITORY:
+			c = strings.Compare(summaryRepository(a), summaryRepository(b))
+		case packages.SortOptions_SORT_FIELD_RELEVANCE:
+			// The most relevant packages come first in ascending order.
+			if a.GetRelevance() > b.GetRelevance() {
+				c = -1
+			} else if a.GetRelevance() < b.GetRelevance() {
+				c = 1
+			}
+		}
+		if c == 0 {
+			c = strings.Compare(summaryName(a), summaryName(b))
+		}
+		if c == 0 {
+			c = strings.Compare(a.GetAvailablePackageRef().GetIdentifier(), b.GetAvailablePackageRef().GetIdentifier())
+		}
+		if c == 0 {
+			c = strings.Compare(pluginKey(a.GetAvailablePackageRef().GetPlugin()), pluginKey(b.GetAvailablePackageRef().GetPlugin()))
+		}
+		if opts.GetDescending() {
+			return c > 0
+		}
+		return c < 0
+	}
+}
+
+// installedSummaryLess orders the installed package summaries by name, then
+// by namespace, identifier and plugin so that the ordering is stable across
+// requests.
+func installedSummaryLess(a, b *packages.InstalledPackageSummary) bool {
+	c := strings.Compare(a.GetName(), b.GetName())
+	if c == 0 {
+		c = strings.Compare(a.GetInstalledPackageRef().GetContext().GetNamespace(), b.GetInstalledPackageRef().GetContext().GetNamespace())
+	}
+	if c == 0 {
+		c = strings.Compare(a.GetInstalledPackageRef().GetIdentifier(), b.GetInstalledPackageRef().GetIdentifier())
+	}
+	if c == 0 {
+		c = strings.Compare(pluginKey(a.GetInstalledPackageRef().GetPlugin()), pluginKey(b.GetInstalledPackageRef().GetPlugin()))
+	}
+	return c < 0
+}
+
+// summaryName returns the package name, falling back to the last segment of
+// the identifier for plugins which do not set it.
+func summaryName(s *packages.AvailablePackageSummary) string {
+	if s.GetName() != "" {
+		return s.GetName()
+	}
+	identifier := s.GetAvailablePackageRef().GetIdentifier()
+	return identifier[strings.LastIndex(identifier, "/")+1:]
+}
+
+// summaryRepository returns the namespace of the package context together
+// with the identifier up to the last segment, eg. "kubeapps/bitnami" for
+// the identifier "bitnami/apache" in the "kubeapps" namespace.
+func summaryRepository(s *packages.AvailablePackageSummary) string {
+	identifier := s.GetAvailablePackageRef().GetIdentifier()
+	repo := ""
+	if i := strings.LastIndex(identifier, "/"); i >= 0 {
+		repo = identifier[:i]
+	}
+	return fmt.Sprintf("%s/%s", s.GetAvailablePackageRef().GetContext().GetNamespace(), repo)
+}
+
+// compareVersions compares two versions semantically when both are valid
+// semantic versions, otherwise lexically.
+func compareVersions(a, b string) int {
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	if errA == nil && errB == nil {
+		return va.Compare(vb)
+	}
+	return strings.Compare(a, b)
+}
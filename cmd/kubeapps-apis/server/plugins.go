@@ -28,6 +28,7 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	packages "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	repositories "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/repositories/v1alpha1"
 	"github.com/kubeapps/kubeapps/pkg/kube"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -49,7 +50,14 @@ const (
 )
 
 // KubernetesClientGetter is a function type used by plugins to get a k8s client
-type KubernetesClientGetter func(context.Context) (kubernetes.Interface, dynamic.Interface, error)
+// for the requested cluster, as specified in the context of a request. An empty
+// cluster returns a client for the cluster on which Kubeapps is installed.
+type KubernetesClientGetter func(ctx context.Context, cluster string) (kubernetes.Interface, dynamic.Interface, error)
+
+// KubernetesConfigGetter is a function type used by plugins to get the rest
+// config for the requested cluster with the user credential of the request,
+// for the plugins which need to build their own clients, such as helm actions.
+type KubernetesConfigGetter func(ctx context.Context, cluster string) (*rest.Config, error)
 
 // pkgsPluginWithServer stores the plugin detail together with its implementation.
 type pkgsPluginWithServer struct {
@@ -57,6 +65,12 @@ type pkgsPluginWithServer struct {
 	server packages.PackagesServiceServer
 }
 
+// repositoriesPluginWithServer stores the plugin detail together with its implementation.
+type repositoriesPluginWithServer struct {
+	plugin *plugins.Plugin
+	server repositories.RepositoriesServiceServer
+}
+
 // coreServer implements the API defined in cmd/kubeapps-api-service/core/core.proto
 type pluginsServer struct {
 	plugins.UnimplementedPluginsServiceServer
@@ -69,6 +83,14 @@ type pluginsServer struct {
 	// TODO: Update the plugins server to be able to register different versions
 	// of core plugins.
 	packagesPlugins []*pkgsPluginWithServer
+
+	// repositoriesPlugins contains plugin server implementations which satisfy
+	// the core server repositories.v1alpha1 interface.
+	repositoriesPlugins []*repositoriesPluginWithServer
+
+	// readinessCheckers contains the plugin server implementations which
+	// report their own readiness.
+	readinessCheckers []*pluginWithReadinessChecker
 }
 
 func NewPluginsServer(serveOpts ServeOptions, registrar grpc.ServiceRegistrar, gwArgs gwHandlerArgs) (*pluginsServer, error) {
@@ -80,16 +102,23 @@ func NewPluginsServer(serveOpts ServeOptions, registrar grpc.ServiceRegistrar, g
 		log.Fatalf("failed to check for plugins: %v", err)
 	}
 
+	pluginsConfig, err := parsePluginsConfig(serveOpts.PluginConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
 	ps := &pluginsServer{}
 
-	pluginDetails, err := ps.registerPlugins(pluginPaths, registrar, gwArgs, serveOpts)
+	pluginDetails, err := ps.registerPlugins(pluginPaths, registrar, gwArgs, serveOpts, pluginsConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to register plugins: %w", err)
 	}
 
 	sortPlugins(pluginDetails)
+	pluginsConfig.sortPluginsByPriority(pluginDetails)
 
 	ps.plugins = pluginDetails
+	ps.sortRegisteredPlugins()
 
 	return ps, nil
 }
@@ -101,6 +130,24 @@ func sortPlugins(p []*plugins.Plugin) {
 	})
 }
 
+// sortRegisteredPlugins orders the plugins registered for aggregation as the
+// configured plugins, so that the aggregated results follow their priority.
+func (s *pluginsServer) sortRegisteredPlugins() {
+	position := map[*plugins.Plugin]int{}
+	for i, p := range s.plugins {
+		position[p] = i
+	}
+	sort.SliceStable(s.packagesPlugins, func(i, j int) bool {
+		return position[s.packagesPlugins[i].plugin] < position[s.packagesPlugins[j].plugin]
+	})
+	sort.SliceStable(s.repositoriesPlugins, func(i, j int) bool {
+		return position[s.repositoriesPlugins[i].plugin] < position[s.repositoriesPlugins[j].plugin]
+	})
+	sort.SliceStable(s.readinessCheckers, func(i, j int) bool {
+		return position[s.readinessCheckers[i].plugin] < position[s.readinessCheckers[j].plugin]
+	})
+}
+
 // GetConfiguredPlugins returns details for each configured plugin.
 func (s *pluginsServer) GetConfiguredPlugins(ctx context.Context, in *plugins.GetConfiguredPluginsRequest) (*plugins.GetConfiguredPluginsResponse, error) {
 	log.Infof("+core GetConfiguredPlugins")
@@ -110,13 +157,14 @@ func (s *pluginsServer) GetConfiguredPlugins(ctx context.Context, in *plugins.Ge
 }
 
 // registerPlugins opens each plugin, looks up the register function and calls it with the registrar.
-func (s *pluginsServer) registerPlugins(pluginPaths []string, grpcReg grpc.ServiceRegistrar, gwArgs gwHandlerArgs, serveOpts ServeOptions) ([]*plugins.Plugin, error) {
+func (s *pluginsServer) registerPlugins(pluginPaths []string, grpcReg grpc.ServiceRegistrar, gwArgs gwHandlerArgs, serveOpts ServeOptions, pluginsConfig *PluginsConfig) ([]*plugins.Plugin, error) {
 	pluginDetails := []*plugins.Plugin{}
 
-	clientGetter, err := createClientGetter(serveOpts)
+	configGetter, clustersConfig, err := createConfigGetter(serveOpts)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create a ClientGetter: %w", err)
+		return nil, fmt.Errorf("unable to create a ConfigGetter: %w", err)
 	}
+	clientGetter := clientGetterForConfigGetter(configGetter)
 
 	for _, pluginPath := range pluginPaths {
 		p, err := plugin.Open(pluginPath)
@@ -127,11 +175,17 @@ func (s *pluginsServer) registerPlugins(pluginPaths []string, grpcReg grpc.Servi
 		var pluginDetail *plugins.Plugin
 		if pluginDetail, err = getPluginDetail(p, pluginPath); err != nil {
 			return nil, err
-		} else {
-			pluginDetails = append(pluginDetails, pluginDetail)
 		}
 
-		if err = s.registerGRPC(p, pluginDetail, grpcReg, clientGetter); err != nil {
+		pluginConfig := pluginsConfig.configFor(pluginDetail)
+		if !pluginConfig.IsEnabled() {
+			log.Infof("Plugin %q is disabled in the plugin config, skipping it", pluginPath)
+			continue
+		}
+		pluginDetails = append(pluginDetails, pluginDetail)
+		pluginConfig.KubeappsCluster = clustersConfig.KubeappsClusterName
+
+		if err = s.registerGRPC(p, pluginDetail, grpcReg, clientGetter, configGetter, pluginConfig); err != nil {
 			return nil, err
 		}
 
@@ -145,20 +199,20 @@ func (s *pluginsServer) registerPlugins(pluginPaths []string, grpcReg grpc.Servi
 }
 
 // registerGRPC finds and calls the required function for registering the plugin for the GRPC server.
-func (s *pluginsServer) registerGRPC(p *plugin.Plugin, pluginDetail *plugins.Plugin, registrar grpc.ServiceRegistrar, clientGetter KubernetesClientGetter) error {
+func (s *pluginsServer) registerGRPC(p *plugin.Plugin, pluginDetail *plugins.Plugin, registrar grpc.ServiceRegistrar, clientGetter KubernetesClientGetter, configGetter KubernetesConfigGetter, pluginConfig *PluginConfig) error {
 	grpcRegFn, err := p.Lookup(grpcRegisterFunction)
 	if err != nil {
 		return fmt.Errorf("unable to lookup %q for %v: %w", grpcRegisterFunction, pluginDetail, err)
 	}
-	type grpcRegisterFunctionType = func(grpc.ServiceRegistrar, KubernetesClientGetter) (interface{}, error)
+	type grpcRegisterFunctionType = func(grpc.ServiceRegistrar, KubernetesClientGetter, KubernetesConfigGetter, *PluginConfig) (interface{}, error)
 
 	grpcFn, ok := grpcRegFn.(grpcRegisterFunctionType)
 	if !ok {
-		var dummyFn grpcRegisterFunctionType = func(grpc.ServiceRegistrar, KubernetesClientGetter) (interface{}, error) { return nil, nil }
+		var dummyFn grpcRegisterFunctionType = func(grpc.ServiceRegistrar, KubernetesClientGetter, KubernetesConfigGetter, *PluginConfig) (interface{}, error) { return nil, nil }
 		return fmt.Errorf("unable to use %q in plugin %v due to mismatched signature.\nwant: %T\ngot: %T", grpcRegisterFunction, pluginDetail, dummyFn, grpcRegFn)
 	}
 
-	server, err := grpcFn(registrar, clientGetter)
+	server, err := grpcFn(registrar, clientGetter, configGetter, pluginConfig)
 	if err != nil {
 		return fmt.Errorf("plug-in %q failed to register due to: %v", pluginDetail, err)
 	} else if server == nil {
@@ -169,7 +223,8 @@ func (s *pluginsServer) registerGRPC(p *plugin.Plugin, pluginDetail *plugins.Plu
 }
 
 // registerPluginsImplementingCoreAPIs checks a plugin implementation to see
-// if it implements a core api (such as `packages.v1alpha1`) and if so,
+// if it implements a core api (such as `packages.v1alpha1` or
+// `repositories.v1alpha1`) and if so,
 // keeps a (typed) reference to the implementation for use on aggregate APIs.
 func (s *pluginsServer) registerPluginsSatisfyingCoreAPIs(pluginSrv interface{}, pluginDetail *plugins.Plugin) error {
 	// The following check if the service implements an interface is what
@@ -189,6 +244,27 @@ func (s *pluginsServer) registerPluginsSatisfyingCoreAPIs(pluginSrv interface{},
 		})
 		log.Infof("Plugin %v implements core.packages.v1alpha1. Registered for aggregation.", pluginDetail)
 	}
+
+	coreRepositoriesType := reflect.TypeOf((*repositories.RepositoriesServiceServer)(nil)).Elem()
+	if serverType.Implements(coreRepositoriesType) {
+		reposSrv, ok := pluginSrv.(repositories.RepositoriesServiceServer)
+		if !ok {
+			return fmt.Errorf("Unable to convert plugin %v to core RepositoriesServiceServer although it implements the same.", pluginDetail)
+		}
+		s.repositoriesPlugins = append(s.repositoriesPlugins, &repositoriesPluginWithServer{
+			plugin: pluginDetail,
+			server: reposSrv,
+		})
+		log.Infof("Plugin %v implements core.repositories.v1alpha1. Registered for aggregation.", pluginDetail)
+	}
+
+	if checker, ok := pluginSrv.(ReadinessChecker); ok {
+		s.readinessCheckers = append(s.readinessCheckers, &pluginWithReadinessChecker{
+			plugin:  pluginDetail,
+			checker: checker,
+		})
+		log.Infof("Plugin %v reports its readiness. Registered for readiness checks.", pluginDetail)
+	}
 	return nil
 }
 
@@ -258,38 +334,23 @@ func listSOFiles(fsys fs.FS, pluginDirs []string) ([]string, error) {
 	return matches, nil
 }
 
-// createClientGetter returns a function closure for creating the k8s client to interact with the cluster.
+// createConfigGetter returns a function closure for creating the rest config to interact with the cluster.
 // The returned function utilizes the user credential present in the request context.
-// The plugins just have to call this function passing the context in order to retrieve the configured k8s client
-func createClientGetter(serveOpts ServeOptions) (KubernetesClientGetter, error) {
-	var restConfig *rest.Config
+// The plugins just have to call this function passing the context in order to retrieve the configured rest config
+// The clusters config is returned as well so that plugins can be told which is the Kubeapps cluster.
+func createConfigGetter(serveOpts ServeOptions) (KubernetesConfigGetter, kube.ClustersConfig, error) {
 	var clustersConfig kube.ClustersConfig
-	var err error
 
-	if !serveOpts.UnsafeLocalDevKubeconfig {
-		// get the default rest inCluster config for the kube.NewClusterConfig function
-		restConfig, err = rest.InClusterConfig()
-		if err != nil {
-			return nil, fmt.Errorf("unable to get inClusterConfig: %w", err)
-		}
-	} else {
-		// using the local kubeconfig instead of the inCluster config
-		log.Warningf("Using the local kubeconfig configuration (in KUBECONFIG='%s' envar) since you passed --unsafe-local-dev-kubeconfig=true", os.Getenv("KUBECONFIG"))
-		kubeconfigBytes, err := ioutil.ReadFile(os.Getenv("KUBECONFIG"))
-		if err != nil {
-			return nil, fmt.Errorf("unable to read the file in KUBECONFIG envar: %w", err)
-		}
-		restConfig, err = clientcmd.RESTConfigFromKubeConfig(kubeconfigBytes)
-		if err != nil {
-			return nil, fmt.Errorf("unable to get local KUBECONFIG='%s' file: %w", os.Getenv("KUBECONFIG"), err)
-		}
+	restConfig, err := getRESTConfig(serveOpts)
+	if err != nil {
+		return nil, clustersConfig, err
 	}
 
 	if !serveOpts.UnsafeUseDemoSA {
 		// get the parsed kube.ClustersConfig from the serveOpts
 		clustersConfig, err = getClustersConfigFromServeOpts(serveOpts)
 		if err != nil {
-			return nil, err
+			return nil, clustersConfig, err
 		}
 	} else {
 		// Just using the created SA, no user account nor clustersConfig is used here
@@ -298,33 +359,67 @@ func createClientGetter(serveOpts ServeOptions) (KubernetesClientGetter, error)
 
 	// return the closure fuction that takes the context, but preserving the required scope,
 	// 'inClusterConfig' and 'config'
-	return createClientGetterWithParams(restConfig, serveOpts, clustersConfig)
+	configGetter, err := createConfigGetterWithParams(restConfig, serveOpts, clustersConfig)
+	return configGetter, clustersConfig, err
 }
 
-// createClientGetter takes the required params and returns the closure fuction.
+// getRESTConfig returns the config to reach the cluster on which Kubeapps is
+// installed with the service account, or with the local kubeconfig during
+// development.
+func getRESTConfig(serveOpts ServeOptions) (*rest.Config, error) {
+	if !serveOpts.UnsafeLocalDevKubeconfig {
+		// get the default rest inCluster config for the kube.NewClusterConfig function
+		restConfig, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get inClusterConfig: %w", err)
+		}
+		return restConfig, nil
+	}
+	// using the local kubeconfig instead of the inCluster config
+	log.Warningf("Using the local kubeconfig configuration (in KUBECONFIG='%s' envar) since you passed --unsafe-local-dev-kubeconfig=true", os.Getenv("KUBECONFIG"))
+	kubeconfigBytes, err := ioutil.ReadFile(os.Getenv("KUBECONFIG"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the file in KUBECONFIG envar: %w", err)
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get local KUBECONFIG='%s' file: %w", os.Getenv("KUBECONFIG"), err)
+	}
+	return restConfig, nil
+}
+
+// createConfigGetterWithParams takes the required params and returns the closure fuction.
 // it's splitted for testing this fn separately
-func createClientGetterWithParams(inClusterConfig *rest.Config, serveOpts ServeOptions, clustersConfig kube.ClustersConfig) (KubernetesClientGetter, error) {
+func createConfigGetterWithParams(inClusterConfig *rest.Config, serveOpts ServeOptions, clustersConfig kube.ClustersConfig) (KubernetesConfigGetter, error) {
 	// return the closure fuction that takes the context, but preserving the required scope,
 	// 'inClusterConfig' and 'config'
-	return func(ctx context.Context) (kubernetes.Interface, dynamic.Interface, error) {
-		log.Infof("+clientGetter.GetClient")
-		var err error
+	return func(ctx context.Context, cluster string) (*rest.Config, error) {
 		token, err := extractToken(ctx)
 		if err != nil {
-			return nil, nil, status.Errorf(codes.Unauthenticated, "invalid authorization metadata: %v", err)
+			return nil, status.Errorf(codes.Unauthenticated, "invalid authorization metadata: %v", err)
 		}
+		return restConfigForCluster(inClusterConfig, token, cluster, serveOpts, clustersConfig)
+	}, nil
+}
 
-		var config *rest.Config
-		if !serveOpts.UnsafeUseDemoSA {
-			// We are using the KubeappsClusterName, but if the endpoint was cluster-scoped,
-			// we should pass the cluster name instead
-			config, err = kube.NewClusterConfig(inClusterConfig, token, clustersConfig.KubeappsClusterName, clustersConfig)
-			if err != nil {
-				return nil, nil, fmt.Errorf("unable to get clusterConfig: %w", err)
-			}
-		} else {
-			// Just using the created SA, no user account is used
-			config = inClusterConfig
+// createClientGetterWithParams returns the closure function creating the k8s
+// clients with the rest config of the request.
+func createClientGetterWithParams(inClusterConfig *rest.Config, serveOpts ServeOptions, clustersConfig kube.ClustersConfig) (KubernetesClientGetter, error) {
+	configGetter, err := createConfigGetterWithParams(inClusterConfig, serveOpts, clustersConfig)
+	if err != nil {
+		return nil, err
+	}
+	return clientGetterForConfigGetter(configGetter), nil
+}
+
+// clientGetterForConfigGetter returns the closure function creating the k8s
+// clients for the rest config returned by the config getter.
+func clientGetterForConfigGetter(configGetter KubernetesConfigGetter) KubernetesClientGetter {
+	return func(ctx context.Context, cluster string) (kubernetes.Interface, dynamic.Interface, error) {
+		log.Infof("+clientGetter.GetClient (cluster=[%s])", cluster)
+		config, err := configGetter(ctx, cluster)
+		if err != nil {
+			return nil, nil, err
 		}
 		dynamicClient, err := dynamic.NewForConfig(config)
 		if err != nil {
@@ -335,7 +430,33 @@ func createClientGetterWithParams(inClusterConfig *rest.Config, serveOpts ServeO
 			return nil, nil, fmt.Errorf("unable to create typed client: %w", err)
 		}
 		return typedClient, dynamicClient, nil
-	}, nil
+	}
+}
+
+// restConfigForCluster returns the rest config to reach the requested cluster
+// with the user token, defaulting to the cluster on which Kubeapps is
+// installed when no cluster is requested. Clusters configured with pinniped
+// are reached through the pinniped-proxy.
+func restConfigForCluster(inClusterConfig *rest.Config, token, cluster string, serveOpts ServeOptions, clustersConfig kube.ClustersConfig) (*rest.Config, error) {
+	if serveOpts.UnsafeUseDemoSA {
+		// Just using the created SA, no user account nor additional cluster is used
+		if cluster != "" && cluster != clustersConfig.KubeappsClusterName {
+			return nil, status.Errorf(codes.InvalidArgument, "cluster %q is not available when using the demo service account", cluster)
+		}
+		return inClusterConfig, nil
+	}
+
+	if cluster == "" {
+		cluster = clustersConfig.KubeappsClusterName
+	}
+	if _, ok := clustersConfig.Clusters[cluster]; cluster != "" && !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "cluster %q has no configuration", cluster)
+	}
+	config, err := kube.NewClusterConfig(inClusterConfig, token, cluster, clustersConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get clusterConfig: %w", err)
+	}
+	return config, nil
 }
 
 // extractToken returns the token passed through the gRPC request in the "authorization" metadata in the context
@@ -352,7 +473,11 @@ func extractToken(ctx context.Context) (string, error) {
 	// metadata is always lowercased
 	if len(md["authorization"]) > 0 {
 		if strings.HasPrefix(md["authorization"][0], "Bearer ") {
-			return strings.TrimPrefix(md["authorization"][0], "Bearer "), nil
+			token := strings.TrimPrefix(md["authorization"][0], "Bearer ")
+			if token == "" || strings.ContainsAny(token, " \t\r\n") {
+				return "", fmt.Errorf("malformed authorization metadata")
+			}
+			return token, nil
 		} else {
 			return "", fmt.Errorf("malformed authorization metadata")
 		}
@@ -0,0 +1,131 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// PluginsConfig is the configuration of the plugins, read from the file
+// passed with --plugin-config. Plugins which are not configured are enabled
+// with the default settings.
+//
+// For example:
+//
+//   plugins:
+//     - name: fluxv2.packages
+//       priority: 10
+//       settings:
+//         redis:
+//           addr: kubeapps-redis-master.kubeapps:6379
+//     - name: kapp_controller.packages
+//       enabled: false
+type PluginsConfig struct {
+	Plugins []PluginConfig `json:"plugins"`
+}
+
+// PluginConfig is the configuration of a single plugin, passed to the plugin
+// when it registers with the gRPC server.
+type PluginConfig struct {
+	// Name of the plugin, as returned by its GetPluginDetail.
+	Name string `json:"name"`
+	// Version of the plugin. The configuration applies to every version of
+	// the plugin when empty.
+	Version string `json:"version,omitempty"`
+	// Enabled is true when omitted.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Priority orders the plugins, those with a higher priority coming first
+	// in the configured plugins and in the aggregated results.
+	Priority int `json:"priority,omitempty"`
+	// Settings are specific to each plugin, which decodes them with
+	// DecodeSettings.
+	Settings json.RawMessage `json:"settings,omitempty"`
+	// KubeappsCluster is the name of the cluster on which Kubeapps is
+	// installed, set by the server from the clusters config rather than
+	// read from the plugin config.
+	KubeappsCluster string `json:"-"`
+}
+
+// IsEnabled returns whether the plugin is enabled.
+func (c *PluginConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// DecodeSettings decodes the settings of the plugin into v, leaving it
+// unchanged when there are no settings.
+func (c *PluginConfig) DecodeSettings(v interface{}) error {
+	if c == nil || len(c.Settings) == 0 || string(c.Settings) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(c.Settings, v); err != nil {
+		return fmt.Errorf("unable to decode the settings of plugin %q: %w", c.Name, err)
+	}
+	return nil
+}
+
+// parsePluginsConfig reads the plugins configuration from the file, returning
+// an empty configuration when no file is given.
+func parsePluginsConfig(path string) (*PluginsConfig, error) {
+	if path == "" {
+		return &PluginsConfig{}, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the plugin config %q: %w", path, err)
+	}
+	var config PluginsConfig
+	if err = yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("unable to parse the plugin config %q: %w", path, err)
+	}
+	for i, p := range config.Plugins {
+		if p.Name == "" {
+			return nil, fmt.Errorf("invalid plugin config %q: plugin %d has no name", path, i)
+		}
+	}
+	return &config, nil
+}
+
+// configFor returns the configuration of the plugin, preferring the one for
+// its exact version, or a default configuration if it is not configured.
+func (c *PluginsConfig) configFor(p *plugins.Plugin) *PluginConfig {
+	var found *PluginConfig
+	for i, pc := range c.Plugins {
+		if pc.Name != p.GetName() {
+			continue
+		}
+		if pc.Version == p.GetVersion() {
+			return &c.Plugins[i]
+		}
+		if pc.Version == "" {
+			found = &c.Plugins[i]
+		}
+	}
+	if found != nil {
+		return found
+	}
+	return &PluginConfig{Name: p.GetName(), Version: p.GetVersion()}
+}
+
+// sortPluginsByPriority orders the plugins by decreasing priority, keeping
+// the order of plugins with the same priority.
+func (c *PluginsConfig) sortPluginsByPriority(p []*plugins.Plugin) {
+	sort.SliceStable(p, func(i, j int) bool {
+		return c.configFor(p[i]).Priority > c.configFor(p[j]).Priority
+	})
+}
@@ -0,0 +1,195 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+)
+
+func writePluginsConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "plugins.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return path
+}
+
+func TestParsePluginsConfig(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expectedErr   bool
+		expectedNames []string
+	}{
+		{
+			name: "it parses the plugins config",
+			content: `
+plugins:
+  - name: fluxv2.packages
+    priority: 10
+    settings:
+      redis:
+        addr: redis:6379
+  - name: kapp_controller.packages
+    enabled: false
+`,
+			expectedNames: []string{"fluxv2.packages", "kapp_controller.packages"},
+		},
+		{
+			name: "it returns an error for an unknown field",
+			content: `
+plugins:
+  - name: fluxv2.packages
+    enable: false
+`,
+			expectedErr: true,
+		},
+		{
+			name: "it returns an error for a plugin without name",
+			content: `
+plugins:
+  - priority: 1
+`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := parsePluginsConfig(writePluginsConfig(t, tc.content))
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("got: nil, want: error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			names := []string{}
+			for _, p := range config.Plugins {
+				names = append(names, p.Name)
+			}
+			if got, want := names, tc.expectedNames; !cmp.Equal(want, got) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("it returns an empty config without file", func(t *testing.T) {
+		config, err := parsePluginsConfig("")
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := len(config.Plugins), 0; got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+	})
+}
+
+func TestPluginConfig(t *testing.T) {
+	config, err := parsePluginsConfig(writePluginsConfig(t, `
+plugins:
+  - name: fluxv2.packages
+    priority: 10
+    settings:
+      redis:
+        addr: redis:6379
+        db: 1
+  - name: kapp_controller.packages
+    enabled: false
+  - name: helm.packages
+    version: v1alpha2
+    priority: 20
+`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	fluxPlugin := &plugins.Plugin{Name: "fluxv2.packages", Version: "v1alpha1"}
+	kappPlugin := &plugins.Plugin{Name: "kapp_controller.packages", Version: "v1alpha1"}
+	helmPlugin := &plugins.Plugin{Name: "helm.packages", Version: "v1alpha1"}
+	helmV2Plugin := &plugins.Plugin{Name: "helm.packages", Version: "v1alpha2"}
+
+	t.Run("it enables the plugins unless disabled", func(t *testing.T) {
+		for _, tc := range []struct {
+			plugin  *plugins.Plugin
+			enabled bool
+		}{
+			{plugin: fluxPlugin, enabled: true},
+			{plugin: kappPlugin, enabled: false},
+			{plugin: helmPlugin, enabled: true},
+		} {
+			if got, want := config.configFor(tc.plugin).IsEnabled(), tc.enabled; got != want {
+				t.Errorf("%s: got: %t, want: %t", tc.plugin.Name, got, want)
+			}
+		}
+	})
+
+	t.Run("it decodes the settings of a plugin", func(t *testing.T) {
+		type settings struct {
+			Redis struct {
+				Addr string `json:"addr"`
+				DB   int    `json:"db"`
+			} `json:"redis"`
+		}
+		var s settings
+		if err := config.configFor(fluxPlugin).DecodeSettings(&s); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if got, want := s.Redis.Addr, "redis:6379"; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+		if got, want := s.Redis.DB, 1; got != want {
+			t.Errorf("got: %d, want: %d", got, want)
+		}
+		if err := config.configFor(helmPlugin).DecodeSettings(&s); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	})
+
+	t.Run("it sorts the plugins by priority", func(t *testing.T) {
+		configured := []*plugins.Plugin{fluxPlugin, helmPlugin, helmV2Plugin, kappPlugin}
+		config.sortPluginsByPriority(configured)
+		expected := []*plugins.Plugin{helmV2Plugin, fluxPlugin, helmPlugin, kappPlugin}
+		if got, want := configured, expected; !cmp.Equal(want, got, cmp.Comparer(pluginEqual)) {
+			t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, cmp.Comparer(pluginEqual)))
+		}
+	})
+
+	t.Run("it orders the plugins registered for aggregation as the configured plugins", func(t *testing.T) {
+		s := &pluginsServer{
+			plugins: []*plugins.Plugin{helmPlugin, fluxPlugin},
+			packagesPlugins: []*pkgsPluginWithServer{
+				{plugin: fluxPlugin},
+				{plugin: helmPlugin},
+			},
+			repositoriesPlugins: []*repositoriesPluginWithServer{
+				{plugin: fluxPlugin},
+				{plugin: helmPlugin},
+			},
+		}
+		s.sortRegisteredPlugins()
+		if got, want := s.packagesPlugins[0].plugin, helmPlugin; got != want {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+		if got, want := s.repositoriesPlugins[0].plugin, helmPlugin; got != want {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	})
+}
@@ -269,6 +269,20 @@ func TestExtractToken(t *testing.T) {
 			expectedToken: "",
 			expectedErr:   fmt.Errorf("malformed authorization metadata"),
 		},
+		{
+			name:          "it returns no token with an error if the bearer token is empty",
+			contextKey:    "authorization",
+			contextValue:  "Bearer ",
+			expectedToken: "",
+			expectedErr:   fmt.Errorf("malformed authorization metadata"),
+		},
+		{
+			name:          "it returns no token with an error if the bearer token contains spaces",
+			contextKey:    "authorization",
+			contextValue:  "Bearer abc def",
+			expectedToken: "",
+			expectedErr:   fmt.Errorf("malformed authorization metadata"),
+		},
 		{
 			name:          "it returns no token and no error if the 'authorization' is empty",
 			contextKey:    "",
@@ -369,7 +383,7 @@ func TestCreateClientGetterWithParams(t *testing.T) {
 				t.Fatalf("in %s: fail creating the clientGetter:  %+v", tc.name, err)
 			}
 
-			typedClient, dynamicClient, err := clientGetter(ctx)
+			typedClient, dynamicClient, err := clientGetter(ctx, "")
 			if tc.expectedErrMsg != nil && err != nil {
 				if got, want := err.Error(), tc.expectedErrMsg.Error(); !cmp.Equal(want, got) {
 					t.Errorf("in %s: mismatch (-want +got):\n%s", tc.name, cmp.Diff(want, got))
@@ -389,3 +403,92 @@ func TestCreateClientGetterWithParams(t *testing.T) {
 		})
 	}
 }
+
+func TestRestConfigForCluster(t *testing.T) {
+	inClusterConfig := &rest.Config{Host: "https://kubeapps-cluster"}
+	clustersConfig := kube.ClustersConfig{
+		KubeappsClusterName: "default",
+		PinnipedProxyURL:    "http://pinniped-proxy",
+		Clusters: map[string]kube.ClusterConfig{
+			"default": {
+				Name:              "default",
+				IsKubeappsCluster: true,
+			},
+			"additional": {
+				Name:          "additional",
+				APIServiceURL: "https://additional-cluster",
+			},
+			"pinniped": {
+				Name:          "pinniped",
+				APIServiceURL: "https://pinniped-cluster",
+				PinnipedConfig: kube.PinnipedConciergeConfig{
+					Enable: true,
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		cluster         string
+		unsafeUseDemoSA bool
+		expectedHost    string
+		statusCode      codes.Code
+	}{
+		{
+			name:         "it defaults to the kubeapps cluster",
+			cluster:      "",
+			expectedHost: "https://kubeapps-cluster",
+		},
+		{
+			name:         "it uses the kubeapps cluster when requested",
+			cluster:      "default",
+			expectedHost: "https://kubeapps-cluster",
+		},
+		{
+			name:         "it uses the api service of an additional cluster",
+			cluster:      "additional",
+			expectedHost: "https://additional-cluster",
+		},
+		{
+			name:         "it routes a pinniped cluster through the pinniped-proxy",
+			cluster:      "pinniped",
+			expectedHost: "http://pinniped-proxy",
+		},
+		{
+			name:       "it returns an invalid argument error for an unknown cluster",
+			cluster:    "unknown",
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:            "it uses the in-cluster config with the demo service account",
+			cluster:         "",
+			unsafeUseDemoSA: true,
+			expectedHost:    "https://kubeapps-cluster",
+		},
+		{
+			name:            "it returns an invalid argument error for an additional cluster with the demo service account",
+			cluster:         "additional",
+			unsafeUseDemoSA: true,
+			statusCode:      codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serveOpts := ServeOptions{UnsafeUseDemoSA: tc.unsafeUseDemoSA}
+
+			config, err := restConfigForCluster(inClusterConfig, "abc", tc.cluster, serveOpts, clustersConfig)
+
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != codes.OK {
+				return
+			}
+			if got, want := config.Host, tc.expectedHost; got != want {
+				t.Errorf("got: %q, want: %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	repositories "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/repositories/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	log "k8s.io/klog/v2"
+)
+
+// repositoriesServer implements the API defined in proto/kubeappsapis/core/repositories/v1alpha1/repositories.proto
+type repositoriesServer struct {
+	repositories.UnimplementedRepositoriesServiceServer
+
+	// plugins is a slice of all registered plugins which satisfy the core.repositories.v1alpha1
+	// interface.
+	plugins []*repositoriesPluginWithServer
+
+	// pluginTimeout is the deadline applied to each plugin when aggregating
+	// results from all plugins.
+	pluginTimeout time.Duration
+}
+
+func NewRepositoriesServer(plugins []*repositoriesPluginWithServer, pluginTimeout time.Duration) *repositoriesServer {
+	return &repositoriesServer{
+		plugins:       plugins,
+		pluginTimeout: pluginTimeout,
+	}
+}
+
+// GetPackageRepositorySummaries returns the package repositories managed by any plugin.
+func (s repositoriesServer) GetPackageRepositorySummaries(ctx context.Context, request *repositories.GetPackageRepositorySummariesRequest) (*repositories.GetPackageRepositorySummariesResponse, error) {
+	contextMsg := ""
+	if request.Context != nil {
+		contextMsg = fmt.Sprintf("(cluster=[%s], namespace=[%s])", request.Context.Cluster, request.Context.Namespace)
+	}
+
+	log.Infof("+core GetPackageRepositorySummaries %s", contextMsg)
+
+	pluginDetails := make([]*plugins.Plugin, len(s.plugins))
+	for i, p := range s.plugins {
+		pluginDetails[i] = p.plugin
+	}
+	results := callAllPlugins(ctx, s.pluginTimeout, pluginDetails, func(ctx context.Context, i int) (interface{}, error) {
+		return s.plugins[i].server.GetPackageRepositorySummaries(ctx, request)
+	})
+	pluginErrors, err := pluginErrors(results)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []*repositories.PackageRepositorySummary{}
+	for _, r := range results {
+		if r.err != nil {
+			continue
+		}
+		// Add the plugin for the repositories
+		pluginSummaries := r.response.(*repositories.GetPackageRepositorySummariesResponse).PackageRepositorySummaries
+		for _, summary := range pluginSummaries {
+			if summary.PackageRepoRef == nil {
+				summary.PackageRepoRef = &repositories.PackageRepositoryReference{}
+			}
+			summary.PackageRepoRef.Plugin = r.plugin
+		}
+		summaries = append(summaries, pluginSummaries...)
+	}
+
+	return &repositories.GetPackageRepositorySummariesResponse{
+		PackageRepositorySummaries: summaries,
+		PluginErrors:               pluginErrors,
+	}, nil
+}
+
+// GetPackageRepositoryDetail returns the package repository detail from the plugin managing it.
+func (s repositoriesServer) GetPackageRepositoryDetail(ctx context.Context, request *repositories.GetPackageRepositoryDetailRequest) (*repositories.GetPackageRepositoryDetailResponse, error) {
+	log.Infof("+core GetPackageRepositoryDetail %s", packageRepositoryRefMsg(request.GetPackageRepoRef()))
+
+	p, err := s.getPluginWithServer(request.GetPackageRepoRef().GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.server.GetPackageRepositoryDetail(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the plugin is set on the returned reference.
+	if detail := response.GetPackageRepositoryDetail(); detail != nil {
+		if detail.PackageRepoRef == nil {
+			detail.PackageRepoRef = &repositories.PackageRepositoryReference{}
+		}
+		detail.PackageRepoRef.Plugin = p.plugin
+	}
+	return response, nil
+}
+
+// CreatePackageRepository creates the package repository using the requested plugin.
+func (s repositoriesServer) CreatePackageRepository(ctx context.Context, request *repositories.CreatePackageRepositoryRequest) (*repositories.CreatePackageRepositoryResponse, error) {
+	contextMsg := ""
+	if request.Context != nil {
+		contextMsg = fmt.Sprintf("(cluster=[%s], namespace=[%s])", request.Context.Cluster, request.Context.Namespace)
+	}
+	log.Infof("+core CreatePackageRepository %s", contextMsg)
+
+	p, err := s.getPluginWithServer(request.GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.server.CreatePackageRepository(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.PackageRepoRef == nil {
+		response.PackageRepoRef = &repositories.PackageRepositoryReference{}
+	}
+	response.PackageRepoRef.Plugin = p.plugin
+	return response, nil
+}
+
+// UpdatePackageRepository updates the package repository using the plugin managing it.
+func (s repositoriesServer) UpdatePackageRepository(ctx context.Context, request *repositories.UpdatePackageRepositoryRequest) (*repositories.UpdatePackageRepositoryResponse, error) {
+	log.Infof("+core UpdatePackageRepository %s", packageRepositoryRefMsg(request.GetPackageRepoRef()))
+
+	p, err := s.getPluginWithServer(request.GetPackageRepoRef().GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.server.UpdatePackageRepository(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.PackageRepoRef == nil {
+		response.PackageRepoRef = &repositories.PackageRepositoryReference{}
+	}
+	response.PackageRepoRef.Plugin = p.plugin
+	return response, nil
+}
+
+// DeletePackageRepository deletes the package repository using the plugin managing it.
+func (s repositoriesServer) DeletePackageRepository(ctx context.Context, request *repositories.DeletePackageRepositoryRequest) (*repositories.DeletePackageRepositoryResponse, error) {
+	log.Infof("+core DeletePackageRepository %s", packageRepositoryRefMsg(request.GetPackageRepoRef()))
+
+	p, err := s.getPluginWithServer(request.GetPackageRepoRef().GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.server.DeletePackageRepository(ctx, request)
+}
+
+// RefreshPackageRepository requests the plugin managing the package repository
+// to synchronize it again.
+func (s repositoriesServer) RefreshPackageRepository(ctx context.Context, request *repositories.RefreshPackageRepositoryRequest) (*repositories.RefreshPackageRepositoryResponse, error) {
+	log.Infof("+core RefreshPackageRepository %s", packageRepositoryRefMsg(request.GetPackageRepoRef()))
+
+	p, err := s.getPluginWithServer(request.GetPackageRepoRef().GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.server.RefreshPackageRepository(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.PackageRepoRef == nil {
+		response.PackageRepoRef = &repositories.PackageRepositoryReference{}
+	}
+	response.PackageRepoRef.Plugin = p.plugin
+	return response, nil
+}
+
+// getPluginWithServer returns the registered plugin matching the requested
+// plugin name and version.
+func (s repositoriesServer) getPluginWithServer(plugin *plugins.Plugin) (*repositoriesPluginWithServer, error) {
+	if plugin == nil || plugin.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "A plugin must be specified for the request")
+	}
+	for _, p := range s.plugins {
+		if p.plugin.Name == plugin.Name && p.plugin.Version == plugin.Version {
+			return p, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "Unable to find the plugin %q (version %q)", plugin.Name, plugin.Version)
+}
+
+// packageRepositoryRefMsg returns a string describing the package repository
+// reference for logging.
+func packageRepositoryRefMsg(ref *repositories.PackageRepositoryReference) string {
+	return fmt.Sprintf("(cluster=[%s], namespace=[%s], identifier=[%s])", ref.GetContext().GetCluster(), ref.GetContext().GetNamespace(), ref.GetIdentifier())
+}
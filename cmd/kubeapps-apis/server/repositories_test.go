@@ -0,0 +1,290 @@
+/*
+Copyright © 2021 VMware
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	packages "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
+	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	repositories "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/repositories/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testRepositoriesServer is a fake plugin implementation of the core
+// repositories API returning pre-configured results.
+type testRepositoriesServer struct {
+	repositories.UnimplementedRepositoriesServiceServer
+
+	summaries []*repositories.PackageRepositorySummary
+	// lastIdentifier records the identifier of the last package repository
+	// reference received so that tests can verify the routing.
+	lastIdentifier string
+	err            error
+}
+
+func (s *testRepositoriesServer) GetPackageRepositorySummaries(ctx context.Context, request *repositories.GetPackageRepositorySummariesRequest) (*repositories.GetPackageRepositorySummariesResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &repositories.GetPackageRepositorySummariesResponse{
+		PackageRepositorySummaries: s.summaries,
+	}, nil
+}
+
+func (s *testRepositoriesServer) GetPackageRepositoryDetail(ctx context.Context, request *repositories.GetPackageRepositoryDetailRequest) (*repositories.GetPackageRepositoryDetailResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetPackageRepoRef().GetIdentifier()
+	return &repositories.GetPackageRepositoryDetailResponse{
+		PackageRepositoryDetail: &repositories.PackageRepositoryDetail{
+			PackageRepoRef: &repositories.PackageRepositoryReference{
+				Identifier: request.GetPackageRepoRef().GetIdentifier(),
+			},
+		},
+	}, nil
+}
+
+func (s *testRepositoriesServer) CreatePackageRepository(ctx context.Context, request *repositories.CreatePackageRepositoryRequest) (*repositories.CreatePackageRepositoryResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetName()
+	return &repositories.CreatePackageRepositoryResponse{
+		PackageRepoRef: &repositories.PackageRepositoryReference{
+			Context:    request.GetContext(),
+			Identifier: request.GetName(),
+		},
+	}, nil
+}
+
+func (s *testRepositoriesServer) UpdatePackageRepository(ctx context.Context, request *repositories.UpdatePackageRepositoryRequest) (*repositories.UpdatePackageRepositoryResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetPackageRepoRef().GetIdentifier()
+	return &repositories.UpdatePackageRepositoryResponse{
+		PackageRepoRef: &repositories.PackageRepositoryReference{
+			Identifier: request.GetPackageRepoRef().GetIdentifier(),
+		},
+	}, nil
+}
+
+func (s *testRepositoriesServer) DeletePackageRepository(ctx context.Context, request *repositories.DeletePackageRepositoryRequest) (*repositories.DeletePackageRepositoryResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetPackageRepoRef().GetIdentifier()
+	return &repositories.DeletePackageRepositoryResponse{}, nil
+}
+
+func (s *testRepositoriesServer) RefreshPackageRepository(ctx context.Context, request *repositories.RefreshPackageRepositoryRequest) (*repositories.RefreshPackageRepositoryResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.lastIdentifier = request.GetPackageRepoRef().GetIdentifier()
+	return &repositories.RefreshPackageRepositoryResponse{
+		PackageRepoRef: &repositories.PackageRepositoryReference{
+			Identifier: request.GetPackageRepoRef().GetIdentifier(),
+		},
+	}, nil
+}
+
+func TestGetPackageRepositorySummaries(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configuredPlugins []*repositoriesPluginWithServer
+		statusCode        codes.Code
+		expectedResponse  *repositories.GetPackageRepositorySummariesResponse
+	}{
+		{
+			name: "it aggregates the package repositories of every plugin, setting the plugin on each reference",
+			configuredPlugins: []*repositoriesPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testRepositoriesServer{
+						summaries: []*repositories.PackageRepositorySummary{
+							{Name: "repo-1", PackageRepoRef: &repositories.PackageRepositoryReference{Identifier: "repo-1"}},
+						},
+					},
+				},
+				{
+					plugin: mockedPlugin2,
+					server: &testRepositoriesServer{
+						summaries: []*repositories.PackageRepositorySummary{
+							{Name: "repo-2"},
+						},
+					},
+				},
+			},
+			statusCode: codes.OK,
+			expectedResponse: &repositories.GetPackageRepositorySummariesResponse{
+				PackageRepositorySummaries: []*repositories.PackageRepositorySummary{
+					{Name: "repo-1", PackageRepoRef: &repositories.PackageRepositoryReference{Identifier: "repo-1", Plugin: mockedPlugin1}},
+					{Name: "repo-2", PackageRepoRef: &repositories.PackageRepositoryReference{Plugin: mockedPlugin2}},
+				},
+				PluginErrors: []*packages.PluginError{},
+			},
+		},
+		{
+			name: "it returns partial results together with the plugin errors",
+			configuredPlugins: []*repositoriesPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testRepositoriesServer{err: status.Errorf(codes.PermissionDenied, "Bang!")},
+				},
+				{
+					plugin: mockedPlugin2,
+					server: &testRepositoriesServer{
+						summaries: []*repositories.PackageRepositorySummary{
+							{Name: "repo-2"},
+						},
+					},
+				},
+			},
+			statusCode: codes.OK,
+			expectedResponse: &repositories.GetPackageRepositorySummariesResponse{
+				PackageRepositorySummaries: []*repositories.PackageRepositorySummary{
+					{Name: "repo-2", PackageRepoRef: &repositories.PackageRepositoryReference{Plugin: mockedPlugin2}},
+				},
+				PluginErrors: []*packages.PluginError{
+					{Plugin: mockedPlugin1, StatusCode: codes.PermissionDenied.String(), Message: "Bang!"},
+				},
+			},
+		},
+		{
+			name: "it returns an error if every plugin returns an error",
+			configuredPlugins: []*repositoriesPluginWithServer{
+				{
+					plugin: mockedPlugin1,
+					server: &testRepositoriesServer{err: status.Errorf(codes.Internal, "Bang!")},
+				},
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	ignoreUnexported := cmpopts.IgnoreUnexported(
+		repositories.GetPackageRepositorySummariesResponse{},
+		repositories.PackageRepositorySummary{},
+		repositories.PackageRepositoryReference{},
+		packages.PluginError{},
+		plugins.Plugin{},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := NewRepositoriesServer(tc.configuredPlugins, 0)
+
+			response, err := server.GetPackageRepositorySummaries(context.Background(), &repositories.GetPackageRepositorySummariesRequest{})
+
+			if got, want := status.Code(err), tc.statusCode; got != want {
+				t.Fatalf("got: %+v, want: %+v, err: %+v", got, want, err)
+			}
+			if tc.statusCode != codes.OK {
+				return
+			}
+
+			if got, want := response, tc.expectedResponse; !cmp.Equal(want, got, ignoreUnexported) {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got, ignoreUnexported))
+			}
+		})
+	}
+}
+
+func TestPackageRepositoryRouting(t *testing.T) {
+	testCases := []struct {
+		name               string
+		plugin             *plugins.Plugin
+		statusCode         codes.Code
+		expectedIdentifier string
+	}{
+		{
+			name:               "it routes the request to the referenced plugin",
+			plugin:             mockedPlugin2,
+			statusCode:         codes.OK,
+			expectedIdentifier: "my-repo",
+		},
+		{
+			name:       "it returns invalid argument when no plugin is referenced",
+			plugin:     nil,
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "it returns not found when the referenced plugin is not registered",
+			plugin:     &plugins.Plugin{Name: "unknown.packages", Version: "v1alpha1"},
+			statusCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pluginServer1 := &testRepositoriesServer{}
+			pluginServer2 := &testRepositoriesServer{}
+			server := NewRepositoriesServer([]*repositoriesPluginWithServer{
+				{plugin: mockedPlugin1, server: pluginServer1},
+				{plugin: mockedPlugin2, server: pluginServer2},
+			}, 0)
+
+			ref := &repositories.PackageRepositoryReference{
+				Context:    &packages.Context{Namespace: "default"},
+				Identifier: "my-repo",
+				Plugin:     tc.plugin,
+			}
+
+			checkResult := func(op string, err error, responseRef *repositories.PackageRepositoryReference) {
+				if got, want := status.Code(err), tc.statusCode; got != want {
+					t.Fatalf("%s: got: %+v, want: %+v, err: %+v", op, got, want, err)
+				}
+				if tc.statusCode != codes.OK {
+					return
+				}
+				if got, want := pluginServer2.lastIdentifier, tc.expectedIdentifier; got != want {
+					t.Errorf("%s: got: %q, want: %q", op, got, want)
+				}
+				if pluginServer1.lastIdentifier != "" {
+					t.Errorf("%s: unexpected request to plugin %v", op, mockedPlugin1)
+				}
+				if responseRef != nil && !pluginEqual(responseRef.GetPlugin(), tc.plugin) {
+					t.Errorf("%s: got plugin: %v, want: %v", op, responseRef.GetPlugin(), tc.plugin)
+				}
+				pluginServer2.lastIdentifier = ""
+			}
+
+			detail, err := server.GetPackageRepositoryDetail(context.Background(), &repositories.GetPackageRepositoryDetailRequest{PackageRepoRef: ref})
+			checkResult("GetPackageRepositoryDetail", err, detail.GetPackageRepositoryDetail().GetPackageRepoRef())
+
+			created, err := server.CreatePackageRepository(context.Background(), &repositories.CreatePackageRepositoryRequest{
+				Context: ref.Context,
+				Name:    "my-repo",
+				Url:     "https://charts.example.com",
+				Plugin:  tc.plugin,
+			})
+			checkResult("CreatePackageRepository", err, created.GetPackageRepoRef())
+
+			updated, err := server.UpdatePackageRepository(context.Background(), &repositories.UpdatePackageRepositoryRequest{PackageRepoRef: ref})
+			checkResult("UpdatePackageRepository", err, updated.GetPackageRepoRef())
+
+			refreshed, err := server.RefreshPackageRepository(context.Background(), &repositories.RefreshPackageRepositoryRequest{PackageRepoRef: ref})
+			checkResult("RefreshPackageRepository", err, refreshed.GetPackageRepoRef())
+
+			_, err = server.DeletePackageRepository(context.Background(), &repositories.DeletePackageRepositoryRequest{PackageRepoRef: ref})
+			checkResult("DeletePackageRepository", err, nil)
+		})
+	}
+}
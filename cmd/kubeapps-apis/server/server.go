@@ -21,12 +21,14 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/soheilhy/cmux"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	packages "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/packages/v1alpha1"
 	plugins "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/plugins/v1alpha1"
+	repositories "github.com/kubeapps/kubeapps/cmd/kubeapps-apis/gen/core/repositories/v1alpha1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -34,10 +36,26 @@ import (
 )
 
 type ServeOptions struct {
-	Port               int
-	PluginDirs         []string
+	Port       int
+	PluginDirs []string
+	// PluginConfigPath is the YAML file enabling, ordering and configuring
+	// the plugins.
+	PluginConfigPath   string
 	ClustersConfigPath string
 	PinnipedProxyURL   string
+	// PluginTimeout is the deadline for each plugin when aggregating results
+	// from all plugins.
+	PluginTimeout time.Duration
+	// AuthMode is the authentication of the bearer tokens of the requests:
+	// "none", "token-review" or "oidc".
+	AuthMode string
+	// The OIDC options are used to authenticate the ID tokens when the
+	// AuthMode is "oidc".
+	OIDCIssuerURL     string
+	OIDCJWKSFile      string
+	OIDCClientID      string
+	OIDCUsernameClaim string
+	OIDCGroupsClaim   string
 	//temporary flags while this component in under heavy development
 	UnsafeUseDemoSA          bool
 	UnsafeLocalDevKubeconfig bool
@@ -48,7 +66,16 @@ type ServeOptions struct {
 func Serve(serveOpts ServeOptions) {
 	// Create the grpc server and register the reflection server (for now, useful for discovery
 	// using grpcurl) or similar.
-	grpcSrv := grpc.NewServer()
+	// The requests are measured and authenticated once by the interceptors,
+	// before reaching the core services or the plugins.
+	authenticator, err := newAuthenticator(serveOpts)
+	if err != nil {
+		log.Fatalf("failed to initialize the authentication: %v", err)
+	}
+	grpcSrv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(metricsUnaryInterceptor, authUnaryInterceptor(authenticator)),
+		grpc.ChainStreamInterceptor(metricsStreamInterceptor, authStreamInterceptor(authenticator)),
+	)
 	reflection.Register(grpcSrv)
 
 	// Create the http server, register our core service followed by any plugins.
@@ -78,12 +105,25 @@ func Serve(serveOpts ServeOptions) {
 	}
 
 	// Create the core.packages server and register it for both grpc and http.
-	packages.RegisterPackagesServiceServer(grpcSrv, NewPackagesServer(pluginsServer.packagesPlugins))
+	packages.RegisterPackagesServiceServer(grpcSrv, NewPackagesServer(pluginsServer.packagesPlugins, serveOpts.PluginTimeout))
 	err = packages.RegisterPackagesServiceHandlerFromEndpoint(gwArgs.ctx, gwArgs.mux, gwArgs.addr, gwArgs.dialOptions)
 	if err != nil {
 		log.Fatalf("failed to register core.packages handler for gateway: %v", err)
 	}
 
+	// Create the core.repositories server and register it for both grpc and http.
+	repositories.RegisterRepositoriesServiceServer(grpcSrv, NewRepositoriesServer(pluginsServer.repositoriesPlugins, serveOpts.PluginTimeout))
+	err = repositories.RegisterRepositoriesServiceHandlerFromEndpoint(gwArgs.ctx, gwArgs.mux, gwArgs.addr, gwArgs.dialOptions)
+	if err != nil {
+		log.Fatalf("failed to register core.repositories handler for gateway: %v", err)
+	}
+
+	// Serve the health and metrics endpoints next to the gateway.
+	err = registerHealthHandlers(gwArgs.mux, newHealthHandler(pluginsServer))
+	if err != nil {
+		log.Fatalf("failed to register the health handlers: %v", err)
+	}
+
 	lis, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
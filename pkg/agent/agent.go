@@ -123,6 +123,51 @@ func UpgradeRelease(actionConfig *action.Configuration, name, valuesYaml string,
 	return res, nil
 }
 
+// ResolveManifest returns the manifest rendered for the installation of a
+// chart, without installing it.
+func ResolveManifest(actionConfig *action.Configuration, name, namespace, valueString string, ch *chart.Chart, registrySecrets map[string]string) (string, error) {
+	cmd := action.NewInstall(actionConfig)
+	cmd.ReleaseName = name
+	cmd.Namespace = namespace
+	cmd.DryRun = true
+	var err error
+	cmd.PostRenderer, err = NewDockerSecretsPostRenderer(registrySecrets)
+	if err != nil {
+		return "", err
+	}
+	values, err := getValues([]byte(valueString))
+	if err != nil {
+		return "", err
+	}
+	rel, err := cmd.Run(ch, values)
+	if err != nil {
+		return "", err
+	}
+	// The manifest returned has some extra new lines at the beginning
+	return strings.TrimLeft(rel.Manifest, "\n"), nil
+}
+
+// ResolveUpgradeManifest returns the manifest rendered for the upgrade of a
+// release, without upgrading it.
+func ResolveUpgradeManifest(actionConfig *action.Configuration, name, valuesYaml string, ch *chart.Chart, registrySecrets map[string]string) (string, error) {
+	cmd := action.NewUpgrade(actionConfig)
+	cmd.DryRun = true
+	var err error
+	cmd.PostRenderer, err = NewDockerSecretsPostRenderer(registrySecrets)
+	if err != nil {
+		return "", err
+	}
+	values, err := chartutil.ReadValues([]byte(valuesYaml))
+	if err != nil {
+		return "", fmt.Errorf("Unable to upgrade the release because values could not be parsed: %v", err)
+	}
+	rel, err := cmd.Run(name, ch, values)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimLeft(rel.Manifest, "\n"), nil
+}
+
 // RollbackRelease rolls back a release to the specified revision.
 func RollbackRelease(actionConfig *action.Configuration, releaseName string, revision int) (*release.Release, error) {
 	log.Printf("Rolling back %s to revision %d.", releaseName, revision)
@@ -748,3 +748,120 @@ func TestNewConfigFlagsFromCluster(t *testing.T) {
 		})
 	}
 }
+
+// chartWithConfigMap returns a chart with a template rendering a ConfigMap
+// with the value of the "greeting" key.
+func chartWithConfigMap(name string) *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: name, Version: "1.0.0"},
+		Values:   map[string]interface{}{"greeting": "hello"},
+		Templates: []*chart.File{
+			{
+				Name: "templates/configmap.yaml",
+				Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}\ndata:\n  greeting: {{ .Values.greeting }}\n"),
+			},
+		},
+	}
+}
+
+func TestResolveManifest(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		values           string
+		existingReleases []releaseStub
+		expectedManifest string
+		shouldFail       bool
+	}{
+		{
+			desc:   "renders the manifest with the values",
+			values: "greeting: hi",
+			expectedManifest: `---
+# Source: mychart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: myrls
+data:
+  greeting: hi
+`,
+		},
+		{
+			desc:       "fails with invalid values",
+			values:     "\\-xx-@myval:\"test value\"\\\n",
+			shouldFail: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := newActionConfigFixture(t)
+			makeReleases(t, cfg, tc.existingReleases)
+
+			manifest, err := ResolveManifest(cfg, "myrls", "default", tc.values, chartWithConfigMap("mychart"), nil)
+			if got, want := err != nil, tc.shouldFail; got != want {
+				t.Fatalf("got: %v, want: %v, err: %+v", got, want, err)
+			}
+			if got, want := manifest, tc.expectedManifest; got != want {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			// The release is not installed
+			rlss, err := cfg.Releases.ListReleases()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if got, want := len(rlss), len(tc.existingReleases); got != want {
+				t.Errorf("got: %d, want: %d", got, want)
+			}
+		})
+	}
+}
+
+func TestResolveUpgradeManifest(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		releases         []releaseStub
+		values           string
+		expectedManifest string
+		shouldFail       bool
+	}{
+		{
+			desc: "renders the manifest with the values",
+			releases: []releaseStub{
+				{"myrls", "default", 1, "1.0.0", release.StatusDeployed},
+			},
+			values: "greeting: hi",
+			expectedManifest: `---
+# Source: mychart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: myrls
+data:
+  greeting: hi
+`,
+		},
+		{
+			desc:       "fails for a missing release",
+			shouldFail: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := newActionConfigFixture(t)
+			makeReleases(t, cfg, tc.releases)
+
+			manifest, err := ResolveUpgradeManifest(cfg, "myrls", tc.values, chartWithConfigMap("mychart"), nil)
+			if got, want := err != nil, tc.shouldFail; got != want {
+				t.Fatalf("got: %v, want: %v, err: %+v", got, want, err)
+			}
+			if got, want := manifest, tc.expectedManifest; got != want {
+				t.Errorf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			// The release is not upgraded
+			if _, err = cfg.Releases.Get("myrls", 2); err != driver.ErrReleaseNotFound {
+				t.Errorf("got: %v, want: %v", err, driver.ErrReleaseNotFound)
+			}
+		})
+	}
+}
@@ -105,6 +105,12 @@ func NewAuth(token, clusterName string, clustersConfig kube.ClustersConfig) (*Us
 	if err != nil {
 		return nil, err
 	}
+	return NewAuthForClient(kubeClient), nil
+}
+
+// NewAuthForClient creates an auth agent checking the permissions with the
+// given client, already configured with the user credentials.
+func NewAuthForClient(kubeClient kubernetes.Interface) *UserAuth {
 	authCli := kubeClient.AuthorizationV1()
 	discoveryCli := kubeClient.Discovery()
 	k8sAuthCli := k8sAuth{
@@ -112,7 +118,7 @@ func NewAuth(token, clusterName string, clustersConfig kube.ClustersConfig) (*Us
 		DiscoveryCli: discoveryCli,
 	}
 
-	return &UserAuth{k8sAuthCli}, nil
+	return &UserAuth{k8sAuthCli}
 }
 
 // ValidateForNamespace checks if the user can access secrets in the given
@@ -96,6 +96,19 @@ type ChartFiles struct {
 	Schema string
 	Repo   *Repo
 	Digest string
+	// Dependencies are the dependencies declared in the Chart.yaml, or the
+	// requirements.yaml of v1 charts, of the chart version.
+	Dependencies []ChartDependency
+}
+
+// ChartDependency is a subchart which a chart version depends on
+type ChartDependency struct {
+	Name string `json:"name"`
+	// Version is the version range of the dependency
+	Version    string `json:"version,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Condition  string `json:"condition,omitempty"`
+	Alias      string `json:"alias,omitempty"`
 }
 
 // Allow to convert ChartFiles to a sql JSON
@@ -114,4 +127,8 @@ const (
 	ReadmeKey = "readme"
 	ValuesKey = "values"
 	SchemaKey = "schema"
+	// ChartYAMLKey and RequirementsKey hold the Chart.yaml and the
+	// requirements.yaml of v1 charts, which declare the dependencies.
+	ChartYAMLKey    = "chartYAML"
+	RequirementsKey = "requirements"
 )
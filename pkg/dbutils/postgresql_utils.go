@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/kubeapps/common/datastore"
 	"github.com/kubeapps/kubeapps/pkg/chart/models"
@@ -35,8 +36,20 @@ const (
 	ChartFilesTable = "files"
 	// EnvvarPostgresTests enables tests that run against a local postgres
 	EnvvarPostgresTests = "ENABLE_PG_INTEGRATION_TESTS"
+	// ChartSearchIndex full-text search index over the charts
+	ChartSearchIndex = "charts_search_idx"
+	// ChartSearchConfig text search configuration of the charts full-text search
+	ChartSearchConfig = "english"
 )
 
+// ChartSearchVector is the full-text search document of a chart, weighting
+// its name over its keywords, description and maintainers. Queries must use
+// the same expression so that the search index is used.
+var ChartSearchVector = fmt.Sprintf(`(setweight(to_tsvector('%[1]s', coalesce(info->>'name', '')), 'A') || `+
+	`setweight(to_tsvector('%[1]s', coalesce(info->'keywords', '[]'::jsonb)), 'B') || `+
+	`setweight(to_tsvector('%[1]s', coalesce(info->>'description', '')), 'C') || `+
+	`setweight(to_tsvector('%[1]s', coalesce(info->'maintainers', '[]'::jsonb)), 'D'))`, ChartSearchConfig)
+
 type PostgresDB interface {
 	Query(query string, args ...interface{}) (*sql.Rows, error)
 	Begin() (*sql.Tx, error)
@@ -211,9 +224,29 @@ CREATE TABLE IF NOT EXISTS %s (
 	if err != nil {
 		return err
 	}
+
+	_, err = m.DB.Exec(fmt.Sprintf(`
+CREATE INDEX IF NOT EXISTS %s ON %s USING GIN (%s)`, ChartSearchIndex, ChartTable, ChartSearchVector))
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
+// ChartSearchQuery returns the text search query, for to_tsquery, matching
+// the charts with words starting with each of the words of the user query.
+// It returns an empty string if the user query has no words.
+func ChartSearchQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, strings.ToLower(word)+":*")
+	}
+	return strings.Join(terms, " & ")
+}
+
 // InvalidateCache for postgresql deletes and re-writes the schema
 func (m *PostgresAssetManager) InvalidateCache() error {
 	tables := strings.Join([]string{RepositoryTable, ChartTable, ChartFilesTable}, ",")
@@ -141,3 +141,47 @@ func Test_QueryAllChartCategories(t *testing.T) {
 		t.Errorf("Unexpected result %v", cmp.Diff(chartCategories, expectedChartCategories))
 	}
 }
+
+func Test_InitTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	manager := PostgresAssetManager{
+		connStr: "localhost",
+		DB:      db,
+	}
+	for _, table := range []string{RepositoryTable, ChartTable, ChartFilesTable} {
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS " + table).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec("CREATE INDEX IF NOT EXISTS " + ChartSearchIndex + " ON " + ChartTable + " USING GIN").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = manager.InitTables()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_ChartSearchQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"single word", "Word", "word:*"},
+		{"several words", "kube prom", "kube:* & prom:*"},
+		{"punctuation is ignored", "  nginx-ingress, (controller)!", "nginx:* & ingress:* & controller:*"},
+		{"operators are not injected", "a | b & !c:*", "a:* & b:* & c:*"},
+		{"no words", "- & |", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChartSearchQuery(tt.query); got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	chart "github.com/kubeapps/kubeapps/pkg/chart/models"
 	httpclient "github.com/kubeapps/kubeapps/pkg/http-client"
+	"sigs.k8s.io/yaml"
 )
 
 //
@@ -79,10 +80,14 @@ func FetchChartDetailFromTarball(name string, chartTarballURL string, userAgent
 	readmeFileName := fixedName + "/README.md"
 	valuesFileName := fixedName + "/values.yaml"
 	schemaFileName := fixedName + "/values.schema.json"
+	chartYAMLFileName := fixedName + "/Chart.yaml"
+	requirementsFileName := fixedName + "/requirements.yaml"
 	filenames := map[string]string{
-		chart.ValuesKey: valuesFileName,
-		chart.ReadmeKey: readmeFileName,
-		chart.SchemaKey: schemaFileName,
+		chart.ValuesKey:       valuesFileName,
+		chart.ReadmeKey:       readmeFileName,
+		chart.SchemaKey:       schemaFileName,
+		chart.ChartYAMLKey:    chartYAMLFileName,
+		chart.RequirementsKey: requirementsFileName,
 	}
 
 	files, err := ExtractFilesFromTarball(filenames, tarf)
@@ -91,12 +96,31 @@ func FetchChartDetailFromTarball(name string, chartTarballURL string, userAgent
 	}
 
 	return map[string]string{
-		chart.ValuesKey: files[chart.ValuesKey],
-		chart.ReadmeKey: files[chart.ReadmeKey],
-		chart.SchemaKey: files[chart.SchemaKey],
+		chart.ValuesKey:       files[chart.ValuesKey],
+		chart.ReadmeKey:       files[chart.ReadmeKey],
+		chart.SchemaKey:       files[chart.SchemaKey],
+		chart.ChartYAMLKey:    files[chart.ChartYAMLKey],
+		chart.RequirementsKey: files[chart.RequirementsKey],
 	}, nil
 }
 
+// ChartDependencies returns the dependencies declared in the Chart.yaml of
+// the chart files or, for v1 charts, in their requirements.yaml.
+func ChartDependencies(files map[string]string) ([]chart.ChartDependency, error) {
+	var metadata struct {
+		Dependencies []chart.ChartDependency `json:"dependencies"`
+	}
+	if err := yaml.Unmarshal([]byte(files[chart.ChartYAMLKey]), &metadata); err != nil {
+		return nil, fmt.Errorf("unable to parse Chart.yaml: %v", err)
+	}
+	if len(metadata.Dependencies) == 0 {
+		if err := yaml.Unmarshal([]byte(files[chart.RequirementsKey]), &metadata); err != nil {
+			return nil, fmt.Errorf("unable to parse requirements.yaml: %v", err)
+		}
+	}
+	return metadata.Dependencies, nil
+}
+
 func ExtractFilesFromTarball(filenames map[string]string, tarf *tar.Reader) (map[string]string, error) {
 	ret := make(map[string]string)
 	for {
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/arschles/assert"
+	chart "github.com/kubeapps/kubeapps/pkg/chart/models"
 	"github.com/kubeapps/kubeapps/pkg/tarutil/test"
 )
 
@@ -84,3 +85,53 @@ func Test_extractFilesFromTarball(t *testing.T) {
 		assert.Equal(t, len(files), 0, "file body")
 	})
 }
+
+func Test_ChartDependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []chart.ChartDependency
+	}{
+		{
+			name: "dependencies of Chart.yaml",
+			files: map[string]string{chart.ChartYAMLKey: `apiVersion: v2
+name: wordpress
+dependencies:
+- name: mariadb
+  version: 9.x.x
+  repository: https://charts.bitnami.com/bitnami
+  condition: mariadb.enabled
+- name: common
+  version: 1.x.x
+  repository: https://charts.bitnami.com/bitnami
+  alias: bitnami-common
+`},
+			want: []chart.ChartDependency{
+				{Name: "mariadb", Version: "9.x.x", Repository: "https://charts.bitnami.com/bitnami", Condition: "mariadb.enabled"},
+				{Name: "common", Version: "1.x.x", Repository: "https://charts.bitnami.com/bitnami", Alias: "bitnami-common"},
+			},
+		},
+		{
+			name: "dependencies of requirements.yaml",
+			files: map[string]string{
+				chart.ChartYAMLKey:    "apiVersion: v1\nname: wordpress\n",
+				chart.RequirementsKey: "dependencies:\n- name: mariadb\n  version: 7.x.x\n  repository: \"@bitnami\"\n",
+			},
+			want: []chart.ChartDependency{
+				{Name: "mariadb", Version: "7.x.x", Repository: "@bitnami"},
+			},
+		},
+		{
+			name:  "no dependencies",
+			files: map[string]string{chart.ChartYAMLKey: "apiVersion: v2\nname: apache\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dependencies, err := ChartDependencies(tt.files)
+			assert.NoErr(t, err)
+			assert.Equal(t, dependencies, tt.want, "dependencies")
+		})
+	}
+}